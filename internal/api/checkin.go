@@ -0,0 +1,550 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"bogowi-blockchain-go/internal/sdk/nft"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gin-gonic/gin"
+	"github.com/skip2/go-qrcode"
+)
+
+// redemptionQRSize is the width and height of redemption QR images in pixels
+const redemptionQRSize = 256
+
+// checkInBundleValidity is how long an offline check-in bundle may be used,
+// as long as its scans may be synced
+const checkInBundleValidity = nft.OfflineScanWindow
+
+// maxOfflineScans caps the scans uploaded in one sync request
+const maxOfflineScans = 200
+
+// checkInOperator is a venue staff credential, bound to the network and the
+// events it may check tickets in for
+type checkInOperator struct {
+	Name    string
+	Key     string
+	Network string
+	Events  map[string]bool
+}
+
+// parseCheckInOperators parses "name:key:eventId|eventId[:network]" entries.
+// Operators without a network are bound to testnet.
+func parseCheckInOperators(entries []string) ([]checkInOperator, error) {
+	operators := make([]checkInOperator, 0, len(entries))
+	for _, entry := range entries {
+		parts := strings.Split(entry, ":")
+		if len(parts) < 3 || len(parts) > 4 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid check-in operator %q: expected name:key:eventId|eventId[:network]", parts[0])
+		}
+
+		operator := checkInOperator{Name: parts[0], Key: parts[1], Network: "testnet", Events: map[string]bool{}}
+		if len(parts) == 4 {
+			operator.Network = parts[3]
+		}
+		if operator.Network != "testnet" && operator.Network != "mainnet" {
+			return nil, fmt.Errorf("check-in operator %s has invalid network %q", operator.Name, operator.Network)
+		}
+		for _, eventID := range strings.Split(parts[2], "|") {
+			if eventID = strings.TrimSpace(eventID); eventID != "" {
+				operator.Events[eventID] = true
+			}
+		}
+		if len(operator.Events) == 0 {
+			return nil, fmt.Errorf("check-in operator %s has no events", operator.Name)
+		}
+		operators = append(operators, operator)
+	}
+	return operators, nil
+}
+
+// CheckInOperatorAuth admits requests carrying the key of a check-in operator
+// in the X-Operator-Key header, and stores the operator in the context
+func CheckInOperatorAuth(operators []checkInOperator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("X-Operator-Key")
+		if key != "" {
+			for i := range operators {
+				if subtle.ConstantTimeCompare([]byte(key), []byte(operators[i].Key)) == 1 {
+					c.Set("operator", &operators[i])
+					c.Next()
+					return
+				}
+			}
+		}
+
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Invalid operator credentials"})
+		c.Abort()
+	}
+}
+
+// checkInGuard keeps two scans of the same ticket from being relayed at once
+type checkInGuard struct {
+	mu      sync.Mutex
+	pending map[string]bool
+}
+
+// acquire reports whether key was free and claims it
+func (g *checkInGuard) acquire(key string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.pending == nil {
+		g.pending = map[string]bool{}
+	}
+	if g.pending[key] {
+		return false
+	}
+	g.pending[key] = true
+	return true
+}
+
+func (g *checkInGuard) release(key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.pending, key)
+}
+
+// GetRedemptionQR returns a short-lived redemption QR code for the holder
+// @Summary Get a redemption QR code
+// @Description Returns a redemption QR code for a ticket of the authenticated wallet, signed by the
+// @Description backend and valid for a few minutes. Venue staff scan it with the check-in endpoint.
+// @Tags NFT
+// @Produce json
+// @Produce png
+// @Param X-Network-Type header string false "Network type (testnet/mainnet)" default(testnet)
+// @Param Authorization header string true "Firebase ID token" default(Bearer <token>)
+// @Param tokenId path int true "Token ID"
+// @Param format query string false "png for the image, datauri (default) for JSON with a data URI"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /nft/tickets/{tokenId}/redemption-qr [get]
+func (h *NFTHandler) GetRedemptionQR(c *gin.Context) {
+	network := GetNetworkFromContext(c)
+
+	wallet, exists := c.Get("wallet")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid token ID"})
+		return
+	}
+	format := c.DefaultQuery("format", "datauri")
+	if format != "png" && format != "datauri" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "format must be png or datauri"})
+		return
+	}
+
+	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	qrData, err := nftSDK.GenerateRedemptionQR(c.Request.Context(), tokenID, common.HexToAddress(wallet.(string)))
+	if err != nil {
+		c.JSON(redemptionErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+	png, err := qrcode.Encode(qrData, qrcode.Medium, redemptionQRSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to render QR code: %v", err)})
+		return
+	}
+
+	if format == "png" {
+		c.Header("Cache-Control", "no-store")
+		c.Data(http.StatusOK, "image/png", png)
+		return
+	}
+
+	response := gin.H{
+		"tokenId": tokenID,
+		"qrData":  qrData,
+		"dataUri": "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}
+	if data, err := nft.ParseRedemptionQRCode(qrData); err == nil {
+		response["expiresAt"] = data.Deadline.Int64()
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetRotatingRedemption issues a rotating redemption token to the holder
+// @Summary Get a rotating redemption token
+// @Description Returns a token from which the holder's app derives a redemption QR code that changes
+// @Description every period seconds. Each code is accepted once, and only while it is current, so
+// @Description screenshots cannot be passed on. The seed must stay on the holder's device.
+// @Tags NFT
+// @Produce json
+// @Param X-Network-Type header string false "Network type (testnet/mainnet)" default(testnet)
+// @Param Authorization header string true "Firebase ID token" default(Bearer <token>)
+// @Param tokenId path int true "Token ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /nft/tickets/{tokenId}/rotating-redemption [get]
+func (h *NFTHandler) GetRotatingRedemption(c *gin.Context) {
+	network := GetNetworkFromContext(c)
+
+	wallet, exists := c.Get("wallet")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid token ID"})
+		return
+	}
+
+	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	token, err := nftSDK.IssueRotatingRedemption(c.Request.Context(), tokenID, common.HexToAddress(wallet.(string)))
+	if err != nil {
+		c.JSON(redemptionErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{
+		"tokenId":  token.Params.TokenID,
+		"redeemer": token.Params.Redeemer.Hex(),
+		"nonce":    token.Params.Nonce,
+		"deadline": token.Params.Deadline,
+		"seed":     hexutil.Encode(token.Seed),
+		"period":   token.Period,
+		"baseUrl":  token.BaseURL,
+		"qrData":   nft.RotatingRedemptionQR(token, time.Now()),
+	})
+}
+
+// ScanTicketRequest is a redemption QR code scanned at a venue
+type ScanTicketRequest struct {
+	QRData  string `json:"qrData" binding:"required"`
+	EventID string `json:"eventId" binding:"required"`
+}
+
+// ScanTicket checks in a ticket from its redemption QR code
+// @Summary Check in a ticket
+// @Description Verifies a scanned redemption QR code, static or rotating, against the event and the
+// @Description on-chain ticket state, and redeems the ticket when it is valid. Rejected scans are answered with
+// @Description 200 and a result of already_redeemed, expired, qr_expired, wrong_event or invalid.
+// @Tags NFT
+// @Accept json
+// @Produce json
+// @Param X-Operator-Key header string true "Check-in operator key"
+// @Param network query string false "Network of the operator (testnet/mainnet)"
+// @Param request body ScanTicketRequest true "Scanned QR code"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /nft/checkin/scan [post]
+func (h *NFTHandler) ScanTicket(c *gin.Context) {
+	var req ScanTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	operator, ok := operatorForEvent(c, req.EventID)
+	if !ok {
+		return
+	}
+	network := operator.Network
+
+	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	checkIn, err := nftSDK.CheckRedemptionQR(ctx, req.QRData, convertToBytes32(req.EventID))
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to check ticket: %v", err)})
+		return
+	}
+
+	response := gin.H{
+		"result":   checkIn.Result,
+		"eventId":  req.EventID,
+		"operator": operator.Name,
+	}
+	if checkIn.Params.TokenID != 0 {
+		response["tokenId"] = checkIn.Params.TokenID
+	}
+	if checkIn.Result != nft.CheckInValid {
+		response["reason"] = checkIn.Reason
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	txHash, err := h.redeemCheckIn(ctx, nftSDK, network, checkIn.Params)
+	if errors.Is(err, errCheckInPending) {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "Ticket is already being checked in"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to redeem ticket: %v", err)})
+		return
+	}
+
+	response["redeemer"] = checkIn.Params.Redeemer.Hex()
+	response["txHash"] = txHash
+	c.JSON(http.StatusOK, response)
+}
+
+// operatorForEvent returns the operator of the request if it is bound to
+// eventID and to the network the request names, and answers 403 otherwise.
+// Check-ins run on the network of the operator.
+func operatorForEvent(c *gin.Context, eventID string) (*checkInOperator, bool) {
+	value, _ := c.Get("operator")
+	operator, ok := value.(*checkInOperator)
+	if !ok || !operator.Events[eventID] {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Operator is not assigned to this event"})
+		return nil, false
+	}
+
+	network := c.Query("network")
+	if network == "" {
+		network = c.GetHeader("X-Network")
+	}
+	if network != "" && network != operator.Network {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Operator is not assigned to this network"})
+		return nil, false
+	}
+	return operator, true
+}
+
+// GetCheckInBundle exports the signed ticket list of an event for offline scanning
+// @Summary Export an offline check-in bundle
+// @Description Returns the tickets of an event with their owners and status, signed by the backend as
+// @Description EIP-712 typed data. Offline scanners verify the bundle and redemption QR codes against
+// @Description the signer it names, and should refresh it before validUntil.
+// @Tags NFT
+// @Produce json
+// @Param X-Operator-Key header string true "Check-in operator key"
+// @Param network query string false "Network of the operator (testnet/mainnet)"
+// @Param eventId path string true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /nft/checkin/events/{eventId}/bundle [get]
+func (h *NFTHandler) GetCheckInBundle(c *gin.Context) {
+	eventID := c.Param("eventId")
+	operator, ok := operatorForEvent(c, eventID)
+	if !ok {
+		return
+	}
+	network := operator.Network
+
+	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	bundle, err := nftSDK.ExportCheckInBundle(c.Request.Context(), convertToBytes32(eventID), checkInBundleValidity)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to export check-in bundle: %v", err)})
+		return
+	}
+
+	tickets := make([]gin.H, 0, len(bundle.Tickets))
+	for _, ticket := range bundle.Tickets {
+		tickets = append(tickets, gin.H{
+			"tokenId":   ticket.TokenID,
+			"owner":     ticket.Owner.Hex(),
+			"status":    ticket.Status,
+			"expiresAt": ticket.ExpiresAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"network":     network,
+		"eventId":     eventID,
+		"eventIdHash": common.Hash(bundle.EventID).Hex(),
+		"issuedAt":    bundle.IssuedAt,
+		"validUntil":  bundle.ValidUntil,
+		"tickets":     tickets,
+		"signer":      bundle.Signer.Hex(),
+		"publicKey":   hexutil.Encode(bundle.PublicKey),
+		"signature":   hexutil.Encode(bundle.Signature),
+		"typedData":   bundle.TypedData,
+	})
+}
+
+// OfflineScan is a redemption QR code scanned without connectivity
+type OfflineScan struct {
+	QRData    string    `json:"qrData" binding:"required"`
+	ScannedAt time.Time `json:"scannedAt" binding:"required"`
+}
+
+// SyncCheckInsRequest uploads the scans an offline scanner collected
+type SyncCheckInsRequest struct {
+	Scans []OfflineScan `json:"scans" binding:"required,min=1,dive"`
+}
+
+// SyncCheckIns reconciles offline scans with the chain
+// @Summary Upload offline check-ins
+// @Description Redeems the tickets of offline scans that were valid when scanned, in scan order.
+// @Description Scans that cannot be redeemed are reported as conflicts with their check-in result,
+// @Description or "failed" when the redemption transaction failed.
+// @Tags NFT
+// @Accept json
+// @Produce json
+// @Param X-Operator-Key header string true "Check-in operator key"
+// @Param network query string false "Network of the operator (testnet/mainnet)"
+// @Param eventId path string true "Event ID"
+// @Param request body SyncCheckInsRequest true "Offline scans"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /nft/checkin/events/{eventId}/sync [post]
+func (h *NFTHandler) SyncCheckIns(c *gin.Context) {
+	eventID := c.Param("eventId")
+	operator, ok := operatorForEvent(c, eventID)
+	if !ok {
+		return
+	}
+	network := operator.Network
+
+	var req SyncCheckInsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if len(req.Scans) > maxOfflineScans {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("At most %d scans per upload", maxOfflineScans)})
+		return
+	}
+
+	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// The earliest scan of a ticket wins; later ones become conflicts
+	order := make([]int, len(req.Scans))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return req.Scans[order[a]].ScannedAt.Before(req.Scans[order[b]].ScannedAt)
+	})
+
+	ctx := c.Request.Context()
+	redeemed := []gin.H{}
+	conflicts := []gin.H{}
+	for _, i := range order {
+		scan := req.Scans[i]
+		entry := gin.H{"index": i, "scannedAt": scan.ScannedAt}
+
+		checkIn, err := nftSDK.CheckOfflineRedemption(ctx, scan.QRData, convertToBytes32(eventID), scan.ScannedAt)
+		if err != nil {
+			entry["result"] = "failed"
+			entry["reason"] = err.Error()
+			conflicts = append(conflicts, entry)
+			continue
+		}
+		if checkIn.Params.TokenID != 0 {
+			entry["tokenId"] = checkIn.Params.TokenID
+		}
+		entry["result"] = checkIn.Result
+		if checkIn.Result != nft.CheckInValid {
+			entry["reason"] = checkIn.Reason
+			conflicts = append(conflicts, entry)
+			continue
+		}
+
+		txHash, err := h.redeemCheckIn(ctx, nftSDK, network, checkIn.Params)
+		if err != nil {
+			entry["result"] = "failed"
+			entry["reason"] = err.Error()
+			conflicts = append(conflicts, entry)
+			continue
+		}
+		entry["txHash"] = txHash
+		redeemed = append(redeemed, entry)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"eventId":   eventID,
+		"operator":  operator.Name,
+		"total":     len(req.Scans),
+		"redeemed":  redeemed,
+		"conflicts": conflicts,
+	})
+}
+
+// errCheckInPending is returned while another scan of the ticket is relayed
+var errCheckInPending = errors.New("ticket is already being checked in")
+
+// redeemCheckIn relays a checked-in redemption and records it, unless the
+// ticket is already being redeemed by another scan
+func (h *NFTHandler) redeemCheckIn(ctx context.Context, nftSDK *nft.Client, network string, params nft.RedemptionParams) (string, error) {
+	key := fmt.Sprintf("%s/%d", network, params.TokenID)
+	if !h.checkIns.acquire(key) {
+		return "", errCheckInPending
+	}
+	defer h.checkIns.release(key)
+
+	tx, err := nftSDK.RedeemTicket(ctx, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to redeem ticket: %w", err)
+	}
+	h.recordRedemption(network, params.TokenID)
+	return tx.Hash().Hex(), nil
+}
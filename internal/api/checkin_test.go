@@ -0,0 +1,230 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCheckInOperators(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		want    []checkInOperator
+		wantErr bool
+	}{
+		{
+			name:    "None",
+			entries: nil,
+			want:    []checkInOperator{},
+		},
+		{
+			name:    "Several events",
+			entries: []string{"gate-a:secret:event-1|event-2", "gate-b:other:event-3"},
+			want: []checkInOperator{
+				{Name: "gate-a", Key: "secret", Network: "testnet", Events: map[string]bool{"event-1": true, "event-2": true}},
+				{Name: "gate-b", Key: "other", Network: "testnet", Events: map[string]bool{"event-3": true}},
+			},
+		},
+		{
+			name:    "Bound to mainnet",
+			entries: []string{"gate-a:secret:event-1:mainnet"},
+			want: []checkInOperator{
+				{Name: "gate-a", Key: "secret", Network: "mainnet", Events: map[string]bool{"event-1": true}},
+			},
+		},
+		{name: "Invalid network", entries: []string{"gate-a:secret:event-1:devnet"}, wantErr: true},
+		{name: "Too many fields", entries: []string{"gate-a:secret:event-1:mainnet:extra"}, wantErr: true},
+		{name: "Missing key", entries: []string{"gate-a::event-1"}, wantErr: true},
+		{name: "Missing events", entries: []string{"gate-a:secret:"}, wantErr: true},
+		{name: "Too few fields", entries: []string{"gate-a:secret"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operators, err := parseCheckInOperators(tt.entries)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, operators)
+		})
+	}
+}
+
+func TestCheckInOperatorAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	operators, err := parseCheckInOperators([]string{"gate-a:secret:event-1"})
+	require.NoError(t, err)
+	handler := &NFTHandler{operators: operators}
+
+	router := gin.New()
+	router.POST("/scan", CheckInOperatorAuth(handler.operators), handler.ScanTicket)
+
+	tests := []struct {
+		name       string
+		key        string
+		network    string
+		body       string
+		wantStatus int
+	}{
+		{"Missing key", "", "", `{"qrData":"x","eventId":"event-1"}`, http.StatusUnauthorized},
+		{"Wrong key", "guess", "", `{"qrData":"x","eventId":"event-1"}`, http.StatusUnauthorized},
+		{"Other event", "secret", "", `{"qrData":"x","eventId":"event-2"}`, http.StatusForbidden},
+		{"Missing QR data", "secret", "", `{"eventId":"event-1"}`, http.StatusBadRequest},
+		{"Testnet key on mainnet", "secret", "mainnet", `{"qrData":"x","eventId":"event-1"}`, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/scan", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.key != "" {
+				req.Header.Set("X-Operator-Key", tt.key)
+			}
+			if tt.network != "" {
+				req.Header.Set("X-Network", tt.network)
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestOfflineCheckInValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	operators, err := parseCheckInOperators([]string{"gate-a:secret:event-1"})
+	require.NoError(t, err)
+	handler := &NFTHandler{operators: operators}
+
+	router := gin.New()
+	checkIn := router.Group("/checkin", CheckInOperatorAuth(handler.operators))
+	checkIn.GET("/events/:eventId/bundle", handler.GetCheckInBundle)
+	checkIn.POST("/events/:eventId/sync", handler.SyncCheckIns)
+
+	scan := `{"qrData":"x","scannedAt":"2026-01-01T10:00:00Z"}`
+	tooMany := `{"scans":[` + strings.TrimSuffix(strings.Repeat(scan+",", maxOfflineScans+1), ",") + `]}`
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		key        string
+		body       string
+		wantStatus int
+	}{
+		{"Bundle without key", "GET", "/checkin/events/event-1/bundle", "", "", http.StatusUnauthorized},
+		{"Bundle for other event", "GET", "/checkin/events/event-2/bundle", "secret", "", http.StatusForbidden},
+		{"Bundle for other network", "GET", "/checkin/events/event-1/bundle?network=mainnet", "secret", "", http.StatusForbidden},
+		{"Sync for other network", "POST", "/checkin/events/event-1/sync?network=mainnet", "secret", `{"scans":[` + scan + `]}`, http.StatusForbidden},
+		{"Sync for other event", "POST", "/checkin/events/event-2/sync", "secret", `{"scans":[` + scan + `]}`, http.StatusForbidden},
+		{"Sync without scans", "POST", "/checkin/events/event-1/sync", "secret", `{"scans":[]}`, http.StatusBadRequest},
+		{"Sync with bad scan time", "POST", "/checkin/events/event-1/sync", "secret", `{"scans":[{"qrData":"x","scannedAt":"yesterday"}]}`, http.StatusBadRequest},
+		{"Sync without QR data", "POST", "/checkin/events/event-1/sync", "secret", `{"scans":[{"scannedAt":"2026-01-01T10:00:00Z"}]}`, http.StatusBadRequest},
+		{"Sync too many scans", "POST", "/checkin/events/event-1/sync", "secret", tooMany, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.key != "" {
+				req.Header.Set("X-Operator-Key", tt.key)
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestGetRedemptionQRValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &NFTHandler{}
+
+	tests := []struct {
+		name       string
+		wallet     string
+		tokenID    string
+		query      string
+		wantStatus int
+	}{
+		{"Not signed in", "", "1", "", http.StatusUnauthorized},
+		{"Invalid token ID", "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0", "abc", "", http.StatusBadRequest},
+		{"Unknown format", "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0", "1", "?format=svg", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "tokenId", Value: tt.tokenID}}
+			c.Request, _ = http.NewRequest("GET", "/api/nft/tickets/"+tt.tokenID+"/redemption-qr"+tt.query, nil)
+			if tt.wallet != "" {
+				c.Set("wallet", tt.wallet)
+			}
+
+			handler.GetRedemptionQR(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestGetRotatingRedemptionValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &NFTHandler{}
+
+	tests := []struct {
+		name       string
+		wallet     string
+		tokenID    string
+		wantStatus int
+	}{
+		{"Not signed in", "", "1", http.StatusUnauthorized},
+		{"Invalid token ID", "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0", "abc", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "tokenId", Value: tt.tokenID}}
+			c.Request, _ = http.NewRequest("GET", "/api/nft/tickets/"+tt.tokenID+"/rotating-redemption", nil)
+			if tt.wallet != "" {
+				c.Set("wallet", tt.wallet)
+			}
+
+			handler.GetRotatingRedemption(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestCheckInGuard(t *testing.T) {
+	var guard checkInGuard
+
+	assert.True(t, guard.acquire("testnet/1"))
+	assert.False(t, guard.acquire("testnet/1"))
+	assert.True(t, guard.acquire("testnet/2"))
+
+	guard.release("testnet/1")
+	assert.True(t, guard.acquire("testnet/1"))
+}
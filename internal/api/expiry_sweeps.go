@@ -0,0 +1,192 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"bogowi-blockchain-go/internal/config"
+	"bogowi-blockchain-go/internal/sdk/nft"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetExpirySweeps reports the ticket expiry sweeps of a network (backend only)
+// @Summary Get ticket expiry sweeps
+// @Description Returns the schedule of the ticket expiry sweeps of a network, whether one is running
+// @Description and the reports of the recent sweeps, newest first
+// @Tags NFT
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Success 200 {object} nft.SweeperStatus
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/nft/expiry-sweeps [get]
+func (h *Handler) GetExpirySweeps(c *gin.Context) {
+	network, ok := h.backendNetwork(c)
+	if !ok {
+		return
+	}
+
+	sweeper, err := h.NetworkHandler.GetExpirySweeper(network)
+	if err != nil {
+		respondSweepError(c, network, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sweeper.Status())
+}
+
+// TriggerExpirySweep starts a ticket expiry sweep of a network (backend only)
+// @Summary Trigger a ticket expiry sweep
+// @Description Starts expiring the tickets of a network past their expiry and grace period. The sweep
+// @Description runs in the background; its report is listed by GET /admin/nft/expiry-sweeps.
+// @Tags NFT
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Success 202 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/nft/expiry-sweeps [post]
+func (h *Handler) TriggerExpirySweep(c *gin.Context) {
+	network, ok := h.backendNetwork(c)
+	if !ok {
+		return
+	}
+
+	if err := h.NetworkHandler.StartExpirySweep(network); err != nil {
+		respondSweepError(c, network, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"network": network,
+		"status":  "started",
+	})
+}
+
+// backendNetwork authenticates a backend request and returns its network, or
+// responds with the error
+func (h *Handler) backendNetwork(c *gin.Context) (string, bool) {
+	if !h.authenticateBackendRequest(c) {
+		return "", false
+	}
+
+	network := c.Query("network")
+	if network == "" {
+		network = c.GetHeader("X-Network")
+	}
+	if network == "" {
+		network = "testnet"
+	}
+
+	if network != "testnet" && network != "mainnet" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid network: " + network + ". Use 'testnet' or 'mainnet'"})
+		return "", false
+	}
+
+	if h.NetworkHandler == nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Network handler not initialized"})
+		return "", false
+	}
+	return network, true
+}
+
+// respondSweepError responds with the error of an expiry sweep request
+func respondSweepError(c *gin.Context, network string, err error) {
+	if respondUnavailable(c, err) {
+		return
+	}
+	if errors.Is(err, nft.ErrSweepInProgress) {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusNotFound, ErrorResponse{Error: "No ticket expiry sweeps configured for " + network})
+}
+
+// OnTicketExpired registers a consumer of the tickets the expiry sweeps of
+// every network find expired. Networks that connect later deliver to it as
+// well.
+func (h *NetworkHandler) OnTicketExpired(handler nft.ExpiryHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.expiryHandlers = append(h.expiryHandlers, handler)
+	for _, sweeper := range h.sweepers {
+		sweeper.OnExpired(handler)
+	}
+}
+
+// GetExpirySweeper returns the ticket expiry sweeper of a network
+func (h *NetworkHandler) GetExpirySweeper(network string) (*nft.Sweeper, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	switch network {
+	case "testnet", "columbus":
+		network = "testnet"
+	case "mainnet", "camino":
+		network = "mainnet"
+	default:
+		return nil, fmt.Errorf("invalid network: %s", network)
+	}
+
+	if err := h.unavailableLocked(network); err != nil {
+		return nil, err
+	}
+
+	sweeper, ok := h.sweepers[network]
+	if !ok {
+		return nil, fmt.Errorf("%s ticket expiry sweeps not initialized", network)
+	}
+	return sweeper, nil
+}
+
+// StartExpirySweep starts a ticket expiry sweep of a network in the
+// background. It returns nft.ErrSweepInProgress while a sweep is running.
+func (h *NetworkHandler) StartExpirySweep(network string) error {
+	sweeper, err := h.GetExpirySweeper(network)
+	if err != nil {
+		return err
+	}
+	return sweeper.Start(h.ctx, nft.SweepManual)
+}
+
+// expirySweeperConfig reads the schedule and batch size of ticket expiry
+// sweeps. An interval of zero leaves sweeps to the manual trigger.
+func expirySweeperConfig(network string, cfg *config.Config) (nft.SweeperConfig, error) {
+	sweeperConfig := nft.SweeperConfig{Network: network}
+
+	if cfg.ExpirySweepInterval != "" {
+		interval, err := time.ParseDuration(cfg.ExpirySweepInterval)
+		if err != nil {
+			return sweeperConfig, fmt.Errorf("invalid interval %q: %w", cfg.ExpirySweepInterval, err)
+		}
+		if interval < 0 {
+			return sweeperConfig, fmt.Errorf("invalid interval %q: must not be negative", cfg.ExpirySweepInterval)
+		}
+		sweeperConfig.Interval = interval
+		if interval == 0 {
+			sweeperConfig.Interval = -1
+		}
+	}
+	if cfg.ExpirySweepBatchSize != "" {
+		size, err := strconv.Atoi(cfg.ExpirySweepBatchSize)
+		if err != nil || size <= 0 {
+			return sweeperConfig, fmt.Errorf("invalid batch size %q", cfg.ExpirySweepBatchSize)
+		}
+		sweeperConfig.BatchSize = size
+	}
+	return sweeperConfig, nil
+}
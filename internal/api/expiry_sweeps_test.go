@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"bogowi-blockchain-go/internal/config"
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/nft"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expiredTickets are active tickets that all expired long ago
+type expiredTickets []uint64
+
+func (e expiredTickets) GetTicketData(ctx context.Context, tokenID uint64) (*nft.TicketData, error) {
+	return &nft.TicketData{ExpiresAt: 1}, nil
+}
+
+func (e expiredTickets) ExpireTicket(ctx context.Context, tokenID uint64) (*types.Transaction, error) {
+	return types.NewTx(&types.LegacyTx{Nonce: tokenID}), nil
+}
+
+func (e expiredTickets) ExpiryGracePeriod(ctx context.Context) (time.Duration, error) {
+	return nft.DefaultExpiryGracePeriod, nil
+}
+
+func (e expiredTickets) WaitForTransaction(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil
+}
+
+func (e expiredTickets) ListNFTMappingsByStatus(network string, status string) ([]database.NFTMapping, error) {
+	var mappings []database.NFTMapping
+	for _, tokenID := range e {
+		mappings = append(mappings, database.NFTMapping{TokenID: tokenID, Network: network, Status: status})
+	}
+	return mappings, nil
+}
+
+func TestExpirySweeps(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tickets := expiredTickets{7, 8}
+	sweeper := nft.NewSweeper(tickets, nil, tickets, nft.SweeperConfig{Network: "testnet", Interval: -1})
+
+	cfg := &config.Config{BackendSecret: "test-secret", DevBackendSecret: "test-dev-secret"}
+	networkHandler := &NetworkHandler{
+		config:   cfg,
+		sweepers: map[string]*nft.Sweeper{"testnet": sweeper},
+		ctx:      context.Background(),
+	}
+	handler := &Handler{Config: cfg, NetworkHandler: networkHandler}
+
+	var mu sync.Mutex
+	var expired []uint64
+	networkHandler.OnTicketExpired(func(ctx context.Context, network string, tokenID uint64) {
+		mu.Lock()
+		defer mu.Unlock()
+		expired = append(expired, tokenID)
+	})
+
+	router := gin.New()
+	router.GET("/api/admin/nft/expiry-sweeps", handler.GetExpirySweeps)
+	router.POST("/api/admin/nft/expiry-sweeps", handler.TriggerExpirySweep)
+
+	request := func(method string, query string, auth string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/admin/nft/expiry-sweeps"+query, nil)
+		req.Header.Set("X-Backend-Auth", auth)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		query          string
+		auth           string
+		expectedStatus int
+	}{
+		{name: "unauthorized", method: http.MethodPost, auth: "wrong", expectedStatus: http.StatusUnauthorized},
+		{name: "invalid network", method: http.MethodPost, query: "?network=devnet", auth: "test-secret", expectedStatus: http.StatusBadRequest},
+		{name: "no sweeper", method: http.MethodPost, query: "?network=mainnet", auth: "test-secret", expectedStatus: http.StatusNotFound},
+		{name: "no sweeper status", method: http.MethodGet, query: "?network=mainnet", auth: "test-secret", expectedStatus: http.StatusNotFound},
+		{name: "status", method: http.MethodGet, auth: "test-dev-secret", expectedStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := request(tt.method, tt.query, tt.auth)
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+
+	w := request(http.MethodPost, "", "test-dev-secret")
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+
+	var status nft.SweeperStatus
+	require.Eventually(t, func() bool {
+		w := request(http.MethodGet, "", "test-dev-secret")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+		return !status.Running && len(status.Reports) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, "testnet", status.Network)
+	assert.Equal(t, "on demand", status.Interval)
+	report := status.Reports[0]
+	assert.Equal(t, nft.SweepManual, report.Trigger)
+	assert.Equal(t, nft.SweepSourceMappings, report.Source)
+	assert.Equal(t, 2, report.Candidates)
+	assert.Len(t, report.Expired, 2)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []uint64{7, 8}, expired)
+}
+
+func TestExpirySweeperConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		interval  string
+		batchSize string
+		want      nft.SweeperConfig
+		wantErr   bool
+	}{
+		{name: "defaults", want: nft.SweeperConfig{Network: "testnet"}},
+		{name: "configured", interval: "30m", batchSize: "5", want: nft.SweeperConfig{Network: "testnet", Interval: 30 * time.Minute, BatchSize: 5}},
+		{name: "on demand only", interval: "0", want: nft.SweeperConfig{Network: "testnet", Interval: -1}},
+		{name: "invalid interval", interval: "hourly", wantErr: true},
+		{name: "negative interval", interval: "-1h", wantErr: true},
+		{name: "invalid batch size", batchSize: "0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{ExpirySweepInterval: tt.interval, ExpirySweepBatchSize: tt.batchSize}
+			sweeperConfig, err := expirySweeperConfig("testnet", cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sweeperConfig)
+		})
+	}
+}
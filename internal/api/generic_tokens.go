@@ -0,0 +1,184 @@
+package api
+
+import (
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// GetERC20Info returns metadata of an allowlisted ERC-20 token
+// @Summary Get ERC-20 token info
+// @Description Returns name, symbol and decimals of an allowlisted ERC-20 token
+// @Tags Tokens
+// @Param token path string true "Token contract address"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Success 200 {object} sdk.ERC20Info
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /erc20/{token} [get]
+func (h *Handler) GetERC20Info(c *gin.Context) {
+	networkSDK, token, ok := h.resolveTokenRead(c)
+	if !ok {
+		return
+	}
+
+	info, err := networkSDK.GetERC20Info(c.Request.Context(), token)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, info)
+}
+
+// GetERC20Balance returns the balance of an allowlisted ERC-20 token for an address
+// @Summary Get ERC-20 token balance
+// @Description Returns the raw and decimal-formatted balance of an allowlisted ERC-20 token
+// @Tags Tokens
+// @Param token path string true "Token contract address"
+// @Param address path string true "Wallet address"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Success 200 {object} sdk.ERC20Balance
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /erc20/{token}/balance/{address} [get]
+func (h *Handler) GetERC20Balance(c *gin.Context) {
+	address := c.Param("address")
+	if !common.IsHexAddress(address) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid Ethereum address"})
+		return
+	}
+
+	networkSDK, token, ok := h.resolveTokenRead(c)
+	if !ok {
+		return
+	}
+
+	balance, err := networkSDK.GetERC20Balance(c.Request.Context(), token, address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, balance)
+}
+
+// GetERC721Owner returns the owner of a token in an allowlisted ERC-721 collection
+// @Summary Get ERC-721 token owner
+// @Description Returns the current owner of a token in an allowlisted ERC-721 collection
+// @Tags Tokens
+// @Param token path string true "Collection contract address"
+// @Param tokenId path string true "Token ID"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /erc721/{token}/owner/{tokenId} [get]
+func (h *Handler) GetERC721Owner(c *gin.Context) {
+	tokenID, ok := new(big.Int).SetString(c.Param("tokenId"), 10)
+	if !ok || tokenID.Sign() < 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid token ID"})
+		return
+	}
+
+	networkSDK, token, ok := h.resolveTokenRead(c)
+	if !ok {
+		return
+	}
+
+	owner, err := networkSDK.GetERC721Owner(c.Request.Context(), token, tokenID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":   common.HexToAddress(token).Hex(),
+		"tokenId": tokenID.String(),
+		"owner":   owner,
+	})
+}
+
+// GetERC721TokenURI returns the metadata URI of a token in an allowlisted ERC-721 collection
+// @Summary Get ERC-721 token URI
+// @Description Returns the metadata URI of a token in an allowlisted ERC-721 collection
+// @Tags Tokens
+// @Param token path string true "Collection contract address"
+// @Param tokenId path string true "Token ID"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /erc721/{token}/token-uri/{tokenId} [get]
+func (h *Handler) GetERC721TokenURI(c *gin.Context) {
+	tokenID, ok := new(big.Int).SetString(c.Param("tokenId"), 10)
+	if !ok || tokenID.Sign() < 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid token ID"})
+		return
+	}
+
+	networkSDK, token, ok := h.resolveTokenRead(c)
+	if !ok {
+		return
+	}
+
+	uri, err := networkSDK.GetERC721TokenURI(c.Request.Context(), token, tokenID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":    common.HexToAddress(token).Hex(),
+		"tokenId":  tokenID.String(),
+		"tokenURI": uri,
+	})
+}
+
+// resolveTokenRead validates the token path parameter against the network's
+// allowlist and returns the network SDK. It writes the error response itself
+// and returns false when the request cannot proceed.
+func (h *Handler) resolveTokenRead(c *gin.Context) (SDKInterface, string, bool) {
+	token := c.Param("token")
+	if !common.IsHexAddress(token) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid token address"})
+		return nil, "", false
+	}
+
+	// Get network parameter
+	network := c.Query("network")
+	if network == "" {
+		network = c.GetHeader("X-Network")
+	}
+	if network == "" {
+		network = "mainnet" // Default to mainnet if not specified
+	}
+
+	if h.NetworkHandler == nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Network handler not initialized"})
+		return nil, "", false
+	}
+
+	networkSDK, err := h.NetworkHandler.GetSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return nil, "", false
+		}
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid network: " + network + ". Use 'testnet' or 'mainnet'"})
+		return nil, "", false
+	}
+
+	// Only allowlisted contracts can be read so the API is not an open RPC proxy
+	if !h.NetworkHandler.IsTokenAllowed(network, token) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Token is not allowlisted on " + network})
+		return nil, "", false
+	}
+
+	return networkSDK, token, true
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bogowi-blockchain-go/internal/config"
+	"bogowi-blockchain-go/internal/sdk"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	allowedToken    = "0x1234567890123456789012345678901234567890"
+	bogoToken       = "0xC53c2f11e1d2e36CB5888BfEE157F78e04Bb4F76"
+	notAllowedToken = "0x00000000000000000000000000000000000000ff"
+	holderAddress   = "0x742d35Cc6634C0532925a3b844Bc9e7595f8E97D"
+)
+
+func setupGenericTokenRouter() (*gin.Engine, *MockSDK) {
+	gin.SetMode(gin.TestMode)
+
+	mockSDK := new(MockSDK)
+	cfg := &config.Config{
+		Testnet: config.NetworkConfig{
+			Contracts: config.ContractAddresses{BOGOToken: bogoToken},
+		},
+		Mainnet: config.NetworkConfig{
+			Contracts:     config.ContractAddresses{BOGOToken: bogoToken},
+			AllowedTokens: []string{allowedToken},
+		},
+	}
+
+	handler := &Handler{
+		SDK: mockSDK,
+		NetworkHandler: &NetworkHandler{
+			testnetSDK: mockSDK,
+			mainnetSDK: mockSDK,
+			config:     cfg,
+		},
+		Config: cfg,
+	}
+
+	router := gin.New()
+	api := router.Group("/api")
+	setupTokenRoutes(api, handler)
+
+	return router, mockSDK
+}
+
+func TestGetERC20Endpoints(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		setupMock      func(m *MockSDK)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "token info",
+			url:  "/api/erc20/" + allowedToken,
+			setupMock: func(m *MockSDK) {
+				m.On("GetERC20Info", allowedToken).Return(&sdk.ERC20Info{Address: allowedToken, Symbol: "USDC", Decimals: 6}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "balance",
+			url:  "/api/erc20/" + allowedToken + "/balance/" + holderAddress,
+			setupMock: func(m *MockSDK) {
+				m.On("GetERC20Balance", allowedToken, holderAddress).Return(&sdk.ERC20Balance{Balance: "1.5"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "own token is always allowed",
+			url:  "/api/erc20/" + bogoToken + "?network=testnet",
+			setupMock: func(m *MockSDK) {
+				m.On("GetERC20Info", bogoToken).Return(&sdk.ERC20Info{Symbol: "BOGO"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "token not allowlisted",
+			url:            "/api/erc20/" + notAllowedToken,
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "Token is not allowlisted on mainnet",
+		},
+		{
+			name:           "allowlist is per network",
+			url:            "/api/erc20/" + allowedToken + "?network=testnet",
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "Token is not allowlisted on testnet",
+		},
+		{
+			name:           "invalid token address",
+			url:            "/api/erc20/invalid",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid token address",
+		},
+		{
+			name:           "invalid holder address",
+			url:            "/api/erc20/" + allowedToken + "/balance/invalid",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid Ethereum address",
+		},
+		{
+			name: "sdk error",
+			url:  "/api/erc20/" + allowedToken,
+			setupMock: func(m *MockSDK) {
+				m.On("GetERC20Info", allowedToken).Return(nil, fmt.Errorf("failed to call name: execution reverted"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "failed to call name: execution reverted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockSDK := setupGenericTokenRouter()
+			if tt.setupMock != nil {
+				tt.setupMock(mockSDK)
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Error)
+			}
+			mockSDK.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetERC721Endpoints(t *testing.T) {
+	t.Run("owner", func(t *testing.T) {
+		router, mockSDK := setupGenericTokenRouter()
+		mockSDK.On("GetERC721Owner", allowedToken, big.NewInt(7)).Return(holderAddress, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/erc721/"+allowedToken+"/owner/7", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, holderAddress, response["owner"])
+		assert.Equal(t, "7", response["tokenId"])
+	})
+
+	t.Run("token uri", func(t *testing.T) {
+		router, mockSDK := setupGenericTokenRouter()
+		mockSDK.On("GetERC721TokenURI", allowedToken, mock.Anything).Return("ipfs://meta/7", nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/erc721/"+allowedToken+"/token-uri/7", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "ipfs://meta/7", response["tokenURI"])
+	})
+
+	t.Run("invalid token id", func(t *testing.T) {
+		router, _ := setupGenericTokenRouter()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/erc721/"+allowedToken+"/owner/abc", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("collection not allowlisted", func(t *testing.T) {
+		router, _ := setupGenericTokenRouter()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/erc721/"+notAllowedToken+"/token-uri/1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
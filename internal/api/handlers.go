@@ -44,12 +44,26 @@ func (h *Handler) TransferBOGOTokens(c *gin.Context) {
 
 	networkSDK, err := h.NetworkHandler.GetSDK(network)
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid network: " + network + ". Use 'testnet' or 'mainnet'"})
 		return
 	}
 
+	// Estimate only when asked for a dry run
+	if dryRunRequested(c) {
+		est, err := networkSDK.EstimateTransferBOGOTokens(c.Request.Context(), req.To, req.Amount)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return
+		}
+		respondDryRun(c, network, est)
+		return
+	}
+
 	// Execute the transfer
-	txHash, err := networkSDK.TransferBOGOTokens(req.To, req.Amount)
+	txHash, err := networkSDK.TransferBOGOTokens(c.Request.Context(), req.To, req.Amount)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
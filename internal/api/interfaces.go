@@ -1,29 +1,43 @@
 package api
 
 import (
+	"context"
 	"math/big"
 
 	"bogowi-blockchain-go/internal/sdk"
+	"bogowi-blockchain-go/internal/sdk/gas"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // SDKInterface defines the methods we use from the SDK
 type SDKInterface interface {
-	GetTokenBalance(address string) (*sdk.TokenBalance, error)
-	GetGasPrice() (string, error)
-	TransferBOGOTokens(to string, amount string) (string, error)
+	GetTokenBalance(ctx context.Context, address string) (*sdk.TokenBalance, error)
+	GetTokenBalances(ctx context.Context, addresses []string) ([]*sdk.AccountBalance, error)
+	GetERC20Info(ctx context.Context, token string) (*sdk.ERC20Info, error)
+	GetERC20Balance(ctx context.Context, token string, holder string) (*sdk.ERC20Balance, error)
+	GetERC721Owner(ctx context.Context, token string, tokenID *big.Int) (string, error)
+	GetERC721TokenURI(ctx context.Context, token string, tokenID *big.Int) (string, error)
+	GetNativeBalance(ctx context.Context, address string) (*sdk.NativeBalance, error)
+	TransferNative(ctx context.Context, to string, amount string) (*sdk.NativeTransfer, error)
+	EstimateTransferNative(ctx context.Context, to string, amount string) (*gas.Estimate, error)
+	GetNativeTransfers() []*sdk.NativeTransfer
+	GetGasPrice(ctx context.Context) (string, error)
+	GetGasFees(ctx context.Context) (*sdk.GasFees, error)
+	TransferBOGOTokens(ctx context.Context, to string, amount string) (string, error)
+	EstimateTransferBOGOTokens(ctx context.Context, to string, amount string) (*gas.Estimate, error)
 	GetPublicKey() (string, error)
 	Close()
 
 	// New reward system methods
-	CheckRewardEligibility(templateID string, wallet common.Address) (bool, string, error)
-	ClaimRewardV2(templateID string, recipient common.Address) (*types.Transaction, error)
-	ClaimCustomReward(recipient common.Address, amount *big.Int, reason string) (*types.Transaction, error)
-	ClaimReferralBonus(referrer common.Address, referred common.Address) (*types.Transaction, error)
-	GetReferrer(wallet common.Address) (common.Address, error)
-	GetRewardTemplate(templateID string) (*sdk.RewardTemplate, error)
-	GetClaimCount(wallet common.Address, templateID string) (*big.Int, error)
-	IsWhitelisted(wallet common.Address) (bool, error)
-	GetRemainingDailyLimit() (*big.Int, error)
+	CheckRewardEligibility(ctx context.Context, templateID string, wallet common.Address) (bool, string, error)
+	ClaimRewardV2(ctx context.Context, templateID string, recipient common.Address) (*types.Transaction, error)
+	ClaimCustomReward(ctx context.Context, recipient common.Address, amount *big.Int, reason string) (*types.Transaction, error)
+	EstimateClaimCustomReward(ctx context.Context, recipient common.Address, amount *big.Int, reason string) (*gas.Estimate, error)
+	ClaimReferralBonus(ctx context.Context, referrer common.Address, referred common.Address) (*types.Transaction, error)
+	GetReferrer(ctx context.Context, wallet common.Address) (common.Address, error)
+	GetRewardTemplate(ctx context.Context, templateID string) (*sdk.RewardTemplate, error)
+	GetClaimCount(ctx context.Context, wallet common.Address, templateID string) (*big.Int, error)
+	IsWhitelisted(ctx context.Context, wallet common.Address) (bool, error)
+	GetRemainingDailyLimit(ctx context.Context) (*big.Int, error)
 }
@@ -1,9 +1,11 @@
 package api
 
 import (
+	"context"
 	"math/big"
 
 	"bogowi-blockchain-go/internal/sdk"
+	"bogowi-blockchain-go/internal/sdk/gas"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
@@ -38,7 +40,7 @@ func NewSimpleMockSDK() *SimpleMockSDK {
 }
 
 // GetTokenBalance implements SDKInterface
-func (m *SimpleMockSDK) GetTokenBalance(address string) (*sdk.TokenBalance, error) {
+func (m *SimpleMockSDK) GetTokenBalance(ctx context.Context, address string) (*sdk.TokenBalance, error) {
 	m.Calls = append(m.Calls, "GetTokenBalance")
 	if m.ShouldFail {
 		return nil, &MockError{Message: m.FailMessage}
@@ -46,8 +48,101 @@ func (m *SimpleMockSDK) GetTokenBalance(address string) (*sdk.TokenBalance, erro
 	return m.Balance, nil
 }
 
+// GetTokenBalances implements SDKInterface
+func (m *SimpleMockSDK) GetTokenBalances(ctx context.Context, addresses []string) ([]*sdk.AccountBalance, error) {
+	m.Calls = append(m.Calls, "GetTokenBalances")
+	if m.ShouldFail {
+		return nil, &MockError{Message: m.FailMessage}
+	}
+	balances := make([]*sdk.AccountBalance, len(addresses))
+	for i, address := range addresses {
+		balances[i] = &sdk.AccountBalance{
+			Address:       address,
+			Balance:       m.Balance.Balance,
+			NativeBalance: "1",
+		}
+	}
+	return balances, nil
+}
+
+// GetERC20Info implements SDKInterface
+func (m *SimpleMockSDK) GetERC20Info(ctx context.Context, token string) (*sdk.ERC20Info, error) {
+	m.Calls = append(m.Calls, "GetERC20Info")
+	if m.ShouldFail {
+		return nil, &MockError{Message: m.FailMessage}
+	}
+	return &sdk.ERC20Info{Address: token, Name: "Mock Token", Symbol: "MOCK", Decimals: 18}, nil
+}
+
+// GetERC20Balance implements SDKInterface
+func (m *SimpleMockSDK) GetERC20Balance(ctx context.Context, token string, holder string) (*sdk.ERC20Balance, error) {
+	m.Calls = append(m.Calls, "GetERC20Balance")
+	if m.ShouldFail {
+		return nil, &MockError{Message: m.FailMessage}
+	}
+	return &sdk.ERC20Balance{
+		Token:      token,
+		Address:    holder,
+		Symbol:     "MOCK",
+		Decimals:   18,
+		RawBalance: "1000000000000000000",
+		Balance:    "1",
+	}, nil
+}
+
+// GetERC721Owner implements SDKInterface
+func (m *SimpleMockSDK) GetERC721Owner(ctx context.Context, token string, tokenID *big.Int) (string, error) {
+	m.Calls = append(m.Calls, "GetERC721Owner")
+	if m.ShouldFail {
+		return "", &MockError{Message: m.FailMessage}
+	}
+	return m.Balance.Address, nil
+}
+
+// GetERC721TokenURI implements SDKInterface
+func (m *SimpleMockSDK) GetERC721TokenURI(ctx context.Context, token string, tokenID *big.Int) (string, error) {
+	m.Calls = append(m.Calls, "GetERC721TokenURI")
+	if m.ShouldFail {
+		return "", &MockError{Message: m.FailMessage}
+	}
+	return "ipfs://mock/" + tokenID.String(), nil
+}
+
+// GetNativeBalance implements SDKInterface
+func (m *SimpleMockSDK) GetNativeBalance(ctx context.Context, address string) (*sdk.NativeBalance, error) {
+	m.Calls = append(m.Calls, "GetNativeBalance")
+	if m.ShouldFail {
+		return nil, &MockError{Message: m.FailMessage}
+	}
+	return &sdk.NativeBalance{Address: address, Balance: "1", RawBalance: "1000000000000000000"}, nil
+}
+
+// TransferNative implements SDKInterface
+func (m *SimpleMockSDK) TransferNative(ctx context.Context, to string, amount string) (*sdk.NativeTransfer, error) {
+	m.Calls = append(m.Calls, "TransferNative")
+	if m.ShouldFail {
+		return nil, &MockError{Message: m.FailMessage}
+	}
+	return &sdk.NativeTransfer{TxHash: m.TransactionHash, To: to, Amount: amount}, nil
+}
+
+// EstimateTransferNative implements SDKInterface
+func (m *SimpleMockSDK) EstimateTransferNative(ctx context.Context, to string, amount string) (*gas.Estimate, error) {
+	m.Calls = append(m.Calls, "EstimateTransferNative")
+	if m.ShouldFail {
+		return nil, &MockError{Message: m.FailMessage}
+	}
+	return &gas.Estimate{EstimatedGas: 21000, GasLimit: 25200, BlockGasLimit: 8000000, Value: "0", MaxCost: "0"}, nil
+}
+
+// GetNativeTransfers implements SDKInterface
+func (m *SimpleMockSDK) GetNativeTransfers() []*sdk.NativeTransfer {
+	m.Calls = append(m.Calls, "GetNativeTransfers")
+	return []*sdk.NativeTransfer{}
+}
+
 // GetGasPrice implements SDKInterface
-func (m *SimpleMockSDK) GetGasPrice() (string, error) {
+func (m *SimpleMockSDK) GetGasPrice(ctx context.Context) (string, error) {
 	m.Calls = append(m.Calls, "GetGasPrice")
 	if m.ShouldFail {
 		return "", &MockError{Message: m.FailMessage}
@@ -55,8 +150,17 @@ func (m *SimpleMockSDK) GetGasPrice() (string, error) {
 	return m.GasPrice, nil
 }
 
+// GetGasFees implements SDKInterface
+func (m *SimpleMockSDK) GetGasFees(ctx context.Context) (*sdk.GasFees, error) {
+	m.Calls = append(m.Calls, "GetGasFees")
+	if m.ShouldFail {
+		return nil, &MockError{Message: m.FailMessage}
+	}
+	return &sdk.GasFees{Strategy: "legacy", GasPrice: m.GasPrice}, nil
+}
+
 // TransferBOGOTokens implements SDKInterface
-func (m *SimpleMockSDK) TransferBOGOTokens(to string, amount string) (string, error) {
+func (m *SimpleMockSDK) TransferBOGOTokens(ctx context.Context, to string, amount string) (string, error) {
 	m.Calls = append(m.Calls, "TransferBOGOTokens")
 	if m.ShouldFail {
 		return "", &MockError{Message: m.FailMessage}
@@ -64,6 +168,15 @@ func (m *SimpleMockSDK) TransferBOGOTokens(to string, amount string) (string, er
 	return m.TransactionHash, nil
 }
 
+// EstimateTransferBOGOTokens implements SDKInterface
+func (m *SimpleMockSDK) EstimateTransferBOGOTokens(ctx context.Context, to string, amount string) (*gas.Estimate, error) {
+	m.Calls = append(m.Calls, "EstimateTransferBOGOTokens")
+	if m.ShouldFail {
+		return nil, &MockError{Message: m.FailMessage}
+	}
+	return &gas.Estimate{EstimatedGas: 21000, GasLimit: 25200, BlockGasLimit: 8000000, Value: "0", MaxCost: "0"}, nil
+}
+
 // GetPublicKey implements SDKInterface
 func (m *SimpleMockSDK) GetPublicKey() (string, error) {
 	m.Calls = append(m.Calls, "GetPublicKey")
@@ -79,7 +192,7 @@ func (m *SimpleMockSDK) Close() {
 }
 
 // CheckRewardEligibility implements SDKInterface
-func (m *SimpleMockSDK) CheckRewardEligibility(templateID string, wallet common.Address) (bool, string, error) {
+func (m *SimpleMockSDK) CheckRewardEligibility(ctx context.Context, templateID string, wallet common.Address) (bool, string, error) {
 	m.Calls = append(m.Calls, "CheckRewardEligibility")
 	if m.ShouldFail {
 		return false, "", &MockError{Message: m.FailMessage}
@@ -88,7 +201,7 @@ func (m *SimpleMockSDK) CheckRewardEligibility(templateID string, wallet common.
 }
 
 // ClaimRewardV2 implements SDKInterface
-func (m *SimpleMockSDK) ClaimRewardV2(templateID string, recipient common.Address) (*types.Transaction, error) {
+func (m *SimpleMockSDK) ClaimRewardV2(ctx context.Context, templateID string, recipient common.Address) (*types.Transaction, error) {
 	m.Calls = append(m.Calls, "ClaimRewardV2")
 	if m.ShouldFail {
 		return nil, &MockError{Message: m.FailMessage}
@@ -106,7 +219,7 @@ func (m *SimpleMockSDK) ClaimRewardV2(templateID string, recipient common.Addres
 }
 
 // ClaimCustomReward implements SDKInterface
-func (m *SimpleMockSDK) ClaimCustomReward(recipient common.Address, amount *big.Int, reason string) (*types.Transaction, error) {
+func (m *SimpleMockSDK) ClaimCustomReward(ctx context.Context, recipient common.Address, amount *big.Int, reason string) (*types.Transaction, error) {
 	m.Calls = append(m.Calls, "ClaimCustomReward")
 	if m.ShouldFail {
 		return nil, &MockError{Message: m.FailMessage}
@@ -123,8 +236,17 @@ func (m *SimpleMockSDK) ClaimCustomReward(recipient common.Address, amount *big.
 	return tx, nil
 }
 
+// EstimateClaimCustomReward implements SDKInterface
+func (m *SimpleMockSDK) EstimateClaimCustomReward(ctx context.Context, recipient common.Address, amount *big.Int, reason string) (*gas.Estimate, error) {
+	m.Calls = append(m.Calls, "EstimateClaimCustomReward")
+	if m.ShouldFail {
+		return nil, &MockError{Message: m.FailMessage}
+	}
+	return &gas.Estimate{EstimatedGas: 21000, GasLimit: 25200, BlockGasLimit: 8000000, Value: "0", MaxCost: "0"}, nil
+}
+
 // ClaimReferralBonus implements SDKInterface
-func (m *SimpleMockSDK) ClaimReferralBonus(referrer common.Address, referred common.Address) (*types.Transaction, error) {
+func (m *SimpleMockSDK) ClaimReferralBonus(ctx context.Context, referrer common.Address, referred common.Address) (*types.Transaction, error) {
 	m.Calls = append(m.Calls, "ClaimReferralBonus")
 	if m.ShouldFail {
 		return nil, &MockError{Message: m.FailMessage}
@@ -142,7 +264,7 @@ func (m *SimpleMockSDK) ClaimReferralBonus(referrer common.Address, referred com
 }
 
 // GetReferrer implements SDKInterface
-func (m *SimpleMockSDK) GetReferrer(wallet common.Address) (common.Address, error) {
+func (m *SimpleMockSDK) GetReferrer(ctx context.Context, wallet common.Address) (common.Address, error) {
 	m.Calls = append(m.Calls, "GetReferrer")
 	if m.ShouldFail {
 		return common.Address{}, &MockError{Message: m.FailMessage}
@@ -152,7 +274,7 @@ func (m *SimpleMockSDK) GetReferrer(wallet common.Address) (common.Address, erro
 }
 
 // GetRewardTemplate implements SDKInterface
-func (m *SimpleMockSDK) GetRewardTemplate(templateID string) (*sdk.RewardTemplate, error) {
+func (m *SimpleMockSDK) GetRewardTemplate(ctx context.Context, templateID string) (*sdk.RewardTemplate, error) {
 	m.Calls = append(m.Calls, "GetRewardTemplate")
 	if m.ShouldFail {
 		return nil, &MockError{Message: m.FailMessage}
@@ -175,7 +297,7 @@ func (m *SimpleMockSDK) GetRewardTemplate(templateID string) (*sdk.RewardTemplat
 }
 
 // GetClaimCount implements SDKInterface
-func (m *SimpleMockSDK) GetClaimCount(wallet common.Address, templateID string) (*big.Int, error) {
+func (m *SimpleMockSDK) GetClaimCount(ctx context.Context, wallet common.Address, templateID string) (*big.Int, error) {
 	m.Calls = append(m.Calls, "GetClaimCount")
 	if m.ShouldFail {
 		return nil, &MockError{Message: m.FailMessage}
@@ -184,7 +306,7 @@ func (m *SimpleMockSDK) GetClaimCount(wallet common.Address, templateID string)
 }
 
 // IsWhitelisted implements SDKInterface
-func (m *SimpleMockSDK) IsWhitelisted(wallet common.Address) (bool, error) {
+func (m *SimpleMockSDK) IsWhitelisted(ctx context.Context, wallet common.Address) (bool, error) {
 	m.Calls = append(m.Calls, "IsWhitelisted")
 	if m.ShouldFail {
 		return false, &MockError{Message: m.FailMessage}
@@ -193,7 +315,7 @@ func (m *SimpleMockSDK) IsWhitelisted(wallet common.Address) (bool, error) {
 }
 
 // GetRemainingDailyLimit implements SDKInterface
-func (m *SimpleMockSDK) GetRemainingDailyLimit() (*big.Int, error) {
+func (m *SimpleMockSDK) GetRemainingDailyLimit(ctx context.Context) (*big.Int, error) {
 	m.Calls = append(m.Calls, "GetRemainingDailyLimit")
 	if m.ShouldFail {
 		return nil, &MockError{Message: m.FailMessage}
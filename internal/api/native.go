@@ -0,0 +1,198 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"bogowi-blockchain-go/internal/sdk"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// TransferNativeRequest represents a native CAM transfer request
+type TransferNativeRequest struct {
+	To     string `json:"to" binding:"required"`
+	Amount string `json:"amount" binding:"required"` // in CAM
+}
+
+// GetNativeBalance returns the native CAM balance for an address
+// @Summary Get native CAM balance
+// @Description Returns the native CAM balance for a given address
+// @Tags Native
+// @Param address path string true "Wallet address"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Success 200 {object} sdk.NativeBalance
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /native/balance/{address} [get]
+func (h *Handler) GetNativeBalance(c *gin.Context) {
+	address := c.Param("address")
+
+	// Validate Ethereum address
+	if !common.IsHexAddress(address) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid Ethereum address"})
+		return
+	}
+
+	// Get network parameter
+	network := c.Query("network")
+	if network == "" {
+		network = c.GetHeader("X-Network")
+	}
+	if network == "" {
+		network = "mainnet" // Default to mainnet if not specified
+	}
+
+	if h.NetworkHandler == nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Network handler not initialized"})
+		return
+	}
+
+	networkSDK, err := h.NetworkHandler.GetSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid network: " + network + ". Use 'testnet' or 'mainnet'"})
+		return
+	}
+
+	balance, err := networkSDK.GetNativeBalance(c.Request.Context(), address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, balance)
+}
+
+// TransferNative sends native CAM from the API wallet (backend only)
+// @Summary Transfer native CAM
+// @Description Sends native CAM to a wallet, e.g. to top up gas. Subject to per-transfer and daily caps.
+// @Tags Native
+// @Accept json
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Param dryRun query bool false "Estimate the transfer without sending it"
+// @Param request body TransferNativeRequest true "Transfer details"
+// @Success 200 {object} sdk.NativeTransfer
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /native/transfer [post]
+func (h *Handler) TransferNative(c *gin.Context) {
+	// Authenticate backend request
+	if !h.authenticateBackendRequest(c) {
+		return
+	}
+
+	var req TransferNativeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if !common.IsHexAddress(req.To) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid recipient address"})
+		return
+	}
+
+	networkSDK, network, ok := h.backendNetworkSDK(c)
+	if !ok {
+		return
+	}
+
+	// A dry run checks the caps and estimates, but reserves nothing
+	if dryRunRequested(c) {
+		est, err := networkSDK.EstimateTransferNative(c.Request.Context(), req.To, req.Amount)
+		if err != nil {
+			respondNativeTransferError(c, err)
+			return
+		}
+		respondDryRun(c, network, est)
+		return
+	}
+
+	transfer, err := networkSDK.TransferNative(c.Request.Context(), req.To, req.Amount)
+	if err != nil {
+		respondNativeTransferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"network":  network,
+		"transfer": transfer,
+	})
+}
+
+// respondNativeTransferError maps native transfer cap errors to their status
+func respondNativeTransferError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, sdk.ErrNativeDailyCapExceeded):
+		c.JSON(http.StatusTooManyRequests, ErrorResponse{Error: err.Error()})
+	case errors.Is(err, sdk.ErrNativeTransferCapExceeded):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+}
+
+// GetNativeTransfers lists native CAM transfers submitted today (backend only)
+// @Summary List native CAM transfers
+// @Description Returns the native CAM transfers submitted during the current UTC day
+// @Tags Native
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /native/transfers [get]
+func (h *Handler) GetNativeTransfers(c *gin.Context) {
+	// Authenticate backend request
+	if !h.authenticateBackendRequest(c) {
+		return
+	}
+
+	networkSDK, network, ok := h.backendNetworkSDK(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"network":   network,
+		"transfers": networkSDK.GetNativeTransfers(),
+	})
+}
+
+// backendNetworkSDK resolves the network SDK for backend-authenticated routes.
+// It defaults to testnet, matching the network authenticateBackendRequest
+// checks the secret against.
+func (h *Handler) backendNetworkSDK(c *gin.Context) (SDKInterface, string, bool) {
+	network := c.Query("network")
+	if network == "" {
+		network = c.GetHeader("X-Network")
+	}
+	if network == "" {
+		network = "testnet"
+	}
+
+	if h.NetworkHandler == nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Network handler not initialized"})
+		return nil, "", false
+	}
+
+	networkSDK, err := h.NetworkHandler.GetSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return nil, "", false
+		}
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid network: " + network + ". Use 'testnet' or 'mainnet'"})
+		return nil, "", false
+	}
+
+	return networkSDK, network, true
+}
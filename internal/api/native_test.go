@@ -0,0 +1,251 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bogowi-blockchain-go/internal/config"
+	"bogowi-blockchain-go/internal/sdk"
+	"bogowi-blockchain-go/internal/sdk/gas"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupNativeRouter() (*gin.Engine, *MockSDK) {
+	gin.SetMode(gin.TestMode)
+
+	mockSDK := new(MockSDK)
+	cfg := &config.Config{
+		BackendSecret:    "test-secret",
+		DevBackendSecret: "test-dev-secret",
+	}
+
+	handler := &Handler{
+		SDK: mockSDK,
+		NetworkHandler: &NetworkHandler{
+			testnetSDK: mockSDK,
+			mainnetSDK: mockSDK,
+			config:     cfg,
+		},
+		Config: cfg,
+	}
+
+	router := gin.New()
+	setupNativeRoutes(router.Group("/api"), handler)
+
+	return router, mockSDK
+}
+
+func TestGetNativeBalance(t *testing.T) {
+	address := "0x742d35Cc6634C0532925a3b844Bc9e7595f8E97D"
+
+	tests := []struct {
+		name           string
+		address        string
+		mockBalance    *sdk.NativeBalance
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "successful retrieval",
+			address:        address,
+			mockBalance:    &sdk.NativeBalance{Address: address, Balance: "2.5", RawBalance: "2500000000000000000"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid address",
+			address:        "invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "sdk error",
+			address:        address,
+			mockError:      fmt.Errorf("connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockSDK := setupNativeRouter()
+			if tt.mockBalance != nil || tt.mockError != nil {
+				mockSDK.On("GetNativeBalance", tt.address).Return(tt.mockBalance, tt.mockError)
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/native/balance/"+tt.address, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockSDK.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransferNative(t *testing.T) {
+	recipient := "0x742d35Cc6634C0532925a3b844Bc9e7595f8E97D"
+
+	tests := []struct {
+		name           string
+		authHeader     string
+		requestBody    interface{}
+		mockTransfer   *sdk.NativeTransfer
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "successful transfer",
+			authHeader:     "test-dev-secret",
+			requestBody:    TransferNativeRequest{To: recipient, Amount: "0.5"},
+			mockTransfer:   &sdk.NativeTransfer{TxHash: "0xabc", To: recipient, Amount: "0.5"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing auth",
+			requestBody:    TransferNativeRequest{To: recipient, Amount: "0.5"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "invalid recipient",
+			authHeader:     "test-dev-secret",
+			requestBody:    TransferNativeRequest{To: "invalid", Amount: "0.5"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing amount",
+			authHeader:     "test-dev-secret",
+			requestBody:    map[string]string{"to": recipient},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "per-transfer cap exceeded",
+			authHeader:     "test-dev-secret",
+			requestBody:    TransferNativeRequest{To: recipient, Amount: "5"},
+			mockError:      fmt.Errorf("%w (1 CAM)", sdk.ErrNativeTransferCapExceeded),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "daily cap exceeded",
+			authHeader:     "test-dev-secret",
+			requestBody:    TransferNativeRequest{To: recipient, Amount: "1"},
+			mockError:      fmt.Errorf("%w (0.5 CAM left today)", sdk.ErrNativeDailyCapExceeded),
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:           "send failure",
+			authHeader:     "test-dev-secret",
+			requestBody:    TransferNativeRequest{To: recipient, Amount: "1"},
+			mockError:      fmt.Errorf("failed to send transaction: nonce too low"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockSDK := setupNativeRouter()
+			if tt.mockTransfer != nil || tt.mockError != nil {
+				body := tt.requestBody.(TransferNativeRequest)
+				mockSDK.On("TransferNative", body.To, body.Amount).Return(tt.mockTransfer, tt.mockError)
+			}
+
+			jsonBody, _ := json.Marshal(tt.requestBody)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/native/transfer", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.authHeader != "" {
+				req.Header.Set("X-Backend-Auth", tt.authHeader)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response map[string]interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "testnet", response["network"])
+				assert.Equal(t, "0xabc", response["transfer"].(map[string]interface{})["transactionHash"])
+			}
+			mockSDK.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransferNativeDryRun(t *testing.T) {
+	recipient := "0x742d35Cc6634C0532925a3b844Bc9e7595f8E97D"
+
+	tests := []struct {
+		name           string
+		mockEstimate   *gas.Estimate
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "returns the estimate",
+			mockEstimate:   &gas.Estimate{EstimatedGas: 21000, GasLimit: 25200, GasPrice: "25000000000", Value: "500000000000000000", MaxCost: "500630000000000000"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "per-transfer cap exceeded",
+			mockError:      fmt.Errorf("%w (1 CAM)", sdk.ErrNativeTransferCapExceeded),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "estimation failure",
+			mockError:      fmt.Errorf("failed to estimate gas: execution reverted"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockSDK := setupNativeRouter()
+			mockSDK.On("EstimateTransferNative", recipient, "0.5").Return(tt.mockEstimate, tt.mockError)
+
+			jsonBody, _ := json.Marshal(TransferNativeRequest{To: recipient, Amount: "0.5"})
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/native/transfer?dryRun=true", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Backend-Auth", "test-dev-secret")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response map[string]interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, true, response["dryRun"])
+				estimate := response["estimate"].(map[string]interface{})
+				assert.Equal(t, float64(25200), estimate["gasLimit"])
+				assert.Equal(t, "500630000000000000", estimate["maxCost"])
+			}
+			mockSDK.AssertNotCalled(t, "TransferNative", recipient, "0.5")
+			mockSDK.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetNativeTransfers(t *testing.T) {
+	router, mockSDK := setupNativeRouter()
+	mockSDK.On("GetNativeTransfers").Return([]*sdk.NativeTransfer{{TxHash: "0xabc"}})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/native/transfers?network=mainnet", nil)
+	req.Header.Set("X-Backend-Auth", "test-secret")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response["transfers"], 1)
+
+	// Dev secret is not valid for mainnet
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/native/transfers?network=mainnet", nil)
+	req.Header.Set("X-Backend-Auth", "test-dev-secret")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -1,15 +1,34 @@
 package api
 
 import (
+	"context"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"bogowi-blockchain-go/internal/config"
+	"bogowi-blockchain-go/internal/database"
 	"bogowi-blockchain-go/internal/sdk"
+	"bogowi-blockchain-go/internal/sdk/contracts"
+	"bogowi-blockchain-go/internal/sdk/events"
+	"bogowi-blockchain-go/internal/sdk/failover"
+	"bogowi-blockchain-go/internal/sdk/gas"
+	"bogowi-blockchain-go/internal/sdk/monitor"
 	"bogowi-blockchain-go/internal/sdk/nft"
+	"bogowi-blockchain-go/internal/sdk/signer"
+	"bogowi-blockchain-go/internal/sdk/txtrack"
+	"bogowi-blockchain-go/internal/sdk/wallet"
+	"bogowi-blockchain-go/internal/storage"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
-// NetworkHandler manages SDK instances for both testnet and mainnet
+// NetworkHandler manages SDK instances for both testnet and mainnet. Networks
+// connect in the background, so one that is unreachable at startup does not
+// keep the server from starting; it is retried until it connects.
 type NetworkHandler struct {
 	testnetSDK    SDKInterface
 	mainnetSDK    SDKInterface
@@ -17,78 +36,589 @@ type NetworkHandler struct {
 	mainnetNFTSDK *nft.Client
 	config        *config.Config
 	mu            sync.RWMutex
+
+	// Transaction signers per network, shared by its SDKs
+	signers map[string]signer.Signer
+
+	// Connection state of each configured network
+	states map[string]*NetworkStatus
+
+	// RPC clients per network shared by the background services
+	rpcClients map[string]*failover.Client
+
+	// Transaction outbox monitors per network
+	trackers map[string]*txtrack.Tracker
+	decoder  *txtrack.Decoder
+	claims   storage.RewardsStorage
+
+	// Hot wallet pools per network
+	pools map[string]*wallet.Pool
+
+	// Signer and distributor balance monitors per network
+	monitors map[string]*monitor.Monitor
+
+	// Ticket contract event managers per network and the consumers of
+	// their events
+	events        map[string]*events.Manager
+	eventHandlers []events.Handler
+
+	// Ticket expiry sweepers per network and the consumers of the
+	// expiries they find
+	sweepers       map[string]*nft.Sweeper
+	expiryHandlers []nft.ExpiryHandler
+
+	// ctx runs the connection attempts and the background services until
+	// stop is called
+	ctx  context.Context
+	stop context.CancelFunc
+	wg   sync.WaitGroup
+}
+
+// networkSetup holds what a network needs before it can connect. Everything
+// that depends on configuration alone is built up front, so a mistake there
+// fails startup instead of being retried forever.
+type networkSetup struct {
+	name          string
+	config        *config.NetworkConfig
+	signer        signer.Signer
+	withSDK       bool
+	nftConfig     *nft.ClientConfig
+	pool          *wallet.Pool
+	trackerOpts   txtrack.Options
+	monitorConfig monitor.Config
+	events        *events.Manager
+	sweeperConfig nft.SweeperConfig
 }
 
-// NewNetworkHandler creates a new network-aware handler
+// NewNetworkHandler creates a new network-aware handler. Configuration errors
+// are returned; networks that cannot be reached start out unavailable and
+// are connected in the background.
 func NewNetworkHandler(cfg *config.Config) (*NetworkHandler, error) {
 	handler := &NetworkHandler{
-		config: cfg,
+		config:     cfg,
+		states:     make(map[string]*NetworkStatus),
+		rpcClients: make(map[string]*failover.Client),
+		trackers:   make(map[string]*txtrack.Tracker),
+		pools:      make(map[string]*wallet.Pool),
+		monitors:   make(map[string]*monitor.Monitor),
+		events:     make(map[string]*events.Manager),
+		sweepers:   make(map[string]*nft.Sweeper),
+	}
+
+	decoder, err := txtrack.NewDecoder(sdk.BOGOTokenABI, sdk.RewardDistributorABI, sdk.RoleManagerABI,
+		contracts.BOGOWITicketsMetaData.ABI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction tracking: %w", err)
+	}
+	handler.decoder = decoder
+
+	var setups []*networkSetup
+	for _, network := range []string{"testnet", "mainnet"} {
+		setup, err := handler.prepareNetwork(network)
+		if err != nil {
+			return nil, err
+		}
+		if setup != nil {
+			setups = append(setups, setup)
+		}
+	}
+
+	handler.ctx, handler.stop = context.WithCancel(context.Background())
+	for _, setup := range setups {
+		handler.states[setup.name] = &NetworkStatus{
+			Network: setup.name,
+			State:   NetworkConnecting,
+			Since:   time.Now().UTC(),
+		}
+		handler.wg.Add(1)
+		go handler.keepConnected(handler.ctx, setup)
+	}
+
+	return handler, nil
+}
+
+// prepareNetwork validates the configuration of a network and builds its
+// signer, hot wallet pool, tracking options, balance monitoring config,
+// ticket event manager and expiry sweeps. It returns nil for a network
+// without contracts.
+func (h *NetworkHandler) prepareNetwork(network string) (*networkSetup, error) {
+	privateKey, networkConfig := h.config.TestnetPrivateKey, &h.config.Testnet
+	if network == "mainnet" {
+		privateKey, networkConfig = h.config.MainnetPrivateKey, &h.config.Mainnet
+	}
+
+	withSDK := networkConfig.Contracts.BOGOToken != "" || networkConfig.Contracts.RewardDistributor != ""
+	withNFT := networkConfig.Contracts.BOGOWITickets != ""
+	if !withSDK && !withNFT {
+		return nil, nil
+	}
+
+	if privateKey == "" && !networkConfig.UsesExternalSigner() {
+		operations := network + " operations"
+		if !withSDK {
+			operations = "NFT operations"
+		}
+		return nil, fmt.Errorf("%s_PRIVATE_KEY is required for %s", strings.ToUpper(network), operations)
 	}
 
-	// Initialize testnet SDK
-	if cfg.Testnet.Contracts.BOGOToken != "" || cfg.Testnet.Contracts.RewardDistributor != "" {
-		testnetPrivateKey := cfg.TestnetPrivateKey
-		if testnetPrivateKey == "" {
-			return nil, fmt.Errorf("TESTNET_PRIVATE_KEY is required for testnet operations")
+	primary, err := h.networkSigner(network)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize %s signer: %w", network, err)
+	}
+	setup := &networkSetup{
+		name:    network,
+		config:  networkConfig,
+		signer:  primary,
+		withSDK: withSDK,
+	}
+
+	if _, err := sdk.RPCConfig(networkConfig); err != nil {
+		return nil, fmt.Errorf("invalid %s RPC config: %w", network, err)
+	}
+	if _, err := sdk.ParseTimeouts(networkConfig); err != nil {
+		return nil, fmt.Errorf("invalid %s timeouts: %w", network, err)
+	}
+
+	if withNFT {
+		nftConfig, err := nftClientConfig(network, primary, networkConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure %s NFT SDK: %w", network, err)
+		}
+		nftConfig.QRSecret = []byte(h.config.RedemptionQRSecret)
+		nftConfig.QRPeriod, err = redemptionQRPeriod(h.config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s redemption QR config: %w", network, err)
+		}
+		setup.nftConfig = &nftConfig
+
+		setup.events, err = ticketEventManager(network, networkConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s event config: %w", network, err)
 		}
-		testnetSDK, err := sdk.NewBOGOWISDK(&cfg.Testnet, testnetPrivateKey)
+
+		setup.sweeperConfig, err = expirySweeperConfig(network, h.config)
 		if err != nil {
-			return nil, fmt.Errorf("failed to initialize testnet SDK: %w", err)
+			return nil, fmt.Errorf("invalid %s expiry sweep config: %w", network, err)
 		}
-		handler.testnetSDK = testnetSDK
 	}
 
-	// Initialize mainnet SDK
-	if cfg.Mainnet.Contracts.BOGOToken != "" || cfg.Mainnet.Contracts.RewardDistributor != "" {
-		mainnetPrivateKey := cfg.MainnetPrivateKey
-		if mainnetPrivateKey == "" {
-			return nil, fmt.Errorf("MAINNET_PRIVATE_KEY is required for mainnet operations")
+	// Spread role-gated writes across the hot wallets; the network signer is
+	// the first wallet
+	if len(networkConfig.HotWalletKeys) > 0 {
+		signers := []signer.Signer{primary}
+		for i, key := range networkConfig.HotWalletKeys {
+			hot, err := signer.FromHex(key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s hot wallet key %d: %w", network, i+1, err)
+			}
+			signers = append(signers, hot)
 		}
-		mainnetSDK, err := sdk.NewBOGOWISDK(&cfg.Mainnet, mainnetPrivateKey)
+		setup.pool, err = wallet.NewPool(big.NewInt(networkConfig.ChainID), signers, wallet.Config{
+			MinBalance: networkConfig.HotWalletMinBalance,
+		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to initialize mainnet SDK: %w", err)
+			return nil, fmt.Errorf("invalid %s hot wallet pool: %w", network, err)
 		}
-		handler.mainnetSDK = mainnetSDK
 	}
 
-	// Initialize NFT SDKs if BOGOWITickets contract is configured
-	if cfg.Testnet.Contracts.BOGOWITickets != "" {
-		testnetPrivateKey := cfg.TestnetPrivateKey
-		if testnetPrivateKey == "" {
-			return nil, fmt.Errorf("TESTNET_PRIVATE_KEY is required for NFT operations")
+	setup.trackerOpts, err = txTrackerOptions(network, networkConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s transaction tracking config: %w", network, err)
+	}
+
+	setup.monitorConfig, err = balanceMonitorConfig(network, h.config, networkConfig, primary, setup.pool)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s balance monitoring config: %w", network, err)
+	}
+
+	return setup, nil
+}
+
+// connect checks that the RPC endpoints of a network answer with the expected
+// chain, creates its SDKs and starts its hot wallet pool, transaction tracker,
+// balance monitor, ticket event delivery, ticket index and expiry sweeps.
+// Nothing is kept when a step fails.
+func (h *NetworkHandler) connect(ctx context.Context, setup *networkSetup) (err error) {
+	var closers []func()
+	defer func() {
+		if err != nil {
+			for _, closer := range closers {
+				closer()
+			}
 		}
-		testnetNFTConfig := nft.ClientConfig{
-			PrivateKey:      testnetPrivateKey,
-			Network:         "testnet",
-			CustomRPCURL:    cfg.Testnet.RPCUrl,
-			DatakyteEnabled: true,
+	}()
+
+	client, err := sdk.DialRPC(ctx, setup.config)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	closers = append(closers, client.Close)
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("RPC unreachable: %w", err)
+	}
+	if setup.config.ChainID != 0 && chainID.Cmp(big.NewInt(setup.config.ChainID)) != 0 {
+		return fmt.Errorf("chain ID mismatch: expected %d, got %s", setup.config.ChainID, chainID)
+	}
+
+	var bogowiSDK *sdk.BOGOWISDK
+	if setup.withSDK {
+		bogowiSDK, err = sdk.NewBOGOWISDKWithSigner(setup.config, setup.signer)
+		if err != nil {
+			return fmt.Errorf("failed to initialize SDK: %w", err)
 		}
-		testnetNFTSDK, err := nft.NewClient(testnetNFTConfig)
+		closers = append(closers, bogowiSDK.Close)
+	}
+
+	var nftSDK *nft.Client
+	if setup.nftConfig != nil {
+		nftSDK, err = nft.NewClient(*setup.nftConfig)
 		if err != nil {
-			return nil, fmt.Errorf("failed to initialize testnet NFT SDK: %w", err)
+			return fmt.Errorf("failed to initialize NFT SDK: %w", err)
+		}
+		closers = append(closers, nftSDK.Close)
+	}
+
+	m, err := monitor.New(client, setup.monitorConfig)
+	if err != nil {
+		return fmt.Errorf("invalid balance monitoring config: %w", err)
+	}
+
+	// The pool must be set before the tracker so the tracker can sign
+	// replacements for every wallet
+	if setup.pool != nil {
+		if bogowiSDK != nil {
+			bogowiSDK.SetWalletPool(setup.pool)
+		}
+		if nftSDK != nil {
+			nftSDK.SetWalletPool(setup.pool)
+		}
+	}
+
+	// Record every transaction in the outbox and follow it until final
+	db := database.GetDB()
+	tracker := txtrack.NewTracker(setup.name, db, client, h.decoder, setup.trackerOpts)
+	tracker.OnReplacement(nftMintReplacementHandler(db, setup.name))
+	tracker.OnStatusChange(nftMintStatusHandler(db, setup.name))
+	if bogowiSDK != nil {
+		bogowiSDK.SetTxTracker(tracker)
+	}
+	if nftSDK != nil {
+		nftSDK.SetTxTracker(tracker)
+	}
+
+	// Top-ups go through the tracker
+	m.SetTxTracker(tracker)
+
+	// Index the tickets from the contract events, resuming where the index
+	// left off
+	var indexer *nft.Indexer
+	if nftSDK != nil && setup.events != nil {
+		indexer = nft.NewIndexer(setup.name, setup.events.Contract(), db, nftSDK)
+		indexer.SetConfirmations(setup.trackerOpts.Confirmations)
+		nftSDK.SetIndexer(indexer)
+		setup.events.SetCursor(indexer)
+		setup.events.OnRollback(indexer.Rollback)
+	}
+
+	// Expire tickets past their expiry, found in the index or else among
+	// the active mappings
+	var sweeper *nft.Sweeper
+	if nftSDK != nil {
+		sweeper = nft.NewSweeper(nftSDK, indexer, db, setup.sweeperConfig)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if h.claims != nil {
+		tracker.OnReplacement(claimReplacementHandler(h.claims))
+		tracker.OnStatusChange(claimStatusHandler(h.claims))
+	}
+	if setup.events != nil {
+		if indexer != nil {
+			setup.events.OnEvent(indexer.HandleEvent)
+		}
+		for _, handler := range h.eventHandlers {
+			setup.events.OnEvent(handler)
+		}
+	}
+	if sweeper != nil {
+		for _, handler := range h.expiryHandlers {
+			sweeper.OnExpired(handler)
+		}
+	}
+
+	if setup.name == "mainnet" {
+		if bogowiSDK != nil {
+			h.mainnetSDK = bogowiSDK
+		}
+		h.mainnetNFTSDK = nftSDK
+	} else {
+		if bogowiSDK != nil {
+			h.testnetSDK = bogowiSDK
 		}
-		handler.testnetNFTSDK = testnetNFTSDK
+		h.testnetNFTSDK = nftSDK
+	}
+	h.rpcClients[setup.name] = client
+	h.trackers[setup.name] = tracker
+	h.monitors[setup.name] = m
+	if setup.pool != nil {
+		h.pools[setup.name] = setup.pool
+	}
+	if setup.events != nil {
+		h.events[setup.name] = setup.events
+	}
+	if sweeper != nil {
+		h.sweepers[setup.name] = sweeper
+	}
+	h.states[setup.name] = &NetworkStatus{
+		Network: setup.name,
+		State:   NetworkAvailable,
+		Since:   time.Now().UTC(),
+	}
+
+	// The services outlive the connection attempt, so they run on the
+	// handler context rather than ctx
+	if setup.pool != nil {
+		h.runService(func(ctx context.Context) { setup.pool.Run(ctx, client) })
 	}
+	h.runService(tracker.Run)
+	h.runService(m.Run)
+	if indexer != nil {
+		h.runService(indexer.Run)
+	}
+	if setup.events != nil {
+		h.runService(func(ctx context.Context) { setup.events.Run(ctx, client) })
+	}
+	if sweeper != nil {
+		h.runService(sweeper.Run)
+	}
+
+	return nil
+}
+
+// redemptionQRPeriod reads how often rotating redemption QR codes change.
+// Holders' apps compute steps in whole seconds.
+func redemptionQRPeriod(cfg *config.Config) (time.Duration, error) {
+	if cfg.RedemptionQRPeriod == "" {
+		return 0, nil
+	}
+	period, err := time.ParseDuration(cfg.RedemptionQRPeriod)
+	if err != nil {
+		return 0, fmt.Errorf("invalid period %q: %w", cfg.RedemptionQRPeriod, err)
+	}
+	if period < time.Second || period%time.Second != 0 {
+		return 0, fmt.Errorf("invalid period %q: must be a whole number of seconds", cfg.RedemptionQRPeriod)
+	}
+	return period, nil
+}
 
-	if cfg.Mainnet.Contracts.BOGOWITickets != "" {
-		mainnetPrivateKey := cfg.MainnetPrivateKey
-		if mainnetPrivateKey == "" {
-			return nil, fmt.Errorf("MAINNET_PRIVATE_KEY is required for NFT operations")
+// ticketEventManager creates the manager following the events of the
+// ticket contract. It subscribes over WebSocket when a ws:// URL is
+// configured and polls the RPC endpoints otherwise.
+func ticketEventManager(network string, networkConfig *config.NetworkConfig) (*events.Manager, error) {
+	eventConfig := events.Config{
+		Network:  network,
+		Contract: common.HexToAddress(networkConfig.Contracts.BOGOWITickets),
+		WSURL:    networkConfig.WSUrl,
+	}
+	if networkConfig.EventPollInterval != "" {
+		interval, err := time.ParseDuration(networkConfig.EventPollInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid poll interval %q: %w", networkConfig.EventPollInterval, err)
 		}
-		mainnetNFTConfig := nft.ClientConfig{
-			PrivateKey:      mainnetPrivateKey,
-			Network:         "mainnet",
-			CustomRPCURL:    cfg.Mainnet.RPCUrl,
-			DatakyteEnabled: true,
+		eventConfig.PollInterval = interval
+	}
+	if networkConfig.EventStartBlock != "" {
+		block, err := strconv.ParseUint(networkConfig.EventStartBlock, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start block %q: %w", networkConfig.EventStartBlock, err)
 		}
-		mainnetNFTSDK, err := nft.NewClient(mainnetNFTConfig)
+		eventConfig.FromBlock = block
+	}
+	return events.New(eventConfig)
+}
+
+// balanceMonitorConfig lists the balances to watch on a network: the CAM of
+// the signer, the hot wallets and the funding wallet, and the BOGO held by
+// the RewardDistributor. Signer and hot wallets are topped up when a funding
+// key is configured.
+func balanceMonitorConfig(network string, cfg *config.Config, networkConfig *config.NetworkConfig, primary signer.Signer, pool *wallet.Pool) (monitor.Config, error) {
+	monitorConfig := monitor.Config{
+		Network: network,
+		ChainID: big.NewInt(networkConfig.ChainID),
+	}
+
+	if cfg.BalanceCheckInterval != "" {
+		interval, err := time.ParseDuration(cfg.BalanceCheckInterval)
 		if err != nil {
-			return nil, fmt.Errorf("failed to initialize mainnet NFT SDK: %w", err)
+			return monitorConfig, fmt.Errorf("invalid check interval %q: %w", cfg.BalanceCheckInterval, err)
 		}
-		handler.mainnetNFTSDK = mainnetNFTSDK
+		monitorConfig.Interval = interval
+	}
+	if cfg.BalanceWebhookURL != "" {
+		monitorConfig.Alerts = monitor.NewWebhook(cfg.BalanceWebhookURL)
 	}
 
-	return handler, nil
+	threshold, err := wallet.ParseCAM(networkConfig.BalanceAlertThreshold)
+	if err != nil {
+		return monitorConfig, fmt.Errorf("invalid balance alert threshold: %w", err)
+	}
+	topUp := networkConfig.FundingPrivateKey != ""
+
+	monitorConfig.Accounts = append(monitorConfig.Accounts, monitor.Account{
+		Name:      monitor.NameSigner,
+		Address:   primary.Address(),
+		Threshold: threshold,
+		TopUp:     topUp,
+	})
+	if pool != nil {
+		for _, hot := range pool.Wallets() {
+			if hot.From == primary.Address() {
+				continue
+			}
+			monitorConfig.Accounts = append(monitorConfig.Accounts, monitor.Account{
+				Name:      monitor.NameHotWallet,
+				Address:   hot.From,
+				Threshold: threshold,
+				TopUp:     topUp,
+			})
+		}
+	}
+
+	if topUp {
+		funder, err := signer.FromHex(networkConfig.FundingPrivateKey)
+		if err != nil {
+			return monitorConfig, fmt.Errorf("invalid funding key: %w", err)
+		}
+		target, err := wallet.ParseCAM(networkConfig.TopUpTarget)
+		if err != nil {
+			return monitorConfig, fmt.Errorf("invalid top-up target: %w", err)
+		}
+		dailyMax, err := wallet.ParseCAM(networkConfig.TopUpDailyMax)
+		if err != nil {
+			return monitorConfig, fmt.Errorf("invalid top-up daily max: %w", err)
+		}
+		feeConfig, err := gas.ParseConfig(networkConfig.GasStrategy, networkConfig.GasMultiplier,
+			networkConfig.MaxGasPrice, networkConfig.FixedGasPrice)
+		if err != nil {
+			return monitorConfig, err
+		}
+		fees, err := gas.NewStrategy(feeConfig)
+		if err != nil {
+			return monitorConfig, err
+		}
+
+		monitorConfig.TopUp = &monitor.TopUp{Funder: funder, Target: target, DailyMax: dailyMax, Fees: fees}
+		monitorConfig.Accounts = append(monitorConfig.Accounts, monitor.Account{
+			Name:      monitor.NameFunding,
+			Address:   funder.Address(),
+			Threshold: threshold,
+		})
+	}
+
+	contracts := networkConfig.Contracts
+	if common.IsHexAddress(contracts.BOGOToken) && common.IsHexAddress(contracts.RewardDistributor) {
+		// BOGO has 18 decimals like CAM
+		distributorThreshold, err := wallet.ParseCAM(networkConfig.DistributorAlertThreshold)
+		if err != nil {
+			return monitorConfig, fmt.Errorf("invalid distributor alert threshold: %w", err)
+		}
+		monitorConfig.Accounts = append(monitorConfig.Accounts, monitor.Account{
+			Name:      monitor.NameRewardDistributor,
+			Address:   common.HexToAddress(contracts.RewardDistributor),
+			Asset:     monitor.AssetBOGO,
+			Token:     common.HexToAddress(contracts.BOGOToken),
+			Threshold: distributorThreshold,
+		})
+	}
+
+	return monitorConfig, nil
+}
+
+// networkSigner returns the transaction signer configured for a network.
+// The BOGOWI and NFT SDKs of a network share one signer, so a remote signer
+// is connected to once.
+func (h *NetworkHandler) networkSigner(network string) (signer.Signer, error) {
+	if s, ok := h.signers[network]; ok {
+		return s, nil
+	}
+
+	privateKey, networkConfig := h.config.TestnetPrivateKey, &h.config.Testnet
+	if network == "mainnet" {
+		privateKey, networkConfig = h.config.MainnetPrivateKey, &h.config.Mainnet
+	}
+
+	s, err := signer.New(context.Background(), signer.Config{
+		Type:             networkConfig.Signer,
+		PrivateKey:       privateKey,
+		KeystoreFile:     networkConfig.KeystoreFile,
+		KeystorePassword: networkConfig.KeystorePassword,
+		RemoteURL:        networkConfig.RemoteSignerURL,
+		Address:          networkConfig.SignerAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if h.signers == nil {
+		h.signers = make(map[string]signer.Signer)
+	}
+	h.signers[network] = s
+	return s, nil
+}
+
+// nftClientConfig builds the NFT client configuration for a network,
+// including its gas pricing, RPC failover settings and receipt wait timeout
+func nftClientConfig(network string, txSigner signer.Signer, networkConfig *config.NetworkConfig) (nft.ClientConfig, error) {
+	fees, err := gas.ParseConfig(networkConfig.GasStrategy, networkConfig.GasMultiplier,
+		networkConfig.MaxGasPrice, networkConfig.FixedGasPrice)
+	if err != nil {
+		return nft.ClientConfig{}, err
+	}
+
+	rpcConfig, err := sdk.RPCConfig(networkConfig)
+	if err != nil {
+		return nft.ClientConfig{}, err
+	}
+
+	timeouts, err := sdk.ParseTimeouts(networkConfig)
+	if err != nil {
+		return nft.ClientConfig{}, err
+	}
+
+	depth, err := confirmationDepth(network, networkConfig)
+	if err != nil {
+		return nft.ClientConfig{}, err
+	}
+
+	clientConfig := nft.ClientConfig{
+		Confirmations:   int(depth),
+		Signer:          txSigner,
+		Network:         network,
+		RequestTimeout:  rpcConfig.RequestTimeout,
+		RetryAttempts:   rpcConfig.RetryAttempts,
+		MaxBlockLag:     rpcConfig.MaxBlockLag,
+		WaitTimeout:     timeouts.TxWait,
+		GasMultiplier:   fees.Multiplier,
+		MaxGasPrice:     fees.MaxGasPrice,
+		FeeMode:         fees.Mode,
+		FixedGasPrice:   fees.FixedGasPrice,
+		DatakyteEnabled: true,
+	}
+	if len(rpcConfig.URLs) > 0 {
+		clientConfig.CustomRPCURL = rpcConfig.URLs[0]
+		clientConfig.FallbackRPCURLs = rpcConfig.URLs[1:]
+	}
+	if common.IsHexAddress(networkConfig.Contracts.RoleManager) {
+		clientConfig.RoleManager = common.HexToAddress(networkConfig.Contracts.RoleManager)
+	}
+	return clientConfig, nil
 }
 
 // GetSDK returns the appropriate SDK based on the network parameter
@@ -98,11 +628,17 @@ func (h *NetworkHandler) GetSDK(network string) (SDKInterface, error) {
 
 	switch network {
 	case "testnet", "columbus":
+		if err := h.unavailableLocked("testnet"); err != nil {
+			return nil, err
+		}
 		if h.testnetSDK == nil {
 			return nil, fmt.Errorf("testnet SDK not initialized")
 		}
 		return h.testnetSDK, nil
 	case "mainnet", "camino":
+		if err := h.unavailableLocked("mainnet"); err != nil {
+			return nil, err
+		}
 		if h.mainnetSDK == nil {
 			return nil, fmt.Errorf("mainnet SDK not initialized")
 		}
@@ -119,11 +655,17 @@ func (h *NetworkHandler) GetNFTSDK(network string) (*nft.Client, error) {
 
 	switch network {
 	case "testnet", "columbus":
+		if err := h.unavailableLocked("testnet"); err != nil {
+			return nil, err
+		}
 		if h.testnetNFTSDK == nil {
 			return nil, fmt.Errorf("testnet NFT SDK not initialized")
 		}
 		return h.testnetNFTSDK, nil
 	case "mainnet", "camino":
+		if err := h.unavailableLocked("mainnet"); err != nil {
+			return nil, err
+		}
 		if h.mainnetNFTSDK == nil {
 			return nil, fmt.Errorf("mainnet NFT SDK not initialized")
 		}
@@ -133,8 +675,204 @@ func (h *NetworkHandler) GetNFTSDK(network string) (*nft.Client, error) {
 	}
 }
 
-// Close closes all SDK connections
+// IsTokenAllowed reports whether a token contract may be queried through the
+// generic token read endpoints on the given network. Our own contracts are
+// always allowed; third-party tokens must be listed in AllowedTokens.
+func (h *NetworkHandler) IsTokenAllowed(network string, token string) bool {
+	if h.config == nil || !common.IsHexAddress(token) {
+		return false
+	}
+
+	var netConfig *config.NetworkConfig
+	switch network {
+	case "testnet", "columbus":
+		netConfig = &h.config.Testnet
+	case "mainnet", "camino":
+		netConfig = &h.config.Mainnet
+	default:
+		return false
+	}
+
+	tokenAddr := common.HexToAddress(token)
+	allowed := append([]string{
+		netConfig.Contracts.BOGOToken,
+		netConfig.Contracts.BOGOWITickets,
+	}, netConfig.AllowedTokens...)
+
+	for _, candidate := range allowed {
+		if common.IsHexAddress(candidate) && common.HexToAddress(candidate) == tokenAddr {
+			return true
+		}
+	}
+	return false
+}
+
+// confirmationDepth returns the depth at which blocks of a network are
+// final: the configured one, or the chain default
+func confirmationDepth(network string, networkConfig *config.NetworkConfig) (uint64, error) {
+	if networkConfig.Confirmations != "" {
+		depth, err := strconv.ParseUint(networkConfig.Confirmations, 10, 64)
+		if err != nil || depth == 0 {
+			return 0, fmt.Errorf("invalid confirmations %q: must be a positive integer", networkConfig.Confirmations)
+		}
+		return depth, nil
+	}
+	if nftConfig, err := nft.GetNetworkConfig(network); err == nil {
+		return uint64(nftConfig.ConfirmationWait), nil
+	}
+	return 0, nil
+}
+
+// txTrackerOptions builds the tracker options of a network: confirmation
+// depth and polling follow the chain, replacements follow the gas settings
+func txTrackerOptions(network string, networkConfig *config.NetworkConfig) (txtrack.Options, error) {
+	var opts txtrack.Options
+	if nftConfig, err := nft.GetNetworkConfig(network); err == nil {
+		opts.PollInterval = nftConfig.BlockTime
+	}
+
+	depth, err := confirmationDepth(network, networkConfig)
+	if err != nil {
+		return opts, err
+	}
+	opts.Confirmations = depth
+
+	if networkConfig.TxReplaceTimeout != "" {
+		timeout, err := time.ParseDuration(networkConfig.TxReplaceTimeout)
+		if err != nil {
+			return opts, fmt.Errorf("invalid replace timeout %q: %w", networkConfig.TxReplaceTimeout, err)
+		}
+		opts.ReplaceAfter = timeout
+	}
+
+	feeConfig, err := gas.ParseConfig(networkConfig.GasStrategy, networkConfig.GasMultiplier,
+		networkConfig.MaxGasPrice, networkConfig.FixedGasPrice)
+	if err != nil {
+		return opts, err
+	}
+	if opts.Fees, err = gas.NewStrategy(feeConfig); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// OnContractEvent registers a consumer of the ticket contract events of
+// every network. Networks that connect later deliver to it as well.
+func (h *NetworkHandler) OnContractEvent(handler events.Handler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.eventHandlers = append(h.eventHandlers, handler)
+	for _, m := range h.events {
+		m.OnEvent(handler)
+	}
+}
+
+// GetTxTracker returns the transaction tracker of a network
+func (h *NetworkHandler) GetTxTracker(network string) (*txtrack.Tracker, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	switch network {
+	case "testnet", "columbus":
+		network = "testnet"
+	case "mainnet", "camino":
+		network = "mainnet"
+	default:
+		return nil, fmt.Errorf("invalid network: %s", network)
+	}
+
+	if err := h.unavailableLocked(network); err != nil {
+		return nil, err
+	}
+
+	tracker, ok := h.trackers[network]
+	if !ok {
+		return nil, fmt.Errorf("%s transaction tracking not initialized", network)
+	}
+	return tracker, nil
+}
+
+// GetWalletPool returns the hot wallet pool of a network
+func (h *NetworkHandler) GetWalletPool(network string) (*wallet.Pool, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	switch network {
+	case "testnet", "columbus":
+		network = "testnet"
+	case "mainnet", "camino":
+		network = "mainnet"
+	default:
+		return nil, fmt.Errorf("invalid network: %s", network)
+	}
+
+	if err := h.unavailableLocked(network); err != nil {
+		return nil, err
+	}
+
+	pool, ok := h.pools[network]
+	if !ok {
+		return nil, fmt.Errorf("%s has no hot wallet pool", network)
+	}
+	return pool, nil
+}
+
+// GetBalanceMonitor returns the balance monitor of a network
+func (h *NetworkHandler) GetBalanceMonitor(network string) (*monitor.Monitor, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	switch network {
+	case "testnet", "columbus":
+		network = "testnet"
+	case "mainnet", "camino":
+		network = "mainnet"
+	default:
+		return nil, fmt.Errorf("invalid network: %s", network)
+	}
+
+	m, ok := h.monitors[network]
+	if !ok {
+		return nil, fmt.Errorf("%s balance monitoring not initialized", network)
+	}
+	return m, nil
+}
+
+// BalanceMonitors returns the balance monitors of every initialized network
+func (h *NetworkHandler) BalanceMonitors() []*monitor.Monitor {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var monitors []*monitor.Monitor
+	for _, network := range []string{"testnet", "mainnet"} {
+		if m, ok := h.monitors[network]; ok {
+			monitors = append(monitors, m)
+		}
+	}
+	return monitors
+}
+
+// runService runs a background service of a connected network on the
+// handler context, so Close waits for it to stop
+func (h *NetworkHandler) runService(run func(ctx context.Context)) {
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		run(h.ctx)
+	}()
+}
+
+// Close stops connecting networks and background services, then closes all
+// SDK connections
 func (h *NetworkHandler) Close() {
+	// Connection attempts publish under the lock and services use the
+	// clients, so wait for both first
+	if h.stop != nil {
+		h.stop()
+	}
+	h.wg.Wait()
+
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
@@ -150,4 +888,12 @@ func (h *NetworkHandler) Close() {
 	if h.mainnetNFTSDK != nil {
 		h.mainnetNFTSDK.Close()
 	}
+	for _, s := range h.signers {
+		if remote, ok := s.(*signer.RemoteSigner); ok {
+			remote.Close()
+		}
+	}
+	for _, client := range h.rpcClients {
+		client.Close()
+	}
 }
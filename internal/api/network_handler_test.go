@@ -1,13 +1,23 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"bogowi-blockchain-go/internal/config"
 	"bogowi-blockchain-go/internal/sdk"
+	"bogowi-blockchain-go/internal/sdk/gas"
+	"bogowi-blockchain-go/internal/sdk/monitor"
 	"bogowi-blockchain-go/internal/sdk/nft"
+	"bogowi-blockchain-go/internal/sdk/signer"
+	"bogowi-blockchain-go/internal/sdk/wallet"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
@@ -21,7 +31,7 @@ type TestMockSDK struct {
 	CloseFunc func() error
 }
 
-func (m *TestMockSDK) GetTokenBalance(address string) (*sdk.TokenBalance, error) {
+func (m *TestMockSDK) GetTokenBalance(ctx context.Context, address string) (*sdk.TokenBalance, error) {
 	args := m.Called(address)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -29,16 +39,95 @@ func (m *TestMockSDK) GetTokenBalance(address string) (*sdk.TokenBalance, error)
 	return args.Get(0).(*sdk.TokenBalance), args.Error(1)
 }
 
-func (m *TestMockSDK) GetGasPrice() (string, error) {
+func (m *TestMockSDK) GetTokenBalances(ctx context.Context, addresses []string) ([]*sdk.AccountBalance, error) {
+	args := m.Called(addresses)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sdk.AccountBalance), args.Error(1)
+}
+
+func (m *TestMockSDK) GetERC20Info(ctx context.Context, token string) (*sdk.ERC20Info, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sdk.ERC20Info), args.Error(1)
+}
+
+func (m *TestMockSDK) GetERC20Balance(ctx context.Context, token string, holder string) (*sdk.ERC20Balance, error) {
+	args := m.Called(token, holder)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sdk.ERC20Balance), args.Error(1)
+}
+
+func (m *TestMockSDK) GetERC721Owner(ctx context.Context, token string, tokenID *big.Int) (string, error) {
+	args := m.Called(token, tokenID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *TestMockSDK) GetERC721TokenURI(ctx context.Context, token string, tokenID *big.Int) (string, error) {
+	args := m.Called(token, tokenID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *TestMockSDK) GetNativeBalance(ctx context.Context, address string) (*sdk.NativeBalance, error) {
+	args := m.Called(address)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sdk.NativeBalance), args.Error(1)
+}
+
+func (m *TestMockSDK) TransferNative(ctx context.Context, to string, amount string) (*sdk.NativeTransfer, error) {
+	args := m.Called(to, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sdk.NativeTransfer), args.Error(1)
+}
+
+func (m *TestMockSDK) EstimateTransferNative(ctx context.Context, to string, amount string) (*gas.Estimate, error) {
+	args := m.Called(to, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gas.Estimate), args.Error(1)
+}
+
+func (m *TestMockSDK) GetNativeTransfers() []*sdk.NativeTransfer {
+	args := m.Called()
+	return args.Get(0).([]*sdk.NativeTransfer)
+}
+
+func (m *TestMockSDK) GetGasPrice(ctx context.Context) (string, error) {
 	args := m.Called()
 	return args.String(0), args.Error(1)
 }
 
-func (m *TestMockSDK) TransferBOGOTokens(to string, amount string) (string, error) {
+func (m *TestMockSDK) GetGasFees(ctx context.Context) (*sdk.GasFees, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sdk.GasFees), args.Error(1)
+}
+
+func (m *TestMockSDK) TransferBOGOTokens(ctx context.Context, to string, amount string) (string, error) {
 	args := m.Called(to, amount)
 	return args.String(0), args.Error(1)
 }
 
+func (m *TestMockSDK) EstimateTransferBOGOTokens(ctx context.Context, to string, amount string) (*gas.Estimate, error) {
+	args := m.Called(to, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gas.Estimate), args.Error(1)
+}
+
 func (m *TestMockSDK) GetPublicKey() (string, error) {
 	args := m.Called()
 	return args.String(0), args.Error(1)
@@ -52,12 +141,12 @@ func (m *TestMockSDK) Close() {
 	}
 }
 
-func (m *TestMockSDK) CheckRewardEligibility(templateID string, wallet common.Address) (bool, string, error) {
+func (m *TestMockSDK) CheckRewardEligibility(ctx context.Context, templateID string, wallet common.Address) (bool, string, error) {
 	args := m.Called(templateID, wallet)
 	return args.Bool(0), args.String(1), args.Error(2)
 }
 
-func (m *TestMockSDK) ClaimRewardV2(templateID string, recipient common.Address) (*types.Transaction, error) {
+func (m *TestMockSDK) ClaimRewardV2(ctx context.Context, templateID string, recipient common.Address) (*types.Transaction, error) {
 	args := m.Called(templateID, recipient)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -65,7 +154,7 @@ func (m *TestMockSDK) ClaimRewardV2(templateID string, recipient common.Address)
 	return args.Get(0).(*types.Transaction), args.Error(1)
 }
 
-func (m *TestMockSDK) ClaimCustomReward(recipient common.Address, amount *big.Int, reason string) (*types.Transaction, error) {
+func (m *TestMockSDK) ClaimCustomReward(ctx context.Context, recipient common.Address, amount *big.Int, reason string) (*types.Transaction, error) {
 	args := m.Called(recipient, amount, reason)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -73,7 +162,15 @@ func (m *TestMockSDK) ClaimCustomReward(recipient common.Address, amount *big.In
 	return args.Get(0).(*types.Transaction), args.Error(1)
 }
 
-func (m *TestMockSDK) ClaimReferralBonus(referrer common.Address, referred common.Address) (*types.Transaction, error) {
+func (m *TestMockSDK) EstimateClaimCustomReward(ctx context.Context, recipient common.Address, amount *big.Int, reason string) (*gas.Estimate, error) {
+	args := m.Called(recipient, amount, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gas.Estimate), args.Error(1)
+}
+
+func (m *TestMockSDK) ClaimReferralBonus(ctx context.Context, referrer common.Address, referred common.Address) (*types.Transaction, error) {
 	args := m.Called(referrer, referred)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -81,12 +178,12 @@ func (m *TestMockSDK) ClaimReferralBonus(referrer common.Address, referred commo
 	return args.Get(0).(*types.Transaction), args.Error(1)
 }
 
-func (m *TestMockSDK) GetReferrer(wallet common.Address) (common.Address, error) {
+func (m *TestMockSDK) GetReferrer(ctx context.Context, wallet common.Address) (common.Address, error) {
 	args := m.Called(wallet)
 	return args.Get(0).(common.Address), args.Error(1)
 }
 
-func (m *TestMockSDK) GetRewardTemplate(templateID string) (*sdk.RewardTemplate, error) {
+func (m *TestMockSDK) GetRewardTemplate(ctx context.Context, templateID string) (*sdk.RewardTemplate, error) {
 	args := m.Called(templateID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -94,7 +191,7 @@ func (m *TestMockSDK) GetRewardTemplate(templateID string) (*sdk.RewardTemplate,
 	return args.Get(0).(*sdk.RewardTemplate), args.Error(1)
 }
 
-func (m *TestMockSDK) GetClaimCount(wallet common.Address, templateID string) (*big.Int, error) {
+func (m *TestMockSDK) GetClaimCount(ctx context.Context, wallet common.Address, templateID string) (*big.Int, error) {
 	args := m.Called(wallet, templateID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -102,12 +199,12 @@ func (m *TestMockSDK) GetClaimCount(wallet common.Address, templateID string) (*
 	return args.Get(0).(*big.Int), args.Error(1)
 }
 
-func (m *TestMockSDK) IsWhitelisted(wallet common.Address) (bool, error) {
+func (m *TestMockSDK) IsWhitelisted(ctx context.Context, wallet common.Address) (bool, error) {
 	args := m.Called(wallet)
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *TestMockSDK) GetRemainingDailyLimit() (*big.Int, error) {
+func (m *TestMockSDK) GetRemainingDailyLimit(ctx context.Context) (*big.Int, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -186,6 +283,19 @@ func TestNewNetworkHandler(t *testing.T) {
 			wantErr: true,
 			errMsg:  "TESTNET_PRIVATE_KEY is required for testnet operations",
 		},
+		{
+			name: "error when testnet keystore signer has no keystore file",
+			config: &config.Config{
+				Testnet: config.NetworkConfig{
+					Contracts: config.ContractAddresses{
+						BOGOToken: "0x123",
+					},
+					Signer: "keystore",
+				},
+			},
+			wantErr: true,
+			errMsg:  "failed to initialize testnet signer: keystore file is required",
+		},
 	}
 
 	for _, tt := range tests {
@@ -640,3 +750,357 @@ func TestNetworkHandler_Close_WithError(t *testing.T) {
 	assert.True(t, testnetClosed)
 	assert.True(t, mainnetClosed)
 }
+
+func TestNetworkHandlerCloseStopsServices(t *testing.T) {
+	mockTestnetSDK := &TestMockSDK{}
+	handler := &NetworkHandler{testnetSDK: mockTestnetSDK}
+	handler.ctx, handler.stop = context.WithCancel(context.Background())
+
+	var stopped atomic.Int32
+	started := make(chan struct{}, 2)
+	for i := 0; i < 2; i++ {
+		handler.runService(func(ctx context.Context) {
+			started <- struct{}{}
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			stopped.Add(1)
+		})
+	}
+	<-started
+	<-started
+
+	// Services use the clients, so they stop before the SDKs close
+	var stoppedAtClose int32
+	mockTestnetSDK.CloseFunc = func() error {
+		stoppedAtClose = stopped.Load()
+		return nil
+	}
+
+	handler.Close()
+	assert.Equal(t, int32(2), stopped.Load())
+	assert.Equal(t, int32(2), stoppedAtClose)
+}
+
+func TestBalanceMonitorConfig(t *testing.T) {
+	primary, err := signer.FromHex("0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	require.NoError(t, err)
+	hot, err := signer.FromHex("0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890")
+	require.NoError(t, err)
+	pool, err := wallet.NewPool(big.NewInt(501), []signer.Signer{primary, hot}, wallet.Config{})
+	require.NoError(t, err)
+
+	baseNetwork := func() config.NetworkConfig {
+		return config.NetworkConfig{
+			ChainID: 501,
+			Contracts: config.ContractAddresses{
+				BOGOToken:         "0xC53c2f11e1d2e36CB5888BfEE157F78e04Bb4F76",
+				RewardDistributor: "0x289cb4E70D0a876E8f885f39D23f8E01E475A111",
+			},
+			BalanceAlertThreshold:     "1",
+			DistributorAlertThreshold: "10000",
+			TopUpTarget:               "2",
+			TopUpDailyMax:             "10",
+		}
+	}
+
+	tests := []struct {
+		name         string
+		modify       func(cfg *config.Config, nc *config.NetworkConfig)
+		pool         *wallet.Pool
+		wantAccounts []string
+		wantTopUp    bool
+		errMsg       string
+	}{
+		{
+			name:         "signer and distributor",
+			wantAccounts: []string{monitor.NameSigner, monitor.NameRewardDistributor},
+		},
+		{
+			name: "hot wallets and funding wallet",
+			pool: pool,
+			modify: func(cfg *config.Config, nc *config.NetworkConfig) {
+				nc.FundingPrivateKey = "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567891"
+			},
+			wantAccounts: []string{monitor.NameSigner, monitor.NameHotWallet, monitor.NameFunding, monitor.NameRewardDistributor},
+			wantTopUp:    true,
+		},
+		{
+			name:         "no distributor without token",
+			modify:       func(cfg *config.Config, nc *config.NetworkConfig) { nc.Contracts.BOGOToken = "" },
+			wantAccounts: []string{monitor.NameSigner},
+		},
+		{
+			name:   "invalid threshold",
+			modify: func(cfg *config.Config, nc *config.NetworkConfig) { nc.BalanceAlertThreshold = "lots" },
+			errMsg: "invalid balance alert threshold",
+		},
+		{
+			name:   "invalid funding key",
+			modify: func(cfg *config.Config, nc *config.NetworkConfig) { nc.FundingPrivateKey = "0xnope" },
+			errMsg: "invalid funding key",
+		},
+		{
+			name:   "invalid interval",
+			modify: func(cfg *config.Config, nc *config.NetworkConfig) { cfg.BalanceCheckInterval = "often" },
+			errMsg: "invalid check interval",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{BalanceCheckInterval: "30s", BalanceWebhookURL: "https://hooks.example.com"}
+			nc := baseNetwork()
+			if tt.modify != nil {
+				tt.modify(cfg, &nc)
+			}
+
+			monitorConfig, err := balanceMonitorConfig("testnet", cfg, &nc, primary, tt.pool)
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			names := make([]string, len(monitorConfig.Accounts))
+			for i, account := range monitorConfig.Accounts {
+				names[i] = account.Name
+				if account.Name == monitor.NameSigner || account.Name == monitor.NameHotWallet {
+					assert.Equal(t, tt.wantTopUp, account.TopUp)
+				}
+			}
+			assert.Equal(t, tt.wantAccounts, names)
+			assert.Equal(t, primary.Address(), monitorConfig.Accounts[0].Address)
+			assert.Equal(t, tt.wantTopUp, monitorConfig.TopUp != nil)
+			assert.Equal(t, 30*time.Second, monitorConfig.Interval)
+			assert.NotNil(t, monitorConfig.Alerts)
+		})
+	}
+}
+
+// fakeChain is a JSON-RPC endpoint that answers 503 until it is brought up
+type fakeChain struct {
+	server *httptest.Server
+	up     atomic.Bool
+}
+
+func newFakeChain(t *testing.T) *fakeChain {
+	t.Helper()
+	chain := &fakeChain{}
+	chain.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !chain.up.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch req.Method {
+		case "eth_chainId":
+			resp["result"] = "0x1f5"
+		case "eth_blockNumber", "eth_getBalance":
+			resp["result"] = "0x1"
+		default:
+			resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(chain.server.Close)
+	return chain
+}
+
+func TestNetworkHandlerReconnects(t *testing.T) {
+	// Tracking opens the default database under HOME
+	t.Setenv("HOME", t.TempDir())
+
+	baseDelay, maxDelay := reconnectBaseDelay, reconnectMaxDelay
+	reconnectBaseDelay, reconnectMaxDelay = 10*time.Millisecond, 20*time.Millisecond
+	t.Cleanup(func() { reconnectBaseDelay, reconnectMaxDelay = baseDelay, maxDelay })
+
+	chain := newFakeChain(t)
+	cfg := &config.Config{
+		Environment:       "development",
+		TestnetPrivateKey: "0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		Testnet: config.NetworkConfig{
+			RPCUrl:           chain.server.URL,
+			ChainID:          501,
+			RPCRetryAttempts: "0",
+			Contracts: config.ContractAddresses{
+				BOGOToken:         "0xC53c2f11e1d2e36CB5888BfEE157F78e04Bb4F76",
+				RewardDistributor: "0x289cb4E70D0a876E8f885f39D23f8E01E475A111",
+			},
+			BalanceAlertThreshold:     "1",
+			DistributorAlertThreshold: "10000",
+		},
+	}
+
+	handler, err := NewNetworkHandler(cfg)
+	require.NoError(t, err, "an unreachable network must not fail startup")
+	t.Cleanup(handler.Close)
+
+	// Down: routes get a NetworkUnavailableError with the reason
+	require.Eventually(t, func() bool {
+		status, _ := handler.NetworkStatus("testnet")
+		return status.State == NetworkUnavailable
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = handler.GetSDK("testnet")
+	var unavailable *NetworkUnavailableError
+	require.True(t, errors.As(err, &unavailable))
+	assert.Equal(t, "testnet", unavailable.Network)
+	assert.Contains(t, unavailable.Reason, "503")
+
+	_, err = handler.DefaultSDK("testnet").GetGasPrice(context.Background())
+	assert.True(t, errors.As(err, &unavailable))
+
+	// Mainnet is not configured, so it is not reported
+	statuses := handler.NetworkStatuses()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "testnet", statuses[0].Network)
+	assert.GreaterOrEqual(t, statuses[0].Attempts, 1)
+	assert.NotNil(t, statuses[0].NextRetry)
+
+	// Up: the next attempt connects and starts the background services
+	chain.up.Store(true)
+	require.Eventually(t, func() bool {
+		status, _ := handler.NetworkStatus("testnet")
+		return status.State == NetworkAvailable
+	}, 5*time.Second, 10*time.Millisecond)
+
+	networkSDK, err := handler.GetSDK("testnet")
+	require.NoError(t, err)
+	assert.NotNil(t, networkSDK)
+	_, err = handler.GetTxTracker("testnet")
+	assert.NoError(t, err)
+	_, err = handler.GetBalanceMonitor("testnet")
+	assert.NoError(t, err)
+
+	status, _ := handler.NetworkStatus("columbus")
+	require.Len(t, status.Endpoints, 1)
+	assert.Empty(t, status.Reason)
+}
+
+func TestRedemptionQRPeriod(t *testing.T) {
+	tests := []struct {
+		period  string
+		want    time.Duration
+		wantErr bool
+	}{
+		{period: "", want: 0},
+		{period: "15s", want: 15 * time.Second},
+		{period: "1m", want: time.Minute},
+		{period: "often", wantErr: true},
+		{period: "500ms", wantErr: true},
+		{period: "1.5s", wantErr: true},
+		{period: "-15s", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			period, err := redemptionQRPeriod(&config.Config{RedemptionQRPeriod: tt.period})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, period)
+		})
+	}
+}
+
+func TestNewNetworkHandlerConfigErrorsAreFatal(t *testing.T) {
+	tests := []struct {
+		name    string
+		network config.NetworkConfig
+		errMsg  string
+	}{
+		{
+			name:    "invalid RPC config",
+			network: config.NetworkConfig{RPCRetryAttempts: "several"},
+			errMsg:  "invalid testnet RPC config",
+		},
+		{
+			name:    "invalid timeout",
+			network: config.NetworkConfig{WriteTimeout: "a while"},
+			errMsg:  "invalid testnet timeouts",
+		},
+		{
+			name: "HTTP URL for event subscriptions",
+			network: config.NetworkConfig{
+				WSUrl:     "https://columbus.camino.network/ext/bc/C/rpc",
+				Contracts: config.ContractAddresses{BOGOWITickets: "0x5FbDB2315678afecb367f032d93F642f64180aa3"},
+			},
+			errMsg: "invalid testnet event config",
+		},
+		{
+			name: "invalid event poll interval",
+			network: config.NetworkConfig{
+				EventPollInterval: "often",
+				Contracts:         config.ContractAddresses{BOGOWITickets: "0x5FbDB2315678afecb367f032d93F642f64180aa3"},
+			},
+			errMsg: "invalid testnet event config",
+		},
+		{
+			name:    "invalid confirmation depth",
+			network: config.NetworkConfig{Confirmations: "deep"},
+			errMsg:  "invalid testnet transaction tracking config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network := tt.network
+			network.RPCUrl = "http://127.0.0.1:1"
+			network.Contracts.BOGOToken = "0xC53c2f11e1d2e36CB5888BfEE157F78e04Bb4F76"
+			cfg := &config.Config{
+				TestnetPrivateKey: "0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+				Testnet:           network,
+			}
+
+			_, err := NewNetworkHandler(cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestConfirmationDepth(t *testing.T) {
+	tests := []struct {
+		name          string
+		network       string
+		confirmations string
+		want          uint64
+		wantErr       bool
+	}{
+		{name: "testnet default", network: "testnet", want: 3},
+		{name: "mainnet default", network: "mainnet", want: 6},
+		{name: "configured", network: "mainnet", confirmations: "12", want: 12},
+		{name: "zero", network: "testnet", confirmations: "0", wantErr: true},
+		{name: "not a number", network: "testnet", confirmations: "deep", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			depth, err := confirmationDepth(tt.network, &config.NetworkConfig{Confirmations: tt.confirmations})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, depth)
+		})
+	}
+}
+
+func TestReconnectDelay(t *testing.T) {
+	assert.Equal(t, reconnectBaseDelay, reconnectDelay(1))
+	assert.Equal(t, 2*reconnectBaseDelay, reconnectDelay(2))
+	assert.Equal(t, reconnectMaxDelay, reconnectDelay(10))
+	assert.Equal(t, reconnectMaxDelay, reconnectDelay(100))
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"context"
+	"math/big"
+
+	"bogowi-blockchain-go/internal/sdk"
+	"bogowi-blockchain-go/internal/sdk/gas"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// networkSDK looks up the SDK of a network on every call, so it can be handed
+// out before the network has connected
+type networkSDK struct {
+	handler *NetworkHandler
+	network string
+}
+
+// DefaultSDK returns the SDK of a network for handlers that are not network
+// aware. It follows the connection of the network: while the network is down
+// its calls fail with a NetworkUnavailableError.
+func (h *NetworkHandler) DefaultSDK(network string) SDKInterface {
+	return &networkSDK{handler: h, network: network}
+}
+
+func (n *networkSDK) GetTokenBalance(ctx context.Context, address string) (*sdk.TokenBalance, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTokenBalance(ctx, address)
+}
+
+func (n *networkSDK) GetTokenBalances(ctx context.Context, addresses []string) ([]*sdk.AccountBalance, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTokenBalances(ctx, addresses)
+}
+
+func (n *networkSDK) GetERC20Info(ctx context.Context, token string) (*sdk.ERC20Info, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetERC20Info(ctx, token)
+}
+
+func (n *networkSDK) GetERC20Balance(ctx context.Context, token string, holder string) (*sdk.ERC20Balance, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetERC20Balance(ctx, token, holder)
+}
+
+func (n *networkSDK) GetERC721Owner(ctx context.Context, token string, tokenID *big.Int) (string, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return "", err
+	}
+	return s.GetERC721Owner(ctx, token, tokenID)
+}
+
+func (n *networkSDK) GetERC721TokenURI(ctx context.Context, token string, tokenID *big.Int) (string, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return "", err
+	}
+	return s.GetERC721TokenURI(ctx, token, tokenID)
+}
+
+func (n *networkSDK) GetNativeBalance(ctx context.Context, address string) (*sdk.NativeBalance, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetNativeBalance(ctx, address)
+}
+
+func (n *networkSDK) TransferNative(ctx context.Context, to string, amount string) (*sdk.NativeTransfer, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.TransferNative(ctx, to, amount)
+}
+
+func (n *networkSDK) EstimateTransferNative(ctx context.Context, to string, amount string) (*gas.Estimate, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.EstimateTransferNative(ctx, to, amount)
+}
+
+// GetNativeTransfers returns no transfers while the network is down
+func (n *networkSDK) GetNativeTransfers() []*sdk.NativeTransfer {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil
+	}
+	return s.GetNativeTransfers()
+}
+
+func (n *networkSDK) GetGasPrice(ctx context.Context) (string, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return "", err
+	}
+	return s.GetGasPrice(ctx)
+}
+
+func (n *networkSDK) GetGasFees(ctx context.Context) (*sdk.GasFees, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetGasFees(ctx)
+}
+
+func (n *networkSDK) TransferBOGOTokens(ctx context.Context, to string, amount string) (string, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return "", err
+	}
+	return s.TransferBOGOTokens(ctx, to, amount)
+}
+
+func (n *networkSDK) EstimateTransferBOGOTokens(ctx context.Context, to string, amount string) (*gas.Estimate, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.EstimateTransferBOGOTokens(ctx, to, amount)
+}
+
+func (n *networkSDK) GetPublicKey() (string, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return "", err
+	}
+	return s.GetPublicKey()
+}
+
+// Close does nothing; the NetworkHandler owns the SDK
+func (n *networkSDK) Close() {}
+
+func (n *networkSDK) CheckRewardEligibility(ctx context.Context, templateID string, wallet common.Address) (bool, string, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return false, "", err
+	}
+	return s.CheckRewardEligibility(ctx, templateID, wallet)
+}
+
+func (n *networkSDK) ClaimRewardV2(ctx context.Context, templateID string, recipient common.Address) (*types.Transaction, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.ClaimRewardV2(ctx, templateID, recipient)
+}
+
+func (n *networkSDK) ClaimCustomReward(ctx context.Context, recipient common.Address, amount *big.Int, reason string) (*types.Transaction, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.ClaimCustomReward(ctx, recipient, amount, reason)
+}
+
+func (n *networkSDK) EstimateClaimCustomReward(ctx context.Context, recipient common.Address, amount *big.Int, reason string) (*gas.Estimate, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.EstimateClaimCustomReward(ctx, recipient, amount, reason)
+}
+
+func (n *networkSDK) ClaimReferralBonus(ctx context.Context, referrer common.Address, referred common.Address) (*types.Transaction, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.ClaimReferralBonus(ctx, referrer, referred)
+}
+
+func (n *networkSDK) GetReferrer(ctx context.Context, wallet common.Address) (common.Address, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return s.GetReferrer(ctx, wallet)
+}
+
+func (n *networkSDK) GetRewardTemplate(ctx context.Context, templateID string) (*sdk.RewardTemplate, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetRewardTemplate(ctx, templateID)
+}
+
+func (n *networkSDK) GetClaimCount(ctx context.Context, wallet common.Address, templateID string) (*big.Int, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetClaimCount(ctx, wallet, templateID)
+}
+
+func (n *networkSDK) IsWhitelisted(ctx context.Context, wallet common.Address) (bool, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return false, err
+	}
+	return s.IsWhitelisted(ctx, wallet)
+}
+
+func (n *networkSDK) GetRemainingDailyLimit(ctx context.Context) (*big.Int, error) {
+	s, err := n.handler.GetSDK(n.network)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetRemainingDailyLimit(ctx)
+}
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"bogowi-blockchain-go/internal/sdk/events"
+	"bogowi-blockchain-go/internal/sdk/failover"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Connection states of a configured network
+const (
+	NetworkConnecting  = "connecting"
+	NetworkAvailable   = "available"
+	NetworkUnavailable = "unavailable"
+)
+
+// Reconnect backoff; variables so tests can shorten them
+var (
+	reconnectBaseDelay = 2 * time.Second
+	reconnectMaxDelay  = time.Minute
+	connectTimeout     = 30 * time.Second
+)
+
+// NetworkStatus reports the connection state of a configured network along
+// with the health of its RPC endpoints
+type NetworkStatus struct {
+	Network   string                    `json:"network"`
+	State     string                    `json:"state"`
+	Reason    string                    `json:"reason,omitempty"`
+	Since     time.Time                 `json:"since"`
+	Attempts  int                       `json:"attempts,omitempty"`
+	NextRetry *time.Time                `json:"nextRetry,omitempty"`
+	Endpoints []failover.EndpointStatus `json:"endpoints,omitempty"`
+	Events    *events.Status            `json:"events,omitempty"`
+}
+
+// NetworkUnavailableError is returned for a configured network that is not
+// connected, either because it has not connected yet or because none of its
+// RPC endpoints is healthy
+type NetworkUnavailableError struct {
+	Network string
+	Reason  string
+	// RetryAfter is the time until the next connection attempt, if known
+	RetryAfter time.Duration
+}
+
+func (e *NetworkUnavailableError) Error() string {
+	return fmt.Sprintf("%s network unavailable: %s", e.Network, e.Reason)
+}
+
+// respondUnavailable answers 503 with the reason when err reports a down
+// network. It returns false for any other error so the caller can answer it.
+func respondUnavailable(c *gin.Context, err error) bool {
+	var unavailable *NetworkUnavailableError
+	if !errors.As(err, &unavailable) {
+		return false
+	}
+	if unavailable.RetryAfter > 0 {
+		c.Header("Retry-After", fmt.Sprintf("%d", int(unavailable.RetryAfter.Seconds())+1))
+	}
+	c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: unavailable.Error()})
+	return true
+}
+
+// keepConnected connects a network, retrying with backoff until it succeeds
+// or the handler is closed
+func (h *NetworkHandler) keepConnected(ctx context.Context, setup *networkSetup) {
+	defer h.wg.Done()
+
+	for attempt := 1; ; attempt++ {
+		connectCtx, cancel := context.WithTimeout(ctx, connectTimeout)
+		err := h.connect(connectCtx, setup)
+		cancel()
+		if err == nil {
+			fmt.Printf("Connected to %s network\n", setup.name)
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		delay := reconnectDelay(attempt)
+		h.setUnavailable(setup.name, err, attempt, delay)
+		fmt.Printf("Warning: %s network unavailable, retrying in %s: %v\n", setup.name, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// reconnectDelay doubles from reconnectBaseDelay up to reconnectMaxDelay
+func reconnectDelay(attempt int) time.Duration {
+	delay := reconnectBaseDelay << (attempt - 1)
+	if delay <= 0 || delay > reconnectMaxDelay {
+		delay = reconnectMaxDelay
+	}
+	return delay
+}
+
+// setUnavailable records a failed connection attempt
+func (h *NetworkHandler) setUnavailable(network string, err error, attempt int, delay time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	state, ok := h.states[network]
+	if !ok {
+		return
+	}
+	if state.State != NetworkUnavailable {
+		state.Since = time.Now().UTC()
+	}
+	nextRetry := time.Now().UTC().Add(delay)
+	state.State = NetworkUnavailable
+	state.Reason = err.Error()
+	state.Attempts = attempt
+	state.NextRetry = &nextRetry
+}
+
+// statusLocked reports the state of a network; h.mu must be held. A connected
+// network whose RPC endpoints are all out of rotation is reported unavailable.
+func (h *NetworkHandler) statusLocked(network string) (NetworkStatus, bool) {
+	state, ok := h.states[network]
+	if !ok {
+		return NetworkStatus{}, false
+	}
+
+	status := *state
+	if client, ok := h.rpcClients[network]; ok {
+		status.Endpoints = client.Status()
+		if status.State == NetworkAvailable && !client.Healthy() {
+			status.State = NetworkUnavailable
+			status.Reason = "no healthy RPC endpoint"
+			if len(status.Endpoints) > 0 && status.Endpoints[0].LastError != "" {
+				status.Reason += ": " + status.Endpoints[0].LastError
+			}
+		}
+	}
+	if m, ok := h.events[network]; ok {
+		eventStatus := m.Status()
+		status.Events = &eventStatus
+	}
+	return status, true
+}
+
+// unavailableLocked returns a NetworkUnavailableError when a configured
+// network is down; h.mu must be held
+func (h *NetworkHandler) unavailableLocked(network string) error {
+	status, ok := h.statusLocked(network)
+	if !ok || status.State == NetworkAvailable {
+		return nil
+	}
+	reason := status.Reason
+	if status.State == NetworkConnecting {
+		reason = "still connecting"
+	}
+	err := &NetworkUnavailableError{Network: network, Reason: reason}
+	if status.NextRetry != nil {
+		err.RetryAfter = time.Until(*status.NextRetry)
+	}
+	return err
+}
+
+// NetworkStatuses reports the connection state of every configured network
+func (h *NetworkHandler) NetworkStatuses() []NetworkStatus {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var statuses []NetworkStatus
+	for _, network := range []string{"testnet", "mainnet"} {
+		if status, ok := h.statusLocked(network); ok {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// NetworkStatus reports the connection state of a network
+func (h *NetworkHandler) NetworkStatus(network string) (NetworkStatus, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	switch network {
+	case "testnet", "columbus":
+		network = "testnet"
+	case "mainnet", "camino":
+		network = "mainnet"
+	default:
+		return NetworkStatus{}, false
+	}
+	return h.statusLocked(network)
+}
@@ -0,0 +1,456 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/nft"
+	"bogowi-blockchain-go/internal/services/datakyte"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateTransferUnlockRequest moves the transfer unlock time of a ticket
+type UpdateTransferUnlockRequest struct {
+	TransferUnlockAt uint64 `json:"transferUnlockAt" binding:"required"`
+}
+
+// SetRoyaltyRequest sets the EIP-2981 royalty of all tickets
+type SetRoyaltyRequest struct {
+	Receiver       string  `json:"receiver" binding:"required"`
+	FeeBasisPoints *uint64 `json:"feeBasisPoints" binding:"required,max=1000"`
+}
+
+// adminErrorStatus maps the errors of ticket admin operations to HTTP status codes
+func adminErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, nft.ErrMissingRole):
+		return http.StatusForbidden
+	case errors.Is(err, nft.ErrNotTicketHolder):
+		return http.StatusConflict
+	case errors.Is(err, nft.ErrInvalidUnlockTime):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// adminNFTSDK returns the NFT SDK of a backend request's network, or
+// responds with the error
+func (h *NFTHandler) adminNFTSDK(c *gin.Context, network string) (*nft.Client, bool) {
+	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return nil, false
+	}
+	return nftSDK, true
+}
+
+// recordBurn marks a burned ticket as burned in Datakyte and the database
+func (h *NFTHandler) recordBurn(network string, tokenID uint64) {
+	db := database.GetDB()
+	datakyteNFTID, err := db.GetDatakyteID(tokenID, network)
+	if err != nil {
+		fmt.Printf("Warning: Failed to get Datakyte ID for token %d: %v\n", tokenID, err)
+		return
+	}
+
+	metadataService := h.getMetadataService(network)
+	if err := metadataService.UpdateTicketStatus(datakyteNFTID, datakyte.StatusBurned); err != nil {
+		// Log but don't fail - the ticket is already burned on-chain
+		fmt.Printf("Warning: Failed to update Datakyte status for token %d: %v\n", tokenID, err)
+	}
+
+	if err := db.UpdateNFTStatus(tokenID, network, "burned"); err != nil {
+		fmt.Printf("Warning: Failed to update burn status in database for token %d: %v\n", tokenID, err)
+	}
+}
+
+// ticketBurner burns tickets and waits for their receipts
+type ticketBurner interface {
+	Burn(ctx context.Context, tokenID uint64) (*types.Transaction, error)
+	WaitForTransaction(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// burnTicket burns a ticket and runs record once the burn is confirmed, so a
+// burn that reverts or is dropped leaves the ticket's records untouched
+func burnTicket(ctx context.Context, burner ticketBurner, tokenID uint64, record func()) (*types.Transaction, error) {
+	tx, err := burner.Burn(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := burner.WaitForTransaction(ctx, tx.Hash())
+	if err != nil {
+		return tx, fmt.Errorf("burn transaction failed: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return tx, fmt.Errorf("burn transaction reverted")
+	}
+
+	record()
+	return tx, nil
+}
+
+// BurnTicket burns a ticket held by the backend wallet (backend only)
+// @Summary Burn a ticket
+// @Description Burns a ticket held by the backend wallet, e.g. after a refund, and once the burn is
+// @Description confirmed marks it burned in the database and Datakyte. The backend wallet must hold
+// @Description ADMIN_ROLE in the RoleManager.
+// @Tags NFT
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Param tokenId path int true "Token ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/nft/tickets/{tokenId}/burn [post]
+func (h *NFTHandler) BurnTicket(c *gin.Context) {
+	network, ok := h.backendNetwork(c)
+	if !ok {
+		return
+	}
+
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid token ID"})
+		return
+	}
+
+	nftSDK, ok := h.adminNFTSDK(c, network)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := nftSDK.CheckBurnable(ctx, tokenID); err != nil {
+		c.JSON(adminErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tx, err := burnTicket(ctx, nftSDK, tokenID, func() { h.recordBurn(network, tokenID) })
+	if err != nil {
+		response := gin.H{"error": fmt.Sprintf("Failed to burn ticket: %v", err)}
+		if tx != nil {
+			response["txHash"] = tx.Hash().Hex()
+		}
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"tokenId": tokenID,
+		"txHash":  tx.Hash().Hex(),
+	})
+}
+
+// UpdateTransferUnlock moves the transfer unlock time of a ticket (backend only)
+// @Summary Update a ticket's transfer unlock time
+// @Description Sets when a ticket becomes transferable. The time must be before the ticket expires,
+// @Description and the backend wallet must hold ADMIN_ROLE in the RoleManager.
+// @Tags NFT
+// @Accept json
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Param tokenId path int true "Token ID"
+// @Param request body UpdateTransferUnlockRequest true "New unlock time (unix seconds)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/nft/tickets/{tokenId}/transfer-unlock [put]
+func (h *NFTHandler) UpdateTransferUnlock(c *gin.Context) {
+	network, ok := h.backendNetwork(c)
+	if !ok {
+		return
+	}
+
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid token ID"})
+		return
+	}
+	var req UpdateTransferUnlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	nftSDK, ok := h.adminNFTSDK(c, network)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := nftSDK.CheckTransferUnlock(ctx, tokenID, req.TransferUnlockAt); err != nil {
+		c.JSON(adminErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tx, err := nftSDK.UpdateTransferUnlock(ctx, tokenID, req.TransferUnlockAt)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to update transfer unlock: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":          true,
+		"tokenId":          tokenID,
+		"transferUnlockAt": req.TransferUnlockAt,
+		"txHash":           tx.Hash().Hex(),
+	})
+}
+
+// GetTicketsPaused reports whether the tickets contract is paused (backend only)
+// @Summary Get the pause state of the tickets contract
+// @Description Reports whether minting and transfers of tickets are paused
+// @Tags NFT
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/nft/pause [get]
+func (h *NFTHandler) GetTicketsPaused(c *gin.Context) {
+	network, ok := h.backendNetwork(c)
+	if !ok {
+		return
+	}
+	nftSDK, ok := h.adminNFTSDK(c, network)
+	if !ok {
+		return
+	}
+
+	paused, err := nftSDK.IsPaused(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"network": network,
+		"paused":  paused,
+	})
+}
+
+// PauseTickets pauses the tickets contract (backend only)
+// @Summary Pause the tickets contract
+// @Description Pauses minting and transfers of tickets. The backend wallet must hold PAUSER_ROLE in
+// @Description the RoleManager.
+// @Tags NFT
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/nft/pause [post]
+func (h *NFTHandler) PauseTickets(c *gin.Context) {
+	h.setPaused(c, true)
+}
+
+// UnpauseTickets unpauses the tickets contract (backend only)
+// @Summary Unpause the tickets contract
+// @Description Resumes minting and transfers of tickets. The backend wallet must hold PAUSER_ROLE in
+// @Description the RoleManager.
+// @Tags NFT
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/nft/unpause [post]
+func (h *NFTHandler) UnpauseTickets(c *gin.Context) {
+	h.setPaused(c, false)
+}
+
+// setPaused pauses or unpauses the tickets contract
+func (h *NFTHandler) setPaused(c *gin.Context, paused bool) {
+	network, ok := h.backendNetwork(c)
+	if !ok {
+		return
+	}
+	nftSDK, ok := h.adminNFTSDK(c, network)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := nftSDK.RequireRole(ctx, nft.PauserRole); err != nil {
+		c.JSON(adminErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	send := nftSDK.Unpause
+	if paused {
+		send = nftSDK.Pause
+	}
+	tx, err := send(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"network": network,
+		"paused":  paused,
+		"txHash":  tx.Hash().Hex(),
+	})
+}
+
+// GetRoyalty returns the EIP-2981 royalty of the tickets (backend only)
+// @Summary Get the ticket royalty
+// @Description Returns the EIP-2981 royalty receiver and fee of a ticket, and the royalty owed on a
+// @Description sale when salePrice is given. All tickets share the contract's default royalty.
+// @Tags NFT
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Param tokenId query int false "Token ID"
+// @Param salePrice query string false "Sale price in wei"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/nft/royalty [get]
+func (h *NFTHandler) GetRoyalty(c *gin.Context) {
+	network, ok := h.backendNetwork(c)
+	if !ok {
+		return
+	}
+
+	var tokenID uint64
+	if value := c.Query("tokenId"); value != "" {
+		var err error
+		if tokenID, err = strconv.ParseUint(value, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid token ID"})
+			return
+		}
+	}
+	var salePrice *big.Int
+	if value := c.Query("salePrice"); value != "" {
+		price, ok := new(big.Int).SetString(value, 10)
+		if !ok || price.Sign() < 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid sale price"})
+			return
+		}
+		salePrice = price
+	}
+
+	nftSDK, ok := h.adminNFTSDK(c, network)
+	if !ok {
+		return
+	}
+
+	royalty, err := nftSDK.GetRoyalty(c.Request.Context(), tokenID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	response := gin.H{
+		"network":        network,
+		"tokenId":        tokenID,
+		"receiver":       royalty.Receiver.Hex(),
+		"feeBasisPoints": royalty.FeeBasisPoints,
+	}
+	if salePrice != nil {
+		response["salePrice"] = salePrice.String()
+		response["amount"] = royalty.Amount(salePrice).String()
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// SetRoyalty sets the EIP-2981 royalty of all tickets (backend only)
+// @Summary Set the ticket royalty
+// @Description Sets the receiver and fee of the EIP-2981 royalty of all tickets. The fee is capped at
+// @Description 1000 basis points (10%), and the backend wallet must hold ADMIN_ROLE in the RoleManager.
+// @Tags NFT
+// @Accept json
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Param request body SetRoyaltyRequest true "Royalty receiver and fee"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/nft/royalty [put]
+func (h *NFTHandler) SetRoyalty(c *gin.Context) {
+	network, ok := h.backendNetwork(c)
+	if !ok {
+		return
+	}
+
+	var req SetRoyaltyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	receiver, ok := parseAddress(c, "receiver", req.Receiver)
+	if !ok {
+		return
+	}
+	if receiver == (common.Address{}) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Royalty receiver must not be the zero address"})
+		return
+	}
+
+	nftSDK, ok := h.adminNFTSDK(c, network)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := nftSDK.RequireRole(ctx, nft.AdminRole); err != nil {
+		c.JSON(adminErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tx, err := nftSDK.SetRoyalty(ctx, receiver, *req.FeeBasisPoints)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":        true,
+		"network":        network,
+		"receiver":       receiver.Hex(),
+		"feeBasisPoints": *req.FeeBasisPoints,
+		"txHash":         tx.Hash().Hex(),
+	})
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bogowi-blockchain-go/internal/config"
+	"bogowi-blockchain-go/internal/sdk/nft"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNFTAdminEndpointsValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{BackendSecret: "test-secret", DevBackendSecret: "test-dev-secret"}
+	handler := &NFTHandler{Handler: &Handler{Config: cfg, NetworkHandler: &NetworkHandler{config: cfg}}}
+	router := gin.New()
+	router.POST("/admin/nft/tickets/:tokenId/burn", handler.BurnTicket)
+	router.PUT("/admin/nft/tickets/:tokenId/transfer-unlock", handler.UpdateTransferUnlock)
+	router.POST("/admin/nft/pause", handler.PauseTickets)
+	router.GET("/admin/nft/royalty", handler.GetRoyalty)
+	router.PUT("/admin/nft/royalty", handler.SetRoyalty)
+
+	receiver := "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0"
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		auth           string
+		expectedStatus int
+	}{
+		{"Burn unauthorized", "POST", "/admin/nft/tickets/1/burn", "", "wrong", http.StatusUnauthorized},
+		{"Pause unauthorized", "POST", "/admin/nft/pause", "", "", http.StatusUnauthorized},
+		{"Pause on mainnet with the testnet secret", "POST", "/admin/nft/pause?network=mainnet", "", "test-dev-secret", http.StatusUnauthorized},
+		{"Burn on invalid network", "POST", "/admin/nft/tickets/1/burn?network=devnet", "", "test-secret", http.StatusBadRequest},
+		{"Burn invalid token ID", "POST", "/admin/nft/tickets/abc/burn", "", "test-dev-secret", http.StatusBadRequest},
+		{"Unlock invalid token ID", "PUT", "/admin/nft/tickets/abc/transfer-unlock", `{"transferUnlockAt":1700000000}`, "test-dev-secret", http.StatusBadRequest},
+		{"Unlock without time", "PUT", "/admin/nft/tickets/1/transfer-unlock", `{}`, "test-dev-secret", http.StatusBadRequest},
+		{"Royalty invalid token ID", "GET", "/admin/nft/royalty?tokenId=abc", "", "test-dev-secret", http.StatusBadRequest},
+		{"Royalty invalid sale price", "GET", "/admin/nft/royalty?salePrice=-5", "", "test-dev-secret", http.StatusBadRequest},
+		{"Set royalty without fee", "PUT", "/admin/nft/royalty", fmt.Sprintf(`{"receiver":%q}`, receiver), "test-dev-secret", http.StatusBadRequest},
+		{"Set royalty too high", "PUT", "/admin/nft/royalty", fmt.Sprintf(`{"receiver":%q,"feeBasisPoints":1001}`, receiver), "test-dev-secret", http.StatusBadRequest},
+		{"Set royalty to invalid address", "PUT", "/admin/nft/royalty", `{"receiver":"0x123","feeBasisPoints":500}`, "test-dev-secret", http.StatusBadRequest},
+		{"Set royalty to zero address", "PUT", "/admin/nft/royalty", `{"receiver":"0x0000000000000000000000000000000000000000","feeBasisPoints":500}`, "test-dev-secret", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Backend-Auth", tt.auth)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestAdminErrorStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{fmt.Errorf("%w: 0xabc does not hold ADMIN_ROLE", nft.ErrMissingRole), http.StatusForbidden},
+		{fmt.Errorf("%w: ticket 1 is held by 0xdef", nft.ErrNotTicketHolder), http.StatusConflict},
+		{fmt.Errorf("%w: ticket 1 expires at 2000", nft.ErrInvalidUnlockTime), http.StatusBadRequest},
+		{errors.New("role manager not configured"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, adminErrorStatus(tt.err), tt.err.Error())
+	}
+}
+
+// receiptBurner burns tickets whose transactions end with a fixed receipt
+type receiptBurner struct {
+	status  uint64
+	waitErr error
+	sendErr error
+}
+
+func (b receiptBurner) Burn(ctx context.Context, tokenID uint64) (*types.Transaction, error) {
+	if b.sendErr != nil {
+		return nil, b.sendErr
+	}
+	return types.NewTx(&types.LegacyTx{Nonce: tokenID}), nil
+}
+
+func (b receiptBurner) WaitForTransaction(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if b.waitErr != nil {
+		return nil, b.waitErr
+	}
+	return &types.Receipt{Status: b.status}, nil
+}
+
+func TestBurnTicketRecordsConfirmedBurns(t *testing.T) {
+	tests := []struct {
+		name       string
+		burner     receiptBurner
+		wantTx     bool
+		wantErr    bool
+		wantRecord bool
+	}{
+		{name: "confirmed", burner: receiptBurner{status: types.ReceiptStatusSuccessful}, wantTx: true, wantRecord: true},
+		{name: "reverted", burner: receiptBurner{status: types.ReceiptStatusFailed}, wantTx: true, wantErr: true},
+		{name: "dropped", burner: receiptBurner{waitErr: errors.New("transaction timeout")}, wantTx: true, wantErr: true},
+		{name: "not sent", burner: receiptBurner{sendErr: errors.New("nonce too low")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorded := false
+			tx, err := burnTicket(context.Background(), tt.burner, 10001, func() { recorded = true })
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantTx, tx != nil)
+			assert.Equal(t, tt.wantRecord, recorded)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 
 	"bogowi-blockchain-go/internal/config"
 	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/gas"
 	"bogowi-blockchain-go/internal/sdk/nft"
 	"bogowi-blockchain-go/internal/services/datakyte"
 	"bogowi-blockchain-go/internal/services/storage"
@@ -27,6 +29,8 @@ type NFTHandler struct {
 	metadataServiceMainnet *datakyte.TicketMetadataService
 	datakyteConfig         *config.DatakyteConfig
 	imageService           *storage.ImageService
+	operators              []checkInOperator
+	checkIns               checkInGuard
 }
 
 // NewNFTHandler creates a new NFT handler
@@ -64,10 +68,18 @@ func NewNFTHandler(h *Handler) *NFTHandler {
 
 	imageService, _ := storage.NewImageService(bucketName, cdnBaseURL)
 
+	// Venue check-in stays closed without valid operator credentials
+	operators, err := parseCheckInOperators(h.Config.CheckInOperators)
+	if err != nil {
+		fmt.Printf("Warning: Ticket check-in disabled: %v\n", err)
+		operators = nil
+	}
+
 	return &NFTHandler{
 		Handler:        h,
 		datakyteConfig: datakyteConfig,
 		imageService:   imageService,
+		operators:      operators,
 		metadataServiceTestnet: datakyte.NewTicketMetadataService(
 			datakyteConfig.TestnetAPIKey,
 			testnetContract,
@@ -145,6 +157,7 @@ type MintTicketResponse struct {
 // @Accept json
 // @Produce json
 // @Param X-Network-Type header string false "Network type (testnet/mainnet)" default(testnet)
+// @Param dryRun query bool false "Estimate the transaction without sending it"
 // @Param request body MintTicketRequest true "Mint ticket request"
 // @Success 200 {object} MintTicketResponse
 // @Failure 400 {object} ErrorResponse
@@ -162,12 +175,20 @@ func (h *NFTHandler) MintTicket(c *gin.Context) {
 	// Get NFT SDK for the network
 	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	// Step 1: Mint the NFT on-chain
-	tokenID, txHash, err := h.mintOnChain(nftSDK, req)
+	// Step 1: Mint the NFT on-chain; a dry run stops at the estimate
+	ctx, est := dryRunContext(c)
+	tokenID, txHash, err := h.mintOnChain(ctx, nftSDK, req)
+	if est != nil && errors.Is(err, gas.ErrDryRun) {
+		respondDryRun(c, network, est)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error: fmt.Sprintf("Failed to mint NFT: %v", err),
@@ -243,7 +264,7 @@ func (h *NFTHandler) MintTicket(c *gin.Context) {
 }
 
 // mintOnChain handles the blockchain transaction for minting
-func (h *NFTHandler) mintOnChain(nftSDK *nft.Client, req MintTicketRequest) (uint64, string, error) {
+func (h *NFTHandler) mintOnChain(ctx context.Context, nftSDK *nft.Client, req MintTicketRequest) (uint64, string, error) {
 
 	// Convert request parameters to SDK format
 	params := nft.MintParams{
@@ -258,7 +279,6 @@ func (h *NFTHandler) mintOnChain(nftSDK *nft.Client, req MintTicketRequest) (uin
 	}
 
 	// Call the actual SDK mint function
-	ctx := context.Background()
 	tx, tokenID, err := nftSDK.MintTicket(ctx, params)
 	if err != nil {
 		return 0, "", fmt.Errorf("failed to mint NFT on blockchain: %w", err)
@@ -319,14 +339,20 @@ type RedeemTicketRequest struct {
 
 // RedeemTicket handles ticket redemption
 // @Summary Redeem an NFT ticket
-// @Description Redeems an NFT ticket using EIP-712 signature verification
+// @Description Redeems an NFT ticket with the holder's EIP-712 signature. The holder, an approved
+// @Description address or an operator signs RedeemTicket with themselves as redeemer; the signature
+// @Description is checked here, through ERC-1271 for smart-contract wallets, and the redemption
+// @Description is relayed with the backend's signature.
 // @Tags NFT
 // @Accept json
 // @Produce json
 // @Param X-Network-Type header string false "Network type (testnet/mainnet)" default(testnet)
+// @Param dryRun query bool false "Estimate the transaction without sending it"
 // @Param request body RedeemTicketRequest true "Redeem ticket request"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /nft/tickets/redeem [post]
 func (h *NFTHandler) RedeemTicket(c *gin.Context) {
@@ -337,10 +363,23 @@ func (h *NFTHandler) RedeemTicket(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
 		return
 	}
+	if !common.IsHexAddress(req.Redeemer) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid redeemer address"})
+		return
+	}
+	// Smart-contract wallets may use signatures of any length
+	signature := common.FromHex(req.Signature)
+	if len(signature) == 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid signature"})
+		return
+	}
 
 	// Get NFT SDK for the network
 	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -353,9 +392,20 @@ func (h *NFTHandler) RedeemTicket(c *gin.Context) {
 		Deadline: int64(req.Deadline),
 	}
 
-	// Execute redemption on blockchain
-	ctx := context.Background()
+	// The contract only checks the backend's signature, so the holder's
+	// signature must be verified before relaying
+	ctx, est := dryRunContext(c)
+	if err := nftSDK.VerifyRedemption(ctx, params, signature); err != nil {
+		c.JSON(redemptionErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// Execute redemption on blockchain; a dry run stops at the estimate
 	tx, err := nftSDK.RedeemTicket(ctx, params)
+	if est != nil && errors.Is(err, gas.ErrDryRun) {
+		respondDryRun(c, network, est)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error: fmt.Sprintf("Failed to redeem ticket: %v", err),
@@ -363,33 +413,128 @@ func (h *NFTHandler) RedeemTicket(c *gin.Context) {
 		return
 	}
 
+	h.recordRedemption(network, req.TokenID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Ticket redeemed successfully",
+		"tokenId": req.TokenID,
+		"txHash":  tx.Hash().Hex(),
+	})
+}
+
+// recordRedemption marks a ticket redeemed on-chain as redeemed in Datakyte
+// and the database
+func (h *NFTHandler) recordRedemption(network string, tokenID uint64) {
 	// Update metadata status in Datakyte
 	db := database.GetDB()
-	datakyteNFTID, err := db.GetDatakyteID(req.TokenID, network)
+	datakyteNFTID, err := db.GetDatakyteID(tokenID, network)
 	if err != nil {
-		fmt.Printf("Warning: Failed to get Datakyte ID for token %d: %v\n", req.TokenID, err)
-	} else {
-		metadataService := h.getMetadataService(network)
-		err = metadataService.UpdateTicketStatus(datakyteNFTID, "Redeemed")
-		if err != nil {
-			// Log but don't fail - redemption already succeeded on-chain
-			fmt.Printf("Warning: Failed to update Datakyte status for token %d: %v\n", req.TokenID, err)
-		}
+		fmt.Printf("Warning: Failed to get Datakyte ID for token %d: %v\n", tokenID, err)
+		return
+	}
+
+	metadataService := h.getMetadataService(network)
+	err = metadataService.UpdateTicketStatus(datakyteNFTID, "Redeemed")
+	if err != nil {
+		// Log but don't fail - redemption already succeeded on-chain
+		fmt.Printf("Warning: Failed to update Datakyte status for token %d: %v\n", tokenID, err)
+	}
+
+	// Update database status
+	if err := db.UpdateNFTRedemption(tokenID, network); err != nil {
+		fmt.Printf("Warning: Failed to update redemption status in database for token %d: %v\n", tokenID, err)
+	}
+}
+
+// recordExpiry marks a ticket the expiry sweep found expired as expired in
+// Datakyte and in the database
+func (h *NFTHandler) recordExpiry(ctx context.Context, network string, tokenID uint64) {
+	db := database.GetDB()
+	datakyteNFTID, err := db.GetDatakyteID(tokenID, network)
+	if err != nil {
+		fmt.Printf("Warning: Failed to get Datakyte ID for token %d: %v\n", tokenID, err)
+		return
+	}
+
+	metadataService := h.getMetadataService(network)
+	if err := metadataService.UpdateTicketStatus(datakyteNFTID, datakyte.StatusExpired); err != nil {
+		// Log but don't fail - the ticket already expired on-chain
+		fmt.Printf("Warning: Failed to update Datakyte status for token %d: %v\n", tokenID, err)
+	}
 
-		// Update database status
-		if err := db.UpdateNFTRedemption(req.TokenID, network); err != nil {
-			fmt.Printf("Warning: Failed to update redemption status in database for token %d: %v\n", req.TokenID, err)
+	if err := db.UpdateNFTStatus(tokenID, network, "expired"); err != nil {
+		fmt.Printf("Warning: Failed to update expiry status in database for token %d: %v\n", tokenID, err)
+	}
+}
+
+// GetRedemptionTypedData returns the typed data a holder signs to redeem a ticket
+// @Summary Get redemption typed data
+// @Description Returns an eth_signTypedData_v4 payload for redeeming a ticket, with a fresh nonce
+// @Description and deadline and the domain reported by the contract's eip712Domain(). The redeemer
+// @Description signs it and submits the signature with the returned parameters to the redeem endpoint.
+// @Tags NFT
+// @Produce json
+// @Param X-Network-Type header string false "Network type (testnet/mainnet)" default(testnet)
+// @Param tokenId path int true "Token ID"
+// @Param redeemer query string true "Address that will sign the redemption"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /nft/tickets/{tokenId}/redemption-typed-data [get]
+func (h *NFTHandler) GetRedemptionTypedData(c *gin.Context) {
+	network := GetNetworkFromContext(c)
+
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid token ID"})
+		return
+	}
+	redeemer := c.Query("redeemer")
+	if !common.IsHexAddress(redeemer) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid redeemer address"})
+		return
+	}
+
+	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
 		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	request, err := nftSDK.PrepareRedemption(c.Request.Context(), tokenID, common.HexToAddress(redeemer))
+	if err != nil {
+		c.JSON(redemptionErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Ticket redeemed successfully",
-		"tokenId": req.TokenID,
-		"txHash":  tx.Hash().Hex(),
+		"tokenId":   request.Params.TokenID,
+		"redeemer":  request.Params.Redeemer.Hex(),
+		"nonce":     request.Params.Nonce,
+		"deadline":  request.Params.Deadline,
+		"typedData": request.TypedData,
 	})
 }
 
+// redemptionErrorStatus maps a redemption verification error to an HTTP status
+func redemptionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, nft.ErrSignatureExpired):
+		return http.StatusBadRequest
+	case errors.Is(err, nft.ErrInvalidSignature):
+		return http.StatusForbidden
+	case errors.Is(err, nft.ErrNonceUsed):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // GetUserTickets retrieves all tickets for a user
 // @Summary Get user's NFT tickets
 // @Description Retrieves all NFT tickets owned by a specific address
@@ -414,12 +559,15 @@ func (h *NFTHandler) GetUserTickets(c *gin.Context) {
 	// Get NFT SDK for the network
 	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
 
 	// Query blockchain for user's balance
-	ctx := context.Background()
+	ctx := c.Request.Context()
 	owner := common.HexToAddress(userAddress)
 
 	balance, err := nftSDK.GetBalanceOf(ctx, owner)
@@ -430,15 +578,21 @@ func (h *NFTHandler) GetUserTickets(c *gin.Context) {
 		return
 	}
 
-	// Try to get user's tickets (note: this requires enumeration support)
+	// List the user's tickets from the ticket index
 	tickets, err := nftSDK.GetUserTickets(ctx, owner)
-	if err != nil {
-		// If enumeration is not supported, just return the balance info
+	if errors.Is(err, nft.ErrNoTicketIndex) {
+		// Without an index, just return the balance info
 		c.JSON(http.StatusOK, gin.H{
 			"address": userAddress,
 			"balance": balance.String(),
 			"tickets": []interface{}{},
-			"message": "Token enumeration requires event filtering (not yet implemented). User owns " + balance.String() + " tickets.",
+			"message": "Ticket enumeration is not available on this network. User owns " + balance.String() + " tickets.",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: fmt.Sprintf("Failed to list user tickets: %v", err),
 		})
 		return
 	}
@@ -466,6 +620,11 @@ func (h *NFTHandler) GetUserTickets(c *gin.Context) {
 			"eventId":          fmt.Sprintf("%x", ticketData.EventID),
 		}
 
+		// Recent changes may still be undone by a reorg
+		if finality, err := nftSDK.GetTicketFinality(ctx, tokenID); err == nil {
+			detail["finality"] = finality
+		}
+
 		if metadata != nil {
 			detail["metadata"] = metadata
 		}
@@ -492,6 +651,7 @@ type BatchMintRequest struct {
 // @Accept json
 // @Produce json
 // @Param X-Network-Type header string false "Network type (testnet/mainnet)" default(testnet)
+// @Param dryRun query bool false "Estimate the transaction without sending it"
 // @Param request body BatchMintRequest true "Batch mint request"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} ErrorResponse
@@ -514,6 +674,9 @@ func (h *NFTHandler) BatchMintTickets(c *gin.Context) {
 	// Get NFT SDK for the network
 	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -533,9 +696,13 @@ func (h *NFTHandler) BatchMintTickets(c *gin.Context) {
 		}
 	}
 
-	// Execute batch mint on blockchain
-	ctx := context.Background()
+	// Execute batch mint on blockchain; a dry run stops at the estimate
+	ctx, est := dryRunContext(c)
 	tx, tokenIDs, err := nftSDK.BatchMint(ctx, mintParams)
+	if est != nil && errors.Is(err, gas.ErrDryRun) {
+		respondDryRun(c, network, est)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error: fmt.Sprintf("Failed to batch mint NFTs: %v", err),
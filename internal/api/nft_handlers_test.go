@@ -0,0 +1,88 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bogowi-blockchain-go/internal/sdk/nft"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedeemTicketValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &NFTHandler{}
+	redeemer := "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0"
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"Missing signature", fmt.Sprintf(`{"tokenId":1,"redeemer":%q,"nonce":1,"deadline":1}`, redeemer)},
+		{"Invalid redeemer", `{"tokenId":1,"redeemer":"0x123","nonce":1,"deadline":1,"signature":"0x01"}`},
+		{"Empty signature", fmt.Sprintf(`{"tokenId":1,"redeemer":%q,"nonce":1,"deadline":1,"signature":"0x"}`, redeemer)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/nft/tickets/1/redeem", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.RedeemTicket(c)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestGetRedemptionTypedDataValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &NFTHandler{}
+
+	tests := []struct {
+		name    string
+		tokenID string
+		query   string
+	}{
+		{"Invalid token ID", "abc", "?redeemer=0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0"},
+		{"Missing redeemer", "1", ""},
+		{"Invalid redeemer", "1", "?redeemer=0x123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "tokenId", Value: tt.tokenID}}
+			c.Request, _ = http.NewRequest("GET", "/api/nft/tickets/"+tt.tokenID+"/redemption-typed-data"+tt.query, nil)
+
+			handler.GetRedemptionTypedData(c)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestRedemptionErrorStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{fmt.Errorf("%w: deadline passed", nft.ErrSignatureExpired), http.StatusBadRequest},
+		{fmt.Errorf("%w: signed by someone else", nft.ErrInvalidSignature), http.StatusForbidden},
+		{nft.ErrNonceUsed, http.StatusConflict},
+		{errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, redemptionErrorStatus(tt.err), tt.err.Error())
+	}
+}
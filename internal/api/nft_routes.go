@@ -1,6 +1,8 @@
 package api
 
 import (
+	"bogowi-blockchain-go/internal/middleware"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -9,6 +11,11 @@ func RegisterNFTRoutes(router *gin.RouterGroup, handler *Handler) {
 	// Create NFT handler
 	nftHandler := NewNFTHandler(handler)
 
+	// Keep the records of tickets the expiry sweeps expire in step
+	if handler.NetworkHandler != nil {
+		handler.NetworkHandler.OnTicketExpired(nftHandler.recordExpiry)
+	}
+
 	// NFT ticket endpoints
 	nft := router.Group("/nft")
 	{
@@ -28,12 +35,32 @@ func RegisterNFTRoutes(router *gin.RouterGroup, handler *Handler) {
 			tickets.PATCH("/:tokenId/status", nftHandler.UpdateTicketStatus)
 
 			// Redemption
+			tickets.GET("/:tokenId/redemption-typed-data", nftHandler.GetRedemptionTypedData)
 			tickets.POST("/:tokenId/redeem", nftHandler.RedeemTicket)
+			holderAuth := AuthMiddleware(middleware.NewAuthMiddleware(handler.Config.FirebaseProjectID))
+			tickets.GET("/:tokenId/redemption-qr", holderAuth, nftHandler.GetRedemptionQR)
+			tickets.GET("/:tokenId/rotating-redemption", holderAuth, nftHandler.GetRotatingRedemption)
+
+			// Transfers
+			tickets.GET("/:tokenId/transferability", nftHandler.GetTicketTransferability)
+			tickets.POST("/:tokenId/transfer", nftHandler.TransferTicket)
+			tickets.POST("/:tokenId/approve", nftHandler.ApproveTicket)
+			tickets.POST("/approval-for-all", nftHandler.SetApprovalForAll)
+			tickets.POST("/batch-transfer", nftHandler.BatchTransferTickets)
+			tickets.POST("/transfer-multiple", nftHandler.TransferTicketsToMultiple)
 
 			// User queries
 			tickets.GET("/user/:address", nftHandler.GetUserTickets)
 		}
 
+		// Venue check-in, for operators bound to events
+		checkIn := nft.Group("/checkin", CheckInOperatorAuth(nftHandler.operators))
+		{
+			checkIn.POST("/scan", nftHandler.ScanTicket)
+			checkIn.GET("/events/:eventId/bundle", nftHandler.GetCheckInBundle)
+			checkIn.POST("/events/:eventId/sync", nftHandler.SyncCheckIns)
+		}
+
 		// Public metadata endpoint (ERC-721 compliant)
 		// This should be accessible without authentication for marketplaces
 		nft.GET("/metadata/:contractAddress/:tokenId", func(c *gin.Context) {
@@ -43,6 +70,19 @@ func RegisterNFTRoutes(router *gin.RouterGroup, handler *Handler) {
 			c.Redirect(301, "https://dklnk.to/api/nfts/"+contractAddress+"/"+tokenId+"/metadata")
 		})
 	}
+
+	// Tickets contract administration, for the backend; roles are checked
+	// through the RoleManager
+	admin := router.Group("/admin/nft")
+	{
+		admin.POST("/tickets/:tokenId/burn", nftHandler.BurnTicket)
+		admin.PUT("/tickets/:tokenId/transfer-unlock", nftHandler.UpdateTransferUnlock)
+		admin.GET("/pause", nftHandler.GetTicketsPaused)
+		admin.POST("/pause", nftHandler.PauseTickets)
+		admin.POST("/unpause", nftHandler.UnpauseTickets)
+		admin.GET("/royalty", nftHandler.GetRoyalty)
+		admin.PUT("/royalty", nftHandler.SetRoyalty)
+	}
 }
 
 // RegisterPublicNFTRoutes registers public NFT routes (no auth required)
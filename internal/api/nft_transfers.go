@@ -0,0 +1,535 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/nft"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gin-gonic/gin"
+)
+
+// maxTransferBatch caps the tickets moved by one batch request; each
+// transfer is confirmed before the next is sent
+const maxTransferBatch = 20
+
+// TransferTicketRequest moves a ticket held by or approved to the backend wallet
+type TransferTicketRequest struct {
+	To   string `json:"to" binding:"required"`
+	From string `json:"from,omitempty"`
+	Safe bool   `json:"safe,omitempty"`
+}
+
+// ApproveTicketRequest approves an address to transfer a ticket
+type ApproveTicketRequest struct {
+	Spender string `json:"spender" binding:"required"`
+}
+
+// ApprovalForAllRequest approves or revokes an operator for all tickets of the backend wallet
+type ApprovalForAllRequest struct {
+	Operator string `json:"operator" binding:"required"`
+	Approved *bool  `json:"approved" binding:"required"`
+}
+
+// BatchTransferRequest moves several tickets to one recipient
+type BatchTransferRequest struct {
+	To       string   `json:"to" binding:"required"`
+	TokenIDs []uint64 `json:"tokenIds" binding:"required,min=1"`
+}
+
+// RecipientTransfer is the tickets one recipient receives
+type RecipientTransfer struct {
+	To       string   `json:"to" binding:"required"`
+	TokenIDs []uint64 `json:"tokenIds" binding:"required,min=1"`
+}
+
+// TransferToMultipleRequest moves tickets to several recipients
+type TransferToMultipleRequest struct {
+	Transfers []RecipientTransfer `json:"transfers" binding:"required,min=1,dive"`
+}
+
+// transferErrorStatus maps transfer errors to HTTP status codes
+func transferErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, nft.ErrNotTransferable):
+		return http.StatusConflict
+	case errors.Is(err, nft.ErrNotApproved):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// parseAddress returns the address in value, or answers 400 naming field
+func parseAddress(c *gin.Context, field string, value string) (common.Address, bool) {
+	if !common.IsHexAddress(value) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("Invalid %s address", field)})
+		return common.Address{}, false
+	}
+	return common.HexToAddress(value), true
+}
+
+// checkTransfers checks that every ticket can be transferred before any is
+// sent, and answers with the first one that cannot
+func checkTransfers(c *gin.Context, nftSDK *nft.Client, tokenIDs []uint64) bool {
+	seen := make(map[uint64]bool, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		if seen[tokenID] {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("Token %d is listed more than once", tokenID)})
+			return false
+		}
+		seen[tokenID] = true
+	}
+	for _, tokenID := range tokenIDs {
+		if err := nftSDK.CheckTransferable(c.Request.Context(), tokenID); err != nil {
+			c.JSON(transferErrorStatus(err), ErrorResponse{Error: err.Error()})
+			return false
+		}
+	}
+	return true
+}
+
+// recordTransfer points the database mapping and Datakyte metadata of a
+// transferred ticket at its new owner
+func (h *NFTHandler) recordTransfer(network string, tokenID uint64, to common.Address) {
+	db := database.GetDB()
+	if err := db.UpdateNFTOwner(tokenID, network, to.Hex()); err != nil {
+		fmt.Printf("Warning: Failed to update owner in database for token %d: %v\n", tokenID, err)
+	}
+
+	datakyteNFTID, err := db.GetDatakyteID(tokenID, network)
+	if err != nil {
+		fmt.Printf("Warning: Failed to get Datakyte ID for token %d: %v\n", tokenID, err)
+		return
+	}
+	if err := h.getMetadataService(network).UpdateTicketRecipient(datakyteNFTID, to.Hex()); err != nil {
+		// Log but don't fail - the transfer already succeeded on-chain
+		fmt.Printf("Warning: Failed to update Datakyte recipient for token %d: %v\n", tokenID, err)
+	}
+}
+
+// transferResults lists the confirmed transfers of a batch
+func transferResults(tokenIDs []uint64, recipients []common.Address, txs []*types.Transaction) []gin.H {
+	results := make([]gin.H, 0, len(txs))
+	for i, tx := range txs {
+		results = append(results, gin.H{
+			"tokenId": tokenIDs[i],
+			"to":      recipients[i].Hex(),
+			"txHash":  tx.Hash().Hex(),
+		})
+	}
+	return results
+}
+
+// GetTicketTransferability reports whether a ticket can be transferred now
+// @Summary Check ticket transferability
+// @Description Reports whether a ticket can be transferred now and, if not, why: it is still
+// @Description locked, it expired, or it was redeemed and cannot move after redemption.
+// @Tags NFT
+// @Produce json
+// @Param X-Network-Type header string false "Network type (testnet/mainnet)" default(testnet)
+// @Param tokenId path int true "Token ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /nft/tickets/{tokenId}/transferability [get]
+func (h *NFTHandler) GetTicketTransferability(c *gin.Context) {
+	network := GetNetworkFromContext(c)
+
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid token ID"})
+		return
+	}
+
+	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	ticket, err := nftSDK.GetTicketData(ctx, tokenID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	owner, err := nftSDK.GetOwnerOf(ctx, tokenID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	response := gin.H{
+		"tokenId":          tokenID,
+		"owner":            owner.Hex(),
+		"transferable":     true,
+		"transferUnlockAt": ticket.TransferUnlockAt,
+		"expiresAt":        ticket.ExpiresAt,
+	}
+	if err := nftSDK.CheckTransferable(ctx, tokenID); err != nil {
+		if !errors.Is(err, nft.ErrNotTransferable) {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return
+		}
+		response["transferable"] = false
+		response["reason"] = err.Error()
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// TransferTicket transfers a ticket from the backend wallet, or from an owner that approved it (backend only)
+// @Summary Transfer a ticket
+// @Description Transfers a ticket held by the backend wallet to another address, or from `from` when
+// @Description the backend wallet is approved for the ticket. The transfer rules of the contract are
+// @Description checked first; a ticket that is locked, expired or redeemed is answered with 409.
+// @Tags NFT
+// @Accept json
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Param tokenId path int true "Token ID"
+// @Param request body TransferTicketRequest true "Transfer request"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /nft/tickets/{tokenId}/transfer [post]
+func (h *NFTHandler) TransferTicket(c *gin.Context) {
+	network, ok := h.backendNetwork(c)
+	if !ok {
+		return
+	}
+
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid token ID"})
+		return
+	}
+	var req TransferTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	to, ok := parseAddress(c, "recipient", req.To)
+	if !ok {
+		return
+	}
+	var from common.Address
+	if req.From != "" {
+		if from, ok = parseAddress(c, "sender", req.From); !ok {
+			return
+		}
+	}
+
+	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if !checkTransfers(c, nftSDK, []uint64{tokenID}) {
+		return
+	}
+
+	if req.From == "" {
+		from = nftSDK.GetAddress()
+	}
+
+	ctx := c.Request.Context()
+	var tx *types.Transaction
+	switch {
+	case from != nftSDK.GetAddress():
+		tx, err = nftSDK.TransferFrom(ctx, from, to, tokenID)
+	case req.Safe:
+		tx, err = nftSDK.SafeTransfer(ctx, to, tokenID)
+	default:
+		tx, err = nftSDK.Transfer(ctx, to, tokenID)
+	}
+	if err != nil {
+		c.JSON(transferErrorStatus(err), ErrorResponse{Error: fmt.Sprintf("Failed to transfer ticket: %v", err)})
+		return
+	}
+	h.recordTransfer(network, tokenID, to)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"tokenId": tokenID,
+		"from":    from.Hex(),
+		"to":      to.Hex(),
+		"txHash":  tx.Hash().Hex(),
+	})
+}
+
+// ApproveTicket approves an address to transfer a ticket of the backend wallet (backend only)
+// @Summary Approve a ticket transfer
+// @Description Approves an address to transfer a ticket held by the backend wallet.
+// @Tags NFT
+// @Accept json
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Param tokenId path int true "Token ID"
+// @Param request body ApproveTicketRequest true "Approval request"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /nft/tickets/{tokenId}/approve [post]
+func (h *NFTHandler) ApproveTicket(c *gin.Context) {
+	network, ok := h.backendNetwork(c)
+	if !ok {
+		return
+	}
+
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid token ID"})
+		return
+	}
+	var req ApproveTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	spender, ok := parseAddress(c, "spender", req.Spender)
+	if !ok {
+		return
+	}
+
+	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tx, err := nftSDK.Approve(c.Request.Context(), spender, tokenID)
+	if err != nil {
+		c.JSON(transferErrorStatus(err), ErrorResponse{Error: fmt.Sprintf("Failed to approve: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"tokenId": tokenID,
+		"spender": spender.Hex(),
+		"txHash":  tx.Hash().Hex(),
+	})
+}
+
+// SetApprovalForAll approves or revokes an operator for all tickets of the backend wallet (backend only)
+// @Summary Set operator approval
+// @Description Approves or revokes an operator to transfer every ticket held by the backend wallet.
+// @Tags NFT
+// @Accept json
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Param request body ApprovalForAllRequest true "Operator approval"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /nft/tickets/approval-for-all [post]
+func (h *NFTHandler) SetApprovalForAll(c *gin.Context) {
+	network, ok := h.backendNetwork(c)
+	if !ok {
+		return
+	}
+
+	var req ApprovalForAllRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	operator, ok := parseAddress(c, "operator", req.Operator)
+	if !ok {
+		return
+	}
+
+	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tx, err := nftSDK.SetApprovalForAll(c.Request.Context(), operator, *req.Approved)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to set approval: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"operator": operator.Hex(),
+		"approved": *req.Approved,
+		"txHash":   tx.Hash().Hex(),
+	})
+}
+
+// BatchTransferTickets transfers several tickets of the backend wallet to one recipient (backend only)
+// @Summary Batch transfer tickets
+// @Description Transfers up to 20 tickets held by the backend wallet to one address, one transaction
+// @Description each. All tickets are checked before the first is sent. When a transfer fails, the
+// @Description confirmed ones are listed with the error and the remaining tickets are not sent.
+// @Tags NFT
+// @Accept json
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Param request body BatchTransferRequest true "Batch transfer request"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /nft/tickets/batch-transfer [post]
+func (h *NFTHandler) BatchTransferTickets(c *gin.Context) {
+	network, ok := h.backendNetwork(c)
+	if !ok {
+		return
+	}
+
+	var req BatchTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if len(req.TokenIDs) > maxTransferBatch {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("At most %d tickets per batch", maxTransferBatch)})
+		return
+	}
+	to, ok := parseAddress(c, "recipient", req.To)
+	if !ok {
+		return
+	}
+
+	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if !checkTransfers(c, nftSDK, req.TokenIDs) {
+		return
+	}
+
+	txs, err := nftSDK.BatchTransfer(c.Request.Context(), to, req.TokenIDs)
+	recipients := make([]common.Address, len(txs))
+	for i := range txs {
+		recipients[i] = to
+		h.recordTransfer(network, req.TokenIDs[i], to)
+	}
+	transfers := transferResults(req.TokenIDs, recipients, txs)
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"error": err.Error(), "transfers": transfers})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "transfers": transfers})
+}
+
+// TransferTicketsToMultiple transfers tickets of the backend wallet to several recipients (backend only)
+// @Summary Transfer tickets to several recipients
+// @Description Transfers up to 20 tickets held by the backend wallet, one transaction each, in
+// @Description ascending recipient address order. All tickets are checked before the first is sent.
+// @Description When a transfer fails, the confirmed ones are listed with the error.
+// @Tags NFT
+// @Accept json
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Param request body TransferToMultipleRequest true "Transfers per recipient"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /nft/tickets/transfer-multiple [post]
+func (h *NFTHandler) TransferTicketsToMultiple(c *gin.Context) {
+	network, ok := h.backendNetwork(c)
+	if !ok {
+		return
+	}
+
+	var req TransferToMultipleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	transfers := make(map[common.Address][]uint64)
+	var tokenIDs []uint64
+	for _, transfer := range req.Transfers {
+		to, ok := parseAddress(c, "recipient", transfer.To)
+		if !ok {
+			return
+		}
+		transfers[to] = append(transfers[to], transfer.TokenIDs...)
+		tokenIDs = append(tokenIDs, transfer.TokenIDs...)
+	}
+	if len(tokenIDs) > maxTransferBatch {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("At most %d tickets per batch", maxTransferBatch)})
+		return
+	}
+
+	nftSDK, err := h.NetworkHandler.GetNFTSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if !checkTransfers(c, nftSDK, tokenIDs) {
+		return
+	}
+
+	txs, err := nftSDK.TransferToMultiple(c.Request.Context(), transfers)
+
+	// Transactions follow the recipient order of the SDK
+	var sent []uint64
+	var recipients []common.Address
+	for _, to := range nft.SortedRecipients(transfers) {
+		for _, tokenID := range transfers[to] {
+			sent = append(sent, tokenID)
+			recipients = append(recipients, to)
+		}
+	}
+	for i := range txs {
+		h.recordTransfer(network, sent[i], recipients[i])
+	}
+	results := transferResults(sent, recipients, txs)
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"error": err.Error(), "transfers": results})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "transfers": results})
+}
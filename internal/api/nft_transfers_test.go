@@ -0,0 +1,111 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bogowi-blockchain-go/internal/config"
+	"bogowi-blockchain-go/internal/sdk/nft"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferEndpointsValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{BackendSecret: "test-secret", DevBackendSecret: "test-dev-secret"}
+	handler := &NFTHandler{Handler: &Handler{Config: cfg, NetworkHandler: &NetworkHandler{config: cfg}}}
+	router := gin.New()
+	router.POST("/tickets/:tokenId/transfer", handler.TransferTicket)
+	router.POST("/tickets/:tokenId/approve", handler.ApproveTicket)
+	router.POST("/tickets/approval-for-all", handler.SetApprovalForAll)
+	router.POST("/tickets/batch-transfer", handler.BatchTransferTickets)
+	router.POST("/tickets/transfer-multiple", handler.TransferTicketsToMultiple)
+
+	to := "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0"
+	tooMany := strings.TrimSuffix(strings.Repeat("1,", maxTransferBatch+1), ",")
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"Transfer with invalid token ID", "/tickets/abc/transfer", fmt.Sprintf(`{"to":%q}`, to)},
+		{"Transfer without recipient", "/tickets/1/transfer", `{}`},
+		{"Transfer to invalid address", "/tickets/1/transfer", `{"to":"0x123"}`},
+		{"Transfer from invalid address", "/tickets/1/transfer", fmt.Sprintf(`{"to":%q,"from":"nobody"}`, to)},
+		{"Approve invalid spender", "/tickets/1/approve", `{"spender":"0x123"}`},
+		{"Approval without flag", "/tickets/approval-for-all", fmt.Sprintf(`{"operator":%q}`, to)},
+		{"Approval for invalid operator", "/tickets/approval-for-all", `{"operator":"0x123","approved":true}`},
+		{"Batch without tokens", "/tickets/batch-transfer", fmt.Sprintf(`{"to":%q,"tokenIds":[]}`, to)},
+		{"Batch too large", "/tickets/batch-transfer", fmt.Sprintf(`{"to":%q,"tokenIds":[%s]}`, to, tooMany)},
+		{"Batch to invalid address", "/tickets/batch-transfer", `{"to":"0x123","tokenIds":[1]}`},
+		{"Multiple without transfers", "/tickets/transfer-multiple", `{"transfers":[]}`},
+		{"Multiple to invalid address", "/tickets/transfer-multiple", `{"transfers":[{"to":"0x123","tokenIds":[1]}]}`},
+		{"Multiple too large", "/tickets/transfer-multiple", fmt.Sprintf(`{"transfers":[{"to":%q,"tokenIds":[%s]}]}`, to, tooMany)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Backend-Auth", "test-dev-secret")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+
+	// The backend wallet signs every transfer, so only the backend may ask for one
+	unauthorized := []struct {
+		name string
+		path string
+		body string
+		auth string
+	}{
+		{"Transfer without token", "/tickets/1/transfer", fmt.Sprintf(`{"to":%q}`, to), ""},
+		{"Approve without token", "/tickets/1/approve", fmt.Sprintf(`{"spender":%q}`, to), ""},
+		{"Approval for all without token", "/tickets/approval-for-all", fmt.Sprintf(`{"operator":%q,"approved":true}`, to), ""},
+		{"Batch without token", "/tickets/batch-transfer", fmt.Sprintf(`{"to":%q,"tokenIds":[1]}`, to), ""},
+		{"Multiple without token", "/tickets/transfer-multiple", fmt.Sprintf(`{"transfers":[{"to":%q,"tokenIds":[1]}]}`, to), ""},
+		{"Transfer with wrong token", "/tickets/1/transfer", fmt.Sprintf(`{"to":%q}`, to), "wrong"},
+		{"Mainnet transfer with the testnet token", "/tickets/1/transfer?network=mainnet", fmt.Sprintf(`{"to":%q}`, to), "test-dev-secret"},
+	}
+
+	for _, tt := range unauthorized {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Backend-Auth", tt.auth)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+}
+
+func TestTransferErrorStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{fmt.Errorf("%w: ticket 1 is locked until 2026-01-01T00:00:00Z", nft.ErrNotTransferable), http.StatusConflict},
+		{fmt.Errorf("failed to transfer token 2: %w", nft.ErrNotTransferable), http.StatusConflict},
+		{fmt.Errorf("%w: sender is not the owner of token 1", nft.ErrNotApproved), http.StatusForbidden},
+		{errors.New("rpc unavailable"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, transferErrorStatus(tt.err), tt.err.Error())
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"bogowi-blockchain-go/internal/middleware"
 	"bogowi-blockchain-go/internal/models"
+	"bogowi-blockchain-go/internal/sdk/events"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 )
@@ -111,8 +112,11 @@ func (h *Handler) ClaimReward(c *gin.Context) {
 	walletAddr := common.HexToAddress(wallet)
 
 	// Check eligibility
-	eligible, message, err := h.SDK.CheckRewardEligibility(req.TemplateID, walletAddr)
+	eligible, message, err := h.SDK.CheckRewardEligibility(c.Request.Context(), req.TemplateID, walletAddr)
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to check eligibility: %v", err)})
 		return
 	}
@@ -123,8 +127,11 @@ func (h *Handler) ClaimReward(c *gin.Context) {
 	}
 
 	// Claim the reward using the clean interface method
-	tx, err := h.SDK.ClaimRewardV2(req.TemplateID, walletAddr) // TODO: SDK method needs renaming too
+	tx, err := h.SDK.ClaimRewardV2(c.Request.Context(), req.TemplateID, walletAddr) // TODO: SDK method needs renaming too
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to claim reward: %v", err)})
 		return
 	}
@@ -176,8 +183,11 @@ func (h *Handler) ClaimReferralBonus(c *gin.Context) {
 	referredAddr := common.HexToAddress(referredWallet)
 
 	// Claim referral bonus
-	tx, err := h.SDK.ClaimReferralBonus(referrerAddr, referredAddr)
+	tx, err := h.SDK.ClaimReferralBonus(c.Request.Context(), referrerAddr, referredAddr)
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to claim referral bonus: %v", err)})
 		return
 	}
@@ -209,6 +219,9 @@ func (h *Handler) ClaimCustomReward(c *gin.Context) {
 		var err error
 		sdk, err = h.NetworkHandler.GetSDK(network)
 		if err != nil {
+			if respondUnavailable(c, err) {
+				return
+			}
 			c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("Failed to get SDK for network %s: %v", network, err)})
 			return
 		}
@@ -270,9 +283,32 @@ func (h *Handler) ClaimCustomReward(c *gin.Context) {
 
 	recipientAddr := common.HexToAddress(recipientAddress)
 
+	// Estimate only when asked for a dry run
+	if dryRunRequested(c) {
+		est, err := sdk.EstimateClaimCustomReward(c.Request.Context(), recipientAddr, amount, reason)
+		if err != nil {
+			if respondUnavailable(c, err) {
+				return
+			}
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to estimate custom reward: %v", err)})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"dryRun":    true,
+			"estimate":  est,
+			"recipient": recipientAddress,
+			"amount":    req.Amount,
+			"reason":    reason,
+		})
+		return
+	}
+
 	// Claim custom reward
-	tx, err := sdk.ClaimCustomReward(recipientAddr, amount, reason)
+	tx, err := sdk.ClaimCustomReward(c.Request.Context(), recipientAddr, amount, reason)
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to claim custom reward: %v", err)})
 		return
 	}
@@ -349,8 +385,11 @@ func (h *Handler) CheckRewardEligibility(c *gin.Context) {
 
 	if templateID != "" {
 		// Check specific template
-		eligible, reason, err := h.SDK.CheckRewardEligibility(templateID, walletAddr)
+		eligible, reason, err := h.SDK.CheckRewardEligibility(c.Request.Context(), templateID, walletAddr)
 		if err != nil {
+			if respondUnavailable(c, err) {
+				return
+			}
 			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Error checking eligibility: %v", err)})
 			return
 		}
@@ -369,7 +408,7 @@ func (h *Handler) CheckRewardEligibility(c *gin.Context) {
 		}
 
 		for _, tmpl := range templates {
-			eligible, reason, _ := h.SDK.CheckRewardEligibility(tmpl, walletAddr)
+			eligible, reason, _ := h.SDK.CheckRewardEligibility(c.Request.Context(), tmpl, walletAddr)
 			eligibilities = append(eligibilities, gin.H{
 				"templateId": tmpl,
 				"eligible":   eligible,
@@ -383,6 +422,23 @@ func (h *Handler) CheckRewardEligibility(c *gin.Context) {
 	})
 }
 
+// claimFinality reports whether the transaction of a claim is final. Claims
+// without a tracked transaction are unconfirmed.
+func (h *Handler) claimFinality(network string, txHash string) string {
+	if h.NetworkHandler == nil || txHash == "" {
+		return events.Unconfirmed
+	}
+	tracker, err := h.NetworkHandler.GetTxTracker(network)
+	if err != nil {
+		return events.Unconfirmed
+	}
+	finality, err := tracker.Finality(txHash)
+	if err != nil {
+		return events.Unconfirmed
+	}
+	return finality
+}
+
 // GetRewardHistory returns the user's reward claim history
 func (h *Handler) GetRewardHistory(c *gin.Context) {
 	wallet, exists := c.Get("wallet")
@@ -416,6 +472,7 @@ func (h *Handler) GetRewardHistory(c *gin.Context) {
 			"templateId": claim.TemplateID,
 			"amount":     claim.Amount,
 			"status":     claim.Status,
+			"finality":   h.claimFinality(claim.Network, claim.TxHash),
 			"txHash":     claim.TxHash,
 			"claimedAt":  claim.ClaimedAt,
 			"network":    claim.Network,
@@ -428,6 +485,7 @@ func (h *Handler) GetRewardHistory(c *gin.Context) {
 			"referrerAddress": claim.ReferrerAddress,
 			"bonusAmount":     claim.BonusAmount,
 			"status":          claim.Status,
+			"finality":        h.claimFinality(claim.Network, claim.TxHash),
 			"txHash":          claim.TxHash,
 			"claimedAt":       claim.ClaimedAt,
 			"network":         claim.Network,
@@ -481,8 +539,11 @@ func (h *Handler) ClaimRewardV2(c *gin.Context) {
 	walletAddr := common.HexToAddress(wallet.(string))
 
 	// Check eligibility first
-	eligible, reason, err := h.SDK.CheckRewardEligibility(req.TemplateID, walletAddr)
+	eligible, reason, err := h.SDK.CheckRewardEligibility(c.Request.Context(), req.TemplateID, walletAddr)
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Error checking eligibility: %v", err)})
 		return
 	}
@@ -493,8 +554,11 @@ func (h *Handler) ClaimRewardV2(c *gin.Context) {
 	}
 
 	// Get template info for amount
-	template, err := h.SDK.GetRewardTemplate(req.TemplateID)
+	template, err := h.SDK.GetRewardTemplate(c.Request.Context(), req.TemplateID)
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Error getting template: %v", err)})
 		return
 	}
@@ -516,10 +580,13 @@ func (h *Handler) ClaimRewardV2(c *gin.Context) {
 	}
 
 	// Claim reward
-	tx, err := h.SDK.ClaimRewardV2(req.TemplateID, walletAddr)
+	tx, err := h.SDK.ClaimRewardV2(c.Request.Context(), req.TemplateID, walletAddr)
 	if err != nil {
 		// Update claim status to failed
 		h.Storage.UpdateRewardClaimStatus(c.Request.Context(), claimRecord.ID, "failed", "")
+		if respondUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Error claiming reward: %v", err)})
 		return
 	}
@@ -46,6 +46,10 @@ func CreateRouter(cfg *RouterConfig) *gin.Engine {
 		Storage:        cfg.Storage,
 	}
 
+	if cfg.NetworkHandler != nil {
+		cfg.NetworkHandler.TrackClaimReplacements(cfg.Storage)
+	}
+
 	router := gin.New()
 
 	// Setup middleware
@@ -125,11 +129,31 @@ func setupAPIRoutes(router *gin.Engine, handler *Handler, cfg *config.Config) {
 
 	// System endpoints
 	api.GET("/health", handler.GetHealth)
+	api.GET("/health/networks", handler.GetNetworkHealth)
 	api.GET("/gas-price", handler.GetGasPrice)
+	api.GET("/metrics", handler.GetMetrics)
 
 	// Token endpoints
 	setupTokenRoutes(api, handler)
 
+	// Native CAM endpoints
+	setupNativeRoutes(api, handler)
+
+	// Transaction status endpoints
+	api.GET("/tx/:hash", handler.GetTransaction)
+
+	// Stuck transaction replacement (backend only)
+	adminTx := api.Group("/admin/tx")
+	adminTx.POST("/speed-up", handler.SpeedUpTransaction)
+	adminTx.POST("/cancel", handler.CancelTransaction)
+
+	// Hot wallet pool status (backend only)
+	api.GET("/admin/wallets", handler.GetWalletPool)
+
+	// Ticket expiry sweeps (backend only)
+	api.GET("/admin/nft/expiry-sweeps", handler.GetExpirySweeps)
+	api.POST("/admin/nft/expiry-sweeps", handler.TriggerExpirySweep)
+
 	// Rewards endpoints
 	setupRewardRoutes(api, handler, cfg)
 
@@ -144,7 +168,25 @@ func setupAPIRoutes(router *gin.Engine, handler *Handler, cfg *config.Config) {
 func setupTokenRoutes(api *gin.RouterGroup, handler *Handler) {
 	token := api.Group("/token")
 	token.GET("/balance/:address", handler.GetTokenBalance)
+	token.POST("/balances", handler.GetTokenBalances)
 	token.POST("/transfer", handler.TransferBOGOTokens)
+
+	// Generic reads for allowlisted third-party tokens
+	erc20 := api.Group("/erc20")
+	erc20.GET("/:token", handler.GetERC20Info)
+	erc20.GET("/:token/balance/:address", handler.GetERC20Balance)
+
+	erc721 := api.Group("/erc721")
+	erc721.GET("/:token/owner/:tokenId", handler.GetERC721Owner)
+	erc721.GET("/:token/token-uri/:tokenId", handler.GetERC721TokenURI)
+}
+
+// setupNativeRoutes configures native CAM endpoints
+func setupNativeRoutes(api *gin.RouterGroup, handler *Handler) {
+	native := api.Group("/native")
+	native.GET("/balance/:address", handler.GetNativeBalance)
+	native.POST("/transfer", handler.TransferNative)
+	native.GET("/transfers", handler.GetNativeTransfers)
 }
 
 // setupRewardRoutes configures reward-related endpoints
@@ -34,13 +34,13 @@ func DefaultRouterDependencies(cfg *config.Config) (*RouterDependencies, error)
 		return nil, err
 	}
 
-	// Get default SDK based on environment
-	var defaultSDK SDKInterface
+	// Get default SDK based on environment; it follows the network's
+	// connection so it can be set up before the network is reachable
+	defaultNetwork := "mainnet"
 	if cfg.Environment == "development" {
-		defaultSDK, _ = networkHandler.GetSDK("testnet")
-	} else {
-		defaultSDK, _ = networkHandler.GetSDK("mainnet")
+		defaultNetwork = "testnet"
 	}
+	defaultSDK := networkHandler.DefaultSDK(defaultNetwork)
 
 	// Create CORS config
 	corsConfig := cors.DefaultConfig()
@@ -92,6 +92,9 @@ func (rb *RouterBuilder) Build() *gin.Engine {
 		Config:         rb.deps.Config,
 		Storage:        rb.deps.Storage,
 	}
+	if rb.deps.NetworkHandler != nil && rb.deps.Storage != nil {
+		rb.deps.NetworkHandler.TrackClaimReplacements(rb.deps.Storage)
+	}
 
 	// Apply middleware unless skipped (for testing)
 	if !rb.skipMiddleware {
@@ -141,6 +144,24 @@ func (rb *RouterBuilder) registerRoutes() {
 	// Token endpoints
 	rb.registerTokenRoutes(api)
 
+	// Native CAM endpoints
+	rb.registerNativeRoutes(api)
+
+	// Transaction status endpoints
+	api.GET("/tx/:hash", rb.handler.GetTransaction)
+
+	// Stuck transaction replacement (backend only)
+	adminTx := api.Group("/admin/tx")
+	adminTx.POST("/speed-up", rb.handler.SpeedUpTransaction)
+	adminTx.POST("/cancel", rb.handler.CancelTransaction)
+
+	// Hot wallet pool status (backend only)
+	api.GET("/admin/wallets", rb.handler.GetWalletPool)
+
+	// Ticket expiry sweeps (backend only)
+	api.GET("/admin/nft/expiry-sweeps", rb.handler.GetExpirySweeps)
+	api.POST("/admin/nft/expiry-sweeps", rb.handler.TriggerExpirySweep)
+
 	// Rewards endpoints
 	rb.registerRewardRoutes(api)
 
@@ -188,14 +209,34 @@ func (rb *RouterBuilder) registerDocsRoutes() {
 // registerSystemRoutes sets up system endpoints
 func (rb *RouterBuilder) registerSystemRoutes(api *gin.RouterGroup) {
 	api.GET("/health", rb.handler.GetHealth)
+	api.GET("/health/networks", rb.handler.GetNetworkHealth)
 	api.GET("/gas-price", rb.handler.GetGasPrice)
+	api.GET("/metrics", rb.handler.GetMetrics)
 }
 
 // registerTokenRoutes sets up token endpoints
 func (rb *RouterBuilder) registerTokenRoutes(api *gin.RouterGroup) {
 	token := api.Group("/token")
 	token.GET("/balance/:address", rb.handler.GetTokenBalance)
+	token.POST("/balances", rb.handler.GetTokenBalances)
 	token.POST("/transfer", rb.handler.TransferBOGOTokens)
+
+	// Generic reads for allowlisted third-party tokens
+	erc20 := api.Group("/erc20")
+	erc20.GET("/:token", rb.handler.GetERC20Info)
+	erc20.GET("/:token/balance/:address", rb.handler.GetERC20Balance)
+
+	erc721 := api.Group("/erc721")
+	erc721.GET("/:token/owner/:tokenId", rb.handler.GetERC721Owner)
+	erc721.GET("/:token/token-uri/:tokenId", rb.handler.GetERC721TokenURI)
+}
+
+// registerNativeRoutes sets up native CAM endpoints
+func (rb *RouterBuilder) registerNativeRoutes(api *gin.RouterGroup) {
+	native := api.Group("/native")
+	native.GET("/balance/:address", rb.handler.GetNativeBalance)
+	native.POST("/transfer", rb.handler.TransferNative)
+	native.GET("/transfers", rb.handler.GetNativeTransfers)
 }
 
 // registerRewardRoutes sets up reward endpoints
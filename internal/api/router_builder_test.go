@@ -31,12 +31,20 @@ func TestRouterBuilder_Build(t *testing.T) {
 
 		// Check system routes
 		AssertRouteExists(t, router, "GET", "/api/health")
+		AssertRouteExists(t, router, "GET", "/api/health/networks")
 		AssertRouteExists(t, router, "GET", "/api/gas-price")
+		AssertRouteExists(t, router, "GET", "/api/metrics")
 
 		// Check token routes
 		AssertRouteExists(t, router, "GET", "/api/token/balance/:address")
 		AssertRouteExists(t, router, "POST", "/api/token/transfer")
 
+		// Check transaction routes
+		AssertRouteExists(t, router, "GET", "/api/tx/:hash")
+		AssertRouteExists(t, router, "POST", "/api/admin/tx/speed-up")
+		AssertRouteExists(t, router, "POST", "/api/admin/tx/cancel")
+		AssertRouteExists(t, router, "GET", "/api/admin/wallets")
+
 		// Check reward routes
 		AssertRouteExists(t, router, "GET", "/api/rewards/templates")
 		AssertRouteExists(t, router, "GET", "/api/rewards/templates/:id")
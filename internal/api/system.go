@@ -1,8 +1,11 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 
+	"bogowi-blockchain-go/internal/sdk/monitor"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -25,14 +28,82 @@ func (h *Handler) GetHealth(c *gin.Context) {
 		contracts = h.Config.Mainnet.Contracts
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"status":    "ok",
 		"network":   network,
 		"contracts": contracts,
+	}
+
+	// Report the connection and watched balances of the network; a down
+	// network or a low or unreadable balance degrades the status
+	if h.NetworkHandler != nil {
+		if status, ok := h.NetworkHandler.NetworkStatus(network); ok {
+			if status.State != NetworkAvailable {
+				response["status"] = "degraded"
+			}
+			response["connection"] = status
+		}
+		if m, err := h.NetworkHandler.GetBalanceMonitor(network); err == nil {
+			balances := m.Status()
+			if !balances.Healthy {
+				response["status"] = "degraded"
+			}
+			response["balances"] = balances
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetNetworkHealth reports the connection state of every configured network
+// @Summary Get network connection states
+// @Description Returns whether each configured network is connecting, available or unavailable, why, and the health of its RPC endpoints. The status is degraded while any network is not available.
+// @Tags System
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /health/networks [get]
+func (h *Handler) GetNetworkHealth(c *gin.Context) {
+	statuses := []NetworkStatus{}
+	if h.NetworkHandler != nil {
+		statuses = append(statuses, h.NetworkHandler.NetworkStatuses()...)
+	}
+
+	status := "ok"
+	for _, network := range statuses {
+		if network.State != NetworkAvailable {
+			status = "degraded"
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   status,
+		"networks": statuses,
 	})
 }
 
-// GetGasPrice returns the current gas price
+// GetMetrics exposes the watched balances of every network in the Prometheus
+// text format
+// @Summary Get Prometheus metrics
+// @Description Returns signer, hot wallet, funding wallet and RewardDistributor balances and top-up totals in the Prometheus text exposition format
+// @Tags System
+// @Produce plain
+// @Success 200 {string} string "Prometheus metrics"
+// @Router /metrics [get]
+func (h *Handler) GetMetrics(c *gin.Context) {
+	var monitors []*monitor.Monitor
+	if h.NetworkHandler != nil {
+		monitors = h.NetworkHandler.BalanceMonitors()
+	}
+
+	c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := monitor.WriteMetrics(c.Writer, monitors...); err != nil {
+		fmt.Printf("Warning: Failed to write metrics: %v\n", err)
+	}
+}
+
+// GetGasPrice returns the current gas price along with EIP-1559 base fee and
+// tip suggestions, and the fee strategy used for the network's transactions
 func (h *Handler) GetGasPrice(c *gin.Context) {
 	// Get network parameter
 	network := c.Query("network")
@@ -51,18 +122,29 @@ func (h *Handler) GetGasPrice(c *gin.Context) {
 
 	networkSDK, err := h.NetworkHandler.GetSDK(network)
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid network: " + network + ". Use 'testnet' or 'mainnet'"})
 		return
 	}
 
-	gasPrice, err := networkSDK.GetGasPrice()
+	fees, err := networkSDK.GetGasFees(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to get gas price"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"network":  network,
-		"gasPrice": gasPrice,
-	})
+		"strategy": fees.Strategy,
+		"gasPrice": fees.GasPrice,
+	}
+	if fees.BaseFee != "" {
+		response["baseFee"] = fees.BaseFee
+		response["maxPriorityFeePerGas"] = fees.MaxPriorityFeePerGas
+		response["maxFeePerGas"] = fees.MaxFeePerGas
+	}
+
+	c.JSON(http.StatusOK, response)
 }
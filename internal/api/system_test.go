@@ -1,14 +1,20 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"bogowi-blockchain-go/internal/config"
+	"bogowi-blockchain-go/internal/middleware"
 	"bogowi-blockchain-go/internal/sdk"
+	"bogowi-blockchain-go/internal/sdk/gas"
+	"bogowi-blockchain-go/internal/sdk/monitor"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/gin-gonic/gin"
@@ -22,7 +28,7 @@ type MockSDK struct {
 	mock.Mock
 }
 
-func (m *MockSDK) GetTokenBalance(address string) (*sdk.TokenBalance, error) {
+func (m *MockSDK) GetTokenBalance(ctx context.Context, address string) (*sdk.TokenBalance, error) {
 	args := m.Called(address)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -30,16 +36,95 @@ func (m *MockSDK) GetTokenBalance(address string) (*sdk.TokenBalance, error) {
 	return args.Get(0).(*sdk.TokenBalance), args.Error(1)
 }
 
-func (m *MockSDK) GetGasPrice() (string, error) {
+func (m *MockSDK) GetTokenBalances(ctx context.Context, addresses []string) ([]*sdk.AccountBalance, error) {
+	args := m.Called(addresses)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sdk.AccountBalance), args.Error(1)
+}
+
+func (m *MockSDK) GetERC20Info(ctx context.Context, token string) (*sdk.ERC20Info, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sdk.ERC20Info), args.Error(1)
+}
+
+func (m *MockSDK) GetERC20Balance(ctx context.Context, token string, holder string) (*sdk.ERC20Balance, error) {
+	args := m.Called(token, holder)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sdk.ERC20Balance), args.Error(1)
+}
+
+func (m *MockSDK) GetERC721Owner(ctx context.Context, token string, tokenID *big.Int) (string, error) {
+	args := m.Called(token, tokenID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSDK) GetERC721TokenURI(ctx context.Context, token string, tokenID *big.Int) (string, error) {
+	args := m.Called(token, tokenID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSDK) GetNativeBalance(ctx context.Context, address string) (*sdk.NativeBalance, error) {
+	args := m.Called(address)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sdk.NativeBalance), args.Error(1)
+}
+
+func (m *MockSDK) TransferNative(ctx context.Context, to string, amount string) (*sdk.NativeTransfer, error) {
+	args := m.Called(to, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sdk.NativeTransfer), args.Error(1)
+}
+
+func (m *MockSDK) EstimateTransferNative(ctx context.Context, to string, amount string) (*gas.Estimate, error) {
+	args := m.Called(to, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gas.Estimate), args.Error(1)
+}
+
+func (m *MockSDK) GetNativeTransfers() []*sdk.NativeTransfer {
+	args := m.Called()
+	return args.Get(0).([]*sdk.NativeTransfer)
+}
+
+func (m *MockSDK) GetGasPrice(ctx context.Context) (string, error) {
 	args := m.Called()
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockSDK) TransferBOGOTokens(to string, amount string) (string, error) {
+func (m *MockSDK) GetGasFees(ctx context.Context) (*sdk.GasFees, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sdk.GasFees), args.Error(1)
+}
+
+func (m *MockSDK) TransferBOGOTokens(ctx context.Context, to string, amount string) (string, error) {
 	args := m.Called(to, amount)
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockSDK) EstimateTransferBOGOTokens(ctx context.Context, to string, amount string) (*gas.Estimate, error) {
+	args := m.Called(to, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gas.Estimate), args.Error(1)
+}
+
 func (m *MockSDK) GetPublicKey() (string, error) {
 	args := m.Called()
 	return args.String(0), args.Error(1)
@@ -86,12 +171,12 @@ func (m *MockSDK) ClaimReward(address string, rewardType string, rewardAmount st
 }
 
 // New reward system methods
-func (m *MockSDK) CheckRewardEligibility(templateID string, wallet common.Address) (bool, string, error) {
+func (m *MockSDK) CheckRewardEligibility(ctx context.Context, templateID string, wallet common.Address) (bool, string, error) {
 	args := m.Called(templateID, wallet)
 	return args.Bool(0), args.String(1), args.Error(2)
 }
 
-func (m *MockSDK) ClaimRewardV2(templateID string, recipient common.Address) (*types.Transaction, error) {
+func (m *MockSDK) ClaimRewardV2(ctx context.Context, templateID string, recipient common.Address) (*types.Transaction, error) {
 	args := m.Called(templateID, recipient)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -99,7 +184,7 @@ func (m *MockSDK) ClaimRewardV2(templateID string, recipient common.Address) (*t
 	return args.Get(0).(*types.Transaction), args.Error(1)
 }
 
-func (m *MockSDK) ClaimCustomReward(recipient common.Address, amount *big.Int, reason string) (*types.Transaction, error) {
+func (m *MockSDK) ClaimCustomReward(ctx context.Context, recipient common.Address, amount *big.Int, reason string) (*types.Transaction, error) {
 	args := m.Called(recipient, amount, reason)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -107,7 +192,15 @@ func (m *MockSDK) ClaimCustomReward(recipient common.Address, amount *big.Int, r
 	return args.Get(0).(*types.Transaction), args.Error(1)
 }
 
-func (m *MockSDK) ClaimReferralBonus(referrer common.Address, referred common.Address) (*types.Transaction, error) {
+func (m *MockSDK) EstimateClaimCustomReward(ctx context.Context, recipient common.Address, amount *big.Int, reason string) (*gas.Estimate, error) {
+	args := m.Called(recipient, amount, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gas.Estimate), args.Error(1)
+}
+
+func (m *MockSDK) ClaimReferralBonus(ctx context.Context, referrer common.Address, referred common.Address) (*types.Transaction, error) {
 	args := m.Called(referrer, referred)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -115,12 +208,12 @@ func (m *MockSDK) ClaimReferralBonus(referrer common.Address, referred common.Ad
 	return args.Get(0).(*types.Transaction), args.Error(1)
 }
 
-func (m *MockSDK) GetReferrer(wallet common.Address) (common.Address, error) {
+func (m *MockSDK) GetReferrer(ctx context.Context, wallet common.Address) (common.Address, error) {
 	args := m.Called(wallet)
 	return args.Get(0).(common.Address), args.Error(1)
 }
 
-func (m *MockSDK) GetRewardTemplate(templateID string) (*sdk.RewardTemplate, error) {
+func (m *MockSDK) GetRewardTemplate(ctx context.Context, templateID string) (*sdk.RewardTemplate, error) {
 	args := m.Called(templateID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -128,7 +221,7 @@ func (m *MockSDK) GetRewardTemplate(templateID string) (*sdk.RewardTemplate, err
 	return args.Get(0).(*sdk.RewardTemplate), args.Error(1)
 }
 
-func (m *MockSDK) GetClaimCount(wallet common.Address, templateID string) (*big.Int, error) {
+func (m *MockSDK) GetClaimCount(ctx context.Context, wallet common.Address, templateID string) (*big.Int, error) {
 	args := m.Called(wallet, templateID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -136,12 +229,12 @@ func (m *MockSDK) GetClaimCount(wallet common.Address, templateID string) (*big.
 	return args.Get(0).(*big.Int), args.Error(1)
 }
 
-func (m *MockSDK) IsWhitelisted(wallet common.Address) (bool, error) {
+func (m *MockSDK) IsWhitelisted(ctx context.Context, wallet common.Address) (bool, error) {
 	args := m.Called(wallet)
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockSDK) GetRemainingDailyLimit() (*big.Int, error) {
+func (m *MockSDK) GetRemainingDailyLimit(ctx context.Context) (*big.Int, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -226,23 +319,42 @@ func TestGetHealth(t *testing.T) {
 func TestGetGasPrice(t *testing.T) {
 	tests := []struct {
 		name           string
-		mockGasPrice   string
+		mockFees       *sdk.GasFees
 		mockError      error
 		expectedStatus int
 		expectedBody   map[string]interface{}
 	}{
 		{
 			name:           "successful gas price retrieval",
-			mockGasPrice:   "25.00 gwei",
+			mockFees:       &sdk.GasFees{Strategy: "legacy", GasPrice: "25.00 gwei"},
 			mockError:      nil,
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
 				"gasPrice": "25.00 gwei",
+				"strategy": "legacy",
+			},
+		},
+		{
+			name: "includes EIP-1559 suggestions",
+			mockFees: &sdk.GasFees{
+				Strategy:             "eip1559",
+				GasPrice:             "27.00 gwei",
+				BaseFee:              "25.00 gwei",
+				MaxPriorityFeePerGas: "2.00 gwei",
+				MaxFeePerGas:         "52.00 gwei",
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"gasPrice":             "27.00 gwei",
+				"strategy":             "eip1559",
+				"baseFee":              "25.00 gwei",
+				"maxPriorityFeePerGas": "2.00 gwei",
+				"maxFeePerGas":         "52.00 gwei",
 			},
 		},
 		{
 			name:           "gas price retrieval error",
-			mockGasPrice:   "",
+			mockFees:       nil,
 			mockError:      assert.AnError,
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: map[string]interface{}{
@@ -255,7 +367,7 @@ func TestGetGasPrice(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			router, mockSDK, _ := setupTestRouter()
 
-			mockSDK.On("GetGasPrice").Return(tt.mockGasPrice, tt.mockError)
+			mockSDK.On("GetGasFees").Return(tt.mockFees, tt.mockError)
 
 			w := httptest.NewRecorder()
 			req, _ := http.NewRequest("GET", "/api/gas-price", nil)
@@ -268,7 +380,12 @@ func TestGetGasPrice(t *testing.T) {
 			require.NoError(t, err)
 
 			if tt.expectedStatus == http.StatusOK {
-				assert.Equal(t, tt.expectedBody["gasPrice"], response["gasPrice"])
+				for key, value := range tt.expectedBody {
+					assert.Equal(t, value, response[key], key)
+				}
+				if tt.mockFees.BaseFee == "" {
+					assert.NotContains(t, response, "baseFee")
+				}
 			} else {
 				assert.Equal(t, tt.expectedBody["error"], response["error"])
 			}
@@ -277,3 +394,150 @@ func TestGetGasPrice(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHealthBalances(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m, err := monitor.New(nil, monitor.Config{
+		Network: "testnet",
+		ChainID: big.NewInt(501),
+		Accounts: []monitor.Account{{
+			Name:      monitor.NameSigner,
+			Address:   common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f6E123"),
+			Threshold: big.NewInt(1e18),
+		}},
+	})
+	require.NoError(t, err)
+
+	cfg := &config.Config{}
+	handler := &Handler{
+		Config: cfg,
+		NetworkHandler: &NetworkHandler{
+			config:   cfg,
+			monitors: map[string]*monitor.Monitor{"testnet": m},
+		},
+	}
+
+	router := gin.New()
+	router.GET("/api/health", handler.GetHealth)
+	router.GET("/api/metrics", handler.GetMetrics)
+
+	t.Run("health with balances", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/health?network=testnet", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Status   string         `json:"status"`
+			Balances monitor.Status `json:"balances"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "ok", response.Status)
+		assert.Equal(t, "testnet", response.Balances.Network)
+		require.Len(t, response.Balances.Balances, 1)
+		assert.Equal(t, monitor.NameSigner, response.Balances.Balances[0].Name)
+		assert.Equal(t, "1", response.Balances.Balances[0].Threshold)
+	})
+
+	t.Run("health without monitor", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/health?network=mainnet", nil)
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "ok", response["status"])
+		assert.NotContains(t, response, "balances")
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/metrics", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+		assert.Contains(t, w.Body.String(), `bogowi_balance_threshold{network="testnet",name="signer"`)
+	})
+}
+
+func TestNetworkHealth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	nextRetry := time.Now().Add(30 * time.Second)
+	cfg := &config.Config{}
+	networkHandler := &NetworkHandler{
+		config:     cfg,
+		mainnetSDK: &MockSDK{},
+		states: map[string]*NetworkStatus{
+			"testnet": {Network: "testnet", State: NetworkUnavailable, Reason: "RPC unreachable: connection refused", Attempts: 2, NextRetry: &nextRetry},
+			"mainnet": {Network: "mainnet", State: NetworkAvailable},
+		},
+	}
+	handler := &Handler{Config: cfg, NetworkHandler: networkHandler, SDK: networkHandler.DefaultSDK("testnet")}
+
+	router := gin.New()
+	router.GET("/api/health", handler.GetHealth)
+	router.GET("/api/health/networks", handler.GetNetworkHealth)
+	router.GET("/api/gas-price", handler.GetGasPrice)
+	router.POST("/api/rewards/claim", func(c *gin.Context) {
+		c.Set("claims", &middleware.FirebaseClaims{WalletAddress: "0x742d35Cc6634C0532925a3b844Bc9e7595f6E123"})
+	}, handler.ClaimReward)
+
+	t.Run("network states", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/health/networks", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Status   string          `json:"status"`
+			Networks []NetworkStatus `json:"networks"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "degraded", response.Status)
+		require.Len(t, response.Networks, 2)
+		assert.Equal(t, "testnet", response.Networks[0].Network)
+		assert.Equal(t, NetworkUnavailable, response.Networks[0].State)
+		assert.Equal(t, "RPC unreachable: connection refused", response.Networks[0].Reason)
+		assert.Equal(t, NetworkAvailable, response.Networks[1].State)
+	})
+
+	t.Run("health of a down network", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/health?network=testnet", nil)
+		router.ServeHTTP(w, req)
+
+		var response struct {
+			Status     string        `json:"status"`
+			Connection NetworkStatus `json:"connection"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "degraded", response.Status)
+		assert.Equal(t, NetworkUnavailable, response.Connection.State)
+	})
+
+	t.Run("route on a down network", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/gas-price?network=testnet", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+		assert.Contains(t, w.Body.String(), "testnet network unavailable: RPC unreachable: connection refused")
+	})
+
+	t.Run("default SDK on a down network", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := `{"templateId":"welcome_bonus"}`
+		req, _ := http.NewRequest("POST", "/api/rewards/claim", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Contains(t, w.Body.String(), "testnet network unavailable")
+	})
+}
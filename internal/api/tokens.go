@@ -1,12 +1,20 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 
+	"bogowi-blockchain-go/internal/sdk"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 )
 
+// TokenBalancesRequest represents a multi-address balance request
+type TokenBalancesRequest struct {
+	Addresses []string `json:"addresses" binding:"required,min=1"`
+}
+
 // GetTokenBalance returns the BOGO token balance for a specific address
 // @Summary Get BOGO token balance
 // @Description Returns the balance of BOGO tokens for a given address
@@ -43,11 +51,14 @@ func (h *Handler) GetTokenBalance(c *gin.Context) {
 
 	networkSDK, err := h.NetworkHandler.GetSDK(network)
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid network: " + network + ". Use 'testnet' or 'mainnet'"})
 		return
 	}
 
-	balance, err := networkSDK.GetTokenBalance(address)
+	balance, err := networkSDK.GetTokenBalance(c.Request.Context(), address)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
@@ -55,3 +66,71 @@ func (h *Handler) GetTokenBalance(c *gin.Context) {
 
 	c.JSON(http.StatusOK, balance)
 }
+
+// GetTokenBalances returns BOGO and native CAM balances for multiple addresses
+// @Summary Get balances for multiple addresses
+// @Description Returns BOGO token and native CAM balances for up to 100 addresses using batched RPC reads
+// @Tags Tokens
+// @Accept json
+// @Produce json
+// @Param network query string false "Network (testnet or mainnet)"
+// @Param request body TokenBalancesRequest true "Addresses to query"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /token/balances [post]
+func (h *Handler) GetTokenBalances(c *gin.Context) {
+	var req TokenBalancesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if len(req.Addresses) > sdk.MaxBalanceBatchSize {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("Too many addresses (maximum %d)", sdk.MaxBalanceBatchSize)})
+		return
+	}
+
+	// Validate Ethereum addresses
+	for _, address := range req.Addresses {
+		if !common.IsHexAddress(address) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid Ethereum address: " + address})
+			return
+		}
+	}
+
+	// Get network parameter (required)
+	network := c.Query("network")
+	if network == "" {
+		network = c.GetHeader("X-Network")
+	}
+	if network == "" {
+		network = "mainnet" // Default to mainnet if not specified
+	}
+
+	// Get network-specific SDK
+	if h.NetworkHandler == nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Network handler not initialized"})
+		return
+	}
+
+	networkSDK, err := h.NetworkHandler.GetSDK(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid network: " + network + ". Use 'testnet' or 'mainnet'"})
+		return
+	}
+
+	balances, err := networkSDK.GetTokenBalances(c.Request.Context(), req.Addresses)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"network":  network,
+		"balances": balances,
+	})
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -44,6 +45,7 @@ func setupTokenRouter() (*gin.Engine, *MockSDK) {
 	token := api.Group("/token")
 	token.GET("/balance/:address", handler.GetTokenBalance)
 	token.POST("/transfer", handler.TransferBOGOTokens)
+	token.POST("/balances", handler.GetTokenBalances)
 
 	return router, mockSDK
 }
@@ -117,6 +119,37 @@ func TestGetTokenBalance(t *testing.T) {
 	}
 }
 
+// contextSDK records the context of the balance lookups it receives
+type contextSDK struct {
+	*SimpleMockSDK
+	ctx context.Context
+}
+
+func (s *contextSDK) GetTokenBalance(ctx context.Context, address string) (*sdk.TokenBalance, error) {
+	s.ctx = ctx
+	return s.SimpleMockSDK.GetTokenBalance(ctx, address)
+}
+
+func TestGetTokenBalanceUsesRequestContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	stub := &contextSDK{SimpleMockSDK: NewSimpleMockSDK()}
+	handler := &Handler{
+		NetworkHandler: &NetworkHandler{testnetSDK: stub, mainnetSDK: stub},
+	}
+	router := gin.New()
+	router.GET("/api/token/balance/:address", handler.GetTokenBalance)
+
+	// A client that has gone away cancels the SDK call
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/api/token/balance/0x742d35Cc6634C0532925a3b844Bc9e7595f8E97D", nil).WithContext(ctx)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.NotNil(t, stub.ctx)
+	assert.ErrorIs(t, stub.ctx.Err(), context.Canceled)
+}
+
 func TestTransferBOGOTokens(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -198,3 +231,96 @@ func TestTransferBOGOTokens(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTokenBalances(t *testing.T) {
+	validAddresses := []string{
+		"0x742d35Cc6634C0532925a3b844Bc9e7595f8E97D",
+		"0x1234567890123456789012345678901234567890",
+	}
+
+	tooMany := make([]string, sdk.MaxBalanceBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = validAddresses[0]
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockBalances   []*sdk.AccountBalance
+		mockError      error
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "successful batch retrieval",
+			requestBody: TokenBalancesRequest{Addresses: validAddresses},
+			mockBalances: []*sdk.AccountBalance{
+				{Address: validAddresses[0], Balance: "10", NativeBalance: "0.5"},
+				{Address: validAddresses[1], Balance: "0", NativeBalance: "1.25"},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty address list",
+			requestBody:    TokenBalancesRequest{Addresses: []string{}},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Key: 'TokenBalancesRequest.Addresses' Error:Field validation for 'Addresses' failed on the 'min' tag",
+		},
+		{
+			name:           "too many addresses",
+			requestBody:    TokenBalancesRequest{Addresses: tooMany},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  fmt.Sprintf("Too many addresses (maximum %d)", sdk.MaxBalanceBatchSize),
+		},
+		{
+			name:           "invalid address in list",
+			requestBody:    TokenBalancesRequest{Addresses: []string{validAddresses[0], "invalid-address"}},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid Ethereum address: invalid-address",
+		},
+		{
+			name:           "sdk error",
+			requestBody:    TokenBalancesRequest{Addresses: validAddresses},
+			mockError:      fmt.Errorf("batch request failed"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "batch request failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockSDK := setupTokenRouter()
+
+			if tt.mockBalances != nil || tt.mockError != nil {
+				req := tt.requestBody.(TokenBalancesRequest)
+				mockSDK.On("GetTokenBalances", req.Addresses).Return(tt.mockBalances, tt.mockError)
+			}
+
+			body, _ := json.Marshal(tt.requestBody)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/token/balances?network=testnet", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var response struct {
+					Network  string                `json:"network"`
+					Balances []*sdk.AccountBalance `json:"balances"`
+				}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "testnet", response.Network)
+				assert.Equal(t, tt.mockBalances, response.Balances)
+			} else {
+				var response ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedError, response.Error)
+			}
+
+			mockSDK.AssertExpectations(t)
+		})
+	}
+}
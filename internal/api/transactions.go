@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"bogowi-blockchain-go/internal/sdk/gas"
+	"bogowi-blockchain-go/internal/sdk/txtrack"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+var txHashPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+
+// dryRunRequested reports whether a write endpoint was called with
+// ?dryRun=true, asking for the estimate instead of sending the transaction
+func dryRunRequested(c *gin.Context) bool {
+	dryRun, _ := strconv.ParseBool(c.Query("dryRun"))
+	return dryRun
+}
+
+// dryRunContext returns the context for an SDK write, derived from the
+// request so that a disconnecting client cancels it: a dry-run context and
+// its estimate when the caller asked for one, otherwise the request context and nil
+func dryRunContext(c *gin.Context) (context.Context, *gas.Estimate) {
+	ctx := c.Request.Context()
+	if !dryRunRequested(c) {
+		return ctx, nil
+	}
+	return gas.WithDryRun(ctx)
+}
+
+// respondDryRun returns the estimate of a transaction that was not sent
+func respondDryRun(c *gin.Context, network string, est *gas.Estimate) {
+	c.JSON(http.StatusOK, gin.H{
+		"dryRun":   true,
+		"network":  network,
+		"estimate": est,
+	})
+}
+
+// ReplaceTransactionRequest selects the stuck nonce to speed up or cancel
+type ReplaceTransactionRequest struct {
+	Signer string  `json:"signer" binding:"required"`
+	Nonce  *uint64 `json:"nonce" binding:"required"`
+}
+
+// GetTransaction returns the tracked status of a transaction sent by the API
+// @Summary Get transaction status
+// @Description Returns status, confirmations, gas used and decoded events of a transaction sent by the API
+// @Tags Transactions
+// @Param hash path string true "Transaction hash"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Success 200 {object} txtrack.Transaction
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tx/{hash} [get]
+func (h *Handler) GetTransaction(c *gin.Context) {
+	hash := c.Param("hash")
+
+	// Validate transaction hash
+	if !txHashPattern.MatchString(hash) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid transaction hash"})
+		return
+	}
+
+	// Get network parameter
+	network := c.Query("network")
+	if network == "" {
+		network = c.GetHeader("X-Network")
+	}
+	if network == "" {
+		network = "mainnet" // Default to mainnet if not specified
+	}
+
+	if h.NetworkHandler == nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Network handler not initialized"})
+		return
+	}
+
+	tracker, err := h.NetworkHandler.GetTxTracker(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid network: " + network + ". Use 'testnet' or 'mainnet'"})
+		return
+	}
+
+	tx, err := tracker.Lookup(c.Request.Context(), hash)
+	if errors.Is(err, txtrack.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Transaction not found on " + network})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tx)
+}
+
+// SpeedUpTransaction resends a stuck nonce with a bumped fee (backend only)
+// @Summary Speed up a stuck transaction
+// @Description Resends the pending transaction of a nonce with the same call and a bumped fee
+// @Tags Transactions
+// @Accept json
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Param request body ReplaceTransactionRequest true "Signer and nonce"
+// @Success 200 {object} txtrack.Transaction
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/tx/speed-up [post]
+func (h *Handler) SpeedUpTransaction(c *gin.Context) {
+	h.replaceTransaction(c, (*txtrack.Tracker).SpeedUp)
+}
+
+// CancelTransaction cancels a stuck nonce with a zero-value self-transfer (backend only)
+// @Summary Cancel a stuck transaction
+// @Description Replaces the pending transaction of a nonce with a zero-value transfer to the signer
+// @Tags Transactions
+// @Accept json
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Param request body ReplaceTransactionRequest true "Signer and nonce"
+// @Success 200 {object} txtrack.Transaction
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/tx/cancel [post]
+func (h *Handler) CancelTransaction(c *gin.Context) {
+	h.replaceTransaction(c, (*txtrack.Tracker).Cancel)
+}
+
+type replaceFunc func(t *txtrack.Tracker, ctx context.Context, from common.Address, nonce uint64) (*txtrack.Transaction, error)
+
+func (h *Handler) replaceTransaction(c *gin.Context, replace replaceFunc) {
+	// Authenticate backend request
+	if !h.authenticateBackendRequest(c) {
+		return
+	}
+
+	var req ReplaceTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if !common.IsHexAddress(req.Signer) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid signer address"})
+		return
+	}
+
+	network := c.Query("network")
+	if network == "" {
+		network = c.GetHeader("X-Network")
+	}
+	if network == "" {
+		network = "testnet"
+	}
+
+	if h.NetworkHandler == nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Network handler not initialized"})
+		return
+	}
+
+	tracker, err := h.NetworkHandler.GetTxTracker(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid network: " + network + ". Use 'testnet' or 'mainnet'"})
+		return
+	}
+
+	tx, err := replace(tracker, c.Request.Context(), common.HexToAddress(req.Signer), *req.Nonce)
+	if err != nil {
+		switch {
+		case errors.Is(err, txtrack.ErrNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "No transaction tracked for this nonce on " + network})
+		case errors.Is(err, txtrack.ErrNotPending):
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, txtrack.ErrFeeAboveCap), errors.Is(err, txtrack.ErrNoSigner):
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tx)
+}
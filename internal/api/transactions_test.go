@@ -0,0 +1,291 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"bogowi-blockchain-go/internal/config"
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/txtrack"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// headOnlyClient reports a fixed chain head and no receipts
+type headOnlyClient struct {
+	head uint64
+}
+
+func (c *headOnlyClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return nil
+}
+
+func (c *headOnlyClient) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	return nil, ethereum.NotFound
+}
+
+func (c *headOnlyClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.head, nil
+}
+
+func (c *headOnlyClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return 0, nil
+}
+
+func (c *headOnlyClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(25e9), nil
+}
+
+func (c *headOnlyClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1e9), nil
+}
+
+func (c *headOnlyClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: new(big.Int).SetUint64(c.head)}, nil
+}
+
+func TestGetTransaction(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "tx.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	hash := common.HexToHash("0xabc1").Hex()
+	success := uint64(types.ReceiptStatusSuccessful)
+	require.NoError(t, db.SaveTransaction(&database.TxRecord{
+		Hash:    hash,
+		Network: "testnet",
+		ChainID: 501,
+		Signer:  "0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2",
+		Nonce:   7,
+		Purpose: "bogo_transfer",
+		RawTx:   "0x00",
+		Status:  txtrack.StatusConfirmed,
+	}))
+	require.NoError(t, db.UpdateTransaction(&database.TxRecord{
+		Hash:          hash,
+		Status:        txtrack.StatusConfirmed,
+		BlockNumber:   100,
+		GasUsed:       52000,
+		ReceiptStatus: &success,
+		Logs:          "[]",
+	}))
+
+	client := &headOnlyClient{head: 104}
+	handler := &Handler{
+		NetworkHandler: &NetworkHandler{
+			config: &config.Config{},
+			trackers: map[string]*txtrack.Tracker{
+				"testnet": txtrack.NewTracker("testnet", db, client, nil, txtrack.Options{Confirmations: 3}),
+				"mainnet": txtrack.NewTracker("mainnet", db, client, nil, txtrack.Options{Confirmations: 6}),
+			},
+		},
+	}
+
+	router := gin.New()
+	router.GET("/api/tx/:hash", handler.GetTransaction)
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{
+			name:           "tracked transaction",
+			path:           "/api/tx/" + hash + "?network=testnet",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "network alias",
+			path:           "/api/tx/" + hash + "?network=columbus",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "tracked on another network",
+			path:           "/api/tx/" + hash,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "unknown transaction",
+			path:           "/api/tx/" + common.HexToHash("0xdead").Hex() + "?network=testnet",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid hash",
+			path:           "/api/tx/0x1234?network=testnet",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid network",
+			path:           "/api/tx/" + hash + "?network=devnet",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response txtrack.Transaction
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, hash, response.Hash)
+			assert.Equal(t, txtrack.StatusConfirmed, response.Status)
+			assert.Equal(t, uint64(5), response.Confirmations)
+			assert.Equal(t, uint64(3), response.RequiredConfirmations)
+			assert.Equal(t, uint64(52000), response.GasUsed)
+			assert.Equal(t, "bogo_transfer", response.Purpose)
+			require.NotNil(t, response.Success)
+			assert.True(t, *response.Success)
+		})
+	}
+}
+
+func TestReplaceTransaction(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "tx.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(501))
+	require.NoError(t, err)
+
+	tracker := txtrack.NewTracker("testnet", db, &headOnlyClient{}, nil, txtrack.Options{})
+	tracker.RegisterSigner(auth.From, auth.Signer)
+
+	to := common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2")
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		tx, err := auth.Signer(auth.From, types.NewTx(&types.LegacyTx{Nonce: nonce, To: &to, Gas: 21000, GasPrice: big.NewInt(25e9)}))
+		require.NoError(t, err)
+		require.NoError(t, tracker.Submit(context.Background(), tx, txtrack.Meta{Purpose: "bogo_transfer"}))
+	}
+
+	// Nonce 2 is already mined
+	require.NoError(t, db.SaveTransaction(&database.TxRecord{
+		Hash:    common.HexToHash("0xbeef").Hex(),
+		Network: "testnet",
+		ChainID: 501,
+		Signer:  auth.From.Hex(),
+		Nonce:   2,
+		RawTx:   "0x00",
+		Status:  txtrack.StatusConfirmed,
+	}))
+
+	cfg := &config.Config{BackendSecret: "test-secret", DevBackendSecret: "test-dev-secret"}
+	handler := &Handler{
+		Config: cfg,
+		NetworkHandler: &NetworkHandler{
+			config:   cfg,
+			trackers: map[string]*txtrack.Tracker{"testnet": tracker},
+		},
+	}
+
+	router := gin.New()
+	router.POST("/api/admin/tx/speed-up", handler.SpeedUpTransaction)
+	router.POST("/api/admin/tx/cancel", handler.CancelTransaction)
+
+	tests := []struct {
+		name            string
+		path            string
+		auth            string
+		body            string
+		expectedStatus  int
+		expectedPurpose string
+	}{
+		{
+			name:            "speed up",
+			path:            "/api/admin/tx/speed-up",
+			auth:            "test-dev-secret",
+			body:            `{"signer":"` + auth.From.Hex() + `","nonce":0}`,
+			expectedStatus:  http.StatusOK,
+			expectedPurpose: "bogo_transfer",
+		},
+		{
+			name:            "cancel",
+			path:            "/api/admin/tx/cancel",
+			auth:            "test-dev-secret",
+			body:            `{"signer":"` + auth.From.Hex() + `","nonce":1}`,
+			expectedStatus:  http.StatusOK,
+			expectedPurpose: txtrack.PurposeCancel,
+		},
+		{
+			name:           "unauthorized",
+			path:           "/api/admin/tx/cancel",
+			auth:           "wrong",
+			body:           `{"signer":"` + auth.From.Hex() + `","nonce":1}`,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "missing nonce",
+			path:           "/api/admin/tx/speed-up",
+			auth:           "test-dev-secret",
+			body:           `{"signer":"` + auth.From.Hex() + `"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid signer",
+			path:           "/api/admin/tx/speed-up",
+			auth:           "test-dev-secret",
+			body:           `{"signer":"invalid","nonce":0}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "already mined",
+			path:           "/api/admin/tx/speed-up",
+			auth:           "test-dev-secret",
+			body:           `{"signer":"` + auth.From.Hex() + `","nonce":2}`,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "unknown nonce",
+			path:           "/api/admin/tx/cancel",
+			auth:           "test-dev-secret",
+			body:           `{"signer":"` + auth.From.Hex() + `","nonce":7}`,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Backend-Auth", tt.auth)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response txtrack.Transaction
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, txtrack.StatusPending, response.Status)
+			assert.Equal(t, tt.expectedPurpose, response.Purpose)
+			assert.NotEmpty(t, response.Replaces)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/txtrack"
+	"bogowi-blockchain-go/internal/storage"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// nftMintReplacementHandler moves NFT mappings to the replacement of their
+// mint transaction, or marks them cancelled when the mint was cancelled
+func nftMintReplacementHandler(db *database.DB, network string) txtrack.ReplacementHandler {
+	return func(ctx context.Context, original, replacement *database.TxRecord) {
+		status := ""
+		if replacement.Purpose == txtrack.PurposeCancel {
+			status = "cancelled"
+		}
+		if err := db.ReplaceNFTTxHash(network, original.Hash, replacement.Hash, status); err != nil {
+			fmt.Printf("Warning: Failed to update NFT mappings of %s: %v\n", original.Hash, err)
+		}
+	}
+}
+
+// nftMintStatusHandler follows mint transactions in the NFT mappings: a
+// mint reorged out of its block leaves its mappings pending until it is
+// mined again, and a mint that reverted or lost its nonce marks them failed.
+// A reverted mint is failed as soon as it is mined, before it is final.
+func nftMintStatusHandler(db *database.DB, network string) txtrack.StatusHandler {
+	return func(ctx context.Context, rec *database.TxRecord, previous string) {
+		var err error
+		switch {
+		case rec.Status == txtrack.StatusPending:
+			err = db.UpdateNFTStatusByTxHash(network, rec.Hash, "pending", "active", "failed")
+		case rec.Status == txtrack.StatusMined && reverted(rec):
+			err = db.UpdateNFTStatusByTxHash(network, rec.Hash, "failed", "active", "pending")
+		case rec.Status == txtrack.StatusMined, rec.Status == txtrack.StatusConfirmed:
+			err = db.UpdateNFTStatusByTxHash(network, rec.Hash, "active", "pending")
+		case rec.Status == txtrack.StatusFailed, rec.Status == txtrack.StatusDropped:
+			err = db.UpdateNFTStatusByTxHash(network, rec.Hash, "failed", "active", "pending")
+		}
+		if err != nil {
+			fmt.Printf("Warning: Failed to update NFT mappings of %s: %v\n", rec.Hash, err)
+		}
+	}
+}
+
+// TrackClaimReplacements keeps reward and referral claims in step with the
+// transaction sent for them: claims move to the replacement of a replaced
+// transaction and follow its status through reorgs. Networks that connect
+// later pick the handlers up as well.
+func (h *NetworkHandler) TrackClaimReplacements(claims storage.RewardsStorage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.claims = claims
+	for _, tracker := range h.trackers {
+		tracker.OnReplacement(claimReplacementHandler(claims))
+		tracker.OnStatusChange(claimStatusHandler(claims))
+	}
+}
+
+// claimReplacementHandler moves claims to the replacement of their claim
+// transaction, or marks them failed when the claim was cancelled
+func claimReplacementHandler(claims storage.RewardsStorage) txtrack.ReplacementHandler {
+	return func(ctx context.Context, original, replacement *database.TxRecord) {
+		status := ""
+		if replacement.Purpose == txtrack.PurposeCancel {
+			status = "failed"
+		}
+		if err := claims.ReplaceClaimTxHash(ctx, original.Hash, replacement.Hash, status); err != nil {
+			fmt.Printf("Warning: Failed to update claims of %s: %v\n", original.Hash, err)
+		}
+	}
+}
+
+// claimStatusHandler follows claim transactions in the claims: a claim
+// reorged out of its block is pending until it is mined again, and a claim
+// that reverted or lost its nonce failed. A reverted claim is failed as soon
+// as it is mined, before it is final.
+func claimStatusHandler(claims storage.RewardsStorage) txtrack.StatusHandler {
+	return func(ctx context.Context, rec *database.TxRecord, previous string) {
+		status := ""
+		switch {
+		case rec.Status == txtrack.StatusPending:
+			status = "pending"
+		case rec.Status == txtrack.StatusMined && reverted(rec):
+			status = "failed"
+		case rec.Status == txtrack.StatusMined, rec.Status == txtrack.StatusConfirmed:
+			status = "completed"
+		case rec.Status == txtrack.StatusFailed, rec.Status == txtrack.StatusDropped:
+			status = "failed"
+		default:
+			return
+		}
+		if err := claims.ReplaceClaimTxHash(ctx, rec.Hash, rec.Hash, status); err != nil {
+			fmt.Printf("Warning: Failed to update claims of %s: %v\n", rec.Hash, err)
+		}
+	}
+}
+
+// reverted reports whether a mined transaction reverted. Transactions below
+// the confirmation depth are mined whatever their receipt status.
+func reverted(rec *database.TxRecord) bool {
+	return rec.ReceiptStatus != nil && *rec.ReceiptStatus == types.ReceiptStatusFailed
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/models"
+	"bogowi-blockchain-go/internal/sdk/txtrack"
+	"bogowi-blockchain-go/internal/storage"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimStatusHandler(t *testing.T) {
+	ctx := context.Background()
+	claims := storage.NewInMemoryRewardsStorage()
+	claim := &models.RewardClaim{WalletAddress: "0xabc", TxHash: "0x01", Status: "completed"}
+	require.NoError(t, claims.CreateRewardClaim(ctx, claim))
+	handler := claimStatusHandler(claims)
+
+	status := func() string {
+		stored, err := claims.GetRewardClaim(ctx, claim.ID)
+		require.NoError(t, err)
+		return stored.Status
+	}
+
+	// Reorged out, mined again, then a second reorg loses the nonce
+	handler(ctx, &database.TxRecord{Hash: "0x01", Status: txtrack.StatusPending}, txtrack.StatusMined)
+	assert.Equal(t, "pending", status())
+	handler(ctx, &database.TxRecord{Hash: "0x01", Status: txtrack.StatusConfirmed}, txtrack.StatusPending)
+	assert.Equal(t, "completed", status())
+	handler(ctx, &database.TxRecord{Hash: "0x01", Status: txtrack.StatusDropped}, txtrack.StatusPending)
+	assert.Equal(t, "failed", status())
+
+	// Other transactions leave the claim alone
+	handler(ctx, &database.TxRecord{Hash: "0x02", Status: txtrack.StatusConfirmed}, txtrack.StatusMined)
+	assert.Equal(t, "failed", status())
+
+	// A reverted claim fails before it reaches the confirmation depth
+	revertedStatus, successStatus := types.ReceiptStatusFailed, types.ReceiptStatusSuccessful
+	handler(ctx, &database.TxRecord{Hash: "0x01", Status: txtrack.StatusMined, ReceiptStatus: &successStatus}, txtrack.StatusPending)
+	assert.Equal(t, "completed", status())
+	handler(ctx, &database.TxRecord{Hash: "0x01", Status: txtrack.StatusMined, ReceiptStatus: &revertedStatus}, txtrack.StatusPending)
+	assert.Equal(t, "failed", status())
+}
+
+func TestNFTMintStatusHandler(t *testing.T) {
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "nft.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.SaveNFTMapping(&database.NFTMapping{
+		TokenID:       1,
+		DatakyteNFTID: "dk-1",
+		Network:       "testnet",
+		ContractAddr:  "0xticket",
+		OwnerAddress:  "0xabc",
+		Status:        "pending",
+		TxHash:        "0x01",
+	}))
+	handler := nftMintStatusHandler(db, "testnet")
+
+	status := func() string {
+		mapping, err := db.GetNFTMapping(1, "testnet")
+		require.NoError(t, err)
+		return mapping.Status
+	}
+
+	revertedStatus, successStatus := types.ReceiptStatusFailed, types.ReceiptStatusSuccessful
+	ctx := context.Background()
+
+	// A reverted mint fails as soon as it is mined
+	handler(ctx, &database.TxRecord{Hash: "0x01", Status: txtrack.StatusMined, ReceiptStatus: &revertedStatus}, txtrack.StatusPending)
+	assert.Equal(t, "failed", status())
+
+	// Reorged out and mined again successfully
+	handler(ctx, &database.TxRecord{Hash: "0x01", Status: txtrack.StatusPending}, txtrack.StatusMined)
+	assert.Equal(t, "pending", status())
+	handler(ctx, &database.TxRecord{Hash: "0x01", Status: txtrack.StatusMined, ReceiptStatus: &successStatus}, txtrack.StatusPending)
+	assert.Equal(t, "active", status())
+	handler(ctx, &database.TxRecord{Hash: "0x01", Status: txtrack.StatusConfirmed, ReceiptStatus: &successStatus}, txtrack.StatusMined)
+	assert.Equal(t, "active", status())
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+
+	"bogowi-blockchain-go/internal/sdk/wallet"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WalletPoolResponse reports the hot wallet pool of a network
+type WalletPoolResponse struct {
+	Network    string          `json:"network"`
+	MinBalance string          `json:"minBalance"`
+	Active     int             `json:"active"`
+	Wallets    []wallet.Status `json:"wallets"`
+}
+
+// GetWalletPool reports balance and health of every hot wallet (backend only)
+// @Summary Get hot wallet pool status
+// @Description Returns the CAM balance, send counts and rotation status of every hot wallet of a network
+// @Tags Wallets
+// @Produce json
+// @Param X-Backend-Auth header string true "Backend authentication token"
+// @Param network query string false "Network (testnet or mainnet)"
+// @Success 200 {object} WalletPoolResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/wallets [get]
+func (h *Handler) GetWalletPool(c *gin.Context) {
+	// Authenticate backend request
+	if !h.authenticateBackendRequest(c) {
+		return
+	}
+
+	network := c.Query("network")
+	if network == "" {
+		network = c.GetHeader("X-Network")
+	}
+	if network == "" {
+		network = "testnet"
+	}
+
+	if network != "testnet" && network != "mainnet" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid network: " + network + ". Use 'testnet' or 'mainnet'"})
+		return
+	}
+
+	if h.NetworkHandler == nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Network handler not initialized"})
+		return
+	}
+
+	pool, err := h.NetworkHandler.GetWalletPool(network)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "No hot wallet pool configured for " + network})
+		return
+	}
+
+	statuses := pool.Status()
+	active := 0
+	for _, status := range statuses {
+		if status.Status != wallet.StatusDrained {
+			active++
+		}
+	}
+
+	c.JSON(http.StatusOK, WalletPoolResponse{
+		Network:    network,
+		MinBalance: pool.MinBalance().String(),
+		Active:     active,
+		Wallets:    statuses,
+	})
+}
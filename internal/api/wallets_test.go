@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bogowi-blockchain-go/internal/config"
+	"bogowi-blockchain-go/internal/sdk/signer"
+	"bogowi-blockchain-go/internal/sdk/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedBalances map[common.Address]*big.Int
+
+func (b fixedBalances) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if balance, ok := b[account]; ok {
+		return balance, nil
+	}
+	return nil, errors.New("unknown account")
+}
+
+func TestGetWalletPool(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	signers := make([]signer.Signer, 2)
+	for i := range signers {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		signers[i] = signer.NewKeySigner(key)
+	}
+	pool, err := wallet.NewPool(big.NewInt(501), signers, wallet.Config{MinBalance: "0.5"})
+	require.NoError(t, err)
+	require.NoError(t, pool.Refresh(context.Background(), fixedBalances{
+		signers[0].Address(): big.NewInt(2e18),
+		signers[1].Address(): big.NewInt(1e17),
+	}))
+
+	cfg := &config.Config{BackendSecret: "test-secret", DevBackendSecret: "test-dev-secret"}
+	handler := &Handler{
+		Config: cfg,
+		NetworkHandler: &NetworkHandler{
+			config: cfg,
+			pools:  map[string]*wallet.Pool{"testnet": pool},
+		},
+	}
+
+	router := gin.New()
+	router.GET("/api/admin/wallets", handler.GetWalletPool)
+
+	tests := []struct {
+		name           string
+		query          string
+		auth           string
+		expectedStatus int
+	}{
+		{name: "testnet pool", auth: "test-dev-secret", expectedStatus: http.StatusOK},
+		{name: "unauthorized", auth: "wrong", expectedStatus: http.StatusUnauthorized},
+		{name: "no pool", query: "?network=mainnet", auth: "test-secret", expectedStatus: http.StatusNotFound},
+		{name: "invalid network", query: "?network=devnet", auth: "test-secret", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/wallets"+tt.query, nil)
+			req.Header.Set("X-Backend-Auth", tt.auth)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var resp WalletPoolResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, "testnet", resp.Network)
+			assert.Equal(t, "500000000000000000", resp.MinBalance)
+			assert.Equal(t, 1, resp.Active)
+			require.Len(t, resp.Wallets, 2)
+			assert.Equal(t, wallet.StatusActive, resp.Wallets[0].Status)
+			assert.Equal(t, "2000000000000000000", resp.Wallets[0].Balance)
+			assert.Equal(t, wallet.StatusDrained, resp.Wallets[1].Status)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -33,6 +34,31 @@ type Config struct {
 	FirebaseProjectID string `json:"firebase_project_id"`
 	BackendSecret     string `json:"backend_secret"`
 	DevBackendSecret  string `json:"dev_backend_secret"`
+
+	// CheckInOperators are the credentials of venue staff scanning
+	// redemption QR codes, as "name:key:eventId|eventId[:network]" entries.
+	// An operator may only check in tickets of the listed events, on its
+	// network (testnet unless set).
+	CheckInOperators []string `json:"-"`
+
+	// RedemptionQRSecret keys rotating redemption QR codes. Without it every
+	// instance uses a random secret, and rotating codes stop working after a
+	// restart or on another instance.
+	RedemptionQRSecret string `json:"-"`
+
+	// RedemptionQRPeriod is how often the code of a rotating redemption QR
+	// code changes, as a Go duration in whole seconds
+	RedemptionQRPeriod string `json:"redemption_qr_period"`
+
+	// Balance monitoring: webhook receiving low balance alerts and the
+	// interval between checks, as a Go duration
+	BalanceWebhookURL    string `json:"balance_webhook_url,omitempty"`
+	BalanceCheckInterval string `json:"balance_check_interval"`
+
+	// Ticket expiry sweeps: interval between sweeps as a Go duration, "0"
+	// to only sweep on demand, and the number of expiries sent per batch
+	ExpirySweepInterval  string `json:"expiry_sweep_interval"`
+	ExpirySweepBatchSize string `json:"expiry_sweep_batch_size"`
 }
 
 // NetworkConfig holds network-specific configuration
@@ -40,6 +66,101 @@ type NetworkConfig struct {
 	RPCUrl    string            `json:"rpc_url"`
 	ChainID   int64             `json:"chain_id"`
 	Contracts ContractAddresses `json:"contracts"`
+
+	// RPC failover: fallback endpoints tried in order after RPCUrl, how often
+	// a transient failure is retried, the timeout of a single attempt as a Go
+	// duration and how many blocks an endpoint may lag before it is skipped
+	RPCFallbackURLs   []string `json:"rpc_fallback_urls,omitempty"`
+	RPCRetryAttempts  string   `json:"rpc_retry_attempts"`
+	RPCRequestTimeout string   `json:"rpc_request_timeout"`
+	RPCMaxBlockLag    string   `json:"rpc_max_block_lag"`
+
+	// Contract event delivery: a ws:// or wss:// endpoint for log
+	// subscriptions, and how often new blocks are polled over HTTP while no
+	// subscription is live, as a Go duration
+	WSUrl             string `json:"ws_url,omitempty"`
+	EventPollInterval string `json:"event_poll_interval"`
+
+	// EventStartBlock is the first block indexed for ticket events when no
+	// cursor is stored yet, usually the deployment block of the contract.
+	// Empty starts at the current head.
+	EventStartBlock string `json:"event_start_block,omitempty"`
+
+	// Confirmations is the depth at which blocks are treated as final by
+	// transaction tracking and event indexing. Empty uses the chain default.
+	Confirmations string `json:"confirmations,omitempty"`
+
+	// AllowedTokens lists third-party ERC-20/ERC-721 contracts that may be
+	// queried through the generic token read endpoints
+	AllowedTokens []string `json:"allowed_tokens,omitempty"`
+
+	// Native CAM transfer caps, in CAM
+	NativeTransferMax      string `json:"native_transfer_max"`
+	NativeTransferDailyMax string `json:"native_transfer_daily_max"`
+
+	// Transaction fee pricing: "legacy", "eip1559" or "fixed". Prices are in gwei.
+	GasStrategy   string `json:"gas_strategy"`
+	GasMultiplier string `json:"gas_multiplier"`
+	MaxGasPrice   string `json:"max_gas_price,omitempty"`
+	FixedGasPrice string `json:"fixed_gas_price,omitempty"`
+
+	// TxReplaceTimeout is how long a transaction may stay pending before it is
+	// resent with a bumped fee, as a Go duration. "0" disables replacement.
+	TxReplaceTimeout string `json:"tx_replace_timeout"`
+
+	// Per-operation deadlines, as Go durations: reads such as balance and
+	// contract calls, writes from pricing to broadcast, and waits for a
+	// transaction receipt. "0" leaves the operation to the request deadline.
+	ReadTimeout   string `json:"read_timeout"`
+	WriteTimeout  string `json:"write_timeout"`
+	TxWaitTimeout string `json:"tx_wait_timeout"`
+
+	// Transaction signer: "key" (default) signs with the network private key,
+	// "keystore" with an encrypted keystore file and "remote" through a
+	// Clef-compatible external signer
+	Signer           string `json:"signer"`
+	KeystoreFile     string `json:"keystore_file,omitempty"`
+	KeystorePassword string `json:"-"`
+	RemoteSignerURL  string `json:"remote_signer_url,omitempty"`
+	SignerAddress    string `json:"signer_address,omitempty"`
+
+	// Hot wallet pool: private keys of extra wallets that share role-gated
+	// writes with the signer, and the CAM balance below which a wallet is
+	// taken out of rotation
+	HotWalletKeys       []string `json:"-"`
+	HotWalletMinBalance string   `json:"hot_wallet_min_balance"`
+
+	// Balance alerts: the CAM balance below which a signer account alerts and
+	// the BOGO balance below which the RewardDistributor alerts
+	BalanceAlertThreshold     string `json:"balance_alert_threshold"`
+	DistributorAlertThreshold string `json:"distributor_alert_threshold"`
+
+	// Automatic top-ups: a funding wallet refills low signer and hot wallets
+	// to TopUpTarget CAM, sending at most TopUpDailyMax CAM per UTC day.
+	// Top-ups are disabled without a funding key.
+	FundingPrivateKey string `json:"-"`
+	TopUpTarget       string `json:"top_up_target"`
+	TopUpDailyMax     string `json:"top_up_daily_max"`
+}
+
+// RPCEndpoints returns the RPC endpoints of the network in order of preference
+func (n *NetworkConfig) RPCEndpoints() []string {
+	endpoints := make([]string, 0, len(n.RPCFallbackURLs)+1)
+	if n.RPCUrl != "" {
+		endpoints = append(endpoints, n.RPCUrl)
+	}
+	for _, url := range n.RPCFallbackURLs {
+		if url != n.RPCUrl {
+			endpoints = append(endpoints, url)
+		}
+	}
+	return endpoints
+}
+
+// UsesExternalSigner reports whether the network signs with a keystore or
+// remote signer instead of a private key
+func (n *NetworkConfig) UsesExternalSigner() bool {
+	return n.Signer != "" && !strings.EqualFold(n.Signer, "key")
 }
 
 // ContractAddresses holds all smart contract addresses
@@ -52,6 +173,9 @@ type ContractAddresses struct {
 	// NFT Tickets Contract
 	BOGOWITickets string `json:"bogowi_tickets"`
 
+	// Multicall3 aggregator (optional, enables single-call batched reads)
+	Multicall3 string `json:"multicall3,omitempty"`
+
 	// Legacy contracts (to be removed after migration)
 	ConservationNFT  string `json:"conservation_nft"`
 	CommercialNFT    string `json:"commercial_nft"`
@@ -99,8 +223,9 @@ func Load() (*Config, error) {
 	}
 
 	// Validate required fields
-	if cfg.TestnetPrivateKey == "" && cfg.MainnetPrivateKey == "" {
-		return nil, fmt.Errorf("at least one private key (TESTNET_PRIVATE_KEY or MAINNET_PRIVATE_KEY) is required")
+	if cfg.TestnetPrivateKey == "" && cfg.MainnetPrivateKey == "" &&
+		!cfg.Testnet.UsesExternalSigner() && !cfg.Mainnet.UsesExternalSigner() {
+		return nil, fmt.Errorf("at least one private key (TESTNET_PRIVATE_KEY or MAINNET_PRIVATE_KEY) or external signer (TESTNET_SIGNER or MAINNET_SIGNER) is required")
 	}
 
 	return cfg, nil
@@ -126,16 +251,42 @@ func loadFromEnv(cfg *Config) {
 	cfg.FirebaseProjectID = getEnv("FIREBASE_PROJECT_ID", "")
 	cfg.BackendSecret = getEnv("BACKEND_SECRET", "backend-secret-key")
 	cfg.DevBackendSecret = getEnv("DEV_BACKEND_SECRET", cfg.BackendSecret) // Default to main secret if not set
+	cfg.CheckInOperators = getEnvList("CHECKIN_OPERATORS")
+	cfg.RedemptionQRSecret = getEnv("REDEMPTION_QR_SECRET", "")
+	cfg.RedemptionQRPeriod = getEnv("REDEMPTION_QR_PERIOD", "15s")
 
 	// Log configuration status
 	log.Printf("Backend secrets configured - Main: %v, Dev: %v", cfg.BackendSecret != "", cfg.DevBackendSecret != "")
 
+	// RPC endpoints; the primary URLs default to the public Camino nodes
+	cfg.Testnet.RPCUrl = getEnv("TESTNET_RPC_URL", cfg.Testnet.RPCUrl)
+	cfg.Testnet.RPCFallbackURLs = getEnvList("TESTNET_RPC_FALLBACK_URLS")
+	cfg.Testnet.RPCRetryAttempts = getEnv("TESTNET_RPC_RETRY_ATTEMPTS", "3")
+	cfg.Testnet.RPCRequestTimeout = getEnv("TESTNET_RPC_REQUEST_TIMEOUT", "10s")
+	cfg.Testnet.RPCMaxBlockLag = getEnv("TESTNET_RPC_MAX_BLOCK_LAG", "5")
+	cfg.Mainnet.RPCUrl = getEnv("MAINNET_RPC_URL", cfg.Mainnet.RPCUrl)
+	cfg.Mainnet.RPCFallbackURLs = getEnvList("MAINNET_RPC_FALLBACK_URLS")
+	cfg.Mainnet.RPCRetryAttempts = getEnv("MAINNET_RPC_RETRY_ATTEMPTS", "3")
+	cfg.Mainnet.RPCRequestTimeout = getEnv("MAINNET_RPC_REQUEST_TIMEOUT", "10s")
+	cfg.Mainnet.RPCMaxBlockLag = getEnv("MAINNET_RPC_MAX_BLOCK_LAG", "5")
+
+	// Contract event subscriptions; without a WebSocket URL events are polled
+	cfg.Testnet.WSUrl = getEnv("TESTNET_WS_URL", "")
+	cfg.Testnet.EventPollInterval = getEnv("TESTNET_EVENT_POLL_INTERVAL", "5s")
+	cfg.Mainnet.WSUrl = getEnv("MAINNET_WS_URL", "")
+	cfg.Mainnet.EventPollInterval = getEnv("MAINNET_EVENT_POLL_INTERVAL", "5s")
+	cfg.Testnet.EventStartBlock = getEnv("TESTNET_EVENT_START_BLOCK", "")
+	cfg.Mainnet.EventStartBlock = getEnv("MAINNET_EVENT_START_BLOCK", "")
+	cfg.Testnet.Confirmations = getEnv("TESTNET_CONFIRMATIONS", "")
+	cfg.Mainnet.Confirmations = getEnv("MAINNET_CONFIRMATIONS", "")
+
 	// Load testnet contracts - these are the Columbus testnet addresses
 	cfg.Testnet.Contracts = ContractAddresses{
 		RoleManager:       getEnv("TESTNET_ROLE_MANAGER_ADDRESS", "0xEB5d2AEf60E6dA1b695b4CBA7DEb9Ab8a9bEc940"),
 		BOGOToken:         getEnv("TESTNET_BOGO_TOKEN_ADDRESS", "0xC53c2f11e1d2e36CB5888BfEE157F78e04Bb4F76"),
 		RewardDistributor: getEnv("TESTNET_REWARD_DISTRIBUTOR_ADDRESS", "0x289cb4E70D0a876E8f885f39D23f8E01E475A111"),
 		BOGOWITickets:     getEnv("NFT_TICKETS_TESTNET_CONTRACT", ""),
+		Multicall3:        getEnv("TESTNET_MULTICALL3_ADDRESS", ""),
 	}
 
 	// Load mainnet contracts - these are the Camino mainnet addresses
@@ -144,8 +295,77 @@ func loadFromEnv(cfg *Config) {
 		BOGOToken:         getEnv("MAINNET_BOGO_TOKEN_ADDRESS", "0x49fc9939D8431371dD22658a8a969Ec798A26fFB"),
 		RewardDistributor: getEnv("MAINNET_REWARD_DISTRIBUTOR_ADDRESS", "0x00439bd5eeED2303bfB64529Dad40C7c3F697724"),
 		BOGOWITickets:     getEnv("NFT_TICKETS_MAINNET_CONTRACT", ""),
+		Multicall3:        getEnv("MAINNET_MULTICALL3_ADDRESS", ""),
 	}
 
+	// Third-party token contracts readable through the generic token API
+	cfg.Testnet.AllowedTokens = getEnvList("TESTNET_ALLOWED_TOKENS")
+	cfg.Mainnet.AllowedTokens = getEnvList("MAINNET_ALLOWED_TOKENS")
+
+	// Caps for native CAM transfers (gas top-ups)
+	cfg.Testnet.NativeTransferMax = getEnv("TESTNET_NATIVE_TRANSFER_MAX", "1")
+	cfg.Testnet.NativeTransferDailyMax = getEnv("TESTNET_NATIVE_TRANSFER_DAILY_MAX", "10")
+	cfg.Mainnet.NativeTransferMax = getEnv("MAINNET_NATIVE_TRANSFER_MAX", "0.5")
+	cfg.Mainnet.NativeTransferDailyMax = getEnv("MAINNET_NATIVE_TRANSFER_DAILY_MAX", "5")
+
+	// Transaction fee pricing
+	cfg.Testnet.GasStrategy = getEnv("TESTNET_GAS_STRATEGY", "legacy")
+	cfg.Testnet.GasMultiplier = getEnv("TESTNET_GAS_MULTIPLIER", "1.2")
+	cfg.Testnet.MaxGasPrice = getEnv("TESTNET_MAX_GAS_PRICE_GWEI", "")
+	cfg.Testnet.FixedGasPrice = getEnv("TESTNET_FIXED_GAS_PRICE_GWEI", "")
+	cfg.Mainnet.GasStrategy = getEnv("MAINNET_GAS_STRATEGY", "legacy")
+	cfg.Mainnet.GasMultiplier = getEnv("MAINNET_GAS_MULTIPLIER", "1.2")
+	cfg.Mainnet.MaxGasPrice = getEnv("MAINNET_MAX_GAS_PRICE_GWEI", "")
+	cfg.Mainnet.FixedGasPrice = getEnv("MAINNET_FIXED_GAS_PRICE_GWEI", "")
+
+	// Stuck transaction replacement
+	cfg.Testnet.TxReplaceTimeout = getEnv("TESTNET_TX_REPLACE_TIMEOUT", "3m")
+	cfg.Mainnet.TxReplaceTimeout = getEnv("MAINNET_TX_REPLACE_TIMEOUT", "3m")
+
+	// Operation deadlines
+	cfg.Testnet.ReadTimeout = getEnv("TESTNET_READ_TIMEOUT", "15s")
+	cfg.Testnet.WriteTimeout = getEnv("TESTNET_WRITE_TIMEOUT", "60s")
+	cfg.Testnet.TxWaitTimeout = getEnv("TESTNET_TX_WAIT_TIMEOUT", "5m")
+	cfg.Mainnet.ReadTimeout = getEnv("MAINNET_READ_TIMEOUT", "15s")
+	cfg.Mainnet.WriteTimeout = getEnv("MAINNET_WRITE_TIMEOUT", "60s")
+	cfg.Mainnet.TxWaitTimeout = getEnv("MAINNET_TX_WAIT_TIMEOUT", "5m")
+
+	// Transaction signers
+	cfg.Testnet.Signer = getEnv("TESTNET_SIGNER", "key")
+	cfg.Testnet.KeystoreFile = getEnv("TESTNET_KEYSTORE_FILE", "")
+	cfg.Testnet.KeystorePassword = getEnv("TESTNET_KEYSTORE_PASSWORD", "")
+	cfg.Testnet.RemoteSignerURL = getEnv("TESTNET_REMOTE_SIGNER_URL", "")
+	cfg.Testnet.SignerAddress = getEnv("TESTNET_SIGNER_ADDRESS", "")
+	cfg.Mainnet.Signer = getEnv("MAINNET_SIGNER", "key")
+	cfg.Mainnet.KeystoreFile = getEnv("MAINNET_KEYSTORE_FILE", "")
+	cfg.Mainnet.KeystorePassword = getEnv("MAINNET_KEYSTORE_PASSWORD", "")
+	cfg.Mainnet.RemoteSignerURL = getEnv("MAINNET_REMOTE_SIGNER_URL", "")
+	cfg.Mainnet.SignerAddress = getEnv("MAINNET_SIGNER_ADDRESS", "")
+
+	// Hot wallet pools
+	cfg.Testnet.HotWalletKeys = getEnvList("TESTNET_HOT_WALLET_KEYS")
+	cfg.Testnet.HotWalletMinBalance = getEnv("TESTNET_HOT_WALLET_MIN_BALANCE", "0.1")
+	cfg.Mainnet.HotWalletKeys = getEnvList("MAINNET_HOT_WALLET_KEYS")
+	cfg.Mainnet.HotWalletMinBalance = getEnv("MAINNET_HOT_WALLET_MIN_BALANCE", "0.1")
+
+	// Balance monitoring and automatic top-ups
+	cfg.BalanceWebhookURL = getEnv("BALANCE_WEBHOOK_URL", "")
+	cfg.BalanceCheckInterval = getEnv("BALANCE_CHECK_INTERVAL", "1m")
+	cfg.Testnet.BalanceAlertThreshold = getEnv("TESTNET_BALANCE_ALERT_CAM", "1")
+	cfg.Testnet.DistributorAlertThreshold = getEnv("TESTNET_DISTRIBUTOR_ALERT_BOGO", "10000")
+	cfg.Testnet.FundingPrivateKey = getEnv("TESTNET_FUNDING_PRIVATE_KEY", "")
+	cfg.Testnet.TopUpTarget = getEnv("TESTNET_TOP_UP_TARGET", "2")
+	cfg.Testnet.TopUpDailyMax = getEnv("TESTNET_TOP_UP_DAILY_MAX", "10")
+	cfg.Mainnet.BalanceAlertThreshold = getEnv("MAINNET_BALANCE_ALERT_CAM", "1")
+	cfg.Mainnet.DistributorAlertThreshold = getEnv("MAINNET_DISTRIBUTOR_ALERT_BOGO", "10000")
+	cfg.Mainnet.FundingPrivateKey = getEnv("MAINNET_FUNDING_PRIVATE_KEY", "")
+	cfg.Mainnet.TopUpTarget = getEnv("MAINNET_TOP_UP_TARGET", "2")
+	cfg.Mainnet.TopUpDailyMax = getEnv("MAINNET_TOP_UP_DAILY_MAX", "5")
+
+	// Expiry of tickets past their expiry
+	cfg.ExpirySweepInterval = getEnv("EXPIRY_SWEEP_INTERVAL", "1h")
+	cfg.ExpirySweepBatchSize = getEnv("EXPIRY_SWEEP_BATCH_SIZE", "20")
+
 	// For backwards compatibility, also load from simple names based on environment
 	if cfg.Environment == "development" {
 		// In dev, simple names override testnet if set
@@ -189,6 +409,17 @@ func loadSecretsFromSSM(cfg *Config) error {
 		"API_PRIVATE_KEY",
 		"TESTNET_PRIVATE_KEY",
 		"MAINNET_PRIVATE_KEY",
+		"TESTNET_KEYSTORE_PASSWORD",
+		"MAINNET_KEYSTORE_PASSWORD",
+		"TESTNET_HOT_WALLET_KEYS",
+		"MAINNET_HOT_WALLET_KEYS",
+		"TESTNET_FUNDING_PRIVATE_KEY",
+		"MAINNET_FUNDING_PRIVATE_KEY",
+		"BALANCE_WEBHOOK_URL",
+		"TESTNET_RPC_FALLBACK_URLS",
+		"MAINNET_RPC_FALLBACK_URLS",
+		"TESTNET_WS_URL",
+		"MAINNET_WS_URL",
 		// V1 Mainnet Contracts
 		"ROLE_MANAGER_ADDRESS",
 		"BOGO_TOKEN_ADDRESS",
@@ -206,6 +437,8 @@ func loadSecretsFromSSM(cfg *Config) error {
 		"FIREBASE_PROJECT_ID",
 		"BACKEND_SECRET",
 		"DEV_BACKEND_SECRET",
+		"CHECKIN_OPERATORS",
+		"REDEMPTION_QR_SECRET",
 		"BACKEND_WALLET_ADDRESS",
 	}
 
@@ -245,3 +478,19 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvList gets a comma-separated environment variable as a trimmed list
+func getEnvList(key string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -36,6 +36,16 @@ func TestConfigDefaults(t *testing.T) {
 	assert.NotEmpty(t, cfg.Environment)
 	assert.Equal(t, "development", cfg.Environment) // default value
 	assert.Equal(t, "3001", cfg.APIPort)            // default value
+	assert.Equal(t, "1", cfg.Testnet.NativeTransferMax)
+	assert.Equal(t, "10", cfg.Testnet.NativeTransferDailyMax)
+	assert.Equal(t, "0.5", cfg.Mainnet.NativeTransferMax)
+	assert.Equal(t, "5", cfg.Mainnet.NativeTransferDailyMax)
+	assert.Equal(t, "legacy", cfg.Testnet.GasStrategy)
+	assert.Equal(t, "1.2", cfg.Testnet.GasMultiplier)
+	assert.Empty(t, cfg.Testnet.MaxGasPrice)
+	assert.Equal(t, "legacy", cfg.Mainnet.GasStrategy)
+	assert.Equal(t, "3m", cfg.Testnet.TxReplaceTimeout)
+	assert.Equal(t, "3m", cfg.Mainnet.TxReplaceTimeout)
 
 	// Cleanup
 	os.Unsetenv("TESTNET_PRIVATE_KEY")
@@ -114,6 +124,133 @@ func TestLoadConfigNoPrivateKeys(t *testing.T) {
 	assert.Contains(t, err.Error(), "at least one private key")
 }
 
+func TestLoadConfigExternalSigner(t *testing.T) {
+	// A keystore signer replaces the private key requirement
+	os.Unsetenv("TESTNET_PRIVATE_KEY")
+	os.Unsetenv("MAINNET_PRIVATE_KEY")
+	os.Unsetenv("PRIVATE_KEY")
+	os.Unsetenv("API_PRIVATE_KEY")
+	os.Setenv("MAINNET_SIGNER", "keystore")
+	os.Setenv("MAINNET_KEYSTORE_FILE", "/secrets/mainnet.json")
+	os.Setenv("MAINNET_KEYSTORE_PASSWORD", "passphrase")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "key", cfg.Testnet.Signer)
+	assert.False(t, cfg.Testnet.UsesExternalSigner())
+	assert.Equal(t, "keystore", cfg.Mainnet.Signer)
+	assert.True(t, cfg.Mainnet.UsesExternalSigner())
+	assert.Equal(t, "/secrets/mainnet.json", cfg.Mainnet.KeystoreFile)
+	assert.Equal(t, "passphrase", cfg.Mainnet.KeystorePassword)
+
+	// Cleanup
+	os.Unsetenv("MAINNET_SIGNER")
+	os.Unsetenv("MAINNET_KEYSTORE_FILE")
+	os.Unsetenv("MAINNET_KEYSTORE_PASSWORD")
+}
+
+func TestLoadConfigHotWallets(t *testing.T) {
+	os.Setenv("TESTNET_PRIVATE_KEY", "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	os.Setenv("TESTNET_HOT_WALLET_KEYS", "0xaaaa, 0xbbbb")
+	os.Setenv("MAINNET_HOT_WALLET_MIN_BALANCE", "2.5")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0xaaaa", "0xbbbb"}, cfg.Testnet.HotWalletKeys)
+	assert.Equal(t, "0.1", cfg.Testnet.HotWalletMinBalance)
+	assert.Empty(t, cfg.Mainnet.HotWalletKeys)
+	assert.Equal(t, "2.5", cfg.Mainnet.HotWalletMinBalance)
+
+	// Cleanup
+	os.Unsetenv("TESTNET_PRIVATE_KEY")
+	os.Unsetenv("TESTNET_HOT_WALLET_KEYS")
+	os.Unsetenv("MAINNET_HOT_WALLET_MIN_BALANCE")
+}
+
+func TestLoadConfigRPCFailover(t *testing.T) {
+	os.Setenv("TESTNET_PRIVATE_KEY", "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	os.Setenv("MAINNET_RPC_URL", "https://rpc-a.example.com")
+	os.Setenv("MAINNET_RPC_FALLBACK_URLS", "https://rpc-b.example.com, https://rpc-c.example.com")
+	os.Setenv("MAINNET_WS_URL", "wss://rpc-a.example.com/ws")
+	os.Setenv("MAINNET_EVENT_START_BLOCK", "1200000")
+	os.Setenv("MAINNET_CONFIRMATIONS", "12")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://columbus.camino.network/ext/bc/C/rpc"}, cfg.Testnet.RPCEndpoints())
+	assert.Equal(t, []string{
+		"https://rpc-a.example.com",
+		"https://rpc-b.example.com",
+		"https://rpc-c.example.com",
+	}, cfg.Mainnet.RPCEndpoints())
+	assert.Equal(t, "3", cfg.Mainnet.RPCRetryAttempts)
+	assert.Equal(t, "10s", cfg.Mainnet.RPCRequestTimeout)
+	assert.Equal(t, "5", cfg.Mainnet.RPCMaxBlockLag)
+	assert.Equal(t, "wss://rpc-a.example.com/ws", cfg.Mainnet.WSUrl)
+	assert.Empty(t, cfg.Testnet.WSUrl)
+	assert.Equal(t, "5s", cfg.Mainnet.EventPollInterval)
+	assert.Equal(t, "1200000", cfg.Mainnet.EventStartBlock)
+	assert.Empty(t, cfg.Testnet.EventStartBlock)
+	assert.Equal(t, "12", cfg.Mainnet.Confirmations)
+	assert.Empty(t, cfg.Testnet.Confirmations)
+
+	// Cleanup
+	os.Unsetenv("TESTNET_PRIVATE_KEY")
+	os.Unsetenv("MAINNET_RPC_URL")
+	os.Unsetenv("MAINNET_RPC_FALLBACK_URLS")
+	os.Unsetenv("MAINNET_WS_URL")
+	os.Unsetenv("MAINNET_EVENT_START_BLOCK")
+	os.Unsetenv("MAINNET_CONFIRMATIONS")
+}
+
+func TestLoadConfigOperationTimeouts(t *testing.T) {
+	os.Setenv("TESTNET_PRIVATE_KEY", "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	os.Setenv("MAINNET_READ_TIMEOUT", "5s")
+	os.Setenv("MAINNET_TX_WAIT_TIMEOUT", "0")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "15s", cfg.Testnet.ReadTimeout)
+	assert.Equal(t, "60s", cfg.Testnet.WriteTimeout)
+	assert.Equal(t, "5m", cfg.Testnet.TxWaitTimeout)
+	assert.Equal(t, "5s", cfg.Mainnet.ReadTimeout)
+	assert.Equal(t, "60s", cfg.Mainnet.WriteTimeout)
+	assert.Equal(t, "0", cfg.Mainnet.TxWaitTimeout)
+
+	// Cleanup
+	os.Unsetenv("TESTNET_PRIVATE_KEY")
+	os.Unsetenv("MAINNET_READ_TIMEOUT")
+	os.Unsetenv("MAINNET_TX_WAIT_TIMEOUT")
+}
+
+func TestLoadConfigBalanceMonitoring(t *testing.T) {
+	os.Setenv("TESTNET_PRIVATE_KEY", "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	os.Setenv("BALANCE_WEBHOOK_URL", "https://hooks.example.com/balances")
+	os.Setenv("TESTNET_FUNDING_PRIVATE_KEY", "0xfund")
+	os.Setenv("MAINNET_BALANCE_ALERT_CAM", "5")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.example.com/balances", cfg.BalanceWebhookURL)
+	assert.Equal(t, "1m", cfg.BalanceCheckInterval)
+	assert.Equal(t, "1h", cfg.ExpirySweepInterval)
+	assert.Equal(t, "20", cfg.ExpirySweepBatchSize)
+	assert.Equal(t, "1", cfg.Testnet.BalanceAlertThreshold)
+	assert.Equal(t, "10000", cfg.Testnet.DistributorAlertThreshold)
+	assert.Equal(t, "0xfund", cfg.Testnet.FundingPrivateKey)
+	assert.Equal(t, "2", cfg.Testnet.TopUpTarget)
+	assert.Equal(t, "10", cfg.Testnet.TopUpDailyMax)
+	assert.Equal(t, "5", cfg.Mainnet.BalanceAlertThreshold)
+	assert.Empty(t, cfg.Mainnet.FundingPrivateKey)
+	assert.Equal(t, "5", cfg.Mainnet.TopUpDailyMax)
+
+	// Cleanup
+	os.Unsetenv("TESTNET_PRIVATE_KEY")
+	os.Unsetenv("BALANCE_WEBHOOK_URL")
+	os.Unsetenv("TESTNET_FUNDING_PRIVATE_KEY")
+	os.Unsetenv("MAINNET_BALANCE_ALERT_CAM")
+}
+
 func TestLoadConfigWithAuthSettings(t *testing.T) {
 	// Test loading auth-related configuration
 	os.Setenv("TESTNET_PRIVATE_KEY", "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
@@ -166,6 +303,7 @@ func TestLoadConfigWithContractAddresses(t *testing.T) {
 	os.Setenv("TESTNET_BOGO_TOKEN_ADDRESS", "0xTestnetBOGO")
 	os.Setenv("TESTNET_REWARD_DISTRIBUTOR_ADDRESS", "0xTestnetReward")
 	os.Setenv("NFT_TICKETS_TESTNET_CONTRACT", "0xTestnetTickets")
+	os.Setenv("TESTNET_MULTICALL3_ADDRESS", "0xTestnetMulticall")
 
 	// Mainnet contracts
 	os.Setenv("MAINNET_ROLE_MANAGER_ADDRESS", "0xMainnetRoleManager")
@@ -182,6 +320,8 @@ func TestLoadConfigWithContractAddresses(t *testing.T) {
 	assert.Equal(t, "0xTestnetBOGO", cfg.Testnet.Contracts.BOGOToken)
 	assert.Equal(t, "0xTestnetReward", cfg.Testnet.Contracts.RewardDistributor)
 	assert.Equal(t, "0xTestnetTickets", cfg.Testnet.Contracts.BOGOWITickets)
+	assert.Equal(t, "0xTestnetMulticall", cfg.Testnet.Contracts.Multicall3)
+	assert.Empty(t, cfg.Mainnet.Contracts.Multicall3)
 
 	// Check mainnet contracts
 	assert.Equal(t, "0xMainnetRoleManager", cfg.Mainnet.Contracts.RoleManager)
@@ -195,6 +335,7 @@ func TestLoadConfigWithContractAddresses(t *testing.T) {
 	os.Unsetenv("TESTNET_BOGO_TOKEN_ADDRESS")
 	os.Unsetenv("TESTNET_REWARD_DISTRIBUTOR_ADDRESS")
 	os.Unsetenv("NFT_TICKETS_TESTNET_CONTRACT")
+	os.Unsetenv("TESTNET_MULTICALL3_ADDRESS")
 	os.Unsetenv("MAINNET_ROLE_MANAGER_ADDRESS")
 	os.Unsetenv("MAINNET_BOGO_TOKEN_ADDRESS")
 	os.Unsetenv("MAINNET_REWARD_DISTRIBUTOR_ADDRESS")
@@ -306,3 +447,38 @@ func TestGetEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestGetEnvList(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []string
+	}{
+		{
+			name:     "unset variable",
+			value:    "",
+			expected: nil,
+		},
+		{
+			name:     "single value",
+			value:    "0xToken",
+			expected: []string{"0xToken"},
+		},
+		{
+			name:     "trims spaces and skips empty entries",
+			value:    " 0xTokenA , ,0xTokenB,",
+			expected: []string{"0xTokenA", "0xTokenB"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.value != "" {
+				os.Setenv("TEST_LIST_VAR", tt.value)
+				defer os.Unsetenv("TEST_LIST_VAR")
+			}
+
+			assert.Equal(t, tt.expected, getEnvList("TEST_LIST_VAR"))
+		})
+	}
+}
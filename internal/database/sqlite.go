@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -114,8 +115,15 @@ func (db *DB) initSchema() error {
 	CREATE INDEX IF NOT EXISTS idx_network ON nft_token_mappings(network);
 	`
 
-	_, err := db.conn.Exec(schema)
-	return err
+	if _, err := db.conn.Exec(schema); err != nil {
+		return err
+	}
+
+	if err := db.initTransactionSchema(); err != nil {
+		return err
+	}
+
+	return db.initTicketSchema()
 }
 
 // SaveNFTMapping stores the mapping between token ID and Datakyte NFT ID
@@ -242,6 +250,34 @@ func (db *DB) UpdateNFTStatus(tokenID uint64, network string, status string) err
 	return nil
 }
 
+// UpdateNFTOwner records the new owner of a transferred NFT
+func (db *DB) UpdateNFTOwner(tokenID uint64, network string, owner string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	query := `
+	UPDATE nft_token_mappings 
+	SET owner_address = ?, updated_at = CURRENT_TIMESTAMP
+	WHERE token_id = ? AND network = ?
+	`
+
+	result, err := db.conn.Exec(query, owner, tokenID, network)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("no mapping found for token %d on %s", tokenID, network)
+	}
+
+	return nil
+}
+
 // UpdateNFTRedemption marks an NFT as redeemed
 func (db *DB) UpdateNFTRedemption(tokenID uint64, network string) error {
 	db.mu.Lock()
@@ -272,6 +308,50 @@ func (db *DB) UpdateNFTRedemption(tokenID uint64, network string) error {
 	return nil
 }
 
+// ReplaceNFTTxHash points the mappings of a mint transaction at the
+// transaction that replaced it. Status is left unchanged when empty.
+func (db *DB) ReplaceNFTTxHash(network string, oldHash string, newHash string, status string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	query := `
+	UPDATE nft_token_mappings
+	SET tx_hash = ?,
+		status = COALESCE(NULLIF(?, ''), status),
+		updated_at = CURRENT_TIMESTAMP
+	WHERE tx_hash = ? AND network = ?
+	`
+
+	_, err := db.conn.Exec(query, newHash, status, oldHash, network)
+	return err
+}
+
+// UpdateNFTStatusByTxHash sets the status of the mappings of a mint
+// transaction whose status is one of from
+func (db *DB) UpdateNFTStatusByTxHash(network string, txHash string, status string, from ...string) error {
+	if len(from) == 0 {
+		return nil
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	query := `
+	UPDATE nft_token_mappings
+	SET status = ?,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE tx_hash = ? AND network = ? AND status IN (?` + strings.Repeat(", ?", len(from)-1) + `)
+	`
+
+	args := []interface{}{status, txHash, network}
+	for _, s := range from {
+		args = append(args, s)
+	}
+
+	_, err := db.conn.Exec(query, args...)
+	return err
+}
+
 // GetUserNFTs retrieves all NFTs owned by a specific address
 func (db *DB) GetUserNFTs(ownerAddress string, network string) ([]NFTMapping, error) {
 	db.mu.RLock()
@@ -320,6 +400,55 @@ func (db *DB) GetUserNFTs(ownerAddress string, network string) ([]NFTMapping, er
 	return mappings, rows.Err()
 }
 
+// ListNFTMappingsByStatus retrieves the NFTs of a network with a status,
+// oldest first
+func (db *DB) ListNFTMappingsByStatus(network string, status string) ([]NFTMapping, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	query := `
+	SELECT id, token_id, datakyte_nft_id, network, contract_address,
+		   owner_address, booking_id, event_id, status, metadata_uri,
+		   image_url, tx_hash, minted_at, redeemed_at
+	FROM nft_token_mappings
+	WHERE network = ? AND status = ?
+	ORDER BY token_id ASC
+	`
+
+	rows, err := db.conn.Query(query, network, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mappings []NFTMapping
+	for rows.Next() {
+		var m NFTMapping
+		err := rows.Scan(
+			&m.ID,
+			&m.TokenID,
+			&m.DatakyteNFTID,
+			&m.Network,
+			&m.ContractAddr,
+			&m.OwnerAddress,
+			&m.BookingID,
+			&m.EventID,
+			&m.Status,
+			&m.MetadataURI,
+			&m.ImageURL,
+			&m.TxHash,
+			&m.MintedAt,
+			&m.RedeemedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, m)
+	}
+
+	return mappings, rows.Err()
+}
+
 // Close closes the database connection
 func (db *DB) Close() error {
 	if db.conn != nil {
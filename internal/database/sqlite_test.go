@@ -104,6 +104,27 @@ func TestDatabase(t *testing.T) {
 		assert.NotNil(t, retrieved.RedeemedAt)
 	})
 
+	t.Run("UpdateOwner", func(t *testing.T) {
+		mapping := &NFTMapping{
+			TokenID:       10005,
+			DatakyteNFTID: "dk_nft_test555",
+			Network:       "testnet",
+			ContractAddr:  "0x1234567890abcdef",
+			OwnerAddress:  "0xabcdef1234567890",
+			Status:        "active",
+			TxHash:        "0xd00dd00d",
+		}
+		require.NoError(t, db.SaveNFTMapping(mapping))
+
+		require.NoError(t, db.UpdateNFTOwner(10005, "testnet", "0xnewowner"))
+
+		retrieved, err := db.GetNFTMapping(10005, "testnet")
+		require.NoError(t, err)
+		assert.Equal(t, "0xnewowner", retrieved.OwnerAddress)
+
+		assert.Error(t, db.UpdateNFTOwner(10005, "mainnet", "0xnewowner"))
+	})
+
 	t.Run("GetUserNFTs", func(t *testing.T) {
 		ownerAddr := "0xuser123"
 
@@ -133,6 +154,56 @@ func TestDatabase(t *testing.T) {
 		assert.Contains(t, tokenIDs, uint64(20003))
 	})
 
+	t.Run("UpdateStatusByTxHash", func(t *testing.T) {
+		mapping := &NFTMapping{
+			TokenID:       10004,
+			DatakyteNFTID: "dk_nft_test000",
+			Network:       "testnet",
+			ContractAddr:  "0x1234567890abcdef",
+			OwnerAddress:  "0xabcdef1234567890",
+			Status:        "active",
+			TxHash:        "0xfeedfeed",
+		}
+		require.NoError(t, db.SaveNFTMapping(mapping))
+
+		// Only mappings in one of the given statuses change
+		require.NoError(t, db.UpdateNFTStatusByTxHash("testnet", "0xfeedfeed", "active", "pending"))
+		require.NoError(t, db.UpdateNFTStatusByTxHash("testnet", "0xfeedfeed", "pending", "active"))
+		retrieved, err := db.GetNFTMapping(10004, "testnet")
+		require.NoError(t, err)
+		assert.Equal(t, "pending", retrieved.Status)
+
+		require.NoError(t, db.UpdateNFTStatusByTxHash("mainnet", "0xfeedfeed", "failed", "pending"))
+		retrieved, err = db.GetNFTMapping(10004, "testnet")
+		require.NoError(t, err)
+		assert.Equal(t, "pending", retrieved.Status)
+	})
+
+	t.Run("ListByStatus", func(t *testing.T) {
+		for i, status := range []string{"active", "expired", "active"} {
+			tokenID := uint64(30003 - i)
+			require.NoError(t, db.SaveNFTMapping(&NFTMapping{
+				TokenID:       tokenID,
+				DatakyteNFTID: fmt.Sprintf("dk_nft_%d", tokenID),
+				Network:       "mainnet",
+				ContractAddr:  "0x1234567890abcdef",
+				OwnerAddress:  "0xabcdef1234567890",
+				Status:        status,
+				TxHash:        fmt.Sprintf("0xhash%d", tokenID),
+			}))
+		}
+
+		active, err := db.ListNFTMappingsByStatus("mainnet", "active")
+		require.NoError(t, err)
+		require.Len(t, active, 2)
+		assert.Equal(t, uint64(30001), active[0].TokenID)
+		assert.Equal(t, uint64(30003), active[1].TokenID)
+
+		none, err := db.ListNFTMappingsByStatus("mainnet", "redeemed")
+		require.NoError(t, err)
+		assert.Empty(t, none)
+	})
+
 	t.Run("NonExistentMapping", func(t *testing.T) {
 		// Try to get non-existent mapping
 		_, err := db.GetDatakyteID(99999, "testnet")
@@ -0,0 +1,706 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Indexed ticket states, mirroring the contract
+const (
+	TicketIssued   = "issued"
+	TicketRedeemed = "redeemed"
+	TicketExpired  = "expired"
+)
+
+// Ticket filters, evaluated at TicketFilter.Now
+const (
+	// TicketsActive are issued tickets that have not expired
+	TicketsActive = "active"
+	// TicketsRedeemed are redeemed tickets
+	TicketsRedeemed = "redeemed"
+	// TicketsExpired are tickets marked expired or past their expiry
+	TicketsExpired = "expired"
+	// TicketsTransferable are tickets the contract lets their owner transfer
+	TicketsTransferable = "transferable"
+)
+
+// IndexedTicket is the state of a ticket built from the contract events
+type IndexedTicket struct {
+	Network                    string
+	ContractAddr               string
+	TokenID                    uint64
+	OwnerAddress               string
+	BookingID                  string
+	EventID                    string
+	State                      string
+	Burned                     bool
+	TransferUnlockAt           uint64
+	ExpiresAt                  uint64
+	UtilityFlags               uint32
+	NonTransferableAfterRedeem bool
+	BurnOnRedeem               bool
+	// DetailsSynced is set once the fields the events do not carry (expiry,
+	// flags) were read from the contract
+	DetailsSynced bool
+	MintTxHash    string
+	MintedBlock   uint64
+	// LogBlock, LogIndex and BlockHash locate the last event applied to
+	// the ticket
+	LogBlock  uint64
+	LogIndex  uint
+	BlockHash string
+	UpdatedAt string
+}
+
+// TicketChange is what a contract event changes on a ticket. Nil fields are
+// left unchanged.
+type TicketChange struct {
+	Network      string
+	ContractAddr string
+	TokenID      uint64
+	Block        uint64
+	LogIndex     uint
+	TxHash       string
+	BlockHash    string
+
+	// Minted records Block and TxHash as the mint of the ticket
+	Minted           bool
+	Burned           bool
+	OwnerAddress     *string
+	BookingID        *string
+	EventID          *string
+	State            *string
+	TransferUnlockAt *uint64
+}
+
+// TicketDetails are the ticket fields read from the contract
+type TicketDetails struct {
+	BookingID                  string
+	EventID                    string
+	TransferUnlockAt           uint64
+	ExpiresAt                  uint64
+	UtilityFlags               uint32
+	NonTransferableAfterRedeem bool
+	BurnOnRedeem               bool
+}
+
+// TicketFilter selects indexed tickets. Burned tickets are never listed.
+type TicketFilter struct {
+	Network      string
+	ContractAddr string
+	// OwnerAddress and EventID are ignored when empty
+	OwnerAddress string
+	EventID      string
+	// Status is one of the Tickets* filters, or empty for every ticket
+	Status string
+	// Now is the unix time expiry and transfer locks are compared with
+	Now uint64
+}
+
+const indexedTicketColumns = `network, contract_address, token_id, owner_address, booking_id,
+	event_id, state, burned, transfer_unlock_at, expires_at, utility_flags,
+	non_transferable_after_redeem, burn_on_redeem, details_synced, mint_tx_hash,
+	minted_block, log_block, log_index, block_hash, updated_at`
+
+const ticketChangeColumns = `network, contract_address, token_id, block_number, log_index,
+	tx_hash, block_hash, minted, burned, owner_address, booking_id, event_id, state,
+	transfer_unlock_at`
+
+// initTicketSchema creates the ticket index, the journal of the events
+// applied to it, the used redemption nonces and the event cursors
+func (db *DB) initTicketSchema() error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS indexed_tickets (
+		network TEXT NOT NULL,
+		contract_address TEXT NOT NULL,
+		token_id INTEGER NOT NULL,
+		owner_address TEXT NOT NULL DEFAULT '',
+		booking_id TEXT NOT NULL DEFAULT '',
+		event_id TEXT NOT NULL DEFAULT '',
+		state TEXT NOT NULL DEFAULT 'issued',
+		burned INTEGER NOT NULL DEFAULT 0,
+		transfer_unlock_at INTEGER NOT NULL DEFAULT 0,
+		expires_at INTEGER NOT NULL DEFAULT 0,
+		utility_flags INTEGER NOT NULL DEFAULT 0,
+		non_transferable_after_redeem INTEGER NOT NULL DEFAULT 0,
+		burn_on_redeem INTEGER NOT NULL DEFAULT 0,
+		details_synced INTEGER NOT NULL DEFAULT 0,
+		mint_tx_hash TEXT NOT NULL DEFAULT '',
+		minted_block INTEGER NOT NULL DEFAULT 0,
+		log_block INTEGER NOT NULL DEFAULT 0,
+		log_index INTEGER NOT NULL DEFAULT 0,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (network, contract_address, token_id)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_indexed_tickets_owner ON indexed_tickets(network, contract_address, owner_address);
+	CREATE INDEX IF NOT EXISTS idx_indexed_tickets_event ON indexed_tickets(network, contract_address, event_id);
+
+	CREATE TABLE IF NOT EXISTS ticket_changes (
+		network TEXT NOT NULL,
+		contract_address TEXT NOT NULL,
+		token_id INTEGER NOT NULL,
+		block_number INTEGER NOT NULL,
+		log_index INTEGER NOT NULL,
+		tx_hash TEXT NOT NULL DEFAULT '',
+		block_hash TEXT NOT NULL DEFAULT '',
+		minted INTEGER NOT NULL DEFAULT 0,
+		burned INTEGER NOT NULL DEFAULT 0,
+		owner_address TEXT,
+		booking_id TEXT,
+		event_id TEXT,
+		state TEXT,
+		transfer_unlock_at INTEGER,
+		PRIMARY KEY (network, contract_address, block_number, log_index, token_id)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_ticket_changes_token ON ticket_changes(network, contract_address, token_id);
+
+	CREATE TABLE IF NOT EXISTS redemption_nonces (
+		network TEXT NOT NULL,
+		contract_address TEXT NOT NULL,
+		nonce TEXT NOT NULL,
+		redeemer TEXT NOT NULL,
+		block_number INTEGER NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (network, contract_address, nonce)
+	);
+
+	CREATE TABLE IF NOT EXISTS event_cursors (
+		network TEXT NOT NULL,
+		contract_address TEXT NOT NULL,
+		block_number INTEGER NOT NULL,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (network, contract_address)
+	);
+	`
+
+	if _, err := db.conn.Exec(schema); err != nil {
+		return err
+	}
+
+	// Block hashes were added for reorg handling; older databases lack them
+	if err := db.addColumnIfMissing("indexed_tickets", "block_hash", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	return db.addColumnIfMissing("event_cursors", "block_hash", "TEXT NOT NULL DEFAULT ''")
+}
+
+// ApplyTicketChange applies a contract event to the indexed ticket, creating
+// it if needed, and records it in the journal RollbackTickets replays.
+// Events at or before the last one applied to the ticket are ignored, so
+// replaying a block is harmless.
+func (db *DB) ApplyTicketChange(change *TicketChange) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	ticket, err := db.getIndexedTicket(change.Network, change.ContractAddr, change.TokenID)
+	if err == sql.ErrNoRows {
+		ticket = newIndexedTicket(change.Network, change.ContractAddr, change.TokenID)
+	} else if err != nil {
+		return err
+	} else if change.Block < ticket.LogBlock || (change.Block == ticket.LogBlock && change.LogIndex <= ticket.LogIndex) {
+		return nil
+	}
+	applyTicketChange(ticket, change)
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT OR REPLACE INTO ticket_changes (` + ticketChangeColumns + `)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err = tx.Exec(query,
+		change.Network,
+		change.ContractAddr,
+		change.TokenID,
+		change.Block,
+		change.LogIndex,
+		change.TxHash,
+		change.BlockHash,
+		change.Minted,
+		change.Burned,
+		change.OwnerAddress,
+		change.BookingID,
+		change.EventID,
+		change.State,
+		change.TransferUnlockAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := saveIndexedTicket(tx, ticket); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RollbackTickets undoes the events of the blocks after ancestor, which a
+// reorg replaced. Each affected ticket is rebuilt from its remaining events,
+// or removed when it was minted in a replaced block. Rebuilt tickets read
+// their details from the contract again. Nonces used in the replaced blocks
+// are released.
+func (db *DB) RollbackTickets(network string, contractAddr string, ancestor uint64) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	tokenIDs, err := queryTokenIDs(tx, `
+	SELECT DISTINCT token_id FROM ticket_changes
+	WHERE network = ? AND contract_address = ? AND block_number > ?
+	ORDER BY token_id ASC
+	`, network, contractAddr, ancestor)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM ticket_changes WHERE network = ? AND contract_address = ? AND block_number > ?`,
+		network, contractAddr, ancestor)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`DELETE FROM redemption_nonces WHERE network = ? AND contract_address = ? AND block_number > ?`,
+		network, contractAddr, ancestor)
+	if err != nil {
+		return err
+	}
+
+	for _, tokenID := range tokenIDs {
+		changes, err := listTicketChanges(tx, network, contractAddr, tokenID)
+		if err != nil {
+			return err
+		}
+
+		if len(changes) == 0 {
+			_, err := tx.Exec(`DELETE FROM indexed_tickets WHERE network = ? AND contract_address = ? AND token_id = ?`,
+				network, contractAddr, tokenID)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		ticket := newIndexedTicket(network, contractAddr, tokenID)
+		for _, change := range changes {
+			applyTicketChange(ticket, change)
+		}
+		if err := saveIndexedTicket(tx, ticket); err != nil {
+			return err
+		}
+		_, err = tx.Exec(`UPDATE indexed_tickets SET details_synced = 0
+		WHERE network = ? AND contract_address = ? AND token_id = ?`, network, contractAddr, tokenID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func newIndexedTicket(network string, contractAddr string, tokenID uint64) *IndexedTicket {
+	return &IndexedTicket{
+		Network:      network,
+		ContractAddr: contractAddr,
+		TokenID:      tokenID,
+		State:        TicketIssued,
+	}
+}
+
+// applyTicketChange applies the fields a change sets to a ticket
+func applyTicketChange(ticket *IndexedTicket, change *TicketChange) {
+	if change.Minted {
+		ticket.MintTxHash = change.TxHash
+		ticket.MintedBlock = change.Block
+	}
+	if change.Burned {
+		ticket.Burned = true
+	}
+	if change.OwnerAddress != nil {
+		ticket.OwnerAddress = *change.OwnerAddress
+	}
+	if change.BookingID != nil {
+		ticket.BookingID = *change.BookingID
+	}
+	if change.EventID != nil {
+		ticket.EventID = *change.EventID
+	}
+	if change.State != nil {
+		ticket.State = *change.State
+	}
+	if change.TransferUnlockAt != nil {
+		ticket.TransferUnlockAt = *change.TransferUnlockAt
+	}
+	ticket.LogBlock = change.Block
+	ticket.LogIndex = change.LogIndex
+	ticket.BlockHash = change.BlockHash
+}
+
+// saveIndexedTicket upserts the fields of a ticket the events set
+func saveIndexedTicket(tx *sql.Tx, ticket *IndexedTicket) error {
+	query := `
+	INSERT INTO indexed_tickets (
+		network, contract_address, token_id, owner_address, booking_id, event_id,
+		state, burned, transfer_unlock_at, mint_tx_hash, minted_block, log_block,
+		log_index, block_hash
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(network, contract_address, token_id)
+	DO UPDATE SET
+		owner_address = excluded.owner_address,
+		booking_id = excluded.booking_id,
+		event_id = excluded.event_id,
+		state = excluded.state,
+		burned = excluded.burned,
+		transfer_unlock_at = excluded.transfer_unlock_at,
+		mint_tx_hash = excluded.mint_tx_hash,
+		minted_block = excluded.minted_block,
+		log_block = excluded.log_block,
+		log_index = excluded.log_index,
+		block_hash = excluded.block_hash,
+		updated_at = CURRENT_TIMESTAMP
+	`
+
+	_, err := tx.Exec(query,
+		ticket.Network,
+		ticket.ContractAddr,
+		ticket.TokenID,
+		ticket.OwnerAddress,
+		ticket.BookingID,
+		ticket.EventID,
+		ticket.State,
+		ticket.Burned,
+		ticket.TransferUnlockAt,
+		ticket.MintTxHash,
+		ticket.MintedBlock,
+		ticket.LogBlock,
+		ticket.LogIndex,
+		ticket.BlockHash,
+	)
+	return err
+}
+
+// listTicketChanges returns the journal of a ticket in chain order
+func listTicketChanges(tx *sql.Tx, network string, contractAddr string, tokenID uint64) ([]*TicketChange, error) {
+	query := `SELECT ` + ticketChangeColumns + ` FROM ticket_changes
+	WHERE network = ? AND contract_address = ? AND token_id = ?
+	ORDER BY block_number ASC, log_index ASC`
+
+	rows, err := tx.Query(query, network, contractAddr, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []*TicketChange
+	for rows.Next() {
+		var change TicketChange
+		err := rows.Scan(
+			&change.Network,
+			&change.ContractAddr,
+			&change.TokenID,
+			&change.Block,
+			&change.LogIndex,
+			&change.TxHash,
+			&change.BlockHash,
+			&change.Minted,
+			&change.Burned,
+			&change.OwnerAddress,
+			&change.BookingID,
+			&change.EventID,
+			&change.State,
+			&change.TransferUnlockAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, &change)
+	}
+
+	return changes, rows.Err()
+}
+
+// SetTicketDetails stores the ticket fields read from the contract. Booking
+// and event IDs already known from the events are kept.
+func (db *DB) SetTicketDetails(network string, contractAddr string, tokenID uint64, details *TicketDetails) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	query := `
+	UPDATE indexed_tickets
+	SET booking_id = COALESCE(NULLIF(booking_id, ''), ?),
+		event_id = COALESCE(NULLIF(event_id, ''), ?),
+		transfer_unlock_at = ?,
+		expires_at = ?,
+		utility_flags = ?,
+		non_transferable_after_redeem = ?,
+		burn_on_redeem = ?,
+		details_synced = 1,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE network = ? AND contract_address = ? AND token_id = ?
+	`
+
+	result, err := db.conn.Exec(query,
+		details.BookingID,
+		details.EventID,
+		details.TransferUnlockAt,
+		details.ExpiresAt,
+		details.UtilityFlags,
+		details.NonTransferableAfterRedeem,
+		details.BurnOnRedeem,
+		network,
+		contractAddr,
+		tokenID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("no indexed ticket %d on %s", tokenID, network)
+	}
+
+	return nil
+}
+
+// GetIndexedTicket retrieves an indexed ticket
+func (db *DB) GetIndexedTicket(network string, contractAddr string, tokenID uint64) (*IndexedTicket, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	ticket, err := db.getIndexedTicket(network, contractAddr, tokenID)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no indexed ticket %d on %s", tokenID, network)
+	}
+	return ticket, err
+}
+
+func (db *DB) getIndexedTicket(network string, contractAddr string, tokenID uint64) (*IndexedTicket, error) {
+	query := `SELECT ` + indexedTicketColumns + ` FROM indexed_tickets
+	WHERE network = ? AND contract_address = ? AND token_id = ?`
+
+	return scanIndexedTicket(db.conn.QueryRow(query, network, contractAddr, tokenID))
+}
+
+// ListIndexedTickets retrieves the tickets matching a filter, by token ID
+func (db *DB) ListIndexedTickets(filter TicketFilter) ([]IndexedTicket, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	query := `SELECT ` + indexedTicketColumns + ` FROM indexed_tickets
+	WHERE network = ? AND contract_address = ? AND burned = 0`
+	args := []interface{}{filter.Network, filter.ContractAddr}
+
+	if filter.OwnerAddress != "" {
+		query += ` AND owner_address = ?`
+		args = append(args, filter.OwnerAddress)
+	}
+	if filter.EventID != "" {
+		query += ` AND event_id = ?`
+		args = append(args, filter.EventID)
+	}
+
+	// Tickets whose details are not read yet were minted with an expiry in
+	// the future, so they count as active until the details say otherwise
+	switch filter.Status {
+	case "":
+	case TicketsActive:
+		query += ` AND state = 'issued' AND (details_synced = 0 OR expires_at > ?)`
+		args = append(args, filter.Now)
+	case TicketsRedeemed:
+		query += ` AND state = 'redeemed'`
+	case TicketsExpired:
+		query += ` AND (state = 'expired' OR (state = 'issued' AND details_synced = 1 AND expires_at <= ?))`
+		args = append(args, filter.Now)
+	case TicketsTransferable:
+		query += ` AND details_synced = 1 AND transfer_unlock_at <= ? AND expires_at > ?
+		AND NOT (state = 'redeemed' AND non_transferable_after_redeem = 1)`
+		args = append(args, filter.Now, filter.Now)
+	default:
+		return nil, fmt.Errorf("unknown ticket filter %q", filter.Status)
+	}
+	query += ` ORDER BY token_id ASC`
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tickets []IndexedTicket
+	for rows.Next() {
+		ticket, err := scanIndexedTicket(rows)
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, *ticket)
+	}
+
+	return tickets, rows.Err()
+}
+
+// ListTicketsMissingDetails retrieves the IDs of tickets whose details were
+// not read from the contract yet
+func (db *DB) ListTicketsMissingDetails(network string, contractAddr string) ([]uint64, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	query := `
+	SELECT token_id FROM indexed_tickets
+	WHERE network = ? AND contract_address = ? AND details_synced = 0 AND burned = 0
+	ORDER BY token_id ASC
+	`
+
+	return queryTokenIDs(db.conn, query, network, contractAddr)
+}
+
+// queryer is a connection or a transaction
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// queryTokenIDs runs a query selecting token IDs
+func queryTokenIDs(q queryer, query string, args ...interface{}) ([]uint64, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokenIDs []uint64
+	for rows.Next() {
+		var tokenID uint64
+		if err := rows.Scan(&tokenID); err != nil {
+			return nil, err
+		}
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+
+	return tokenIDs, rows.Err()
+}
+
+// CountMintedTickets returns how many tickets of a contract were minted,
+// including burned ones
+func (db *DB) CountMintedTickets(network string, contractAddr string) (uint64, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var count uint64
+	query := `SELECT COUNT(*) FROM indexed_tickets WHERE network = ? AND contract_address = ?`
+	err := db.conn.QueryRow(query, network, contractAddr).Scan(&count)
+	return count, err
+}
+
+// SaveUsedNonce records a redemption nonce the contract marked as used. The
+// nonce is a decimal string, as nonces are uint256 on-chain.
+func (db *DB) SaveUsedNonce(network string, contractAddr string, nonce string, redeemer string, block uint64) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	query := `
+	INSERT INTO redemption_nonces (network, contract_address, nonce, redeemer, block_number)
+	VALUES (?, ?, ?, ?, ?)
+	ON CONFLICT(network, contract_address, nonce)
+	DO UPDATE SET
+		redeemer = excluded.redeemer,
+		block_number = excluded.block_number
+	`
+
+	_, err := db.conn.Exec(query, network, contractAddr, nonce, redeemer, block)
+	return err
+}
+
+// IsNonceUsed reports whether a redemption nonce was used
+func (db *DB) IsNonceUsed(network string, contractAddr string, nonce string) (bool, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var count int
+	query := `SELECT COUNT(*) FROM redemption_nonces WHERE network = ? AND contract_address = ? AND nonce = ?`
+	err := db.conn.QueryRow(query, network, contractAddr, nonce).Scan(&count)
+	return count > 0, err
+}
+
+// GetEventCursor returns the last block whose events of a contract were all
+// processed and its hash, empty when unknown. The boolean is false when no
+// cursor is stored.
+func (db *DB) GetEventCursor(network string, contractAddr string) (uint64, string, bool, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var block uint64
+	var hash string
+	query := `SELECT block_number, block_hash FROM event_cursors WHERE network = ? AND contract_address = ?`
+	err := db.conn.QueryRow(query, network, contractAddr).Scan(&block, &hash)
+	if err == sql.ErrNoRows {
+		return 0, "", false, nil
+	}
+	if err != nil {
+		return 0, "", false, err
+	}
+	return block, hash, true, nil
+}
+
+// SaveEventCursor stores the last block whose events of a contract were all
+// processed and its hash
+func (db *DB) SaveEventCursor(network string, contractAddr string, block uint64, hash string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	query := `
+	INSERT INTO event_cursors (network, contract_address, block_number, block_hash)
+	VALUES (?, ?, ?, ?)
+	ON CONFLICT(network, contract_address)
+	DO UPDATE SET
+		block_number = excluded.block_number,
+		block_hash = excluded.block_hash,
+		updated_at = CURRENT_TIMESTAMP
+	`
+
+	_, err := db.conn.Exec(query, network, contractAddr, block, hash)
+	return err
+}
+
+func scanIndexedTicket(row rowScanner) (*IndexedTicket, error) {
+	var ticket IndexedTicket
+
+	err := row.Scan(
+		&ticket.Network,
+		&ticket.ContractAddr,
+		&ticket.TokenID,
+		&ticket.OwnerAddress,
+		&ticket.BookingID,
+		&ticket.EventID,
+		&ticket.State,
+		&ticket.Burned,
+		&ticket.TransferUnlockAt,
+		&ticket.ExpiresAt,
+		&ticket.UtilityFlags,
+		&ticket.NonTransferableAfterRedeem,
+		&ticket.BurnOnRedeem,
+		&ticket.DetailsSynced,
+		&ticket.MintTxHash,
+		&ticket.MintedBlock,
+		&ticket.LogBlock,
+		&ticket.LogIndex,
+		&ticket.BlockHash,
+		&ticket.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ticket, nil
+}
@@ -0,0 +1,185 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketIndex(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	const contract = "0xTickets"
+	alice, bob := "0xAlice", "0xBob"
+	issued, redeemed := TicketIssued, TicketRedeemed
+
+	apply := func(tokenID uint64, block uint64, index uint, change TicketChange) {
+		t.Helper()
+		change.Network = "testnet"
+		change.ContractAddr = contract
+		change.TokenID = tokenID
+		change.Block = block
+		change.LogIndex = index
+		require.NoError(t, db.ApplyTicketChange(&change))
+	}
+	details := func(tokenID uint64, unlockAt, expiresAt uint64, nonTransferableAfterRedeem bool) {
+		t.Helper()
+		require.NoError(t, db.SetTicketDetails("testnet", contract, tokenID, &TicketDetails{
+			TransferUnlockAt:           unlockAt,
+			ExpiresAt:                  expiresAt,
+			NonTransferableAfterRedeem: nonTransferableAfterRedeem,
+		}))
+	}
+	eventA := "0xaa"
+
+	// 1: active and transferable, 2: locked, 3: past expiry, 4: redeemed,
+	// 5: redeemed and burned, 6: details not read yet
+	for tokenID := uint64(1); tokenID <= 6; tokenID++ {
+		apply(tokenID, tokenID, 0, TicketChange{Minted: true, TxHash: "0xmint", OwnerAddress: &alice})
+		apply(tokenID, tokenID, 1, TicketChange{Minted: true, TxHash: "0xmint", EventID: &eventA})
+	}
+	details(1, 50, 200, true)
+	details(2, 150, 200, true)
+	details(3, 50, 80, true)
+	details(4, 50, 200, true)
+	details(5, 50, 200, false)
+	apply(4, 10, 0, TicketChange{State: &redeemed})
+	apply(5, 11, 0, TicketChange{State: &redeemed})
+	apply(5, 11, 1, TicketChange{Burned: true})
+
+	list := func(filter TicketFilter) []uint64 {
+		t.Helper()
+		filter.Network = "testnet"
+		filter.ContractAddr = contract
+		filter.Now = 100
+		tickets, err := db.ListIndexedTickets(filter)
+		require.NoError(t, err)
+		ids := []uint64{}
+		for _, ticket := range tickets {
+			ids = append(ids, ticket.TokenID)
+		}
+		return ids
+	}
+
+	t.Run("Filters", func(t *testing.T) {
+		assert.Equal(t, []uint64{1, 2, 3, 4, 6}, list(TicketFilter{OwnerAddress: alice}))
+		assert.Equal(t, []uint64{1, 2, 6}, list(TicketFilter{OwnerAddress: alice, Status: TicketsActive}))
+		assert.Equal(t, []uint64{4}, list(TicketFilter{OwnerAddress: alice, Status: TicketsRedeemed}))
+		assert.Equal(t, []uint64{3}, list(TicketFilter{OwnerAddress: alice, Status: TicketsExpired}))
+		assert.Equal(t, []uint64{1}, list(TicketFilter{OwnerAddress: alice, Status: TicketsTransferable}))
+		assert.Equal(t, []uint64{1, 2, 3, 4, 6}, list(TicketFilter{EventID: eventA}))
+		assert.Empty(t, list(TicketFilter{OwnerAddress: bob}))
+
+		_, err := db.ListIndexedTickets(TicketFilter{Status: "lost"})
+		assert.Error(t, err)
+	})
+
+	t.Run("TransferAndReplay", func(t *testing.T) {
+		apply(1, 20, 3, TicketChange{OwnerAddress: &bob})
+		// Replaying an older event leaves the newer one in place
+		apply(1, 20, 2, TicketChange{OwnerAddress: &alice, State: &issued})
+		apply(1, 20, 3, TicketChange{OwnerAddress: &alice})
+
+		ticket, err := db.GetIndexedTicket("testnet", contract, 1)
+		require.NoError(t, err)
+		assert.Equal(t, bob, ticket.OwnerAddress)
+		assert.Equal(t, eventA, ticket.EventID)
+		assert.Equal(t, uint64(200), ticket.ExpiresAt)
+		assert.True(t, ticket.DetailsSynced)
+		assert.Equal(t, "0xmint", ticket.MintTxHash)
+		assert.Equal(t, uint64(1), ticket.MintedBlock)
+		assert.Equal(t, []uint64{1}, list(TicketFilter{OwnerAddress: bob}))
+	})
+
+	t.Run("Counts", func(t *testing.T) {
+		count, err := db.CountMintedTickets("testnet", contract)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(6), count)
+
+		missing, err := db.ListTicketsMissingDetails("testnet", contract)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{6}, missing)
+
+		assert.Error(t, db.SetTicketDetails("testnet", contract, 99, &TicketDetails{}))
+	})
+
+	t.Run("Cursor", func(t *testing.T) {
+		_, _, ok, err := db.GetEventCursor("testnet", contract)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		require.NoError(t, db.SaveEventCursor("testnet", contract, 41, "0x41"))
+		require.NoError(t, db.SaveEventCursor("testnet", contract, 42, "0x42"))
+		block, hash, ok, err := db.GetEventCursor("testnet", contract)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, uint64(42), block)
+		assert.Equal(t, "0x42", hash)
+
+		_, _, ok, err = db.GetEventCursor("mainnet", contract)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("Nonces", func(t *testing.T) {
+		used, err := db.IsNonceUsed("testnet", contract, "77")
+		require.NoError(t, err)
+		assert.False(t, used)
+
+		require.NoError(t, db.SaveUsedNonce("testnet", contract, "77", alice, 18))
+		require.NoError(t, db.SaveUsedNonce("testnet", contract, "78", alice, 21))
+		used, err = db.IsNonceUsed("testnet", contract, "77")
+		require.NoError(t, err)
+		assert.True(t, used)
+		used, err = db.IsNonceUsed("mainnet", contract, "77")
+		require.NoError(t, err)
+		assert.False(t, used)
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		// Blocks after 20 are replaced: ticket 7 was minted in them, ticket
+		// 1 went back to alice in them and ticket 2 was transferred before
+		apply(2, 19, 0, TicketChange{OwnerAddress: &bob, BlockHash: "0x19"})
+		apply(7, 21, 0, TicketChange{Minted: true, TxHash: "0xmint7", OwnerAddress: &alice, BlockHash: "0x21"})
+		apply(1, 22, 0, TicketChange{OwnerAddress: &alice, BlockHash: "0x22"})
+
+		require.NoError(t, db.RollbackTickets("testnet", contract, 20))
+
+		// The nonce used in a replaced block is free again
+		used, err := db.IsNonceUsed("testnet", contract, "78")
+		require.NoError(t, err)
+		assert.False(t, used)
+		used, err = db.IsNonceUsed("testnet", contract, "77")
+		require.NoError(t, err)
+		assert.True(t, used)
+
+		_, err = db.GetIndexedTicket("testnet", contract, 7)
+		assert.Error(t, err)
+
+		ticket, err := db.GetIndexedTicket("testnet", contract, 1)
+		require.NoError(t, err)
+		assert.Equal(t, bob, ticket.OwnerAddress)
+		assert.Equal(t, eventA, ticket.EventID)
+		assert.Equal(t, "0xmint", ticket.MintTxHash)
+		assert.Equal(t, uint64(20), ticket.LogBlock)
+		assert.Equal(t, uint(3), ticket.LogIndex)
+		assert.False(t, ticket.DetailsSynced)
+
+		ticket, err = db.GetIndexedTicket("testnet", contract, 2)
+		require.NoError(t, err)
+		assert.Equal(t, bob, ticket.OwnerAddress)
+		assert.Equal(t, "0x19", ticket.BlockHash)
+		assert.True(t, ticket.DetailsSynced)
+
+		// The replacement blocks apply on top of the rolled back state
+		apply(1, 21, 0, TicketChange{OwnerAddress: &alice, BlockHash: "0x21b"})
+		ticket, err = db.GetIndexedTicket("testnet", contract, 1)
+		require.NoError(t, err)
+		assert.Equal(t, alice, ticket.OwnerAddress)
+		assert.Equal(t, "0x21b", ticket.BlockHash)
+	})
+}
@@ -0,0 +1,343 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TxRecord represents a transaction sent by the API and tracked until final
+type TxRecord struct {
+	ID            int64
+	Hash          string
+	Network       string
+	ChainID       int64
+	Signer        string
+	Nonce         uint64
+	ToAddress     string
+	Purpose       string
+	RequestRef    string
+	Replaces      string // hash of the first transaction sent for the nonce
+	RawTx         string
+	Status        string
+	Error         string
+	BlockNumber   uint64
+	BlockHash     string
+	GasUsed       uint64
+	Confirmations uint64
+	ReceiptStatus *uint64
+	Logs          string
+	CreatedAt     string
+	UpdatedAt     string
+}
+
+const txRecordColumns = `id, hash, network, chain_id, signer, nonce, to_address, purpose,
+	request_ref, replaces, raw_tx, status, error, block_number, block_hash, gas_used,
+	confirmations, receipt_status, logs, created_at, updated_at`
+
+// initTransactionSchema creates the transaction outbox table
+func (db *DB) initTransactionSchema() error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS transactions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		hash TEXT NOT NULL UNIQUE,
+		network TEXT NOT NULL,
+		chain_id INTEGER NOT NULL,
+		signer TEXT NOT NULL,
+		nonce INTEGER NOT NULL,
+		to_address TEXT,
+		purpose TEXT,
+		request_ref TEXT,
+		replaces TEXT,
+		raw_tx TEXT NOT NULL,
+		status TEXT NOT NULL,
+		error TEXT,
+		block_number INTEGER DEFAULT 0,
+		block_hash TEXT,
+		gas_used INTEGER DEFAULT 0,
+		confirmations INTEGER DEFAULT 0,
+		receipt_status INTEGER,
+		logs TEXT,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_tx_status ON transactions(network, status);
+	CREATE INDEX IF NOT EXISTS idx_tx_signer_nonce ON transactions(signer, nonce);
+	`
+
+	if _, err := db.conn.Exec(schema); err != nil {
+		return err
+	}
+
+	// Added for fee replacements; older databases lack the column
+	return db.addColumnIfMissing("transactions", "replaces", "TEXT")
+}
+
+// addColumnIfMissing adds a column to an existing table
+func (db *DB) addColumnIfMissing(table, column, definition string) error {
+	rows, err := db.conn.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = db.conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
+// SaveTransaction stores a newly signed transaction. Saving the same signed
+// transaction again, e.g. when a broadcast is retried, resets its status.
+func (db *DB) SaveTransaction(rec *TxRecord) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	query := `
+	INSERT INTO transactions (
+		hash, network, chain_id, signer, nonce, to_address,
+		purpose, request_ref, replaces, raw_tx, status, error
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(hash)
+	DO UPDATE SET
+		status = excluded.status,
+		error = excluded.error,
+		updated_at = CURRENT_TIMESTAMP
+	`
+
+	result, err := db.conn.Exec(query,
+		rec.Hash,
+		rec.Network,
+		rec.ChainID,
+		rec.Signer,
+		rec.Nonce,
+		rec.ToAddress,
+		rec.Purpose,
+		rec.RequestRef,
+		rec.Replaces,
+		rec.RawTx,
+		rec.Status,
+		rec.Error,
+	)
+	if err != nil {
+		return err
+	}
+
+	rec.ID, err = result.LastInsertId()
+	return err
+}
+
+// UpdateTransaction stores the latest status and receipt data of a transaction
+func (db *DB) UpdateTransaction(rec *TxRecord) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	query := `
+	UPDATE transactions
+	SET status = ?, error = ?, block_number = ?, block_hash = ?, gas_used = ?,
+		confirmations = ?, receipt_status = ?, logs = ?, updated_at = CURRENT_TIMESTAMP
+	WHERE hash = ?
+	`
+
+	result, err := db.conn.Exec(query,
+		rec.Status,
+		rec.Error,
+		rec.BlockNumber,
+		rec.BlockHash,
+		rec.GasUsed,
+		rec.Confirmations,
+		rec.ReceiptStatus,
+		rec.Logs,
+		rec.Hash,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("no transaction found with hash %s", rec.Hash)
+	}
+
+	return nil
+}
+
+// GetTransaction retrieves a tracked transaction by hash
+func (db *DB) GetTransaction(hash string) (*TxRecord, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	query := `SELECT ` + txRecordColumns + ` FROM transactions WHERE hash = ? LIMIT 1`
+
+	rec, err := scanTxRecord(db.conn.QueryRow(query, hash))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no transaction found with hash %s", hash)
+	}
+	return rec, err
+}
+
+// ListTransactionsByStatus retrieves the transactions of a network that are in
+// one of the given statuses, oldest first
+func (db *DB) ListTransactionsByStatus(network string, statuses ...string) ([]*TxRecord, error) {
+	if len(statuses) == 0 {
+		return nil, nil
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(statuses)), ", ")
+	query := `SELECT ` + txRecordColumns + ` FROM transactions
+	WHERE network = ? AND status IN (` + placeholders + `)
+	ORDER BY id ASC`
+
+	args := []interface{}{network}
+	for _, status := range statuses {
+		args = append(args, status)
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*TxRecord
+	for rows.Next() {
+		rec, err := scanTxRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+
+	return records, rows.Err()
+}
+
+// ListTransactionsByNonce retrieves every transaction a signer sent with a
+// nonce, i.e. an original and its replacements, oldest first
+func (db *DB) ListTransactionsByNonce(network string, signer string, nonce uint64) ([]*TxRecord, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	query := `SELECT ` + txRecordColumns + ` FROM transactions
+	WHERE network = ? AND signer = ? AND nonce = ?
+	ORDER BY id ASC`
+
+	rows, err := db.conn.Query(query, network, signer, nonce)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*TxRecord
+	for rows.Next() {
+		rec, err := scanTxRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+
+	return records, rows.Err()
+}
+
+// ListTransactionsByPurpose retrieves the transactions a signer sent for a
+// purpose since a time, oldest first
+func (db *DB) ListTransactionsByPurpose(network string, signer string, purpose string, since time.Time) ([]*TxRecord, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	// created_at is stored by SQLite as UTC text, which sorts as time
+	query := `SELECT ` + txRecordColumns + ` FROM transactions
+	WHERE network = ? AND signer = ? AND purpose = ? AND created_at >= ?
+	ORDER BY id ASC`
+
+	rows, err := db.conn.Query(query, network, signer, purpose, since.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*TxRecord
+	for rows.Next() {
+		rec, err := scanTxRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+
+	return records, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTxRecord(row rowScanner) (*TxRecord, error) {
+	var (
+		rec                                       TxRecord
+		toAddress, purpose, ref, txErr, blockHash sql.NullString
+		replaces, logs                            sql.NullString
+		receiptStatus                             sql.NullInt64
+	)
+
+	err := row.Scan(
+		&rec.ID,
+		&rec.Hash,
+		&rec.Network,
+		&rec.ChainID,
+		&rec.Signer,
+		&rec.Nonce,
+		&toAddress,
+		&purpose,
+		&ref,
+		&replaces,
+		&rec.RawTx,
+		&rec.Status,
+		&txErr,
+		&rec.BlockNumber,
+		&blockHash,
+		&rec.GasUsed,
+		&rec.Confirmations,
+		&receiptStatus,
+		&logs,
+		&rec.CreatedAt,
+		&rec.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rec.ToAddress = toAddress.String
+	rec.Purpose = purpose.String
+	rec.RequestRef = ref.String
+	rec.Replaces = replaces.String
+	rec.Error = txErr.String
+	rec.BlockHash = blockHash.String
+	rec.Logs = logs.String
+	if receiptStatus.Valid {
+		status := uint64(receiptStatus.Int64)
+		rec.ReceiptStatus = &status
+	}
+
+	return &rec, nil
+}
@@ -0,0 +1,142 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactions(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	newRecord := func(hash string, network string, nonce uint64) *TxRecord {
+		return &TxRecord{
+			Hash:    hash,
+			Network: network,
+			ChainID: 501,
+			Signer:  "0x1234567890123456789012345678901234567890",
+			Nonce:   nonce,
+			Purpose: "bogo_transfer",
+			RawTx:   "0x01",
+			Status:  "pending",
+		}
+	}
+
+	require.NoError(t, db.SaveTransaction(newRecord("0xaa", "testnet", 0)))
+	require.NoError(t, db.SaveTransaction(newRecord("0xbb", "testnet", 1)))
+	require.NoError(t, db.SaveTransaction(newRecord("0xcc", "mainnet", 0)))
+
+	t.Run("UpdateAndGet", func(t *testing.T) {
+		receiptStatus := uint64(1)
+		rec := newRecord("0xaa", "testnet", 0)
+		rec.Status = "confirmed"
+		rec.BlockNumber = 42
+		rec.GasUsed = 21000
+		rec.ReceiptStatus = &receiptStatus
+		require.NoError(t, db.UpdateTransaction(rec))
+
+		stored, err := db.GetTransaction("0xaa")
+		require.NoError(t, err)
+		assert.Equal(t, "confirmed", stored.Status)
+		assert.Equal(t, uint64(42), stored.BlockNumber)
+		assert.Equal(t, uint64(21000), stored.GasUsed)
+		require.NotNil(t, stored.ReceiptStatus)
+		assert.Equal(t, uint64(1), *stored.ReceiptStatus)
+		assert.NotEmpty(t, stored.CreatedAt)
+	})
+
+	t.Run("ListByStatus", func(t *testing.T) {
+		records, err := db.ListTransactionsByStatus("testnet", "pending", "mined")
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, "0xbb", records[0].Hash)
+		assert.Nil(t, records[0].ReceiptStatus)
+	})
+
+	t.Run("ResaveResetsStatus", func(t *testing.T) {
+		rec := newRecord("0xbb", "testnet", 1)
+		rec.Status = "rejected"
+		rec.Error = "connection reset"
+		require.NoError(t, db.UpdateTransaction(rec))
+
+		require.NoError(t, db.SaveTransaction(newRecord("0xbb", "testnet", 1)))
+		stored, err := db.GetTransaction("0xbb")
+		require.NoError(t, err)
+		assert.Equal(t, "pending", stored.Status)
+		assert.Empty(t, stored.Error)
+	})
+
+	t.Run("ListByPurpose", func(t *testing.T) {
+		signer := "0x1234567890123456789012345678901234567890"
+		records, err := db.ListTransactionsByPurpose("testnet", signer, "bogo_transfer", time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, "0xaa", records[0].Hash)
+
+		records, err = db.ListTransactionsByPurpose("testnet", signer, "native_transfer", time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, records)
+
+		records, err = db.ListTransactionsByPurpose("testnet", signer, "bogo_transfer", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := db.GetTransaction("0xdd")
+		assert.Error(t, err)
+		assert.Error(t, db.UpdateTransaction(newRecord("0xdd", "testnet", 9)))
+	})
+}
+
+func TestTransactionReplacements(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	signer := "0x1234567890123456789012345678901234567890"
+	for _, rec := range []*TxRecord{
+		{Hash: "0xaa", Network: "testnet", ChainID: 501, Signer: signer, Nonce: 5, RawTx: "0x01", Status: "pending"},
+		{Hash: "0xbb", Network: "testnet", ChainID: 501, Signer: signer, Nonce: 5, Replaces: "0xaa", RawTx: "0x02", Status: "pending"},
+		{Hash: "0xcc", Network: "testnet", ChainID: 501, Signer: signer, Nonce: 6, RawTx: "0x03", Status: "pending"},
+		{Hash: "0xdd", Network: "mainnet", ChainID: 500, Signer: signer, Nonce: 5, RawTx: "0x04", Status: "pending"},
+	} {
+		require.NoError(t, db.SaveTransaction(rec))
+	}
+
+	records, err := db.ListTransactionsByNonce("testnet", signer, 5)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "0xaa", records[0].Hash)
+	assert.Empty(t, records[0].Replaces)
+	assert.Equal(t, "0xbb", records[1].Hash)
+	assert.Equal(t, "0xaa", records[1].Replaces)
+
+	t.Run("ReplaceNFTTxHash", func(t *testing.T) {
+		require.NoError(t, db.SaveNFTMapping(&NFTMapping{
+			TokenID:       1,
+			DatakyteNFTID: "dk-1",
+			Network:       "testnet",
+			ContractAddr:  "0xticket",
+			OwnerAddress:  signer,
+			Status:        "active",
+			TxHash:        "0xaa",
+		}))
+
+		require.NoError(t, db.ReplaceNFTTxHash("testnet", "0xaa", "0xbb", ""))
+		mapping, err := db.GetNFTMapping(1, "testnet")
+		require.NoError(t, err)
+		assert.Equal(t, "0xbb", mapping.TxHash)
+		assert.Equal(t, "active", mapping.Status)
+
+		require.NoError(t, db.ReplaceNFTTxHash("testnet", "0xbb", "0xbb", "cancelled"))
+		mapping, err = db.GetNFTMapping(1, "testnet")
+		require.NoError(t, err)
+		assert.Equal(t, "cancelled", mapping.Status)
+	})
+}
@@ -0,0 +1,226 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// MaxBalanceBatchSize is the maximum number of addresses accepted by GetTokenBalances
+const MaxBalanceBatchSize = 100
+
+// maxRPCBatchItems caps the number of calls sent in a single JSON-RPC batch,
+// since public Camino nodes reject very large batches
+const maxRPCBatchItems = 100
+
+// AccountBalance represents the BOGO and native CAM balances of an address
+type AccountBalance struct {
+	Address       string `json:"address"`
+	Balance       string `json:"balance"`
+	NativeBalance string `json:"nativeBalance"`
+	Error         string `json:"error,omitempty"`
+}
+
+// multicall3Call mirrors the Multicall3.Call3 struct
+type multicall3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// multicall3Result mirrors the Multicall3.Result struct
+type multicall3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// GetTokenBalances gets BOGO and native CAM balances for multiple addresses.
+// Reads go through a single Multicall3 aggregate3 call when the aggregator is
+// configured, otherwise through JSON-RPC batch requests.
+func (s *BOGOWISDK) GetTokenBalances(ctx context.Context, addresses []string) ([]*AccountBalance, error) {
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no addresses provided")
+	}
+	if len(addresses) > MaxBalanceBatchSize {
+		return nil, fmt.Errorf("too many addresses: maximum is %d", MaxBalanceBatchSize)
+	}
+
+	if s.contracts == nil || s.contracts.BOGOToken == nil {
+		return nil, fmt.Errorf("BOGO token contract not initialized")
+	}
+
+	for _, address := range addresses {
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid address: %s", address)
+		}
+	}
+
+	ctx, cancel := s.readContext(ctx)
+	defer cancel()
+
+	if s.contracts.Multicall3 != nil {
+		return s.getTokenBalancesMulticall(ctx, addresses)
+	}
+	return s.getTokenBalancesBatch(ctx, addresses)
+}
+
+// getTokenBalancesMulticall reads all balances with one Multicall3 aggregate3 call
+func (s *BOGOWISDK) getTokenBalancesMulticall(ctx context.Context, addresses []string) ([]*AccountBalance, error) {
+	token := s.contracts.BOGOToken
+	multicall := s.contracts.Multicall3
+
+	calls := make([]multicall3Call, 0, 2*len(addresses))
+	for _, address := range addresses {
+		addr := common.HexToAddress(address)
+
+		balanceData, err := token.ABI.Pack("balanceOf", addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode balanceOf: %w", err)
+		}
+		nativeData, err := multicall.ABI.Pack("getEthBalance", addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode getEthBalance: %w", err)
+		}
+
+		calls = append(calls,
+			multicall3Call{Target: token.Address, AllowFailure: true, CallData: balanceData},
+			multicall3Call{Target: multicall.Address, AllowFailure: true, CallData: nativeData},
+		)
+	}
+
+	var out []interface{}
+	err := multicall.Instance.Call(
+		&bind.CallOpts{Context: ctx},
+		&out,
+		"aggregate3",
+		calls,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute multicall: %w", err)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("empty multicall response")
+	}
+
+	results := *abi.ConvertType(out[0], new([]multicall3Result)).(*[]multicall3Result)
+	if len(results) != len(calls) {
+		return nil, fmt.Errorf("expected %d multicall results, got %d", len(calls), len(results))
+	}
+
+	balances := make([]*AccountBalance, len(addresses))
+	for i, address := range addresses {
+		balance := &AccountBalance{Address: address}
+
+		tokenBalance, err := unpackUint256(token.ABI, "balanceOf", results[2*i])
+		if err != nil {
+			balance.Error = fmt.Sprintf("failed to get token balance: %v", err)
+		}
+		nativeBalance, err := unpackUint256(multicall.ABI, "getEthBalance", results[2*i+1])
+		if err != nil && balance.Error == "" {
+			balance.Error = fmt.Sprintf("failed to get native balance: %v", err)
+		}
+
+		if balance.Error == "" {
+			balance.Balance = formatEther(tokenBalance)
+			balance.NativeBalance = formatEther(nativeBalance)
+		}
+		balances[i] = balance
+	}
+
+	return balances, nil
+}
+
+// getTokenBalancesBatch reads all balances with JSON-RPC batch requests
+func (s *BOGOWISDK) getTokenBalancesBatch(ctx context.Context, addresses []string) ([]*AccountBalance, error) {
+	if s.rpc == nil {
+		return nil, fmt.Errorf("RPC batch client not initialized")
+	}
+
+	token := s.contracts.BOGOToken
+	tokenResults := make([]hexutil.Bytes, len(addresses))
+	nativeResults := make([]hexutil.Big, len(addresses))
+
+	elems := make([]rpc.BatchElem, 0, 2*len(addresses))
+	for i, address := range addresses {
+		addr := common.HexToAddress(address)
+
+		data, err := token.ABI.Pack("balanceOf", addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode balanceOf: %w", err)
+		}
+
+		callArgs := map[string]interface{}{
+			"to":   token.Address,
+			"data": hexutil.Bytes(data),
+		}
+
+		elems = append(elems,
+			rpc.BatchElem{Method: "eth_call", Args: []interface{}{callArgs, "latest"}, Result: &tokenResults[i]},
+			rpc.BatchElem{Method: "eth_getBalance", Args: []interface{}{addr, "latest"}, Result: &nativeResults[i]},
+		)
+	}
+
+	for start := 0; start < len(elems); start += maxRPCBatchItems {
+		end := start + maxRPCBatchItems
+		if end > len(elems) {
+			end = len(elems)
+		}
+		if err := s.rpc.BatchCallContext(ctx, elems[start:end]); err != nil {
+			return nil, fmt.Errorf("failed to execute batch request: %w", err)
+		}
+	}
+
+	balances := make([]*AccountBalance, len(addresses))
+	for i, address := range addresses {
+		balance := &AccountBalance{Address: address}
+
+		tokenElem, nativeElem := elems[2*i], elems[2*i+1]
+		switch {
+		case tokenElem.Error != nil:
+			balance.Error = fmt.Sprintf("failed to get token balance: %v", tokenElem.Error)
+		case nativeElem.Error != nil:
+			balance.Error = fmt.Sprintf("failed to get native balance: %v", nativeElem.Error)
+		default:
+			tokenBalance, err := unpackUint256(token.ABI, "balanceOf", multicall3Result{
+				Success:    true,
+				ReturnData: tokenResults[i],
+			})
+			if err != nil {
+				balance.Error = fmt.Sprintf("failed to get token balance: %v", err)
+				break
+			}
+			balance.Balance = formatEther(tokenBalance)
+			balance.NativeBalance = formatEther(nativeResults[i].ToInt())
+		}
+		balances[i] = balance
+	}
+
+	return balances, nil
+}
+
+// unpackUint256 decodes a single uint256 return value of a contract call
+func unpackUint256(contractABI abi.ABI, method string, result multicall3Result) (*big.Int, error) {
+	if !result.Success {
+		return nil, fmt.Errorf("%s reverted", method)
+	}
+
+	values, err := contractABI.Unpack(method, result.ReturnData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", method, err)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("empty %s result", method)
+	}
+
+	value, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s result type %T", method, values[0])
+	}
+	return value, nil
+}
@@ -0,0 +1,227 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBatchCaller answers eth_call and eth_getBalance batch elements
+type fakeBatchCaller struct {
+	tokenBalances  map[common.Address]*big.Int
+	nativeBalances map[common.Address]*big.Int
+	failAddress    common.Address
+	batchErr       error
+	batchSizes     []int
+}
+
+func (f *fakeBatchCaller) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	f.batchSizes = append(f.batchSizes, len(b))
+	if f.batchErr != nil {
+		return f.batchErr
+	}
+
+	uint256, _ := abi.NewType("uint256", "", nil)
+	args := abi.Arguments{{Type: uint256}}
+
+	for i := range b {
+		switch b[i].Method {
+		case "eth_call":
+			data := b[i].Args[0].(map[string]interface{})["data"].(hexutil.Bytes)
+			addr := common.BytesToAddress(data[4:36])
+			if addr == f.failAddress {
+				b[i].Error = errors.New("execution reverted")
+				continue
+			}
+			packed, _ := args.Pack(f.tokenBalances[addr])
+			*b[i].Result.(*hexutil.Bytes) = packed
+		case "eth_getBalance":
+			addr := b[i].Args[0].(common.Address)
+			*b[i].Result.(*hexutil.Big) = hexutil.Big(*f.nativeBalances[addr])
+		}
+	}
+	return nil
+}
+
+// fakeMulticallContract answers aggregate3 calls using fixed balances
+type fakeMulticallContract struct {
+	tokenABI       abi.ABI
+	multicallABI   abi.ABI
+	tokenBalances  map[common.Address]*big.Int
+	nativeBalances map[common.Address]*big.Int
+	failAddress    common.Address
+}
+
+func (f *fakeMulticallContract) Call(opts *bind.CallOpts, results *[]interface{}, method string, params ...interface{}) error {
+	calls := params[0].([]multicall3Call)
+
+	out := make([]struct {
+		Success    bool   `json:"success"`
+		ReturnData []byte `json:"returnData"`
+	}, len(calls))
+
+	for i, call := range calls {
+		addr := common.BytesToAddress(call.CallData[4:36])
+		if addr == f.failAddress {
+			continue
+		}
+
+		var err error
+		if i%2 == 0 {
+			out[i].ReturnData, err = f.tokenABI.Methods["balanceOf"].Outputs.Pack(f.tokenBalances[addr])
+		} else {
+			out[i].ReturnData, err = f.multicallABI.Methods["getEthBalance"].Outputs.Pack(f.nativeBalances[addr])
+		}
+		if err != nil {
+			return err
+		}
+		out[i].Success = true
+	}
+
+	*results = []interface{}{out}
+	return nil
+}
+
+func (f *fakeMulticallContract) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return nil, errors.New("not supported")
+}
+
+func TestGetTokenBalances_Validation(t *testing.T) {
+	s := &BOGOWISDK{contracts: &ContractInstances{}}
+
+	_, err := s.GetTokenBalances(context.Background(), nil)
+	assert.EqualError(t, err, "no addresses provided")
+
+	_, err = s.GetTokenBalances(context.Background(), make([]string, MaxBalanceBatchSize+1))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "too many addresses")
+
+	_, err = s.GetTokenBalances(context.Background(), []string{"0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2"})
+	assert.EqualError(t, err, "BOGO token contract not initialized")
+
+	s.contracts.BOGOToken = &Contract{ABI: mustParseABI(BOGOTokenABI)}
+	_, err = s.GetTokenBalances(context.Background(), []string{"not-an-address"})
+	assert.EqualError(t, err, "invalid address: not-an-address")
+}
+
+func TestGetTokenBalances_Batch(t *testing.T) {
+	addr1 := common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2")
+	addr2 := common.HexToAddress("0x1234567890123456789012345678901234567890")
+
+	caller := &fakeBatchCaller{
+		tokenBalances: map[common.Address]*big.Int{
+			addr1: big.NewInt(2000000000000000000),
+			addr2: big.NewInt(0),
+		},
+		nativeBalances: map[common.Address]*big.Int{
+			addr1: big.NewInt(500000000000000000),
+			addr2: big.NewInt(1000000000000000000),
+		},
+		failAddress: common.HexToAddress("0x00000000000000000000000000000000000000ff"),
+	}
+
+	s := &BOGOWISDK{
+		rpc: caller,
+		contracts: &ContractInstances{
+			BOGOToken: &Contract{
+				Address: common.HexToAddress("0xC53c2f11e1d2e36CB5888BfEE157F78e04Bb4F76"),
+				ABI:     mustParseABI(BOGOTokenABI),
+			},
+		},
+	}
+
+	t.Run("reads token and native balances", func(t *testing.T) {
+		balances, err := s.GetTokenBalances(context.Background(), []string{addr1.Hex(), addr2.Hex()})
+		require.NoError(t, err)
+		require.Len(t, balances, 2)
+
+		assert.Equal(t, addr1.Hex(), balances[0].Address)
+		assert.Equal(t, "2", balances[0].Balance)
+		assert.Equal(t, "0.5", balances[0].NativeBalance)
+		assert.Empty(t, balances[0].Error)
+
+		assert.Equal(t, "0", balances[1].Balance)
+		assert.Equal(t, "1", balances[1].NativeBalance)
+	})
+
+	t.Run("reports per-address errors", func(t *testing.T) {
+		caller.nativeBalances[caller.failAddress] = big.NewInt(0)
+		balances, err := s.GetTokenBalances(context.Background(), []string{addr1.Hex(), caller.failAddress.Hex()})
+		require.NoError(t, err)
+		assert.Empty(t, balances[0].Error)
+		assert.Contains(t, balances[1].Error, "execution reverted")
+		assert.Empty(t, balances[1].Balance)
+	})
+
+	t.Run("splits large batches", func(t *testing.T) {
+		caller.batchSizes = nil
+		addresses := make([]string, MaxBalanceBatchSize)
+		for i := range addresses {
+			addresses[i] = addr1.Hex()
+		}
+		_, err := s.GetTokenBalances(context.Background(), addresses)
+		require.NoError(t, err)
+		assert.Equal(t, []int{maxRPCBatchItems, maxRPCBatchItems}, caller.batchSizes)
+	})
+
+	t.Run("batch failure", func(t *testing.T) {
+		caller.batchErr = errors.New("connection refused")
+		defer func() { caller.batchErr = nil }()
+
+		_, err := s.GetTokenBalances(context.Background(), []string{addr1.Hex()})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "connection refused")
+	})
+}
+
+func TestGetTokenBalances_Multicall(t *testing.T) {
+	addr1 := common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2")
+	failAddr := common.HexToAddress("0x00000000000000000000000000000000000000ff")
+
+	tokenABI := mustParseABI(BOGOTokenABI)
+	multicallABI := mustParseABI(Multicall3ABI)
+
+	fake := &fakeMulticallContract{
+		tokenABI:       tokenABI,
+		multicallABI:   multicallABI,
+		tokenBalances:  map[common.Address]*big.Int{addr1: big.NewInt(3000000000000000000)},
+		nativeBalances: map[common.Address]*big.Int{addr1: big.NewInt(250000000000000000)},
+		failAddress:    failAddr,
+	}
+
+	// A batch caller that fails proves the multicall path is preferred
+	s := &BOGOWISDK{
+		rpc: &fakeBatchCaller{batchErr: errors.New("should not be called")},
+		contracts: &ContractInstances{
+			BOGOToken: &Contract{
+				Address: common.HexToAddress("0xC53c2f11e1d2e36CB5888BfEE157F78e04Bb4F76"),
+				ABI:     tokenABI,
+			},
+			Multicall3: &Contract{
+				Address:  common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11"),
+				ABI:      multicallABI,
+				Instance: fake,
+			},
+		},
+	}
+
+	balances, err := s.GetTokenBalances(context.Background(), []string{addr1.Hex(), failAddr.Hex()})
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+
+	assert.Equal(t, "3", balances[0].Balance)
+	assert.Equal(t, "0.25", balances[0].NativeBalance)
+	assert.Empty(t, balances[0].Error)
+
+	assert.Contains(t, balances[1].Error, "balanceOf reverted")
+}
@@ -0,0 +1,131 @@
+package events
+
+import (
+	"fmt"
+
+	"bogowi-blockchain-go/internal/sdk/contracts"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Names of the BOGOWITickets events
+const (
+	TicketMinted           = "TicketMinted"
+	TicketRedeemed         = "TicketRedeemed"
+	TicketBurned           = "TicketBurned"
+	TicketExpired          = "TicketExpired"
+	Transfer               = "Transfer"
+	TransferUnlockUpdated  = "TransferUnlockUpdated"
+	NonceUsed              = "NonceUsed"
+	Approval               = "Approval"
+	ApprovalForAll         = "ApprovalForAll"
+	BatchMintStarted       = "BatchMintStarted"
+	BaseURIUpdated         = "BaseURIUpdated"
+	MetadataUpdate         = "MetadataUpdate"
+	BatchMetadataUpdate    = "BatchMetadataUpdate"
+	DatakyteMetadataLinked = "DatakyteMetadataLinked"
+	RoyaltyInfoUpdated     = "RoyaltyInfoUpdated"
+	RoleManagerSet         = "RoleManagerSet"
+	EIP712DomainChanged    = "EIP712DomainChanged"
+	Paused                 = "Paused"
+	Unpaused               = "Unpaused"
+)
+
+// Event is a decoded log of the ticket contract
+type Event struct {
+	Network string
+	// Name is the event name, empty for a log the contract ABI does not describe
+	Name string
+	// Data is the event decoded by the contract bindings, e.g.
+	// *contracts.BOGOWITicketsTicketMinted; nil when Name is empty
+	Data interface{}
+	// Log is the raw log. Removed is set when a reorg dropped a log that
+	// was delivered before.
+	Log types.Log
+}
+
+type parseFunc func(log types.Log) (interface{}, error)
+
+// parser adapts a generated Parse method
+func parser[T any](parse func(log types.Log) (*T, error)) parseFunc {
+	return func(log types.Log) (interface{}, error) {
+		return parse(log)
+	}
+}
+
+// Decoder decodes ticket contract logs with the Parse methods of the
+// generated bindings
+type Decoder struct {
+	names   map[common.Hash]string
+	parsers map[common.Hash]parseFunc
+}
+
+// NewDecoder creates a decoder for the BOGOWITickets events
+func NewDecoder() (*Decoder, error) {
+	parsed, err := contracts.BOGOWITicketsMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tickets ABI: %w", err)
+	}
+	filterer, err := contracts.NewBOGOWITicketsFilterer(common.Address{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind tickets contract: %w", err)
+	}
+
+	parsers := map[string]parseFunc{
+		TicketMinted:           parser(filterer.ParseTicketMinted),
+		TicketRedeemed:         parser(filterer.ParseTicketRedeemed),
+		TicketBurned:           parser(filterer.ParseTicketBurned),
+		TicketExpired:          parser(filterer.ParseTicketExpired),
+		Transfer:               parser(filterer.ParseTransfer),
+		TransferUnlockUpdated:  parser(filterer.ParseTransferUnlockUpdated),
+		NonceUsed:              parser(filterer.ParseNonceUsed),
+		Approval:               parser(filterer.ParseApproval),
+		ApprovalForAll:         parser(filterer.ParseApprovalForAll),
+		BatchMintStarted:       parser(filterer.ParseBatchMintStarted),
+		BaseURIUpdated:         parser(filterer.ParseBaseURIUpdated),
+		MetadataUpdate:         parser(filterer.ParseMetadataUpdate),
+		BatchMetadataUpdate:    parser(filterer.ParseBatchMetadataUpdate),
+		DatakyteMetadataLinked: parser(filterer.ParseDatakyteMetadataLinked),
+		RoyaltyInfoUpdated:     parser(filterer.ParseRoyaltyInfoUpdated),
+		RoleManagerSet:         parser(filterer.ParseRoleManagerSet),
+		EIP712DomainChanged:    parser(filterer.ParseEIP712DomainChanged),
+		Paused:                 parser(filterer.ParsePaused),
+		Unpaused:               parser(filterer.ParseUnpaused),
+	}
+
+	d := &Decoder{
+		names:   make(map[common.Hash]string, len(parsers)),
+		parsers: make(map[common.Hash]parseFunc, len(parsers)),
+	}
+	for name, parse := range parsers {
+		event, ok := parsed.Events[name]
+		if !ok {
+			return nil, fmt.Errorf("event %s is missing from the tickets ABI", name)
+		}
+		d.names[event.ID] = name
+		d.parsers[event.ID] = parse
+	}
+	return d, nil
+}
+
+// Decode decodes a log. A log without a known event is returned without a
+// name or data.
+func (d *Decoder) Decode(log types.Log) (Event, error) {
+	ev := Event{Log: log}
+	if len(log.Topics) == 0 {
+		return ev, nil
+	}
+	parse, ok := d.parsers[log.Topics[0]]
+	if !ok {
+		return ev, nil
+	}
+
+	data, err := parse(log)
+	if err != nil {
+		return ev, fmt.Errorf("failed to decode %s: %w", d.names[log.Topics[0]], err)
+	}
+	ev.Name = d.names[log.Topics[0]]
+	ev.Data = data
+	return ev, nil
+}
@@ -0,0 +1,20 @@
+package events
+
+// Finality of indexed records
+const (
+	// Confirmed means the record's block is buried deep enough that a reorg
+	// is not expected to remove it
+	Confirmed = "confirmed"
+	// Unconfirmed means a reorg may still remove or change the record
+	Unconfirmed = "unconfirmed"
+)
+
+// Finality returns whether a record from block is confirmed at head when
+// blocks become final at depth confirmations. A block counts as its own
+// first confirmation; depth 0 treats every mined block as final.
+func Finality(block, head, depth uint64) string {
+	if block == 0 || head < block || head-block+1 < depth {
+		return Unconfirmed
+	}
+	return Confirmed
+}
@@ -0,0 +1,561 @@
+// Package events delivers the logs of the ticket contract to internal
+// consumers. When a WebSocket endpoint is configured logs arrive through a
+// subscription as they are mined; otherwise, and whenever the subscription
+// drops, new blocks are polled with eth_getLogs over HTTP. Every
+// (re)subscription first backfills the blocks mined since the last delivered
+// one, so consumers see each log once and in order.
+//
+// Polling checks that each new block builds on the last one delivered. When
+// the chain was reorganised, the manager walks back to the newest block
+// that is still canonical, tells the rollback handlers, and delivers the
+// logs of the replacement blocks.
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/big"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Delivery modes
+const (
+	// ModeSubscription means logs arrive through a WebSocket subscription
+	ModeSubscription = "subscription"
+	// ModePolling means new blocks are polled over HTTP
+	ModePolling = "polling"
+)
+
+const (
+	// DefaultPollInterval is how often new blocks are polled
+	DefaultPollInterval = 5 * time.Second
+	// DefaultMaxRange caps the blocks of a single eth_getLogs call
+	DefaultMaxRange = 2000
+	// DefaultResubscribeDelay is the minimum time between subscription attempts
+	DefaultResubscribeDelay = 10 * time.Second
+)
+
+// logBuffer holds live logs that arrive while a backfill runs
+const logBuffer = 256
+
+// reorgWindow is how many recent block hashes are kept to find the common
+// ancestor of a reorg. Deeper reorgs roll back to the oldest known block.
+const reorgWindow = 256
+
+// Client reads logs over HTTP, typically the failover client of the network
+type Client interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// Subscriber opens log subscriptions, typically an ethclient dialed over
+// WebSocket
+type Subscriber interface {
+	SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error)
+	Close()
+}
+
+// Checkpoint is the last block whose logs were all delivered
+type Checkpoint struct {
+	Block uint64
+	// Hash is the hash of the block, zero when unknown
+	Hash common.Hash
+}
+
+// Cursor persists the checkpoint, so delivery resumes after it when the
+// process restarts and a reorg of the checkpoint block is noticed
+type Cursor interface {
+	LoadCursor(ctx context.Context) (checkpoint Checkpoint, ok bool, err error)
+	SaveCursor(ctx context.Context, checkpoint Checkpoint) error
+}
+
+// RollbackHandler is called when the blocks after ancestor were replaced by
+// a reorg. The logs of the replacement blocks are delivered afterwards.
+type RollbackHandler func(ctx context.Context, ancestor uint64)
+
+// Handler consumes events. Handlers run one at a time, in log order, on the
+// manager's goroutine, so slow work should be handed off.
+type Handler func(ctx context.Context, ev Event)
+
+// Config configures a Manager. Zero durations use the defaults.
+type Config struct {
+	Network  string
+	Contract common.Address
+	// WSURL is a ws:// or wss:// endpoint for log subscriptions; empty polls
+	WSURL string
+	// FromBlock is the first block whose logs are delivered when no cursor
+	// is stored; zero starts with the blocks mined after Run is called
+	FromBlock        uint64
+	PollInterval     time.Duration
+	MaxRange         uint64
+	ResubscribeDelay time.Duration
+}
+
+// Status reports how events are delivered
+type Status struct {
+	Mode string `json:"mode"`
+	// Block is the last block whose logs were all delivered
+	Block         uint64     `json:"block"`
+	Subscriptions int        `json:"subscriptions"`
+	Reorgs        int        `json:"reorgs"`
+	LastEventAt   *time.Time `json:"lastEventAt,omitempty"`
+	LastError     string     `json:"lastError,omitempty"`
+}
+
+// position orders logs within the chain
+type position struct {
+	block uint64
+	index uint
+}
+
+func (p position) before(other position) bool {
+	return p.block < other.block || (p.block == other.block && p.index < other.index)
+}
+
+// Manager follows the logs of the ticket contract and fans them out to the
+// registered handlers
+type Manager struct {
+	cfg     Config
+	decoder *Decoder
+	dial    func(ctx context.Context, rawURL string) (Subscriber, error)
+
+	cursor Cursor
+
+	mu        sync.Mutex
+	handlers  []Handler
+	rollbacks []RollbackHandler
+	status    Status
+
+	// synced is the last block whose logs were all delivered and last the
+	// last log delivered, which may be ahead of synced while subscribed.
+	// hashes holds the known hashes of recent blocks. All are owned by the
+	// Run goroutine.
+	synced uint64
+	last   position
+	hashes map[uint64]common.Hash
+}
+
+// New creates a manager; it delivers nothing until Run is called
+func New(cfg Config) (*Manager, error) {
+	if cfg.WSURL != "" {
+		u, err := url.Parse(cfg.WSURL)
+		if err != nil || (u.Scheme != "ws" && u.Scheme != "wss") || u.Host == "" {
+			return nil, fmt.Errorf("event subscriptions need a ws:// or wss:// URL")
+		}
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.MaxRange == 0 {
+		cfg.MaxRange = DefaultMaxRange
+	}
+	if cfg.ResubscribeDelay <= 0 {
+		cfg.ResubscribeDelay = DefaultResubscribeDelay
+	}
+
+	decoder, err := NewDecoder()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		cfg:     cfg,
+		decoder: decoder,
+		dial:    dialWebSocket,
+		status:  Status{Mode: ModePolling},
+		hashes:  make(map[uint64]common.Hash),
+	}, nil
+}
+
+func dialWebSocket(ctx context.Context, rawURL string) (Subscriber, error) {
+	return ethclient.DialContext(ctx, rawURL)
+}
+
+// Network returns the network the manager follows
+func (m *Manager) Network() string {
+	return m.cfg.Network
+}
+
+// Contract returns the address of the contract the manager follows
+func (m *Manager) Contract() common.Address {
+	return m.cfg.Contract
+}
+
+// OnEvent registers a handler for every event delivered from now on
+func (m *Manager) OnEvent(handler Handler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers = append(m.handlers, handler)
+}
+
+// OnRollback registers a handler for reorgs noticed from now on
+func (m *Manager) OnRollback(handler RollbackHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rollbacks = append(m.rollbacks, handler)
+}
+
+// SetCursor makes the manager resume after the block stored in cursor and
+// keep it up to date. It must be called before Run.
+func (m *Manager) SetCursor(cursor Cursor) {
+	m.cursor = cursor
+}
+
+// Status reports how events are delivered
+func (m *Manager) Status() Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
+
+// Run delivers events until ctx is done. While a WebSocket subscription is
+// live it delivers logs as they arrive; otherwise it polls, and tries to
+// subscribe again every ResubscribeDelay.
+func (m *Manager) Run(ctx context.Context, client Client) {
+	if !m.start(ctx, client) {
+		return
+	}
+
+	ticker := time.NewTicker(m.cfg.PollInterval)
+	defer ticker.Stop()
+
+	var subscribed time.Time
+	for {
+		if m.cfg.WSURL != "" && time.Since(subscribed) >= m.cfg.ResubscribeDelay {
+			subscribed = time.Now()
+			err := m.subscribe(ctx, client)
+			if ctx.Err() != nil {
+				return
+			}
+			m.setError(err)
+			log.Printf("events: %s subscription down, polling: %v", m.cfg.Network, err)
+		}
+
+		if err := m.poll(ctx, client); err != nil && ctx.Err() == nil {
+			m.setError(err)
+			log.Printf("events: %s poll failed: %v", m.cfg.Network, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// start sets the block delivery begins after, retrying until it is known.
+// It returns false when ctx is done first.
+func (m *Manager) start(ctx context.Context, client Client) bool {
+	for {
+		checkpoint, err := m.startBlock(ctx, client)
+		if err == nil {
+			m.setSynced(ctx, checkpoint.Block, checkpoint.Hash)
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+		m.setError(err)
+		log.Printf("events: %s failed to start: %v", m.cfg.Network, err)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(m.cfg.PollInterval):
+		}
+	}
+}
+
+// startBlock returns the checkpoint stored in the cursor, the block before
+// FromBlock, or the current head, in that order
+func (m *Manager) startBlock(ctx context.Context, client Client) (Checkpoint, error) {
+	if m.cursor != nil {
+		checkpoint, ok, err := m.cursor.LoadCursor(ctx)
+		if err != nil {
+			return Checkpoint{}, fmt.Errorf("failed to load cursor: %w", err)
+		}
+		if ok {
+			return checkpoint, nil
+		}
+	}
+	if m.cfg.FromBlock > 0 {
+		return Checkpoint{Block: m.cfg.FromBlock - 1}, nil
+	}
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("failed to get block number: %w", err)
+	}
+	return Checkpoint{Block: head}, nil
+}
+
+// subscribe delivers logs through a WebSocket subscription until it drops.
+// The subscription is opened before the gap since the last delivered block
+// is backfilled, so no log falls between the two.
+func (m *Manager) subscribe(ctx context.Context, client Client) error {
+	subscriber, err := m.dial(ctx, m.cfg.WSURL)
+	if err != nil {
+		return fmt.Errorf("failed to dial: %w", err)
+	}
+	defer subscriber.Close()
+
+	logs := make(chan types.Log, logBuffer)
+	sub, err := subscriber.SubscribeFilterLogs(ctx, m.query(0, 0), logs)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	if err := m.poll(ctx, client); err != nil {
+		return fmt.Errorf("failed to backfill: %w", err)
+	}
+
+	m.mu.Lock()
+	m.status.Mode = ModeSubscription
+	m.status.Subscriptions++
+	m.status.LastError = ""
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.status.Mode = ModePolling
+		m.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			if err == nil {
+				err = errors.New("subscription closed")
+			}
+			return err
+		case l := <-logs:
+			m.deliver(ctx, l)
+			// Logs arrive in block order, so earlier blocks are complete
+			if !l.Removed && l.BlockNumber > 0 && l.BlockNumber-1 > m.synced {
+				m.setSynced(ctx, l.BlockNumber-1, m.hashes[l.BlockNumber-1])
+			}
+		}
+	}
+}
+
+// poll delivers the logs of every block mined since the last delivered one,
+// rolling back first when the last delivered block was reorganised
+func (m *Manager) poll(ctx context.Context, client Client) error {
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block number: %w", err)
+	}
+	if head <= m.synced {
+		return nil
+	}
+
+	if err := m.checkReorg(ctx, client); err != nil {
+		return err
+	}
+
+	for from := m.synced + 1; from <= head; {
+		to := head
+		if head-from >= m.cfg.MaxRange {
+			to = from + m.cfg.MaxRange - 1
+		}
+
+		header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(to))
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %w", to, err)
+		}
+		logs, err := client.FilterLogs(ctx, m.query(from, to))
+		if err != nil {
+			return fmt.Errorf("failed to get logs of blocks %d-%d: %w", from, to, err)
+		}
+		// Logs from another version of the last block mean a reorg raced
+		// the query; the next poll notices it
+		for _, l := range logs {
+			if l.BlockNumber == to && l.BlockHash != header.Hash() {
+				return fmt.Errorf("block %d changed while its logs were read", to)
+			}
+		}
+
+		for _, l := range logs {
+			m.deliver(ctx, l)
+		}
+		m.setSynced(ctx, to, header.Hash())
+		from = to + 1
+	}
+	return nil
+}
+
+// checkReorg rolls back to the common ancestor when the block after the
+// last delivered one does not build on it
+func (m *Manager) checkReorg(ctx context.Context, client Client) error {
+	known, ok := m.hashes[m.synced]
+	if !ok {
+		return nil
+	}
+
+	next, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(m.synced+1))
+	if err != nil {
+		return fmt.Errorf("failed to get block %d: %w", m.synced+1, err)
+	}
+	if next.ParentHash == known {
+		return nil
+	}
+
+	// Blocks before a canonical block are canonical too, so the newest
+	// known block whose hash is unchanged is the common ancestor
+	ancestor := uint64(0)
+	if m.synced > reorgWindow {
+		ancestor = m.synced - reorgWindow
+	}
+	for block := m.synced; block > ancestor; block-- {
+		hash, ok := m.hashes[block]
+		if !ok {
+			continue
+		}
+		header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(block))
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %w", block, err)
+		}
+		if header.Hash() == hash {
+			ancestor = block
+			break
+		}
+	}
+
+	log.Printf("events: %s block %d was reorganised, rolling back to block %d", m.cfg.Network, m.synced, ancestor)
+	m.rollback(ctx, ancestor)
+	return nil
+}
+
+// rollback forgets every block after ancestor and tells the rollback
+// handlers, so the replacement blocks are delivered again
+func (m *Manager) rollback(ctx context.Context, ancestor uint64) {
+	for block := range m.hashes {
+		if block > ancestor {
+			delete(m.hashes, block)
+		}
+	}
+	if m.synced > ancestor {
+		m.synced = ancestor
+	}
+	m.last = position{block: ancestor, index: math.MaxUint}
+
+	m.mu.Lock()
+	m.status.Block = m.synced
+	m.status.Reorgs++
+	rollbacks := append([]RollbackHandler(nil), m.rollbacks...)
+	m.mu.Unlock()
+
+	m.saveCursor(ctx)
+	for _, handler := range rollbacks {
+		handler(ctx, ancestor)
+	}
+}
+
+// deliver decodes a log and hands it to every handler, skipping logs that
+// were delivered before
+func (m *Manager) deliver(ctx context.Context, l types.Log) {
+	if l.Removed {
+		// The block is gone; its replacement must be delivered again
+		if l.BlockNumber <= m.last.block && l.BlockNumber > 0 {
+			m.rollback(ctx, l.BlockNumber-1)
+		}
+	} else {
+		pos := position{block: l.BlockNumber, index: l.Index}
+		if l.BlockNumber <= m.synced || !m.last.before(pos) {
+			return
+		}
+		m.last = pos
+		if l.BlockHash != (common.Hash{}) {
+			m.hashes[l.BlockNumber] = l.BlockHash
+		}
+	}
+
+	ev, err := m.decoder.Decode(l)
+	if err != nil {
+		log.Printf("events: %s skipping log %d of tx %s: %v", m.cfg.Network, l.Index, l.TxHash.Hex(), err)
+		return
+	}
+	ev.Network = m.cfg.Network
+
+	now := time.Now().UTC()
+	m.mu.Lock()
+	handlers := append([]Handler(nil), m.handlers...)
+	m.status.LastEventAt = &now
+	m.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(ctx, ev)
+	}
+}
+
+// query filters the logs of the contract; zero bounds leave the range open
+func (m *Manager) query(from, to uint64) ethereum.FilterQuery {
+	q := ethereum.FilterQuery{Addresses: []common.Address{m.cfg.Contract}}
+	if from > 0 {
+		q.FromBlock = new(big.Int).SetUint64(from)
+	}
+	if to > 0 {
+		q.ToBlock = new(big.Int).SetUint64(to)
+	}
+	return q
+}
+
+// setSynced records that the logs of every block up to block were
+// delivered; hash is the hash of block, zero when unknown
+func (m *Manager) setSynced(ctx context.Context, block uint64, hash common.Hash) {
+	m.synced = block
+	if m.last.block < block {
+		m.last = position{block: block, index: math.MaxUint}
+	}
+	if hash != (common.Hash{}) {
+		m.hashes[block] = hash
+	}
+	if block > reorgWindow {
+		for known := range m.hashes {
+			if known < block-reorgWindow {
+				delete(m.hashes, known)
+			}
+		}
+	}
+
+	m.mu.Lock()
+	m.status.Block = block
+	m.mu.Unlock()
+
+	m.saveCursor(ctx)
+}
+
+// saveCursor stores the checkpoint. A failed save only means some logs are
+// delivered again after a restart.
+func (m *Manager) saveCursor(ctx context.Context) {
+	if m.cursor == nil {
+		return
+	}
+	checkpoint := Checkpoint{Block: m.synced, Hash: m.hashes[m.synced]}
+	if err := m.cursor.SaveCursor(ctx, checkpoint); err != nil && ctx.Err() == nil {
+		log.Printf("events: %s failed to save cursor at block %d: %v", m.cfg.Network, m.synced, err)
+	}
+}
+
+func (m *Manager) setError(err error) {
+	if err == nil {
+		return
+	}
+	m.mu.Lock()
+	m.status.LastError = err.Error()
+	m.mu.Unlock()
+}
@@ -0,0 +1,465 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"bogowi-blockchain-go/internal/sdk/contracts"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var ticketsAddress = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+// chain is an in-memory HTTP client holding the contract logs
+type chain struct {
+	mu      sync.Mutex
+	head    uint64
+	headers []*types.Header
+	logs    []types.Log
+	queries []ethereum.FilterQuery
+	forks   byte
+	err     error
+}
+
+func (c *chain) BlockNumber(ctx context.Context) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.head, c.err
+}
+
+func (c *chain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.genesis()
+	if number.Uint64() > c.head {
+		return nil, errors.New("not found")
+	}
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *chain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.queries = append(c.queries, q)
+	var out []types.Log
+	for _, l := range c.logs {
+		if l.BlockNumber >= q.FromBlock.Uint64() && l.BlockNumber <= q.ToBlock.Uint64() {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+// mine adds a block with the given logs
+func (c *chain) mine(logs ...types.Log) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.genesis()
+	c.head++
+	header := &types.Header{
+		Number:     new(big.Int).SetUint64(c.head),
+		ParentHash: c.headers[c.head-1].Hash(),
+		Extra:      []byte{c.forks},
+	}
+	c.headers = append(c.headers, header)
+	for i := range logs {
+		logs[i].BlockNumber = c.head
+		logs[i].BlockHash = header.Hash()
+		logs[i].Index = uint(i)
+	}
+	c.logs = append(c.logs, logs...)
+	return c.head
+}
+
+// reorg drops the blocks after ancestor, so the next mined blocks replace them
+func (c *chain) reorg(ancestor uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.head = ancestor
+	c.headers = c.headers[:ancestor+1]
+	c.forks++
+	kept := c.logs[:0]
+	for _, l := range c.logs {
+		if l.BlockNumber <= ancestor {
+			kept = append(kept, l)
+		}
+	}
+	c.logs = kept
+}
+
+func (c *chain) genesis() {
+	if len(c.headers) == 0 {
+		c.headers = []*types.Header{{Number: new(big.Int)}}
+	}
+}
+
+// socket is a WebSocket subscriber whose subscription the test drives
+type socket struct {
+	mu   sync.Mutex
+	ch   chan<- types.Log
+	errc chan error
+}
+
+func (s *socket) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ch = ch
+	s.errc = make(chan error, 1)
+	return &subscription{errc: s.errc}, nil
+}
+
+func (s *socket) Close() {}
+
+func (s *socket) send(l types.Log) {
+	s.mu.Lock()
+	ch := s.ch
+	s.mu.Unlock()
+	ch <- l
+}
+
+func (s *socket) drop(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errc <- err
+	s.ch = nil
+}
+
+func (s *socket) live() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ch != nil
+}
+
+type subscription struct{ errc chan error }
+
+func (s *subscription) Unsubscribe()      {}
+func (s *subscription) Err() <-chan error { return s.errc }
+
+// collector records delivered events
+type collector struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (c *collector) handle(ctx context.Context, ev Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, ev)
+}
+
+func (c *collector) tokenIDs() []int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids := make([]int64, 0, len(c.events))
+	for _, ev := range c.events {
+		if transfer, ok := ev.Data.(*contracts.BOGOWITicketsTransfer); ok {
+			ids = append(ids, transfer.TokenId.Int64())
+		}
+	}
+	return ids
+}
+
+func transferLog(tokenID int64) types.Log {
+	parsed, _ := contracts.BOGOWITicketsMetaData.GetAbi()
+	return types.Log{
+		Address: ticketsAddress,
+		Topics: []common.Hash{
+			parsed.Events[Transfer].ID,
+			common.Hash{},
+			common.BytesToHash(common.HexToAddress("0x00000000000000000000000000000000000000bb").Bytes()),
+			common.BigToHash(big.NewInt(tokenID)),
+		},
+	}
+}
+
+func newTestManager(t *testing.T, cfg Config) (*Manager, *collector) {
+	t.Helper()
+	cfg.Network = "testnet"
+	cfg.Contract = ticketsAddress
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = 5 * time.Millisecond
+	}
+	m, err := New(cfg)
+	require.NoError(t, err)
+	c := &collector{}
+	m.OnEvent(c.handle)
+	return m, c
+}
+
+func runManager(t *testing.T, m *Manager, client Client) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.Run(ctx, client)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func TestDecodeTransfer(t *testing.T) {
+	d, err := NewDecoder()
+	require.NoError(t, err)
+
+	ev, err := d.Decode(transferLog(7))
+	require.NoError(t, err)
+	assert.Equal(t, Transfer, ev.Name)
+	transfer, ok := ev.Data.(*contracts.BOGOWITicketsTransfer)
+	require.True(t, ok)
+	assert.Equal(t, int64(7), transfer.TokenId.Int64())
+	assert.Equal(t, common.HexToAddress("0x00000000000000000000000000000000000000bb"), transfer.To)
+
+	unknown, err := d.Decode(types.Log{Topics: []common.Hash{common.HexToHash("0x01")}})
+	require.NoError(t, err)
+	assert.Empty(t, unknown.Name)
+	assert.Nil(t, unknown.Data)
+
+	// A known event with malformed topics is reported
+	bad := transferLog(7)
+	bad.Topics = bad.Topics[:2]
+	_, err = d.Decode(bad)
+	assert.Error(t, err)
+}
+
+func TestPollingDeliversInRanges(t *testing.T) {
+	c := &chain{}
+	for i := int64(1); i <= 5; i++ {
+		c.mine(transferLog(i))
+	}
+
+	m, got := newTestManager(t, Config{FromBlock: 2, MaxRange: 2})
+	require.True(t, m.start(context.Background(), c))
+	require.NoError(t, m.poll(context.Background(), c))
+
+	assert.Equal(t, []int64{2, 3, 4, 5}, got.tokenIDs())
+	require.Len(t, c.queries, 2)
+	assert.Equal(t, uint64(2), c.queries[0].FromBlock.Uint64())
+	assert.Equal(t, uint64(3), c.queries[0].ToBlock.Uint64())
+	assert.Equal(t, uint64(4), c.queries[1].FromBlock.Uint64())
+	assert.Equal(t, uint64(5), c.queries[1].ToBlock.Uint64())
+	assert.Equal(t, uint64(5), m.Status().Block)
+	assert.Equal(t, "testnet", got.events[0].Network)
+
+	// Nothing new, nothing delivered
+	require.NoError(t, m.poll(context.Background(), c))
+	assert.Len(t, got.tokenIDs(), 4)
+}
+
+func TestRunStartsAtHead(t *testing.T) {
+	c := &chain{}
+	c.mine(transferLog(1))
+
+	m, got := newTestManager(t, Config{})
+	runManager(t, m, c)
+
+	require.Eventually(t, func() bool { return m.Status().Block == 1 }, time.Second, time.Millisecond)
+	c.mine(transferLog(2))
+	require.Eventually(t, func() bool { return len(got.tokenIDs()) == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, []int64{2}, got.tokenIDs())
+	assert.Equal(t, ModePolling, m.Status().Mode)
+}
+
+// memoryCursor is a cursor kept in memory
+type memoryCursor struct {
+	mu         sync.Mutex
+	checkpoint Checkpoint
+	ok         bool
+}
+
+func (c *memoryCursor) LoadCursor(ctx context.Context) (Checkpoint, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.checkpoint, c.ok, nil
+}
+
+func (c *memoryCursor) SaveCursor(ctx context.Context, checkpoint Checkpoint) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checkpoint, c.ok = checkpoint, true
+	return nil
+}
+
+func (c *memoryCursor) get() Checkpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.checkpoint
+}
+
+func TestRunResumesFromCursor(t *testing.T) {
+	c := &chain{}
+	for i := int64(1); i <= 4; i++ {
+		c.mine(transferLog(i))
+	}
+
+	// The cursor wins over FromBlock
+	cursor := &memoryCursor{checkpoint: Checkpoint{Block: 2}, ok: true}
+	m, got := newTestManager(t, Config{FromBlock: 1})
+	m.SetCursor(cursor)
+	runManager(t, m, c)
+
+	require.Eventually(t, func() bool { return len(got.tokenIDs()) == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, []int64{3, 4}, got.tokenIDs())
+	require.Eventually(t, func() bool { return cursor.get().Block == 4 }, time.Second, time.Millisecond)
+	assert.Equal(t, c.headers[4].Hash(), cursor.get().Hash)
+}
+
+func TestPollingRollsBackReorgs(t *testing.T) {
+	c := &chain{}
+	for i := int64(1); i <= 4; i++ {
+		c.mine(transferLog(i))
+	}
+
+	cursor := &memoryCursor{}
+	m, got := newTestManager(t, Config{FromBlock: 1})
+	m.SetCursor(cursor)
+	var rollbacks []uint64
+	m.OnRollback(func(ctx context.Context, ancestor uint64) {
+		rollbacks = append(rollbacks, ancestor)
+	})
+	require.True(t, m.start(context.Background(), c))
+	require.NoError(t, m.poll(context.Background(), c))
+	assert.Equal(t, []int64{1, 2, 3, 4}, got.tokenIDs())
+
+	// Blocks 3 and 4 are replaced by a longer fork
+	c.reorg(2)
+	c.mine(transferLog(13))
+	c.mine()
+	c.mine(transferLog(15))
+	require.NoError(t, m.poll(context.Background(), c))
+
+	assert.Equal(t, []uint64{2}, rollbacks)
+	assert.Equal(t, []int64{1, 2, 3, 4, 13, 15}, got.tokenIDs())
+	assert.Equal(t, 1, m.Status().Reorgs)
+	assert.Equal(t, uint64(5), m.Status().Block)
+	assert.Equal(t, Checkpoint{Block: 5, Hash: c.headers[5].Hash()}, cursor.get())
+
+	// A reorg of blocks without logs rolls back to the last known block
+	c.reorg(4)
+	c.mine()
+	c.mine()
+	require.NoError(t, m.poll(context.Background(), c))
+	assert.Equal(t, []uint64{2, 3}, rollbacks)
+	assert.Equal(t, []int64{1, 2, 3, 4, 13, 15}, got.tokenIDs())
+	assert.Equal(t, 2, m.Status().Reorgs)
+}
+
+func TestFinality(t *testing.T) {
+	tests := []struct {
+		name  string
+		block uint64
+		head  uint64
+		depth uint64
+		want  string
+	}{
+		{"buried deep enough", 10, 15, 6, Confirmed},
+		{"one confirmation short", 10, 14, 6, Unconfirmed},
+		{"head block without depth", 10, 10, 0, Confirmed},
+		{"head behind block", 10, 9, 1, Unconfirmed},
+		{"not mined", 0, 100, 1, Unconfirmed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Finality(tt.block, tt.head, tt.depth))
+		})
+	}
+}
+
+func TestSubscriptionBackfillsAfterDisconnect(t *testing.T) {
+	c := &chain{}
+	ws := &socket{}
+
+	m, got := newTestManager(t, Config{
+		FromBlock:        1,
+		WSURL:            "wss://rpc.example.com/ws",
+		ResubscribeDelay: 20 * time.Millisecond,
+	})
+	m.dial = func(ctx context.Context, rawURL string) (Subscriber, error) {
+		return ws, nil
+	}
+
+	// Mined before the subscription: backfilled
+	c.mine(transferLog(1))
+	runManager(t, m, c)
+	require.Eventually(t, func() bool { return m.Status().Mode == ModeSubscription }, time.Second, time.Millisecond)
+
+	// Live logs, including one the backfill already delivered
+	ws.send(c.logs[0])
+	live := transferLog(2)
+	c.mine(live)
+	live.BlockNumber = 2
+	ws.send(live)
+	require.Eventually(t, func() bool { return len(got.tokenIDs()) == 2 }, time.Second, time.Millisecond)
+
+	// Blocks mined while the socket is dead never arrive on it; they are
+	// backfilled when the subscription is restored, without repeating anything
+	c.mine(transferLog(3))
+	c.mine(transferLog(4))
+	ws.drop(errors.New("connection reset"))
+	require.Eventually(t, func() bool {
+		return ws.live() && m.Status().Mode == ModeSubscription && m.Status().Subscriptions == 2
+	}, time.Second, time.Millisecond)
+
+	live = transferLog(5)
+	c.mine(live)
+	live.BlockNumber = 5
+	ws.send(live)
+	require.Eventually(t, func() bool { return len(got.tokenIDs()) == 5 }, time.Second, time.Millisecond)
+
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, got.tokenIDs())
+}
+
+func TestRemovedLogsAreRedelivered(t *testing.T) {
+	c := &chain{}
+	m, got := newTestManager(t, Config{FromBlock: 1})
+	require.True(t, m.start(context.Background(), c))
+
+	original := transferLog(1)
+	original.BlockNumber = 1
+	m.deliver(context.Background(), original)
+
+	// A reorg drops the block and mines the log again in its replacement
+	var rollbacks []uint64
+	m.OnRollback(func(ctx context.Context, ancestor uint64) {
+		rollbacks = append(rollbacks, ancestor)
+	})
+	removed := original
+	removed.Removed = true
+	m.deliver(context.Background(), removed)
+	m.deliver(context.Background(), original)
+
+	assert.Equal(t, []uint64{0}, rollbacks)
+	require.Len(t, got.events, 3)
+	assert.True(t, got.events[1].Log.Removed)
+	assert.False(t, got.events[2].Log.Removed)
+
+	// The polled block does not repeat it
+	c.mine(transferLog(1))
+	require.NoError(t, m.poll(context.Background(), c))
+	assert.Len(t, got.events, 3)
+}
+
+func TestNewRejectsHTTPSubscriptions(t *testing.T) {
+	_, err := New(Config{WSURL: "https://rpc.example.com"})
+	assert.EqualError(t, err, "event subscriptions need a ws:// or wss:// URL")
+
+	_, err = New(Config{WSURL: "ws://127.0.0.1:9650/ext/bc/C/ws"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,266 @@
+// Package failover spreads JSON-RPC traffic over an ordered list of endpoints.
+// Calls go to the first healthy endpoint; transient failures are retried with
+// exponential backoff and jitter on the next endpoint. A background check
+// takes endpoints that lag behind the best block height or keep failing out
+// of rotation until they recover.
+package failover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// DefaultRetryAttempts is how often a transient failure is retried
+	DefaultRetryAttempts = 3
+	// DefaultRequestTimeout bounds a single attempt
+	DefaultRequestTimeout = 10 * time.Second
+	// DefaultBaseDelay is the backoff before the first retry
+	DefaultBaseDelay = 200 * time.Millisecond
+	// DefaultMaxDelay caps the backoff between retries
+	DefaultMaxDelay = 2 * time.Second
+	// DefaultMaxBlockLag is how many blocks an endpoint may trail the best one
+	DefaultMaxBlockLag = 5
+	// DefaultMaxErrorRate is the share of failed calls since the last check
+	// above which an endpoint leaves the rotation
+	DefaultMaxErrorRate = 0.5
+	// DefaultCheckInterval is how often endpoint health is checked
+	DefaultCheckInterval = 30 * time.Second
+)
+
+// minSamples is the number of calls needed before the error rate counts
+const minSamples = 5
+
+// ErrNoEndpoint is returned by Dial when no endpoint could be dialed
+var ErrNoEndpoint = errors.New("no RPC endpoint available")
+
+// Config configures a Client. Zero values use the defaults.
+type Config struct {
+	// URLs lists the endpoints in order of preference
+	URLs []string
+	// RetryAttempts is how often a transient failure is retried; negative
+	// disables retries
+	RetryAttempts  int
+	RequestTimeout time.Duration
+	BaseDelay      time.Duration
+	MaxDelay       time.Duration
+	MaxBlockLag    uint64
+	MaxErrorRate   float64
+	CheckInterval  time.Duration
+}
+
+// EndpointStatus reports the health of an endpoint. The URL is reduced to
+// scheme and host so API keys in paths are not exposed.
+type EndpointStatus struct {
+	URL       string     `json:"url"`
+	Healthy   bool       `json:"healthy"`
+	Active    bool       `json:"active"`
+	Head      uint64     `json:"head,omitempty"`
+	Lag       uint64     `json:"lag"`
+	ErrorRate float64    `json:"errorRate"`
+	LastError string     `json:"lastError,omitempty"`
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+}
+
+type endpoint struct {
+	url    string
+	name   string
+	rpc    *rpc.Client
+	client *ethclient.Client
+
+	healthy   bool
+	head      uint64
+	lag       uint64
+	calls     int
+	failures  int
+	lastErr   string
+	checkedAt time.Time
+}
+
+// Client is an Ethereum client that fails over between endpoints. It
+// implements the ethclient methods the SDKs use, including the bind backend
+// interfaces, so it can stand in for an *ethclient.Client.
+type Client struct {
+	cfg       Config
+	mu        sync.Mutex
+	endpoints []*endpoint
+	stop      context.CancelFunc
+	done      chan struct{}
+}
+
+// Dial connects to every endpoint and starts the health checks. Endpoints
+// that cannot be dialed are skipped; Dial fails only when none can.
+func Dial(ctx context.Context, cfg Config) (*Client, error) {
+	if cfg.RetryAttempts == 0 {
+		cfg.RetryAttempts = DefaultRetryAttempts
+	}
+	if cfg.RetryAttempts < 0 {
+		cfg.RetryAttempts = 0
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = DefaultRequestTimeout
+	}
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = DefaultBaseDelay
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = DefaultMaxDelay
+	}
+	if cfg.MaxBlockLag == 0 {
+		cfg.MaxBlockLag = DefaultMaxBlockLag
+	}
+	if cfg.MaxErrorRate <= 0 {
+		cfg.MaxErrorRate = DefaultMaxErrorRate
+	}
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = DefaultCheckInterval
+	}
+
+	c := &Client{cfg: cfg}
+	var errs []error
+	for _, rawURL := range cfg.URLs {
+		rpcClient, err := rpc.DialContext(ctx, rawURL)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", redact(rawURL), err))
+			continue
+		}
+		c.endpoints = append(c.endpoints, &endpoint{
+			url:     rawURL,
+			name:    redact(rawURL),
+			rpc:     rpcClient,
+			client:  ethclient.NewClient(rpcClient),
+			healthy: true,
+		})
+	}
+	if len(c.endpoints) == 0 {
+		return nil, errors.Join(append([]error{ErrNoEndpoint}, errs...)...)
+	}
+	for _, err := range errs {
+		log.Printf("failover: skipping endpoint %v", err)
+	}
+
+	runCtx, stop := context.WithCancel(context.Background())
+	c.stop = stop
+	c.done = make(chan struct{})
+	go c.run(runCtx)
+
+	return c, nil
+}
+
+// Close stops the health checks and closes every endpoint
+func (c *Client) Close() {
+	if c.stop != nil {
+		c.stop()
+		<-c.done
+	}
+	for _, e := range c.endpoints {
+		e.rpc.Close()
+	}
+}
+
+// do runs fn against the preferred endpoint, retrying transient failures on
+// the next endpoints with backoff
+func (c *Client) do(ctx context.Context, fn func(ctx context.Context, e *endpoint) error) error {
+	tried := make(map[*endpoint]bool)
+	var lastErr error
+
+	for attempt := 0; attempt <= c.cfg.RetryAttempts; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(c.backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return lastErr
+			case <-timer.C:
+			}
+		}
+
+		e := c.pick(tried)
+		tried[e] = true
+
+		attemptCtx, cancel := context.WithTimeout(ctx, c.cfg.RequestTimeout)
+		err := fn(attemptCtx, e)
+		cancel()
+
+		if err == nil || !isTransient(ctx, err) {
+			c.record(e, nil)
+			return err
+		}
+		c.record(e, err)
+		lastErr = err
+	}
+
+	return lastErr
+}
+
+// pick returns the first healthy endpoint not tried yet, falling back to
+// untried unhealthy ones and then to the preferred endpoint again
+func (c *Client) pick(tried map[*endpoint]bool) *endpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, e := range c.endpoints {
+		if e.healthy && !tried[e] {
+			return e
+		}
+	}
+	for _, e := range c.endpoints {
+		if !tried[e] {
+			return e
+		}
+	}
+	for _, e := range c.endpoints {
+		if e.healthy {
+			return e
+		}
+	}
+	return c.endpoints[0]
+}
+
+// record counts a call and takes the endpoint out of rotation once its error
+// rate since the last check is too high
+func (c *Client) record(e *endpoint, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e.calls++
+	if err == nil {
+		return
+	}
+	e.failures++
+	e.lastErr = err.Error()
+
+	if e.healthy && e.calls >= minSamples && float64(e.failures)/float64(e.calls) > c.cfg.MaxErrorRate {
+		e.healthy = false
+		log.Printf("failover: %s out of rotation after %d of %d calls failed: %v", e.name, e.failures, e.calls, err)
+	}
+}
+
+// backoff returns the delay before a retry: exponential from BaseDelay up
+// to MaxDelay, with jitter over the upper half so retries spread out
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.cfg.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > c.cfg.MaxDelay {
+		delay = c.cfg.MaxDelay
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// redact reduces a URL to scheme and host
+func redact(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "invalid-url"
+	}
+	return u.Scheme + "://" + u.Host
+}
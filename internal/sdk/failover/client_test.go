@@ -0,0 +1,264 @@
+package failover
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// node is a fake JSON-RPC endpoint answering from a method table
+type node struct {
+	server  *httptest.Server
+	calls   map[string]*atomic.Int32
+	answers map[string]func() (interface{}, *rpcError)
+	status  int
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func newNode(t *testing.T, answers map[string]func() (interface{}, *rpcError)) *node {
+	t.Helper()
+	n := &node{calls: make(map[string]*atomic.Int32), answers: answers}
+	for method := range answers {
+		n.calls[method] = new(atomic.Int32)
+	}
+	n.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if counter, ok := n.calls[req.Method]; ok {
+			counter.Add(1)
+		}
+		if n.status != 0 {
+			w.WriteHeader(n.status)
+			return
+		}
+		answer, ok := n.answers[req.Method]
+		if !ok {
+			answer = func() (interface{}, *rpcError) { return nil, &rpcError{-32601, "method not found"} }
+		}
+		result, rpcErr := answer()
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if rpcErr != nil {
+			resp["error"] = rpcErr
+		} else {
+			resp["result"] = result
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(n.server.Close)
+	return n
+}
+
+func (n *node) count(method string) int32 {
+	return n.calls[method].Load()
+}
+
+func fixed(result interface{}) func() (interface{}, *rpcError) {
+	return func() (interface{}, *rpcError) { return result, nil }
+}
+
+func failing(code int, message string) func() (interface{}, *rpcError) {
+	return func() (interface{}, *rpcError) { return nil, &rpcError{code, message} }
+}
+
+func dialNodes(t *testing.T, nodes ...*node) *Client {
+	t.Helper()
+	urls := make([]string, len(nodes))
+	for i, n := range nodes {
+		urls[i] = n.server.URL
+	}
+	c, err := Dial(context.Background(), Config{
+		URLs:          urls,
+		BaseDelay:     time.Millisecond,
+		MaxDelay:      2 * time.Millisecond,
+		CheckInterval: time.Hour,
+	})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	return c
+}
+
+// stopChecks ends the background health checks so tests control the
+// endpoint state
+func stopChecks(c *Client) {
+	c.stop()
+	<-c.done
+	c.stop = nil
+}
+
+func TestFailoverOnServerError(t *testing.T) {
+	down := newNode(t, map[string]func() (interface{}, *rpcError){
+		"eth_chainId":     fixed("0x1f5"),
+		"eth_blockNumber": fixed("0x64"),
+	})
+	down.status = http.StatusServiceUnavailable
+	up := newNode(t, map[string]func() (interface{}, *rpcError){
+		"eth_chainId":     fixed("0x1f5"),
+		"eth_blockNumber": fixed("0x64"),
+	})
+
+	c := dialNodes(t, down, up)
+
+	chainID, err := c.ChainID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(501), chainID)
+	assert.Equal(t, int32(1), up.count("eth_chainId"))
+}
+
+func TestNodeErrorsAreNotRetried(t *testing.T) {
+	reverting := newNode(t, map[string]func() (interface{}, *rpcError){
+		"eth_chainId":     failing(3, "execution reverted"),
+		"eth_blockNumber": fixed("0x64"),
+	})
+	backup := newNode(t, map[string]func() (interface{}, *rpcError){
+		"eth_chainId":     fixed("0x1f5"),
+		"eth_blockNumber": fixed("0x64"),
+	})
+
+	c := dialNodes(t, reverting, backup)
+
+	_, err := c.ChainID(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "execution reverted")
+	assert.Equal(t, int32(1), reverting.count("eth_chainId"))
+	assert.Equal(t, int32(0), backup.count("eth_chainId"))
+}
+
+func TestRetriesExhausted(t *testing.T) {
+	down := newNode(t, map[string]func() (interface{}, *rpcError){"eth_chainId": fixed("0x1f5")})
+	down.status = http.StatusBadGateway
+
+	c := dialNodes(t, down)
+
+	_, err := c.ChainID(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "502")
+	assert.Equal(t, int32(DefaultRetryAttempts+1), down.count("eth_chainId"))
+}
+
+func TestCheckDropsLaggingEndpoint(t *testing.T) {
+	lagging := newNode(t, map[string]func() (interface{}, *rpcError){
+		"eth_chainId":     fixed("0x1f5"),
+		"eth_blockNumber": fixed("0x64"),
+	})
+	synced := newNode(t, map[string]func() (interface{}, *rpcError){
+		"eth_chainId":     fixed("0x1f5"),
+		"eth_blockNumber": fixed("0xc8"),
+	})
+
+	c := dialNodes(t, lagging, synced)
+	c.Check(context.Background())
+
+	statuses := c.Status()
+	require.Len(t, statuses, 2)
+	assert.False(t, statuses[0].Healthy)
+	assert.Equal(t, uint64(100), statuses[0].Lag)
+	assert.Equal(t, "100 blocks behind", statuses[0].LastError)
+	assert.True(t, statuses[1].Healthy)
+	assert.True(t, statuses[1].Active)
+	assert.Equal(t, uint64(200), statuses[1].Head)
+	assert.True(t, c.Healthy())
+
+	_, err := c.ChainID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), lagging.count("eth_chainId"))
+}
+
+func TestErrorRateTakesEndpointOutOfRotation(t *testing.T) {
+	flaky := newNode(t, map[string]func() (interface{}, *rpcError){"eth_chainId": fixed("0x1f5")})
+	flaky.status = http.StatusTooManyRequests
+	backup := newNode(t, map[string]func() (interface{}, *rpcError){"eth_chainId": fixed("0x1f5")})
+
+	c := dialNodes(t, flaky, backup)
+	stopChecks(c)
+
+	for i := 0; i < minSamples+2; i++ {
+		_, err := c.ChainID(context.Background())
+		require.NoError(t, err)
+	}
+
+	// After enough failures the flaky endpoint is skipped up front
+	assert.Equal(t, int32(minSamples), flaky.count("eth_chainId"))
+	assert.Equal(t, int32(minSamples+2), backup.count("eth_chainId"))
+	assert.False(t, c.Status()[0].Healthy)
+}
+
+func TestSendTransactionAlreadyKnownAfterRetry(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx, err := types.SignNewTx(key, types.NewEIP155Signer(big.NewInt(501)), &types.LegacyTx{
+		Nonce: 1, Gas: 21000, GasPrice: big.NewInt(1),
+	})
+	require.NoError(t, err)
+
+	down := newNode(t, map[string]func() (interface{}, *rpcError){"eth_sendRawTransaction": fixed(tx.Hash())})
+	down.status = http.StatusServiceUnavailable
+	known := newNode(t, map[string]func() (interface{}, *rpcError){
+		"eth_sendRawTransaction": failing(-32000, "already known"),
+	})
+
+	c := dialNodes(t, down, known)
+
+	require.NoError(t, c.SendTransaction(context.Background(), tx))
+	assert.Equal(t, int32(1), known.count("eth_sendRawTransaction"))
+}
+
+func TestDialWithoutEndpoints(t *testing.T) {
+	_, err := Dial(context.Background(), Config{URLs: []string{"ftp://example.com"}})
+	assert.True(t, errors.Is(err, ErrNoEndpoint))
+
+	_, err = Dial(context.Background(), Config{})
+	assert.True(t, errors.Is(err, ErrNoEndpoint))
+}
+
+func TestBackoff(t *testing.T) {
+	c := &Client{cfg: Config{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}}
+
+	tests := []struct {
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("attempt %d", tt.attempt), func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				delay := c.backoff(tt.attempt)
+				assert.GreaterOrEqual(t, delay, tt.min)
+				assert.LessOrEqual(t, delay, tt.max)
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	assert.Equal(t, "https://rpc.example.com", redact("https://rpc.example.com/v1/secret-key"))
+	assert.Equal(t, "invalid-url", redact("not a url"))
+}
@@ -0,0 +1,180 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Check reads the block height of every endpoint. Endpoints that fail or
+// trail the best height by more than MaxBlockLag leave the rotation; the
+// others rejoin it with their error counts reset.
+func (c *Client) Check(ctx context.Context) {
+	heads := make([]uint64, len(c.endpoints))
+	errs := make([]error, len(c.endpoints))
+
+	var wg sync.WaitGroup
+	for i, e := range c.endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, c.cfg.RequestTimeout)
+			defer cancel()
+			heads[i], errs[i] = e.client.BlockNumber(checkCtx)
+		}(i, e)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	var best uint64
+	for i, head := range heads {
+		if errs[i] == nil && head > best {
+			best = head
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now().UTC()
+	for i, e := range c.endpoints {
+		wasHealthy := e.healthy
+		e.checkedAt = now
+		e.calls, e.failures = 0, 0
+
+		switch {
+		case errs[i] != nil:
+			e.healthy = false
+			e.lastErr = errs[i].Error()
+		case best-heads[i] > c.cfg.MaxBlockLag:
+			e.head, e.lag = heads[i], best-heads[i]
+			e.healthy = false
+			e.lastErr = fmt.Sprintf("%d blocks behind", e.lag)
+		default:
+			e.head, e.lag = heads[i], best-heads[i]
+			e.healthy = true
+		}
+
+		if wasHealthy && !e.healthy {
+			log.Printf("failover: %s out of rotation: %s", e.name, e.lastErr)
+		} else if !wasHealthy && e.healthy {
+			log.Printf("failover: %s back in rotation at block %d", e.name, e.head)
+		}
+	}
+}
+
+// run checks endpoint health until ctx is cancelled. Single endpoints are
+// still checked so their status is reported.
+func (c *Client) run(ctx context.Context) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		c.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Status reports the health of every endpoint in order of preference
+func (c *Client) Status() []EndpointStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	active := -1
+	for i, e := range c.endpoints {
+		if e.healthy {
+			active = i
+			break
+		}
+	}
+	if active < 0 {
+		active = 0
+	}
+
+	statuses := make([]EndpointStatus, len(c.endpoints))
+	for i, e := range c.endpoints {
+		status := EndpointStatus{
+			URL:       e.name,
+			Healthy:   e.healthy,
+			Active:    i == active,
+			Head:      e.head,
+			Lag:       e.lag,
+			LastError: e.lastErr,
+		}
+		if e.calls > 0 {
+			status.ErrorRate = float64(e.failures) / float64(e.calls)
+		}
+		if !e.checkedAt.IsZero() {
+			checkedAt := e.checkedAt
+			status.CheckedAt = &checkedAt
+		}
+		statuses[i] = status
+	}
+	return statuses
+}
+
+// Healthy reports whether at least one endpoint is in rotation
+func (c *Client) Healthy() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, e := range c.endpoints {
+		if e.healthy {
+			return true
+		}
+	}
+	return false
+}
+
+// isTransient reports whether err is worth retrying on another endpoint:
+// transport failures, attempt timeouts, rate limiting and server errors.
+// Errors the node answered with, such as reverts or nonce errors, are final.
+func isTransient(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		// The caller gave up; the attempt timeout is not the cause
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == 429 || httpErr.StatusCode >= 500
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		// -32005 is the conventional "limit exceeded" code
+		return rpcErr.ErrorCode() == -32005
+	}
+
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "connection refused") || strings.Contains(msg, "connection reset") ||
+		strings.Contains(msg, "no such host") || strings.Contains(msg, "too many requests")
+}
@@ -0,0 +1,202 @@
+package failover
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// call runs a read against the endpoints with retries
+func call[T any](c *Client, ctx context.Context, fn func(ctx context.Context, client *ethclient.Client) (T, error)) (T, error) {
+	var result T
+	err := c.do(ctx, func(ctx context.Context, e *endpoint) error {
+		var err error
+		result, err = fn(ctx, e.client)
+		return err
+	})
+	return result, err
+}
+
+// ChainID retrieves the chain ID
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) (*big.Int, error) {
+		return client.ChainID(ctx)
+	})
+}
+
+// NetworkID returns the network ID
+func (c *Client) NetworkID(ctx context.Context) (*big.Int, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) (*big.Int, error) {
+		return client.NetworkID(ctx)
+	})
+}
+
+// BlockNumber returns the most recent block number
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) (uint64, error) {
+		return client.BlockNumber(ctx)
+	})
+}
+
+// HeaderByNumber returns a block header; nil returns the latest
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) (*types.Header, error) {
+		return client.HeaderByNumber(ctx, number)
+	})
+}
+
+// BlockByNumber returns a block; nil returns the latest
+func (c *Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) (*types.Block, error) {
+		return client.BlockByNumber(ctx, number)
+	})
+}
+
+// SuggestGasPrice retrieves the suggested legacy gas price
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) (*big.Int, error) {
+		return client.SuggestGasPrice(ctx)
+	})
+}
+
+// SuggestGasTipCap retrieves the suggested EIP-1559 tip
+func (c *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) (*big.Int, error) {
+		return client.SuggestGasTipCap(ctx)
+	})
+}
+
+// EstimateGas estimates the gas needed for a call
+func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) (uint64, error) {
+		return client.EstimateGas(ctx, msg)
+	})
+}
+
+// BalanceAt returns the wei balance of an account
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) (*big.Int, error) {
+		return client.BalanceAt(ctx, account, blockNumber)
+	})
+}
+
+// NonceAt returns the account nonce at a block
+func (c *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) (uint64, error) {
+		return client.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+// PendingNonceAt returns the account nonce in the pending state
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) (uint64, error) {
+		return client.PendingNonceAt(ctx, account)
+	})
+}
+
+// CodeAt returns the contract code of an account at a block
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) ([]byte, error) {
+		return client.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+// PendingCodeAt returns the contract code of an account in the pending state
+func (c *Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) ([]byte, error) {
+		return client.PendingCodeAt(ctx, account)
+	})
+}
+
+// CallContract executes a message call
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) ([]byte, error) {
+		return client.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+// PendingCallContract executes a message call against the pending state
+func (c *Client) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) ([]byte, error) {
+		return client.PendingCallContract(ctx, msg)
+	})
+}
+
+// TransactionReceipt returns the receipt of a mined transaction
+func (c *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) (*types.Receipt, error) {
+		return client.TransactionReceipt(ctx, txHash)
+	})
+}
+
+// TransactionByHash returns a transaction and whether it is still pending
+func (c *Client) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	var pending bool
+	tx, err := call(c, ctx, func(ctx context.Context, client *ethclient.Client) (*types.Transaction, error) {
+		tx, isPending, err := client.TransactionByHash(ctx, hash)
+		pending = isPending
+		return tx, err
+	})
+	return tx, pending, err
+}
+
+// FilterLogs returns the logs matching a query
+func (c *Client) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return call(c, ctx, func(ctx context.Context, client *ethclient.Client) ([]types.Log, error) {
+		return client.FilterLogs(ctx, query)
+	})
+}
+
+// SubscribeFilterLogs subscribes to logs on the preferred endpoint. A
+// subscription is bound to its endpoint and is not moved on failure.
+func (c *Client) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return c.pick(nil).client.SubscribeFilterLogs(ctx, query, ch)
+}
+
+// SendTransaction broadcasts a signed transaction. After a transport failure
+// the transaction may have reached the node anyway, so a retry that finds it
+// already known, or mined, counts as sent.
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	retried := false
+	return c.do(ctx, func(ctx context.Context, e *endpoint) error {
+		err := e.client.SendTransaction(ctx, tx)
+		if err == nil || !retried {
+			retried = true
+			return err
+		}
+		if isAlreadyKnown(err) {
+			return nil
+		}
+		if _, _, lookupErr := e.client.TransactionByHash(ctx, tx.Hash()); lookupErr == nil {
+			return nil
+		}
+		return err
+	})
+}
+
+// BatchCallContext sends a JSON-RPC batch. Per-call errors are reported in
+// the elements; only transport failures are retried.
+func (c *Client) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return c.do(ctx, func(ctx context.Context, e *endpoint) error {
+		for i := range b {
+			b[i].Error = nil
+		}
+		return e.rpc.BatchCallContext(ctx, b)
+	})
+}
+
+// Client returns the RPC client of the preferred endpoint
+func (c *Client) Client() *rpc.Client {
+	return c.pick(nil).rpc
+}
+
+func isAlreadyKnown(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "known transaction")
+}
@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"bogowi-blockchain-go/internal/sdk/gas"
+)
+
+// defaultGasMultiplier is the buffer applied to suggested prices when the SDK
+// was not given a fee strategy, matching the configuration default
+const defaultGasMultiplier = 1.2
+
+// GasFees reports the current fee suggestions of a network. Prices are in gwei.
+type GasFees struct {
+	Strategy             string `json:"strategy"`
+	GasPrice             string `json:"gasPrice"`
+	BaseFee              string `json:"baseFee,omitempty"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas,omitempty"`
+	MaxFeePerGas         string `json:"maxFeePerGas,omitempty"`
+}
+
+// feeStrategy returns the configured fee strategy, falling back to legacy
+// pricing when the SDK was not built through NewBOGOWISDK
+func (s *BOGOWISDK) feeStrategy() *gas.Strategy {
+	if s.fees != nil {
+		return s.fees
+	}
+	fees, _ := gas.NewStrategy(gas.Config{Mode: gas.ModeLegacy, Multiplier: defaultGasMultiplier})
+	return fees
+}
+
+// suggestFees prices a transaction with the SDK's fee strategy
+func (s *BOGOWISDK) suggestFees(ctx context.Context) (*gas.Fees, error) {
+	return s.feeStrategy().Fees(ctx, s.client)
+}
+
+// GetGasFees returns the legacy gas price together with the EIP-1559 base
+// fee and tip suggestions. The EIP-1559 fields are omitted on networks
+// without a base fee.
+func (s *BOGOWISDK) GetGasFees(ctx context.Context) (*GasFees, error) {
+	ctx, cancel := s.readContext(ctx)
+	defer cancel()
+
+	gasPrice, err := s.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	fees := &GasFees{
+		Strategy: string(s.feeStrategy().Mode()),
+		GasPrice: formatGwei(gasPrice),
+	}
+
+	head, err := s.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+	if head.BaseFee == nil {
+		return fees, nil
+	}
+
+	tip, err := s.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas tip cap: %w", err)
+	}
+
+	fees.BaseFee = formatGwei(head.BaseFee)
+	fees.MaxPriorityFeePerGas = formatGwei(tip)
+	fees.MaxFeePerGas = formatGwei(new(big.Int).Add(tip, new(big.Int).Mul(head.BaseFee, big.NewInt(2))))
+
+	return fees, nil
+}
+
+// formatGwei formats a wei amount as gwei, in the same form as GetGasPrice
+func formatGwei(wei *big.Int) string {
+	gwei := new(big.Float).Quo(new(big.Float).SetInt(wei), new(big.Float).SetInt(big.NewInt(1000000000)))
+	return fmt.Sprintf("%.2f gwei", gwei)
+}
+
+// dryRun runs a write path under a dry-run context and returns the estimate
+// of the transaction it would have sent. Nothing is broadcast, so the run is
+// bounded by the read timeout.
+func (s *BOGOWISDK) dryRun(ctx context.Context, run func(ctx context.Context) error) (*gas.Estimate, error) {
+	ctx, cancel := s.readContext(ctx)
+	defer cancel()
+
+	ctx, est := gas.WithDryRun(ctx)
+	err := run(ctx)
+	if errors.Is(err, gas.ErrDryRun) {
+		return est, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("dry run did not reach a transaction")
+}
@@ -0,0 +1,107 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"bogowi-blockchain-go/internal/sdk/gas"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGasFees(t *testing.T) {
+	t.Run("with base fee", func(t *testing.T) {
+		client := new(MockEthClient)
+		client.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(27e9), nil)
+		client.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: big.NewInt(25e9)}, nil)
+		client.On("SuggestGasTipCap", mock.Anything).Return(big.NewInt(2e9), nil)
+
+		fees, err := (&BOGOWISDK{client: client}).GetGasFees(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "legacy", fees.Strategy)
+		assert.Equal(t, "27.00 gwei", fees.GasPrice)
+		assert.Equal(t, "25.00 gwei", fees.BaseFee)
+		assert.Equal(t, "2.00 gwei", fees.MaxPriorityFeePerGas)
+		assert.Equal(t, "52.00 gwei", fees.MaxFeePerGas)
+	})
+
+	t.Run("without base fee", func(t *testing.T) {
+		client := new(MockEthClient)
+		client.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(25e9), nil)
+		client.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{}, nil)
+
+		fees, err := (&BOGOWISDK{client: client}).GetGasFees(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "25.00 gwei", fees.GasPrice)
+		assert.Empty(t, fees.BaseFee)
+		assert.Empty(t, fees.MaxFeePerGas)
+		client.AssertNotCalled(t, "SuggestGasTipCap", mock.Anything)
+	})
+
+	t.Run("gas price error", func(t *testing.T) {
+		client := new(MockEthClient)
+		client.On("SuggestGasPrice", mock.Anything).Return(nil, errors.New("network error"))
+
+		_, err := (&BOGOWISDK{client: client}).GetGasFees(context.Background())
+		assert.EqualError(t, err, "failed to get gas price: network error")
+	})
+}
+
+func TestReadTimeout(t *testing.T) {
+	client := new(MockEthClient)
+	var deadline time.Time
+	client.On("SuggestGasPrice", mock.Anything).Run(func(args mock.Arguments) {
+		deadline, _ = args.Get(0).(context.Context).Deadline()
+	}).Return(big.NewInt(25e9), nil)
+
+	s := &BOGOWISDK{client: client, timeouts: Timeouts{Read: time.Second}}
+	_, err := s.GetGasPrice(context.Background())
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 500*time.Millisecond)
+
+	// A request deadline shorter than the read timeout is kept
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	want, _ := ctx.Deadline()
+	_, err = s.GetGasPrice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, want, deadline)
+
+}
+
+func TestTransferNativeDynamicFees(t *testing.T) {
+	client := new(MockEthClient)
+	s := newNativeTestSDK(t, client, "1", "1")
+	s.chainID = big.NewInt(501)
+
+	fees, err := gas.NewStrategy(gas.Config{Mode: gas.ModeEIP1559, MaxGasPrice: big.NewInt(40e9)})
+	require.NoError(t, err)
+	s.fees = fees
+
+	client.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: big.NewInt(25e9)}, nil)
+	client.On("SuggestGasTipCap", mock.Anything).Return(big.NewInt(2e9), nil)
+	client.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	client.On("BalanceAt", mock.Anything, s.auth.From, (*big.Int)(nil)).Return(big.NewInt(5e18), nil)
+	client.On("PendingNonceAt", mock.Anything, s.auth.From).Return(uint64(0), nil)
+
+	var sent *types.Transaction
+	client.On("SendTransaction", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		sent = args.Get(1).(*types.Transaction)
+	}).Return(nil)
+
+	transfer, err := s.TransferNative(context.Background(), "0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2", "0.5")
+	require.NoError(t, err)
+	require.NotNil(t, sent)
+
+	assert.Equal(t, uint8(types.DynamicFeeTxType), sent.Type())
+	assert.Equal(t, big.NewInt(2e9), sent.GasTipCap())
+	assert.Equal(t, big.NewInt(40e9), sent.GasFeeCap(), "fee cap is limited by the max gas price")
+	assert.Equal(t, "40000000000", transfer.GasPrice)
+	client.AssertNotCalled(t, "SuggestGasPrice", mock.Anything)
+}
@@ -0,0 +1,172 @@
+package gas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrDryRun is returned by write paths running under WithDryRun once the
+// transaction has been estimated. Nothing was signed or sent.
+var ErrDryRun = errors.New("dry run: transaction not sent")
+
+// ErrExceedsBlockGasLimit is returned when a transaction needs more gas than
+// fits in a block
+var ErrExceedsBlockGasLimit = errors.New("transaction exceeds block gas limit")
+
+// Estimator provides gas estimates, typically an ethclient
+type Estimator interface {
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Estimate describes the gas and cost of a transaction before it is sent.
+// Amounts are in wei.
+type Estimate struct {
+	From                 string `json:"from"`
+	To                   string `json:"to,omitempty"`
+	EstimatedGas         uint64 `json:"estimatedGas"`
+	GasLimit             uint64 `json:"gasLimit"`
+	BlockGasLimit        uint64 `json:"blockGasLimit"`
+	GasPrice             string `json:"gasPrice,omitempty"`
+	MaxFeePerGas         string `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas,omitempty"`
+	Value                string `json:"value"`
+	// MaxCost is the value plus the gas limit at the highest price per gas
+	MaxCost string `json:"maxCost"`
+}
+
+// EstimateCall estimates a contract call made through a bound contract. send
+// is invoked once with a copy of opts that only builds the unsigned call, so
+// the estimate covers the real calldata; opts itself is not modified.
+func EstimateCall(ctx context.Context, src Estimator, opts *bind.TransactOpts, multiplier float64, send func(opts *bind.TransactOpts) (*types.Transaction, error)) (*Estimate, error) {
+	head, err := src.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+
+	// The block gas limit as placeholder keeps bind from estimating itself,
+	// and the pass-through signer keeps the key out of the probe
+	probe := *opts
+	probe.Context = ctx
+	probe.NoSend = true
+	probe.Nonce = new(big.Int)
+	probe.GasLimit = head.GasLimit
+	probe.Signer = func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		return tx, nil
+	}
+	call, err := send(&probe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transaction: %w", err)
+	}
+
+	fees := TxFees(call)
+	if opts.GasFeeCap != nil {
+		fees = &Fees{GasTipCap: opts.GasTipCap, GasFeeCap: opts.GasFeeCap}
+	} else if opts.GasPrice != nil {
+		fees = &Fees{GasPrice: opts.GasPrice}
+	}
+	return estimate(ctx, src, head.GasLimit, opts.From, call.To(), call.Value(), call.Data(), fees, multiplier)
+}
+
+// EstimateTransfer estimates a transaction that is built directly, such as a
+// plain value transfer
+func EstimateTransfer(ctx context.Context, src Estimator, from common.Address, to *common.Address, value *big.Int, data []byte, fees *Fees, multiplier float64) (*Estimate, error) {
+	head, err := src.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+	return estimate(ctx, src, head.GasLimit, from, to, value, data, fees, multiplier)
+}
+
+// estimate asks the node for the gas msg needs, scales it by multiplier and
+// caps it at the block gas limit
+func estimate(ctx context.Context, src Estimator, blockGasLimit uint64, from common.Address, to *common.Address, value *big.Int, data []byte, fees *Fees, multiplier float64) (*Estimate, error) {
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	used, err := src.EstimateGas(ctx, ethereum.CallMsg{
+		From:      from,
+		To:        to,
+		GasPrice:  fees.GasPrice,
+		GasTipCap: fees.GasTipCap,
+		GasFeeCap: fees.GasFeeCap,
+		Value:     value,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	if blockGasLimit > 0 && used > blockGasLimit {
+		return nil, fmt.Errorf("%w: needs %d gas, block limit %d", ErrExceedsBlockGasLimit, used, blockGasLimit)
+	}
+
+	limit := scaleGas(used, multiplier)
+	if blockGasLimit > 0 && limit > blockGasLimit {
+		limit = blockGasLimit
+	}
+
+	est := &Estimate{
+		From:          from.Hex(),
+		EstimatedGas:  used,
+		GasLimit:      limit,
+		BlockGasLimit: blockGasLimit,
+		Value:         value.String(),
+	}
+	if to != nil {
+		est.To = to.Hex()
+	}
+	if fees.Dynamic() {
+		est.MaxFeePerGas = fees.GasFeeCap.String()
+		est.MaxPriorityFeePerGas = fees.GasTipCap.String()
+	} else if fees.GasPrice != nil {
+		est.GasPrice = fees.GasPrice.String()
+	}
+	if maxPrice := fees.MaxPrice(); maxPrice != nil {
+		cost := new(big.Int).Mul(maxPrice, new(big.Int).SetUint64(limit))
+		est.MaxCost = cost.Add(cost, value).String()
+	}
+	return est, nil
+}
+
+// scaleGas applies a safety multiplier to a gas estimate, rounding up
+func scaleGas(used uint64, multiplier float64) uint64 {
+	if multiplier <= 1 {
+		return used
+	}
+	return uint64(math.Ceil(float64(used) * multiplier))
+}
+
+type dryRunKey struct{}
+
+// WithDryRun returns a context under which SDK write paths stop after
+// estimating their transaction. The estimate is stored in the returned
+// Estimate and the write path returns ErrDryRun.
+func WithDryRun(ctx context.Context) (context.Context, *Estimate) {
+	est := new(Estimate)
+	return context.WithValue(ctx, dryRunKey{}, est), est
+}
+
+// DryRun records est when ctx is a dry run and reports whether it is
+func DryRun(ctx context.Context, est *Estimate) bool {
+	sink, ok := ctx.Value(dryRunKey{}).(*Estimate)
+	if !ok {
+		return false
+	}
+	*sink = *est
+	return true
+}
+
+// Multiplier returns the strategy's price multiplier, which the SDK clients
+// also apply to gas estimates
+func (s *Strategy) Multiplier() float64 {
+	return s.cfg.Multiplier
+}
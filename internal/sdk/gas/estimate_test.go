@@ -0,0 +1,142 @@
+package gas
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEstimator returns a fixed estimate and records the call it was given
+type fakeEstimator struct {
+	used          uint64
+	blockGasLimit uint64
+	msg           ethereum.CallMsg
+}
+
+func (f *fakeEstimator) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	f.msg = msg
+	return f.used, nil
+}
+
+func (f *fakeEstimator) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{GasLimit: f.blockGasLimit}, nil
+}
+
+func TestEstimateCall(t *testing.T) {
+	contract := common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2")
+	calldata := []byte{0xa9, 0x05, 0x9c, 0xbb}
+
+	// send builds the call the way a bound contract does
+	send := func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		assert.True(t, opts.NoSend)
+		assert.Equal(t, uint64(30_000_000), opts.GasLimit)
+		tx := types.NewTx(&types.LegacyTx{Nonce: opts.Nonce.Uint64(), To: &contract, Gas: opts.GasLimit, GasPrice: opts.GasPrice, Data: calldata})
+		return opts.Signer(opts.From, tx)
+	}
+
+	tests := []struct {
+		name       string
+		used       uint64
+		multiplier float64
+		gasLimit   uint64
+		maxCost    string
+		expectErr  error
+	}{
+		{
+			name:     "no multiplier",
+			used:     52000,
+			gasLimit: 52000,
+			maxCost:  "1300000000000000",
+		},
+		{
+			name:       "multiplier rounds up",
+			used:       52001,
+			multiplier: 1.2,
+			gasLimit:   62402,
+			maxCost:    "1560050000000000",
+		},
+		{
+			name:       "capped at block gas limit",
+			used:       29_000_000,
+			multiplier: 1.2,
+			gasLimit:   30_000_000,
+			maxCost:    "750000000000000000",
+		},
+		{
+			name:      "exceeds block gas limit",
+			used:      31_000_000,
+			expectErr: ErrExceedsBlockGasLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &fakeEstimator{used: tt.used, blockGasLimit: 30_000_000}
+			opts := &bind.TransactOpts{
+				From:     common.HexToAddress("0x1234567890123456789012345678901234567890"),
+				GasPrice: gwei(25),
+				Signer: func(common.Address, *types.Transaction) (*types.Transaction, error) {
+					t.Fatal("the probe must not sign")
+					return nil, nil
+				},
+			}
+
+			est, err := EstimateCall(context.Background(), src, opts, tt.multiplier, send)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+
+			// The estimate covers the real calldata at the caller's price
+			assert.Equal(t, calldata, src.msg.Data)
+			assert.Equal(t, &contract, src.msg.To)
+			assert.Equal(t, opts.From, src.msg.From)
+			assert.Equal(t, gwei(25), src.msg.GasPrice)
+
+			assert.Equal(t, tt.used, est.EstimatedGas)
+			assert.Equal(t, tt.gasLimit, est.GasLimit)
+			assert.Equal(t, uint64(30_000_000), est.BlockGasLimit)
+			assert.Equal(t, "25000000000", est.GasPrice)
+			assert.Equal(t, tt.maxCost, est.MaxCost)
+
+			// The caller's options are untouched
+			assert.Nil(t, opts.Nonce)
+			assert.False(t, opts.NoSend)
+			assert.Zero(t, opts.GasLimit)
+		})
+	}
+}
+
+func TestEstimateTransfer(t *testing.T) {
+	src := &fakeEstimator{used: 21000, blockGasLimit: 8_000_000}
+	to := common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2")
+	fees := &Fees{GasTipCap: gwei(2), GasFeeCap: gwei(102)}
+
+	est, err := EstimateTransfer(context.Background(), src, common.Address{}, &to, big.NewInt(1e18), nil, fees, 1.2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(25200), est.GasLimit)
+	assert.Equal(t, "102000000000", est.MaxFeePerGas)
+	assert.Equal(t, "2000000000", est.MaxPriorityFeePerGas)
+	assert.Empty(t, est.GasPrice)
+	assert.Equal(t, "1000000000000000000", est.Value)
+	assert.Equal(t, "1002570400000000000", est.MaxCost)
+	assert.Equal(t, gwei(102), src.msg.GasFeeCap)
+}
+
+func TestDryRun(t *testing.T) {
+	est := &Estimate{GasLimit: 52000}
+
+	assert.False(t, DryRun(context.Background(), est))
+
+	ctx, sink := WithDryRun(context.Background())
+	assert.True(t, DryRun(ctx, est))
+	assert.Equal(t, uint64(52000), sink.GasLimit)
+}
@@ -0,0 +1,314 @@
+// Package gas prices transactions for the SDK clients. A Strategy turns node
+// suggestions into either legacy or EIP-1559 fee fields, applying the
+// configured multiplier and never exceeding the configured max gas price.
+package gas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Mode selects how transaction fees are priced
+type Mode string
+
+const (
+	// ModeLegacy uses a single gas price from eth_gasPrice
+	ModeLegacy Mode = "legacy"
+	// ModeEIP1559 uses a priority tip and a fee cap derived from the base fee
+	ModeEIP1559 Mode = "eip1559"
+	// ModeFixed uses a configured legacy gas price
+	ModeFixed Mode = "fixed"
+)
+
+// ErrBaseFeeAboveCap is returned when the current base fee is already above
+// the max gas price, so no transaction could be included without overpaying
+var ErrBaseFeeAboveCap = errors.New("base fee exceeds max gas price")
+
+// Source provides fee suggestions, typically an ethclient
+type Source interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Config configures a Strategy
+type Config struct {
+	Mode Mode
+	// Multiplier scales suggested prices (legacy price or priority tip).
+	// Zero means no scaling.
+	Multiplier float64
+	// MaxGasPrice is a hard ceiling on the price per gas; nil means no cap
+	MaxGasPrice *big.Int
+	// FixedGasPrice is the gas price used in ModeFixed
+	FixedGasPrice *big.Int
+}
+
+// Strategy prices transactions according to its Config
+type Strategy struct {
+	cfg Config
+}
+
+// NewStrategy validates cfg and returns a Strategy. An empty mode is legacy.
+func NewStrategy(cfg Config) (*Strategy, error) {
+	if cfg.Mode == "" {
+		cfg.Mode = ModeLegacy
+	}
+	if cfg.Multiplier == 0 {
+		cfg.Multiplier = 1
+	}
+	if cfg.Multiplier < 1 {
+		return nil, fmt.Errorf("gas multiplier must be at least 1, got %g", cfg.Multiplier)
+	}
+	if cfg.MaxGasPrice != nil && cfg.MaxGasPrice.Sign() <= 0 {
+		return nil, fmt.Errorf("max gas price must be positive")
+	}
+
+	switch cfg.Mode {
+	case ModeLegacy, ModeEIP1559:
+	case ModeFixed:
+		if cfg.FixedGasPrice == nil || cfg.FixedGasPrice.Sign() <= 0 {
+			return nil, fmt.Errorf("fixed gas strategy requires a gas price")
+		}
+		if cfg.MaxGasPrice != nil && cfg.FixedGasPrice.Cmp(cfg.MaxGasPrice) > 0 {
+			return nil, fmt.Errorf("fixed gas price exceeds max gas price")
+		}
+	default:
+		return nil, fmt.Errorf("unknown gas strategy %q", cfg.Mode)
+	}
+
+	return &Strategy{cfg: cfg}, nil
+}
+
+// Default returns a legacy strategy without multiplier or cap
+func Default() *Strategy {
+	return &Strategy{cfg: Config{Mode: ModeLegacy, Multiplier: 1}}
+}
+
+// Mode returns the strategy's pricing mode
+func (s *Strategy) Mode() Mode {
+	return s.cfg.Mode
+}
+
+// MaxGasPrice returns the configured price ceiling, or nil when uncapped
+func (s *Strategy) MaxGasPrice() *big.Int {
+	return s.cfg.MaxGasPrice
+}
+
+// Fees holds the fee fields of one transaction. Exactly one of GasPrice or
+// the GasTipCap/GasFeeCap pair is set.
+type Fees struct {
+	GasPrice  *big.Int
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+}
+
+// Dynamic reports whether the fees describe an EIP-1559 transaction
+func (f *Fees) Dynamic() bool {
+	return f.GasFeeCap != nil
+}
+
+// MaxPrice returns the most that can be paid per unit of gas
+func (f *Fees) MaxPrice() *big.Int {
+	if f.Dynamic() {
+		return f.GasFeeCap
+	}
+	return f.GasPrice
+}
+
+// TxFees returns the fee fields of a transaction
+func TxFees(tx *types.Transaction) *Fees {
+	if tx.Type() == types.DynamicFeeTxType {
+		return &Fees{GasTipCap: tx.GasTipCap(), GasFeeCap: tx.GasFeeCap()}
+	}
+	return &Fees{GasPrice: tx.GasPrice()}
+}
+
+// Bump returns the fees of a transaction replacing one sent with f. Nodes
+// only accept a replacement that raises every fee field by their price bump
+// (10% by default), so each field is raised by percent, or to current when
+// the market has moved further. The transaction type is kept.
+func (f *Fees) Bump(percent uint64, current *Fees) *Fees {
+	raise := func(value *big.Int, floor *big.Int) *big.Int {
+		bumped := new(big.Int).Mul(value, new(big.Int).SetUint64(100+percent))
+		bumped.Add(bumped, big.NewInt(99))
+		bumped.Div(bumped, big.NewInt(100))
+		if floor != nil && floor.Cmp(bumped) > 0 {
+			return new(big.Int).Set(floor)
+		}
+		return bumped
+	}
+
+	if !f.Dynamic() {
+		var floor *big.Int
+		if current != nil {
+			floor = current.MaxPrice()
+		}
+		return &Fees{GasPrice: raise(f.GasPrice, floor)}
+	}
+
+	var tipFloor, capFloor *big.Int
+	if current != nil {
+		capFloor = current.MaxPrice()
+		tipFloor = current.GasTipCap
+		if tipFloor == nil {
+			tipFloor = current.GasPrice
+		}
+	}
+	bumped := &Fees{GasTipCap: raise(f.GasTipCap, tipFloor), GasFeeCap: raise(f.GasFeeCap, capFloor)}
+	if bumped.GasTipCap.Cmp(bumped.GasFeeCap) > 0 {
+		bumped.GasFeeCap = new(big.Int).Set(bumped.GasTipCap)
+	}
+	return bumped
+}
+
+// Apply sets the fee fields on transaction options
+func (f *Fees) Apply(opts *bind.TransactOpts) {
+	opts.GasPrice = f.GasPrice
+	opts.GasTipCap = f.GasTipCap
+	opts.GasFeeCap = f.GasFeeCap
+}
+
+// NewTx builds an unsigned transaction of the matching type
+func (f *Fees) NewTx(chainID *big.Int, nonce uint64, to *common.Address, value *big.Int, gasLimit uint64, data []byte) *types.Transaction {
+	if f.Dynamic() {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: f.GasTipCap,
+			GasFeeCap: f.GasFeeCap,
+			Gas:       gasLimit,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		})
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: f.GasPrice,
+		Gas:      gasLimit,
+		To:       to,
+		Value:    value,
+		Data:     data,
+	})
+}
+
+// Fees prices a transaction using the strategy's mode
+func (s *Strategy) Fees(ctx context.Context, src Source) (*Fees, error) {
+	switch s.cfg.Mode {
+	case ModeFixed:
+		return &Fees{GasPrice: new(big.Int).Set(s.cfg.FixedGasPrice)}, nil
+	case ModeEIP1559:
+		return s.dynamicFees(ctx, src)
+	default:
+		return s.legacyFees(ctx, src)
+	}
+}
+
+func (s *Strategy) legacyFees(ctx context.Context, src Source) (*Fees, error) {
+	price, err := src.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Fees{GasPrice: s.capped(s.scale(price))}, nil
+}
+
+// dynamicFees follows geth's defaults: the fee cap leaves room for the base
+// fee to double before the transaction stops being includable
+func (s *Strategy) dynamicFees(ctx context.Context, src Source) (*Fees, error) {
+	head, err := src.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+	if head.BaseFee == nil {
+		// Chain has not activated EIP-1559
+		return s.legacyFees(ctx, src)
+	}
+
+	tip, err := src.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas tip cap: %w", err)
+	}
+	tip = s.scale(tip)
+
+	if s.cfg.MaxGasPrice != nil && head.BaseFee.Cmp(s.cfg.MaxGasPrice) > 0 {
+		return nil, fmt.Errorf("%w: base fee %s wei, max %s wei", ErrBaseFeeAboveCap, head.BaseFee, s.cfg.MaxGasPrice)
+	}
+
+	feeCap := new(big.Int).Add(tip, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+	feeCap = s.capped(feeCap)
+	if tip.Cmp(feeCap) > 0 {
+		tip = new(big.Int).Set(feeCap)
+	}
+
+	return &Fees{GasTipCap: tip, GasFeeCap: feeCap}, nil
+}
+
+// scale applies the multiplier in basis points to keep integer math exact
+func (s *Strategy) scale(value *big.Int) *big.Int {
+	if s.cfg.Multiplier == 1 {
+		return new(big.Int).Set(value)
+	}
+	bps := big.NewInt(int64(math.Round(s.cfg.Multiplier * 10000)))
+	scaled := new(big.Int).Mul(value, bps)
+	return scaled.Div(scaled, big.NewInt(10000))
+}
+
+func (s *Strategy) capped(value *big.Int) *big.Int {
+	if s.cfg.MaxGasPrice != nil && value.Cmp(s.cfg.MaxGasPrice) > 0 {
+		return new(big.Int).Set(s.cfg.MaxGasPrice)
+	}
+	return value
+}
+
+// ParseConfig builds a Config from string settings as found in the network
+// configuration. Prices are in gwei; empty values leave the field unset.
+func ParseConfig(mode, multiplier, maxGasPrice, fixedGasPrice string) (Config, error) {
+	cfg := Config{Mode: Mode(strings.ToLower(strings.TrimSpace(mode)))}
+
+	if multiplier = strings.TrimSpace(multiplier); multiplier != "" {
+		m, err := strconv.ParseFloat(multiplier, 64)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid gas multiplier: %q", multiplier)
+		}
+		cfg.Multiplier = m
+	}
+
+	var err error
+	if cfg.MaxGasPrice, err = ParseGwei(maxGasPrice); err != nil {
+		return Config{}, fmt.Errorf("invalid max gas price: %w", err)
+	}
+	if cfg.FixedGasPrice, err = ParseGwei(fixedGasPrice); err != nil {
+		return Config{}, fmt.Errorf("invalid fixed gas price: %w", err)
+	}
+	return cfg, nil
+}
+
+// ParseGwei parses a decimal gwei amount into wei. An empty string yields nil.
+func ParseGwei(value string) (*big.Int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	if strings.ContainsAny(value, "/eE") {
+		return nil, fmt.Errorf("invalid gwei amount: %q", value)
+	}
+
+	amount, ok := new(big.Rat).SetString(value)
+	if !ok || amount.Sign() < 0 {
+		return nil, fmt.Errorf("invalid gwei amount: %q", value)
+	}
+	amount.Mul(amount, new(big.Rat).SetInt64(1e9))
+	if !amount.IsInt() {
+		return nil, fmt.Errorf("gwei amount %q is below 1 wei precision", value)
+	}
+	return new(big.Int).Set(amount.Num()), nil
+}
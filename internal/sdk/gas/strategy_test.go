@@ -0,0 +1,264 @@
+package gas
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource returns fixed fee suggestions
+type fakeSource struct {
+	gasPrice *big.Int
+	tip      *big.Int
+	baseFee  *big.Int
+	err      error
+}
+
+func (f *fakeSource) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return f.gasPrice, f.err
+}
+
+func (f *fakeSource) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return f.tip, f.err
+}
+
+func (f *fakeSource) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: f.baseFee}, f.err
+}
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e9))
+}
+
+func TestStrategyFees(t *testing.T) {
+	src := &fakeSource{gasPrice: gwei(100), tip: gwei(2), baseFee: gwei(50)}
+
+	tests := []struct {
+		name      string
+		cfg       Config
+		src       *fakeSource
+		gasPrice  *big.Int
+		tipCap    *big.Int
+		feeCap    *big.Int
+		expectErr error
+	}{
+		{
+			name:     "legacy",
+			cfg:      Config{Mode: ModeLegacy},
+			gasPrice: gwei(100),
+		},
+		{
+			name:     "legacy with multiplier",
+			cfg:      Config{Mode: ModeLegacy, Multiplier: 1.2},
+			gasPrice: gwei(120),
+		},
+		{
+			name:     "legacy capped",
+			cfg:      Config{Mode: ModeLegacy, Multiplier: 1.2, MaxGasPrice: gwei(110)},
+			gasPrice: gwei(110),
+		},
+		{
+			name:   "eip1559",
+			cfg:    Config{Mode: ModeEIP1559},
+			tipCap: gwei(2),
+			feeCap: gwei(102),
+		},
+		{
+			name:   "eip1559 with multiplier scales the tip",
+			cfg:    Config{Mode: ModeEIP1559, Multiplier: 1.5},
+			tipCap: gwei(3),
+			feeCap: gwei(103),
+		},
+		{
+			name:   "eip1559 fee cap is capped",
+			cfg:    Config{Mode: ModeEIP1559, MaxGasPrice: gwei(80)},
+			tipCap: gwei(2),
+			feeCap: gwei(80),
+		},
+		{
+			name:      "eip1559 base fee above cap",
+			cfg:       Config{Mode: ModeEIP1559, MaxGasPrice: gwei(40)},
+			expectErr: ErrBaseFeeAboveCap,
+		},
+		{
+			name:     "eip1559 falls back to legacy before London",
+			cfg:      Config{Mode: ModeEIP1559},
+			src:      &fakeSource{gasPrice: gwei(30)},
+			gasPrice: gwei(30),
+		},
+		{
+			name:     "fixed",
+			cfg:      Config{Mode: ModeFixed, FixedGasPrice: gwei(25)},
+			gasPrice: gwei(25),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewStrategy(tt.cfg)
+			require.NoError(t, err)
+
+			source := src
+			if tt.src != nil {
+				source = tt.src
+			}
+
+			fees, err := s.Fees(context.Background(), source)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.gasPrice, fees.GasPrice)
+			assert.Equal(t, tt.tipCap, fees.GasTipCap)
+			assert.Equal(t, tt.feeCap, fees.GasFeeCap)
+		})
+	}
+}
+
+func TestStrategySourceError(t *testing.T) {
+	src := &fakeSource{err: errors.New("connection refused")}
+
+	_, err := Default().Fees(context.Background(), src)
+	assert.EqualError(t, err, "connection refused")
+}
+
+func TestNewStrategyValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		err  string
+	}{
+		{"unknown mode", Config{Mode: "turbo"}, `unknown gas strategy "turbo"`},
+		{"multiplier below one", Config{Multiplier: 0.5}, "gas multiplier must be at least 1, got 0.5"},
+		{"fixed without price", Config{Mode: ModeFixed}, "fixed gas strategy requires a gas price"},
+		{"fixed above cap", Config{Mode: ModeFixed, FixedGasPrice: gwei(10), MaxGasPrice: gwei(5)}, "fixed gas price exceeds max gas price"},
+		{"non-positive cap", Config{MaxGasPrice: big.NewInt(0)}, "max gas price must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewStrategy(tt.cfg)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+
+	s, err := NewStrategy(Config{})
+	require.NoError(t, err)
+	assert.Equal(t, ModeLegacy, s.Mode())
+}
+
+func TestFeesApplyAndNewTx(t *testing.T) {
+	legacy := &Fees{GasPrice: gwei(25)}
+	dynamic := &Fees{GasTipCap: gwei(2), GasFeeCap: gwei(102)}
+
+	opts := &bind.TransactOpts{}
+	dynamic.Apply(opts)
+	assert.Nil(t, opts.GasPrice)
+	assert.Equal(t, gwei(102), opts.GasFeeCap)
+	assert.Equal(t, gwei(102), dynamic.MaxPrice())
+
+	legacy.Apply(opts)
+	assert.Equal(t, gwei(25), opts.GasPrice)
+	assert.Nil(t, opts.GasFeeCap)
+	assert.Equal(t, gwei(25), legacy.MaxPrice())
+
+	assert.Equal(t, uint8(types.LegacyTxType), legacy.NewTx(big.NewInt(501), 1, nil, big.NewInt(0), 21000, nil).Type())
+	assert.Equal(t, uint8(types.DynamicFeeTxType), dynamic.NewTx(big.NewInt(501), 1, nil, big.NewInt(0), 21000, nil).Type())
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig(" EIP1559 ", "1.25", "100", "")
+	require.NoError(t, err)
+	assert.Equal(t, ModeEIP1559, cfg.Mode)
+	assert.Equal(t, 1.25, cfg.Multiplier)
+	assert.Equal(t, gwei(100), cfg.MaxGasPrice)
+	assert.Nil(t, cfg.FixedGasPrice)
+
+	_, err = ParseConfig("legacy", "fast", "", "")
+	assert.EqualError(t, err, `invalid gas multiplier: "fast"`)
+
+	_, err = ParseConfig("fixed", "", "", "cheap")
+	assert.Error(t, err)
+}
+
+func TestParseGwei(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected *big.Int
+		wantErr  bool
+	}{
+		{"", nil, false},
+		{"25", gwei(25), false},
+		{"0.5", big.NewInt(5e8), false},
+		{"0.0000000001", nil, true},
+		{"1e9", nil, true},
+		{"-1", nil, true},
+		{"abc", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			value, err := ParseGwei(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestFeesBump(t *testing.T) {
+	gwei := func(v int64) *big.Int { return new(big.Int).Mul(big.NewInt(v), big.NewInt(1e9)) }
+
+	tests := []struct {
+		name     string
+		prev     *Fees
+		current  *Fees
+		expected *Fees
+	}{
+		{
+			name:     "legacy bumped",
+			prev:     &Fees{GasPrice: gwei(100)},
+			current:  &Fees{GasPrice: gwei(90)},
+			expected: &Fees{GasPrice: gwei(110)},
+		},
+		{
+			name:     "legacy follows market",
+			prev:     &Fees{GasPrice: gwei(100)},
+			current:  &Fees{GasPrice: gwei(150)},
+			expected: &Fees{GasPrice: gwei(150)},
+		},
+		{
+			name:     "legacy rounds up",
+			prev:     &Fees{GasPrice: big.NewInt(15)},
+			expected: &Fees{GasPrice: big.NewInt(17)},
+		},
+		{
+			name:     "dynamic bumped",
+			prev:     &Fees{GasTipCap: gwei(2), GasFeeCap: gwei(52)},
+			current:  &Fees{GasTipCap: gwei(1), GasFeeCap: gwei(51)},
+			expected: &Fees{GasTipCap: big.NewInt(2.2e9), GasFeeCap: big.NewInt(57.2e9)},
+		},
+		{
+			name:     "dynamic follows legacy market",
+			prev:     &Fees{GasTipCap: gwei(2), GasFeeCap: gwei(52)},
+			current:  &Fees{GasPrice: gwei(80)},
+			expected: &Fees{GasTipCap: gwei(80), GasFeeCap: gwei(80)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.prev.Bump(10, tt.current))
+		})
+	}
+}
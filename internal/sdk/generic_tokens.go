@@ -0,0 +1,244 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/patrickmn/go-cache"
+)
+
+// Cache lifetimes for generic token reads
+const (
+	tokenInfoCacheTTL    = 24 * time.Hour // name, symbol and decimals never change
+	tokenBalanceCacheTTL = 15 * time.Second
+	nftOwnerCacheTTL     = 15 * time.Second
+	nftURICacheTTL       = 10 * time.Minute
+)
+
+var (
+	erc20ABI  = mustParseABI(ERC20ABI)
+	erc721ABI = mustParseABI(ERC721ABI)
+)
+
+// ERC20Info represents the static metadata of an ERC-20 token
+type ERC20Info struct {
+	Address  string `json:"address"`
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+}
+
+// ERC20Balance represents a holder's balance of an arbitrary ERC-20 token
+type ERC20Balance struct {
+	Token      string `json:"token"`
+	Address    string `json:"address"`
+	Symbol     string `json:"symbol"`
+	Decimals   uint8  `json:"decimals"`
+	RawBalance string `json:"rawBalance"`
+	Balance    string `json:"balance"`
+}
+
+// GetERC20Info gets name, symbol and decimals of an ERC-20 token
+func (s *BOGOWISDK) GetERC20Info(ctx context.Context, token string) (*ERC20Info, error) {
+	if !common.IsHexAddress(token) {
+		return nil, fmt.Errorf("invalid token address")
+	}
+	tokenAddr := common.HexToAddress(token)
+
+	cacheKey := "erc20:info:" + tokenAddr.Hex()
+	if cached, found := s.readCache().Get(cacheKey); found {
+		return cached.(*ERC20Info), nil
+	}
+
+	ctx, cancel := s.readContext(ctx)
+	defer cancel()
+
+	info := &ERC20Info{Address: tokenAddr.Hex()}
+
+	name, err := s.callView(ctx, erc20ABI, tokenAddr, "name")
+	if err != nil {
+		return nil, err
+	}
+	symbol, err := s.callView(ctx, erc20ABI, tokenAddr, "symbol")
+	if err != nil {
+		return nil, err
+	}
+	decimals, err := s.callView(ctx, erc20ABI, tokenAddr, "decimals")
+	if err != nil {
+		return nil, err
+	}
+
+	var ok bool
+	if info.Name, ok = name.(string); !ok {
+		return nil, fmt.Errorf("unexpected name result type %T", name)
+	}
+	if info.Symbol, ok = symbol.(string); !ok {
+		return nil, fmt.Errorf("unexpected symbol result type %T", symbol)
+	}
+	if info.Decimals, ok = decimals.(uint8); !ok {
+		return nil, fmt.Errorf("unexpected decimals result type %T", decimals)
+	}
+
+	s.readCache().Set(cacheKey, info, tokenInfoCacheTTL)
+	return info, nil
+}
+
+// GetERC20Balance gets the balance of an arbitrary ERC-20 token for a holder
+func (s *BOGOWISDK) GetERC20Balance(ctx context.Context, token string, holder string) (*ERC20Balance, error) {
+	if !common.IsHexAddress(holder) {
+		return nil, fmt.Errorf("invalid holder address")
+	}
+
+	info, err := s.GetERC20Info(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	holderAddr := common.HexToAddress(holder)
+
+	cacheKey := "erc20:balance:" + info.Address + ":" + holderAddr.Hex()
+	if cached, found := s.readCache().Get(cacheKey); found {
+		return cached.(*ERC20Balance), nil
+	}
+
+	ctx, cancel := s.readContext(ctx)
+	defer cancel()
+
+	result, err := s.callView(ctx, erc20ABI, common.HexToAddress(info.Address), "balanceOf", holderAddr)
+	if err != nil {
+		return nil, err
+	}
+	balance, ok := result.(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected balanceOf result type %T", result)
+	}
+
+	tokenBalance := &ERC20Balance{
+		Token:      info.Address,
+		Address:    holderAddr.Hex(),
+		Symbol:     info.Symbol,
+		Decimals:   info.Decimals,
+		RawBalance: balance.String(),
+		Balance:    formatUnits(balance, info.Decimals),
+	}
+
+	s.readCache().Set(cacheKey, tokenBalance, tokenBalanceCacheTTL)
+	return tokenBalance, nil
+}
+
+// GetERC721Owner gets the owner of a token in an arbitrary ERC-721 collection
+func (s *BOGOWISDK) GetERC721Owner(ctx context.Context, token string, tokenID *big.Int) (string, error) {
+	if !common.IsHexAddress(token) {
+		return "", fmt.Errorf("invalid token address")
+	}
+	tokenAddr := common.HexToAddress(token)
+
+	cacheKey := "erc721:owner:" + tokenAddr.Hex() + ":" + tokenID.String()
+	if cached, found := s.readCache().Get(cacheKey); found {
+		return cached.(string), nil
+	}
+
+	ctx, cancel := s.readContext(ctx)
+	defer cancel()
+
+	result, err := s.callView(ctx, erc721ABI, tokenAddr, "ownerOf", tokenID)
+	if err != nil {
+		return "", err
+	}
+	owner, ok := result.(common.Address)
+	if !ok {
+		return "", fmt.Errorf("unexpected ownerOf result type %T", result)
+	}
+
+	s.readCache().Set(cacheKey, owner.Hex(), nftOwnerCacheTTL)
+	return owner.Hex(), nil
+}
+
+// GetERC721TokenURI gets the metadata URI of a token in an arbitrary ERC-721 collection
+func (s *BOGOWISDK) GetERC721TokenURI(ctx context.Context, token string, tokenID *big.Int) (string, error) {
+	if !common.IsHexAddress(token) {
+		return "", fmt.Errorf("invalid token address")
+	}
+	tokenAddr := common.HexToAddress(token)
+
+	cacheKey := "erc721:uri:" + tokenAddr.Hex() + ":" + tokenID.String()
+	if cached, found := s.readCache().Get(cacheKey); found {
+		return cached.(string), nil
+	}
+
+	ctx, cancel := s.readContext(ctx)
+	defer cancel()
+
+	result, err := s.callView(ctx, erc721ABI, tokenAddr, "tokenURI", tokenID)
+	if err != nil {
+		return "", err
+	}
+	uri, ok := result.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected tokenURI result type %T", result)
+	}
+
+	s.readCache().Set(cacheKey, uri, nftURICacheTTL)
+	return uri, nil
+}
+
+// callView executes a read-only contract call and returns its single return value
+func (s *BOGOWISDK) callView(ctx context.Context, contractABI abi.ABI, to common.Address, method string, args ...interface{}) (interface{}, error) {
+	data, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", method, err)
+	}
+
+	output, err := s.client.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	if len(output) == 0 {
+		return nil, fmt.Errorf("%s returned no data (is %s a contract?)", method, to.Hex())
+	}
+
+	values, err := contractABI.Unpack(method, output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", method, err)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("empty %s result", method)
+	}
+	return values[0], nil
+}
+
+// readCache returns the SDK's read cache, creating it on first use
+func (s *BOGOWISDK) readCache() *cache.Cache {
+	s.cacheOnce.Do(func() {
+		s.cache = cache.New(tokenBalanceCacheTTL, time.Minute)
+	})
+	return s.cache
+}
+
+// formatUnits converts a raw token amount to a decimal string
+func formatUnits(amount *big.Int, decimals uint8) string {
+	if amount == nil {
+		amount = big.NewInt(0)
+	}
+
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	value := new(big.Float).Quo(
+		new(big.Float).SetInt(amount),
+		new(big.Float).SetInt(divisor),
+	)
+	return value.String()
+}
+
+// mustParseABI parses a compiled-in ABI definition
+func mustParseABI(abiJSON string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		panic(fmt.Sprintf("invalid ABI definition: %v", err))
+	}
+	return parsed
+}
@@ -0,0 +1,130 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// expectView registers a CallContract expectation for a single view method
+func expectView(client *MockEthClient, contractABI abi.ABI, method string, output []byte, err error) *mock.Call {
+	selector := contractABI.Methods[method].ID
+	return client.On("CallContract", mock.Anything, mock.MatchedBy(func(call ethereum.CallMsg) bool {
+		return bytes.HasPrefix(call.Data, selector)
+	}), mock.Anything).Return(output, err)
+}
+
+func packOutput(t *testing.T, contractABI abi.ABI, method string, values ...interface{}) []byte {
+	packed, err := contractABI.Methods[method].Outputs.Pack(values...)
+	require.NoError(t, err)
+	return packed
+}
+
+func TestGetERC20Balance(t *testing.T) {
+	token := "0x1234567890123456789012345678901234567890"
+	holder := "0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2"
+
+	client := new(MockEthClient)
+	expectView(client, erc20ABI, "name", packOutput(t, erc20ABI, "name", "USD Coin"), nil).Once()
+	expectView(client, erc20ABI, "symbol", packOutput(t, erc20ABI, "symbol", "USDC"), nil).Once()
+	expectView(client, erc20ABI, "decimals", packOutput(t, erc20ABI, "decimals", uint8(6)), nil).Once()
+	expectView(client, erc20ABI, "balanceOf", packOutput(t, erc20ABI, "balanceOf", big.NewInt(1500000)), nil).Once()
+
+	s := &BOGOWISDK{client: client}
+
+	balance, err := s.GetERC20Balance(context.Background(), token, holder)
+	require.NoError(t, err)
+	assert.Equal(t, "USDC", balance.Symbol)
+	assert.Equal(t, uint8(6), balance.Decimals)
+	assert.Equal(t, "1500000", balance.RawBalance)
+	assert.Equal(t, "1.5", balance.Balance)
+
+	// Second read is served from cache; Once() above would fail on a repeat call
+	cached, err := s.GetERC20Balance(context.Background(), token, holder)
+	require.NoError(t, err)
+	assert.Equal(t, balance, cached)
+
+	info, err := s.GetERC20Info(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, "USD Coin", info.Name)
+
+	client.AssertExpectations(t)
+}
+
+func TestGetERC20Info_Errors(t *testing.T) {
+	t.Run("invalid address", func(t *testing.T) {
+		s := &BOGOWISDK{client: new(MockEthClient)}
+		_, err := s.GetERC20Info(context.Background(), "not-an-address")
+		assert.EqualError(t, err, "invalid token address")
+	})
+
+	t.Run("not a contract", func(t *testing.T) {
+		client := new(MockEthClient)
+		expectView(client, erc20ABI, "name", []byte{}, nil)
+		s := &BOGOWISDK{client: client}
+
+		_, err := s.GetERC20Info(context.Background(), "0x1234567890123456789012345678901234567890")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "returned no data")
+	})
+
+	t.Run("call failure", func(t *testing.T) {
+		client := new(MockEthClient)
+		expectView(client, erc20ABI, "name", nil, errors.New("execution reverted"))
+		s := &BOGOWISDK{client: client}
+
+		_, err := s.GetERC20Info(context.Background(), "0x1234567890123456789012345678901234567890")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "execution reverted")
+	})
+}
+
+func TestGetERC721OwnerAndTokenURI(t *testing.T) {
+	token := "0x1234567890123456789012345678901234567890"
+	owner := common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2")
+
+	client := new(MockEthClient)
+	expectView(client, erc721ABI, "ownerOf", packOutput(t, erc721ABI, "ownerOf", owner), nil).Once()
+	expectView(client, erc721ABI, "tokenURI", packOutput(t, erc721ABI, "tokenURI", "ipfs://meta/7"), nil).Once()
+
+	s := &BOGOWISDK{client: client}
+
+	got, err := s.GetERC721Owner(context.Background(), token, big.NewInt(7))
+	require.NoError(t, err)
+	assert.Equal(t, owner.Hex(), got)
+
+	uri, err := s.GetERC721TokenURI(context.Background(), token, big.NewInt(7))
+	require.NoError(t, err)
+	assert.Equal(t, "ipfs://meta/7", uri)
+
+	_, err = s.GetERC721Owner(context.Background(), token, big.NewInt(7))
+	require.NoError(t, err)
+
+	client.AssertExpectations(t)
+}
+
+func TestFormatUnits(t *testing.T) {
+	tests := []struct {
+		amount   *big.Int
+		decimals uint8
+		expected string
+	}{
+		{big.NewInt(1500000), 6, "1.5"},
+		{big.NewInt(0), 18, "0"},
+		{nil, 18, "0"},
+		{big.NewInt(42), 0, "42"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, formatUnits(tt.amount, tt.decimals))
+	}
+}
@@ -0,0 +1,68 @@
+package monitor
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// WriteMetrics writes the balances of the monitors in the Prometheus text
+// exposition format. Amounts are in whole CAM or BOGO.
+func WriteMetrics(w io.Writer, monitors ...*Monitor) error {
+	type sample struct {
+		labels string
+		value  string
+	}
+	var balances, thresholds, lows, spent, topUps []sample
+
+	for _, m := range monitors {
+		m.mu.Lock()
+		for _, a := range m.accounts {
+			labels := fmt.Sprintf(`network=%q,name=%q,address=%q,asset=%q`, m.cfg.Network, a.Name, a.Address.Hex(), a.Asset)
+			if a.balance != nil {
+				balances = append(balances, sample{labels, metricValue(a.balance)})
+			}
+			if a.Threshold != nil {
+				thresholds = append(thresholds, sample{labels, metricValue(a.Threshold)})
+			}
+			low := "0"
+			if a.low {
+				low = "1"
+			}
+			lows = append(lows, sample{labels, low})
+		}
+		if m.cfg.TopUp != nil {
+			m.rollover()
+			labels := fmt.Sprintf(`network=%q,funder=%q`, m.cfg.Network, m.cfg.TopUp.Funder.Address().Hex())
+			spent = append(spent, sample{labels, metricValue(m.spent)})
+			topUps = append(topUps, sample{labels, fmt.Sprint(m.topUps)})
+		}
+		m.mu.Unlock()
+	}
+
+	var b strings.Builder
+	write := func(name, kind, help string, samples []sample) {
+		if len(samples) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+		for _, s := range samples {
+			fmt.Fprintf(&b, "%s{%s} %s\n", name, s.labels, s.value)
+		}
+	}
+	write("bogowi_balance", "gauge", "Balance of a watched account.", balances)
+	write("bogowi_balance_threshold", "gauge", "Balance below which a watched account alerts.", thresholds)
+	write("bogowi_balance_low", "gauge", "Whether a watched account is below its threshold.", lows)
+	write("bogowi_top_up_spent_today", "gauge", "CAM sent by the funding wallet in the current UTC day.", spent)
+	write("bogowi_top_ups_total", "counter", "Top-ups sent by the funding wallet since start.", topUps)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// metricValue renders an 18-decimal amount as a float sample value
+func metricValue(value *big.Int) string {
+	f, _ := new(big.Rat).SetFrac(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)).Float64()
+	return fmt.Sprint(f)
+}
@@ -0,0 +1,367 @@
+// Package monitor watches the balances the API depends on: the CAM of every
+// signer account and the BOGO held by the RewardDistributor. Balances below
+// their threshold raise webhook alerts, and hot wallets can be refilled
+// automatically from a funding wallet under a daily cap.
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"bogowi-blockchain-go/internal/sdk/gas"
+	"bogowi-blockchain-go/internal/sdk/nonce"
+	"bogowi-blockchain-go/internal/sdk/signer"
+	"bogowi-blockchain-go/internal/sdk/txtrack"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Assets a monitored balance can be held in
+const (
+	AssetCAM  = "CAM"
+	AssetBOGO = "BOGO"
+)
+
+// Account names used by the API
+const (
+	NameSigner            = "signer"
+	NameHotWallet         = "hot_wallet"
+	NameFunding           = "funding"
+	NameRewardDistributor = "reward_distributor"
+)
+
+const (
+	// DefaultInterval is how often Run checks balances
+	DefaultInterval = time.Minute
+	// DefaultAlertCooldown is how long a low balance stays quiet after an alert
+	DefaultAlertCooldown = time.Hour
+	// DefaultTopUpCooldown is how long a refilled wallet waits before it can
+	// be refilled again, so a top-up is mined before the next one is sent
+	DefaultTopUpCooldown = 10 * time.Minute
+)
+
+// PurposeTopUp marks top-up transfers in the transaction outbox
+const PurposeTopUp = "gas_top_up"
+
+var erc20BalanceOf = mustParseABI(`[{"inputs":[{"name":"account","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`)
+
+// Client reads balances and sends top-ups, typically an ethclient
+type Client interface {
+	gas.Source
+	gas.Estimator
+	nonce.Source
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// Account is a balance to watch
+type Account struct {
+	// Name says what the account is used for, e.g. "signer" or "hot_wallet"
+	Name    string
+	Address common.Address
+	// Asset is AssetCAM for the native balance or AssetBOGO for a token
+	// balance read from Token
+	Asset string
+	Token common.Address
+	// Threshold is the balance in base units below which the account is
+	// low; nil never alerts
+	Threshold *big.Int
+	// TopUp refills the account from the funding wallet when low
+	TopUp bool
+}
+
+// TopUp configures automatic refills from a funding wallet
+type TopUp struct {
+	Funder signer.Signer
+	// Target is the CAM balance, in wei, a low wallet is refilled to
+	Target *big.Int
+	// DailyMax caps the CAM, in wei, sent by the funding wallet per UTC day
+	DailyMax *big.Int
+	Fees     *gas.Strategy
+	// Cooldown is the minimum time between two top-ups of a wallet. Zero
+	// uses DefaultTopUpCooldown.
+	Cooldown time.Duration
+}
+
+// Config configures a Monitor
+type Config struct {
+	Network  string
+	ChainID  *big.Int
+	Accounts []Account
+	// Interval between checks; zero uses DefaultInterval
+	Interval time.Duration
+	// Alerts receives low balance and top-up events; nil disables alerts
+	Alerts *Webhook
+	// AlertCooldown is how long a low balance stays quiet after an alert;
+	// zero uses DefaultAlertCooldown
+	AlertCooldown time.Duration
+	// TopUp enables automatic refills; nil disables them
+	TopUp *TopUp
+}
+
+// Balance reports a watched balance. Amounts are in whole CAM or BOGO.
+type Balance struct {
+	Name      string     `json:"name"`
+	Address   string     `json:"address"`
+	Asset     string     `json:"asset"`
+	Balance   string     `json:"balance,omitempty"`
+	Threshold string     `json:"threshold,omitempty"`
+	Low       bool       `json:"low"`
+	Error     string     `json:"error,omitempty"`
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+}
+
+// TopUpStatus reports the funding wallet's activity today
+type TopUpStatus struct {
+	Funder     string `json:"funder"`
+	Target     string `json:"target"`
+	DailyMax   string `json:"dailyMax"`
+	SpentToday string `json:"spentToday"`
+	Count      uint64 `json:"count"`
+	LastError  string `json:"lastError,omitempty"`
+}
+
+// Status reports every watched balance of a network
+type Status struct {
+	Network  string       `json:"network"`
+	Healthy  bool         `json:"healthy"`
+	Balances []Balance    `json:"balances"`
+	TopUp    *TopUpStatus `json:"topUp,omitempty"`
+}
+
+type watched struct {
+	Account
+	balance      *big.Int
+	err          error
+	checkedAt    time.Time
+	low          bool
+	alertedAt    time.Time
+	lastTopUp    time.Time
+	topUpAlerted time.Time
+}
+
+// Monitor checks the configured balances periodically
+type Monitor struct {
+	client Client
+	cfg    Config
+
+	mu       sync.Mutex
+	accounts []*watched
+	tracker  *txtrack.Tracker
+
+	// Funding wallet spend for the current UTC day
+	day        string
+	spent      *big.Int
+	topUps     uint64
+	topUpError string
+
+	now func() time.Time
+}
+
+// New creates a monitor for cfg
+func New(client Client, cfg Config) (*Monitor, error) {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.AlertCooldown <= 0 {
+		cfg.AlertCooldown = DefaultAlertCooldown
+	}
+	if cfg.TopUp != nil {
+		if cfg.TopUp.Funder == nil {
+			return nil, fmt.Errorf("top-up needs a funding wallet")
+		}
+		if cfg.TopUp.Target == nil || cfg.TopUp.Target.Sign() <= 0 {
+			return nil, fmt.Errorf("top-up target must be positive")
+		}
+		if cfg.TopUp.DailyMax == nil {
+			cfg.TopUp.DailyMax = big.NewInt(0)
+		}
+		if cfg.TopUp.Fees == nil {
+			cfg.TopUp.Fees = gas.Default()
+		}
+		if cfg.TopUp.Cooldown <= 0 {
+			cfg.TopUp.Cooldown = DefaultTopUpCooldown
+		}
+	}
+
+	m := &Monitor{client: client, cfg: cfg, spent: big.NewInt(0), now: time.Now}
+	for _, account := range cfg.Accounts {
+		if account.Asset == "" {
+			account.Asset = AssetCAM
+		}
+		if account.Asset != AssetCAM && account.Asset != AssetBOGO {
+			return nil, fmt.Errorf("unsupported asset %q", account.Asset)
+		}
+		m.accounts = append(m.accounts, &watched{Account: account})
+	}
+	return m, nil
+}
+
+// Network returns the network the monitor watches
+func (m *Monitor) Network() string {
+	return m.cfg.Network
+}
+
+// SetTxTracker records top-ups in the tracker's outbox and lets it replace
+// stuck top-ups of the funding wallet
+func (m *Monitor) SetTxTracker(tracker *txtrack.Tracker) {
+	m.mu.Lock()
+	m.tracker = tracker
+	m.mu.Unlock()
+
+	if m.cfg.TopUp != nil {
+		auth := signer.TransactOpts(m.cfg.TopUp.Funder, m.cfg.ChainID)
+		tracker.RegisterSigner(auth.From, auth.Signer)
+	}
+}
+
+// Run checks balances until ctx is cancelled
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		m.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check reads every balance once, raises alerts and refills low hot wallets
+func (m *Monitor) Check(ctx context.Context) {
+	for _, w := range m.accounts {
+		balance, err := m.balanceOf(ctx, w.Account)
+
+		m.mu.Lock()
+		w.checkedAt = m.now().UTC()
+		w.err = err
+		if err != nil {
+			m.mu.Unlock()
+			log.Printf("monitor: %s %s balance of %s failed: %v", m.cfg.Network, w.Asset, w.Address.Hex(), err)
+			continue
+		}
+		w.balance = balance
+		wasLow := w.low
+		w.low = w.Threshold != nil && balance.Cmp(w.Threshold) < 0
+		alert := w.low && (!wasLow || m.now().Sub(w.alertedAt) >= m.cfg.AlertCooldown)
+		if alert {
+			w.alertedAt = m.now()
+		}
+		m.mu.Unlock()
+
+		switch {
+		case alert:
+			log.Printf("monitor: %s %s %s balance %s is below %s", m.cfg.Network, w.Name, w.Asset, formatUnits(balance), formatUnits(w.Threshold))
+			m.alert(ctx, EventBalanceLow, w)
+		case wasLow && !w.low:
+			m.alert(ctx, EventBalanceRecovered, w)
+		}
+
+		if w.low && w.TopUp {
+			m.topUp(ctx, w)
+		}
+	}
+}
+
+// balanceOf reads the CAM or BOGO balance of an account
+func (m *Monitor) balanceOf(ctx context.Context, account Account) (*big.Int, error) {
+	if account.Asset == AssetCAM {
+		return m.client.BalanceAt(ctx, account.Address, nil)
+	}
+
+	data, err := erc20BalanceOf.Pack("balanceOf", account.Address)
+	if err != nil {
+		return nil, err
+	}
+	token := account.Token
+	out, err := m.client.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	values, err := erc20BalanceOf.Unpack("balanceOf", out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode balance: %w", err)
+	}
+	return values[0].(*big.Int), nil
+}
+
+// Status reports every watched balance. The network is healthy when no
+// balance is low or unreadable.
+func (m *Monitor) Status() Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := Status{Network: m.cfg.Network, Healthy: true}
+	for _, w := range m.accounts {
+		b := Balance{
+			Name:    w.Name,
+			Address: w.Address.Hex(),
+			Asset:   w.Asset,
+			Low:     w.low,
+		}
+		if w.Threshold != nil {
+			b.Threshold = formatUnits(w.Threshold)
+		}
+		if w.balance != nil {
+			b.Balance = formatUnits(w.balance)
+		}
+		if w.err != nil {
+			b.Error = w.err.Error()
+		}
+		if !w.checkedAt.IsZero() {
+			checkedAt := w.checkedAt
+			b.CheckedAt = &checkedAt
+		}
+		if w.low || w.err != nil {
+			status.Healthy = false
+		}
+		status.Balances = append(status.Balances, b)
+	}
+
+	if m.cfg.TopUp != nil {
+		m.rollover()
+		status.TopUp = &TopUpStatus{
+			Funder:     m.cfg.TopUp.Funder.Address().Hex(),
+			Target:     formatUnits(m.cfg.TopUp.Target),
+			DailyMax:   formatUnits(m.cfg.TopUp.DailyMax),
+			SpentToday: formatUnits(m.spent),
+			Count:      m.topUps,
+			LastError:  m.topUpError,
+		}
+	}
+	return status
+}
+
+// formatUnits renders an 18-decimal base-unit amount in whole units
+func formatUnits(value *big.Int) string {
+	rat := new(big.Rat).SetFrac(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+	s := rat.FloatString(18)
+	for s[len(s)-1] == '0' {
+		s = s[:len(s)-1]
+	}
+	if s[len(s)-1] == '.' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
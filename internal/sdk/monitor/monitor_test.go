@@ -0,0 +1,394 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"bogowi-blockchain-go/internal/sdk/signer"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var chainID = big.NewInt(501)
+
+type stubClient struct {
+	mu       sync.Mutex
+	balances map[common.Address]*big.Int
+	tokens   map[common.Address]*big.Int
+	sent     []*types.Transaction
+	sendErr  error
+}
+
+func newStubClient() *stubClient {
+	return &stubClient{
+		balances: make(map[common.Address]*big.Int),
+		tokens:   make(map[common.Address]*big.Int),
+	}
+}
+
+func (s *stubClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(25e9), nil
+}
+
+func (s *stubClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1e9), nil
+}
+
+func (s *stubClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{GasLimit: 30_000_000}, nil
+}
+
+func (s *stubClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return 21000, nil
+}
+
+func (s *stubClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return 0, nil
+}
+
+func (s *stubClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	balance, ok := s.balances[account]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return new(big.Int).Set(balance), nil
+}
+
+func (s *stubClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	account, err := erc20BalanceOf.Methods["balanceOf"].Inputs.Unpack(msg.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return erc20BalanceOf.Methods["balanceOf"].Outputs.Pack(s.tokens[account[0].(common.Address)])
+}
+
+func (s *stubClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.sent = append(s.sent, tx)
+	s.balances[*tx.To()] = new(big.Int).Add(s.balances[*tx.To()], tx.Value())
+	return nil
+}
+
+func (s *stubClient) setBalance(account common.Address, cam float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.balances[account] = cam2wei(cam)
+}
+
+func cam2wei(cam float64) *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(cam), big.NewFloat(1e18)).Int(nil)
+	return wei
+}
+
+func newAddress(t *testing.T) common.Address {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func newSigner(t *testing.T) signer.Signer {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return signer.NewKeySigner(key)
+}
+
+// recorder collects the alerts posted to a test webhook
+type recorder struct {
+	mu     sync.Mutex
+	alerts []Alert
+}
+
+func (r *recorder) events() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := make([]string, len(r.alerts))
+	for i, alert := range r.alerts {
+		events[i] = alert.Event
+	}
+	return events
+}
+
+func newRecorder(t *testing.T) (*recorder, *Webhook) {
+	t.Helper()
+	r := &recorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var alert Alert
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&alert))
+		r.mu.Lock()
+		r.alerts = append(r.alerts, alert)
+		r.mu.Unlock()
+	}))
+	t.Cleanup(server.Close)
+	return r, NewWebhook(server.URL)
+}
+
+func TestCheckAlerts(t *testing.T) {
+	client := newStubClient()
+	account := newAddress(t)
+	client.setBalance(account, 5)
+
+	r, webhook := newRecorder(t)
+	m, err := New(client, Config{
+		Network:       "testnet",
+		ChainID:       chainID,
+		Accounts:      []Account{{Name: NameSigner, Address: account, Threshold: cam2wei(1)}},
+		Alerts:        webhook,
+		AlertCooldown: time.Hour,
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	m.Check(ctx)
+	assert.Empty(t, r.events())
+	assert.True(t, m.Status().Healthy)
+
+	// Dropping below the threshold alerts once per cooldown
+	client.setBalance(account, 0.5)
+	m.Check(ctx)
+	m.Check(ctx)
+	assert.Equal(t, []string{EventBalanceLow}, r.events())
+
+	status := m.Status()
+	assert.False(t, status.Healthy)
+	require.Len(t, status.Balances, 1)
+	assert.Equal(t, "0.5", status.Balances[0].Balance)
+	assert.Equal(t, "1", status.Balances[0].Threshold)
+	assert.True(t, status.Balances[0].Low)
+
+	now = now.Add(time.Hour)
+	m.Check(ctx)
+	assert.Equal(t, []string{EventBalanceLow, EventBalanceLow}, r.events())
+
+	client.setBalance(account, 3)
+	m.Check(ctx)
+	assert.Equal(t, []string{EventBalanceLow, EventBalanceLow, EventBalanceRecovered}, r.events())
+	assert.True(t, m.Status().Healthy)
+}
+
+func TestCheckUnreadableBalance(t *testing.T) {
+	client := newStubClient()
+	m, err := New(client, Config{
+		Network:  "testnet",
+		ChainID:  chainID,
+		Accounts: []Account{{Name: NameSigner, Address: newAddress(t), Threshold: cam2wei(1)}},
+	})
+	require.NoError(t, err)
+
+	m.Check(context.Background())
+
+	status := m.Status()
+	assert.False(t, status.Healthy)
+	assert.Equal(t, "connection refused", status.Balances[0].Error)
+	assert.NotNil(t, status.Balances[0].CheckedAt)
+}
+
+func TestCheckTokenBalance(t *testing.T) {
+	client := newStubClient()
+	token := newAddress(t)
+	distributor := newAddress(t)
+	client.tokens[distributor] = cam2wei(250)
+
+	m, err := New(client, Config{
+		Network: "testnet",
+		ChainID: chainID,
+		Accounts: []Account{{
+			Name:      NameRewardDistributor,
+			Address:   distributor,
+			Asset:     AssetBOGO,
+			Token:     token,
+			Threshold: cam2wei(1000),
+		}},
+	})
+	require.NoError(t, err)
+
+	m.Check(context.Background())
+
+	status := m.Status()
+	require.Len(t, status.Balances, 1)
+	assert.Equal(t, AssetBOGO, status.Balances[0].Asset)
+	assert.Equal(t, "250", status.Balances[0].Balance)
+	assert.True(t, status.Balances[0].Low)
+}
+
+func TestTopUp(t *testing.T) {
+	client := newStubClient()
+	funder := newSigner(t)
+	client.setBalance(funder.Address(), 100)
+
+	wallets := []common.Address{newAddress(t), newAddress(t), newAddress(t)}
+	accounts := make([]Account, len(wallets))
+	for i, address := range wallets {
+		client.setBalance(address, 0)
+		accounts[i] = Account{Name: NameHotWallet, Address: address, Threshold: cam2wei(0.5), TopUp: true}
+	}
+
+	r, webhook := newRecorder(t)
+	m, err := New(client, Config{
+		Network:  "testnet",
+		ChainID:  chainID,
+		Accounts: accounts,
+		Alerts:   webhook,
+		TopUp: &TopUp{
+			Funder:   funder,
+			Target:   cam2wei(2),
+			DailyMax: cam2wei(3),
+		},
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	m.Check(context.Background())
+
+	// The first wallet is filled to the target, the second gets what is left
+	// of the daily cap and the third is refused
+	require.Len(t, client.sent, 2)
+	assert.Equal(t, wallets[0], *client.sent[0].To())
+	assert.Equal(t, cam2wei(2), client.sent[0].Value())
+	assert.Equal(t, wallets[1], *client.sent[1].To())
+	assert.Equal(t, cam2wei(1), client.sent[1].Value())
+	assert.Equal(t, uint64(1), client.sent[1].Nonce())
+
+	assert.Contains(t, r.events(), EventTopUpSent)
+	assert.Contains(t, r.events(), EventTopUpFailed)
+
+	status := m.Status()
+	require.NotNil(t, status.TopUp)
+	assert.Equal(t, "3", status.TopUp.SpentToday)
+	assert.Equal(t, uint64(2), status.TopUp.Count)
+	assert.Equal(t, "daily top-up cap reached", status.TopUp.LastError)
+
+	// The cap resets at UTC midnight
+	now = now.Add(12 * time.Hour)
+	m.Check(context.Background())
+	require.Len(t, client.sent, 3)
+	assert.Equal(t, wallets[2], *client.sent[2].To())
+	assert.Equal(t, "2", m.Status().TopUp.SpentToday)
+}
+
+func TestTopUpFailureReleasesCap(t *testing.T) {
+	client := newStubClient()
+	client.sendErr = errors.New("insufficient funds for gas * price + value")
+	funder := newSigner(t)
+	wallet := newAddress(t)
+	client.setBalance(wallet, 0.1)
+
+	m, err := New(client, Config{
+		Network:  "testnet",
+		ChainID:  chainID,
+		Accounts: []Account{{Name: NameHotWallet, Address: wallet, Threshold: cam2wei(0.5), TopUp: true}},
+		TopUp:    &TopUp{Funder: funder, Target: cam2wei(2), DailyMax: cam2wei(10)},
+	})
+	require.NoError(t, err)
+
+	m.Check(context.Background())
+
+	status := m.Status()
+	assert.Equal(t, "0", status.TopUp.SpentToday)
+	assert.Equal(t, uint64(0), status.TopUp.Count)
+	assert.Contains(t, status.TopUp.LastError, "insufficient funds")
+
+	// The wallet is retried on the next check instead of waiting out the cooldown
+	client.sendErr = nil
+	m.Check(context.Background())
+	require.Len(t, client.sent, 1)
+	assert.Equal(t, cam2wei(1.9), client.sent[0].Value())
+}
+
+func TestNewValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{
+			name:    "top-up without funder",
+			cfg:     Config{TopUp: &TopUp{Target: big.NewInt(1)}},
+			wantErr: "top-up needs a funding wallet",
+		},
+		{
+			name:    "top-up without target",
+			cfg:     Config{TopUp: &TopUp{Funder: newSigner(t)}},
+			wantErr: "top-up target must be positive",
+		},
+		{
+			name:    "unsupported asset",
+			cfg:     Config{Accounts: []Account{{Asset: "ETH"}}},
+			wantErr: `unsupported asset "ETH"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(newStubClient(), tt.cfg)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWriteMetrics(t *testing.T) {
+	client := newStubClient()
+	account := newAddress(t)
+	client.setBalance(account, 0.25)
+
+	m, err := New(client, Config{
+		Network:  "testnet",
+		ChainID:  chainID,
+		Accounts: []Account{{Name: NameSigner, Address: account, Threshold: cam2wei(1)}},
+	})
+	require.NoError(t, err)
+	m.Check(context.Background())
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteMetrics(&buf, m))
+
+	labels := `{network="testnet",name="signer",address="` + account.Hex() + `",asset="CAM"}`
+	assert.Contains(t, buf.String(), "# TYPE bogowi_balance gauge\n")
+	assert.Contains(t, buf.String(), "bogowi_balance"+labels+" 0.25\n")
+	assert.Contains(t, buf.String(), "bogowi_balance_threshold"+labels+" 1\n")
+	assert.Contains(t, buf.String(), "bogowi_balance_low"+labels+" 1\n")
+	assert.NotContains(t, buf.String(), "bogowi_top_up_spent_today")
+}
+
+func TestFormatUnits(t *testing.T) {
+	tests := []struct {
+		value    *big.Int
+		expected string
+	}{
+		{big.NewInt(0), "0"},
+		{cam2wei(2), "2"},
+		{cam2wei(0.5), "0.5"},
+		{big.NewInt(1), "0.000000000000000001"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, formatUnits(tt.value))
+	}
+}
@@ -0,0 +1,121 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+
+	"bogowi-blockchain-go/internal/sdk/gas"
+	"bogowi-blockchain-go/internal/sdk/nonce"
+	"bogowi-blockchain-go/internal/sdk/txtrack"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// topUp refills a low hot wallet to the target balance from the funding
+// wallet, within what is left of today's cap
+func (m *Monitor) topUp(ctx context.Context, w *watched) {
+	topUp := m.cfg.TopUp
+	if topUp == nil || w.Asset != AssetCAM || w.Address == topUp.Funder.Address() {
+		return
+	}
+
+	m.mu.Lock()
+	if m.now().Sub(w.lastTopUp) < topUp.Cooldown {
+		m.mu.Unlock()
+		return
+	}
+	amount := new(big.Int).Sub(topUp.Target, w.balance)
+	if amount.Sign() <= 0 {
+		m.mu.Unlock()
+		return
+	}
+	m.rollover()
+	left := new(big.Int).Sub(topUp.DailyMax, m.spent)
+	if left.Sign() <= 0 {
+		m.topUpError = "daily top-up cap reached"
+		alert := m.now().Sub(w.topUpAlerted) >= m.cfg.AlertCooldown
+		if alert {
+			w.topUpAlerted = m.now()
+		}
+		m.mu.Unlock()
+		if alert {
+			m.alert(ctx, EventTopUpFailed, w)
+		}
+		return
+	}
+	if amount.Cmp(left) > 0 {
+		amount = left
+	}
+	// Book the amount before sending so concurrent checks cannot exceed the cap
+	m.spent.Add(m.spent, amount)
+	w.lastTopUp = m.now()
+	tracker := m.tracker
+	m.mu.Unlock()
+
+	tx, err := m.send(ctx, tracker, w, amount)
+	if err != nil {
+		m.mu.Lock()
+		m.spent.Sub(m.spent, amount)
+		w.lastTopUp = w.lastTopUp.Add(-topUp.Cooldown)
+		m.topUpError = err.Error()
+		m.mu.Unlock()
+
+		log.Printf("monitor: %s top-up of %s failed: %v", m.cfg.Network, w.Address.Hex(), err)
+		m.alert(ctx, EventTopUpFailed, w)
+		return
+	}
+
+	m.mu.Lock()
+	m.topUps++
+	m.topUpError = ""
+	m.mu.Unlock()
+
+	log.Printf("monitor: %s topped up %s with %s CAM in %s", m.cfg.Network, w.Address.Hex(), formatUnits(amount), tx.Hash().Hex())
+	m.alertTopUp(ctx, w, amount, tx.Hash().Hex())
+}
+
+// send transfers amount from the funding wallet to w
+func (m *Monitor) send(ctx context.Context, tracker *txtrack.Tracker, w *watched, amount *big.Int) (*types.Transaction, error) {
+	topUp := m.cfg.TopUp
+	from := topUp.Funder.Address()
+	to := w.Address
+
+	fees, err := topUp.Fees.Fees(ctx, m.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to price top-up: %w", err)
+	}
+	est, err := gas.EstimateTransfer(ctx, m.client, from, &to, amount, nil, fees, topUp.Fees.Multiplier())
+	if err != nil {
+		return nil, err
+	}
+
+	var tx *types.Transaction
+	err = nonce.For(m.cfg.ChainID, from).Send(ctx, m.client, func(n uint64) error {
+		signed, err := topUp.Funder.SignTx(ctx, fees.NewTx(m.cfg.ChainID, n, &to, amount, est.GasLimit, nil), m.cfg.ChainID)
+		if err != nil {
+			return fmt.Errorf("failed to sign top-up: %w", err)
+		}
+		if tracker != nil {
+			err = tracker.Submit(ctx, signed, txtrack.Meta{Purpose: PurposeTopUp, Ref: to.Hex()})
+		} else {
+			err = m.client.SendTransaction(ctx, signed)
+		}
+		if err != nil {
+			return err
+		}
+		tx = signed
+		return nil
+	})
+	return tx, err
+}
+
+// rollover starts a new spending day at UTC midnight. Callers hold m.mu.
+func (m *Monitor) rollover() {
+	today := m.now().UTC().Format("2006-01-02")
+	if m.day != today {
+		m.day = today
+		m.spent = big.NewInt(0)
+	}
+}
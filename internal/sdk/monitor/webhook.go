@@ -0,0 +1,136 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"time"
+)
+
+// Alert events
+const (
+	// EventBalanceLow fires when a balance drops below its threshold, and
+	// again after the alert cooldown while it stays low
+	EventBalanceLow = "balance_low"
+	// EventBalanceRecovered fires when a low balance is back above its threshold
+	EventBalanceRecovered = "balance_recovered"
+	// EventTopUpSent fires when the funding wallet refilled a hot wallet
+	EventTopUpSent = "top_up_sent"
+	// EventTopUpFailed fires when a top-up could not be sent, including when
+	// the daily cap is exhausted
+	EventTopUpFailed = "top_up_failed"
+)
+
+// Alert is the JSON body posted to the webhook. Amounts are in whole CAM or BOGO.
+type Alert struct {
+	Event     string    `json:"event"`
+	Network   string    `json:"network"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	Asset     string    `json:"asset"`
+	Balance   string    `json:"balance,omitempty"`
+	Threshold string    `json:"threshold,omitempty"`
+	Amount    string    `json:"amount,omitempty"`
+	TxHash    string    `json:"txHash,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// Webhook posts alerts to an HTTP endpoint, e.g. a Slack or PagerDuty relay
+type Webhook struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhook creates a webhook posting to url
+func NewWebhook(url string) *Webhook {
+	return &Webhook{
+		url: url,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// Send posts alert as JSON. Any non-2xx response is an error.
+func (w *Webhook) Send(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// alert reports an event about w to the webhook, if one is configured
+func (m *Monitor) alert(ctx context.Context, event string, w *watched) {
+	m.mu.Lock()
+	alert := Alert{
+		Event:   event,
+		Network: m.cfg.Network,
+		Name:    w.Name,
+		Address: w.Address.Hex(),
+		Asset:   w.Asset,
+		Time:    m.now().UTC(),
+	}
+	if w.balance != nil {
+		alert.Balance = formatUnits(w.balance)
+	}
+	if w.Threshold != nil {
+		alert.Threshold = formatUnits(w.Threshold)
+	}
+	if event == EventTopUpFailed {
+		alert.Error = m.topUpError
+	}
+	m.mu.Unlock()
+
+	m.deliver(ctx, alert)
+}
+
+// alertTopUp reports a top-up of amount sent to w in txHash
+func (m *Monitor) alertTopUp(ctx context.Context, w *watched, amount *big.Int, txHash string) {
+	m.mu.Lock()
+	alert := Alert{
+		Event:   EventTopUpSent,
+		Network: m.cfg.Network,
+		Name:    w.Name,
+		Address: w.Address.Hex(),
+		Asset:   w.Asset,
+		Balance: formatUnits(w.balance),
+		Amount:  formatUnits(amount),
+		TxHash:  txHash,
+		Time:    m.now().UTC(),
+	}
+	m.mu.Unlock()
+
+	m.deliver(ctx, alert)
+}
+
+// deliver posts an alert, logging delivery failures
+func (m *Monitor) deliver(ctx context.Context, alert Alert) {
+	if m.cfg.Alerts == nil {
+		return
+	}
+	if err := m.cfg.Alerts.Send(ctx, alert); err != nil {
+		log.Printf("monitor: %s alert %s for %s failed: %v", alert.Network, alert.Event, alert.Address, err)
+	}
+}
@@ -0,0 +1,415 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"bogowi-blockchain-go/internal/sdk/gas"
+	"bogowi-blockchain-go/internal/sdk/txtrack"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Errors returned when a native transfer would exceed the configured caps
+var (
+	ErrNativeTransferCapExceeded = errors.New("amount exceeds per-transfer cap")
+	ErrNativeDailyCapExceeded    = errors.New("amount exceeds remaining daily cap")
+)
+
+// NativeBalance represents the native CAM balance of an address
+type NativeBalance struct {
+	Address    string `json:"address"`
+	Balance    string `json:"balance"`
+	RawBalance string `json:"rawBalance"`
+}
+
+// NativeTransfer represents a submitted native CAM transfer
+type NativeTransfer struct {
+	TxHash         string    `json:"transactionHash"`
+	From           string    `json:"from"`
+	To             string    `json:"to"`
+	Amount         string    `json:"amount"`
+	Nonce          uint64    `json:"nonce"`
+	GasLimit       uint64    `json:"gasLimit"`
+	GasPrice       string    `json:"gasPrice"` // max price per gas, in wei
+	DailyRemaining string    `json:"dailyRemaining"`
+	SubmittedAt    time.Time `json:"submittedAt"`
+
+	value *big.Int
+}
+
+// purposeNativeTransfer marks native transfers in the transaction outbox
+const purposeNativeTransfer = "native_transfer"
+
+// nativeTransferLimiter enforces per-transfer and per-day caps on native
+// transfers and keeps the transfers submitted during the current UTC day
+type nativeTransferLimiter struct {
+	mu        sync.Mutex
+	maxPerTx  *big.Int
+	maxPerDay *big.Int
+	day       string
+	spent     *big.Int
+	transfers []*NativeTransfer
+	now       func() time.Time
+
+	// load returns the transfers persisted since a time, so the daily cap
+	// holds across restarts; nil keeps the accounting in memory only
+	load func(since time.Time) ([]*NativeTransfer, error)
+}
+
+// newNativeTransferLimiter creates a limiter from caps expressed in CAM.
+// An empty cap is treated as zero, which disables native transfers.
+func newNativeTransferLimiter(maxPerTx string, maxPerDay string) (*nativeTransferLimiter, error) {
+	perTx, err := parseCap(maxPerTx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid per-transfer cap: %w", err)
+	}
+	perDay, err := parseCap(maxPerDay)
+	if err != nil {
+		return nil, fmt.Errorf("invalid daily cap: %w", err)
+	}
+
+	return &nativeTransferLimiter{
+		maxPerTx:  perTx,
+		maxPerDay: perDay,
+		spent:     big.NewInt(0),
+		now:       time.Now,
+	}, nil
+}
+
+func parseCap(value string) (*big.Int, error) {
+	if strings.TrimSpace(value) == "" {
+		return big.NewInt(0), nil
+	}
+	return parseUnits(value, 18)
+}
+
+// reserve books an amount against today's cap and returns what is left
+func (l *nativeTransferLimiter) reserve(amount *big.Int) (*big.Int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	spent, err := l.allow(amount)
+	if err != nil {
+		return nil, err
+	}
+	l.spent = spent
+
+	return new(big.Int).Sub(l.maxPerDay, l.spent), nil
+}
+
+// check reports whether the caps allow an amount without booking it, and
+// returns what would be left today
+func (l *nativeTransferLimiter) check(amount *big.Int) (*big.Int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	spent, err := l.allow(amount)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Sub(l.maxPerDay, spent), nil
+}
+
+// allow checks an amount against the caps and returns today's spending with
+// it; callers hold l.mu
+func (l *nativeTransferLimiter) allow(amount *big.Int) (*big.Int, error) {
+	if l.maxPerTx.Sign() == 0 || l.maxPerDay.Sign() == 0 {
+		return nil, fmt.Errorf("native transfers are disabled on this network")
+	}
+	if amount.Cmp(l.maxPerTx) > 0 {
+		return nil, fmt.Errorf("%w (%s CAM)", ErrNativeTransferCapExceeded, formatEther(l.maxPerTx))
+	}
+
+	if err := l.rollover(); err != nil {
+		return nil, err
+	}
+	spent := new(big.Int).Add(l.spent, amount)
+	if spent.Cmp(l.maxPerDay) > 0 {
+		remaining := new(big.Int).Sub(l.maxPerDay, l.spent)
+		return nil, fmt.Errorf("%w (%s CAM left today)", ErrNativeDailyCapExceeded, formatEther(remaining))
+	}
+	return spent, nil
+}
+
+// release returns a reserved amount after a failed transfer
+func (l *nativeTransferLimiter) release(amount *big.Int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.spent = new(big.Int).Sub(l.spent, amount)
+	if l.spent.Sign() < 0 {
+		l.spent = big.NewInt(0)
+	}
+}
+
+// record stores a submitted transfer in today's history
+func (l *nativeTransferLimiter) record(transfer *NativeTransfer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.transfers = append(l.transfers, transfer)
+}
+
+// setLoader makes the limiter start each day from the transfers load returns
+func (l *nativeTransferLimiter) setLoader(load func(since time.Time) ([]*NativeTransfer, error)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.load = load
+	l.day = ""
+}
+
+// history returns the transfers submitted today. While today's transfers
+// cannot be loaded none are listed, and reserve refuses new ones.
+func (l *nativeTransferLimiter) history() []*NativeTransfer {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.rollover(); err != nil {
+		return []*NativeTransfer{}
+	}
+	transfers := make([]*NativeTransfer, len(l.transfers))
+	copy(transfers, l.transfers)
+	return transfers
+}
+
+// rollover resets the daily accounting when the UTC day changes, starting
+// from the transfers already persisted that day; callers hold l.mu
+func (l *nativeTransferLimiter) rollover() error {
+	now := l.now().UTC()
+	day := now.Format("2006-01-02")
+	if day == l.day {
+		return nil
+	}
+
+	spent := big.NewInt(0)
+	var transfers []*NativeTransfer
+	if l.load != nil {
+		midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		loaded, err := l.load(midnight)
+		if err != nil {
+			return fmt.Errorf("failed to load today's native transfers: %w", err)
+		}
+		for _, transfer := range loaded {
+			spent.Add(spent, transfer.value)
+		}
+		transfers = loaded
+	}
+
+	l.day = day
+	l.spent = spent
+	l.transfers = transfers
+	return nil
+}
+
+// GetNativeBalance gets the native CAM balance of an address
+func (s *BOGOWISDK) GetNativeBalance(ctx context.Context, address string) (*NativeBalance, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid address")
+	}
+
+	ctx, cancel := s.readContext(ctx)
+	defer cancel()
+
+	balance, err := s.client.BalanceAt(ctx, common.HexToAddress(address), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get native balance: %w", err)
+	}
+
+	return &NativeBalance{
+		Address:    address,
+		Balance:    formatEther(balance),
+		RawBalance: balance.String(),
+	}, nil
+}
+
+// TransferNative sends native CAM from the SDK signer to a recipient.
+// The amount is in CAM and is checked against the network's transfer caps.
+func (s *BOGOWISDK) TransferNative(ctx context.Context, to string, amount string) (*NativeTransfer, error) {
+	ctx, cancel := s.writeContext(ctx)
+	defer cancel()
+	return s.transferNative(ctx, to, amount)
+}
+
+// EstimateTransferNative estimates a native transfer without sending it. The
+// transfer caps are checked but nothing is reserved.
+func (s *BOGOWISDK) EstimateTransferNative(ctx context.Context, to string, amount string) (*gas.Estimate, error) {
+	return s.dryRun(ctx, func(ctx context.Context) error {
+		recipient, value, err := s.nativeTransferValue(to, amount)
+		if err != nil {
+			return err
+		}
+		if _, err := s.nativeLimits.check(value); err != nil {
+			return err
+		}
+		_, err = s.sendNative(ctx, recipient, value)
+		return err
+	})
+}
+
+func (s *BOGOWISDK) transferNative(ctx context.Context, to string, amount string) (*NativeTransfer, error) {
+	recipient, value, err := s.nativeTransferValue(to, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining, err := s.nativeLimits.reserve(value)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := s.sendNative(ctx, recipient, value)
+	if err != nil {
+		s.nativeLimits.release(value)
+		return nil, err
+	}
+
+	transfer.DailyRemaining = formatEther(remaining)
+	s.nativeLimits.record(transfer)
+
+	return transfer, nil
+}
+
+// nativeTransferValue validates the recipient and amount of a native
+// transfer and returns the amount in wei
+func (s *BOGOWISDK) nativeTransferValue(to string, amount string) (common.Address, *big.Int, error) {
+	if !common.IsHexAddress(to) {
+		return common.Address{}, nil, fmt.Errorf("invalid recipient address")
+	}
+
+	value, err := parseUnits(amount, 18)
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("invalid amount format")
+	}
+	if value.Sign() <= 0 {
+		return common.Address{}, nil, fmt.Errorf("amount must be greater than zero")
+	}
+
+	if s.nativeLimits == nil {
+		return common.Address{}, nil, fmt.Errorf("native transfers not configured")
+	}
+	return common.HexToAddress(to), value, nil
+}
+
+// GetNativeTransfers returns the native transfers submitted today
+func (s *BOGOWISDK) GetNativeTransfers() []*NativeTransfer {
+	if s.nativeLimits == nil {
+		return []*NativeTransfer{}
+	}
+	return s.nativeLimits.history()
+}
+
+// sendNative builds, signs and submits a plain value transfer
+func (s *BOGOWISDK) sendNative(ctx context.Context, to common.Address, value *big.Int) (*NativeTransfer, error) {
+	fees, err := s.suggestFees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	// Recipients may be contracts with payable fallbacks, so estimate
+	// rather than assume the 21000 gas of a plain transfer
+	est, err := gas.EstimateTransfer(ctx, s.client, s.auth.From, &to, value, nil, fees, s.feeStrategy().Multiplier())
+	if err != nil {
+		return nil, err
+	}
+	gasLimit := est.GasLimit
+
+	balance, err := s.client.BalanceAt(ctx, s.auth.From, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signer balance: %w", err)
+	}
+	gasPrice := fees.MaxPrice()
+	cost := new(big.Int).Add(value, new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit)))
+	if balance.Cmp(cost) < 0 {
+		return nil, fmt.Errorf("insufficient CAM balance: have %s, need %s", formatEther(balance), formatEther(cost))
+	}
+	if gas.DryRun(ctx, est) {
+		return nil, gas.ErrDryRun
+	}
+
+	var signedTx *types.Transaction
+	err = s.nonceManager().Send(ctx, s.client, func(nonce uint64) error {
+		tx := fees.NewTx(s.chainID, nonce, &to, value, gasLimit, nil)
+
+		var err error
+		signedTx, err = s.auth.Signer(s.auth.From, tx)
+		if err != nil {
+			return fmt.Errorf("failed to sign transaction: %w", err)
+		}
+		meta := txtrack.Meta{Purpose: purposeNativeTransfer, Ref: to.Hex()}
+		if err := s.broadcast(ctx, signedTx, meta); err != nil {
+			return fmt.Errorf("failed to send transaction: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &NativeTransfer{
+		TxHash:      signedTx.Hash().Hex(),
+		From:        s.auth.From.Hex(),
+		To:          to.Hex(),
+		Amount:      formatEther(value),
+		Nonce:       signedTx.Nonce(),
+		GasLimit:    gasLimit,
+		GasPrice:    gasPrice.String(),
+		SubmittedAt: time.Now().UTC(),
+		value:       value,
+	}, nil
+}
+
+// loadNativeTransfers returns the native transfers the SDK signer sent
+// through tracker since a time
+func (s *BOGOWISDK) loadNativeTransfers(tracker *txtrack.Tracker, since time.Time) ([]*NativeTransfer, error) {
+	sent, err := tracker.ListSent(s.auth.From, purposeNativeTransfer, since)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make([]*NativeTransfer, 0, len(sent))
+	for _, st := range sent {
+		transfer := &NativeTransfer{
+			TxHash:      st.Tx.Hash().Hex(),
+			From:        s.auth.From.Hex(),
+			Amount:      formatEther(st.Tx.Value()),
+			Nonce:       st.Tx.Nonce(),
+			GasLimit:    st.Tx.Gas(),
+			GasPrice:    st.Tx.GasFeeCap().String(),
+			SubmittedAt: st.SubmittedAt,
+			value:       st.Tx.Value(),
+		}
+		if st.Tx.To() != nil {
+			transfer.To = st.Tx.To().Hex()
+		}
+		transfers = append(transfers, transfer)
+	}
+	return transfers, nil
+}
+
+// parseUnits converts a decimal amount string to its integer base-unit value
+func parseUnits(amount string, decimals uint8) (*big.Int, error) {
+	amount = strings.TrimSpace(amount)
+	if amount == "" || strings.ContainsAny(amount, "/eE") {
+		return nil, fmt.Errorf("invalid amount: %q", amount)
+	}
+
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount: %q", amount)
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	value.Mul(value, new(big.Rat).SetInt(scale))
+	if !value.IsInt() {
+		return nil, fmt.Errorf("amount %q has more than %d decimal places", amount, decimals)
+	}
+	return new(big.Int).Set(value.Num()), nil
+}
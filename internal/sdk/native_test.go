@@ -0,0 +1,296 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/signer"
+	"bogowi-blockchain-go/internal/sdk/txtrack"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newNativeTestSDK(t *testing.T, client *MockEthClient, maxPerTx, maxPerDay string) *BOGOWISDK {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(501))
+	require.NoError(t, err)
+	limits, err := newNativeTransferLimiter(maxPerTx, maxPerDay)
+	require.NoError(t, err)
+
+	return &BOGOWISDK{client: client, auth: auth, signer: signer.NewKeySigner(key), nativeLimits: limits}
+}
+
+func TestGetNativeBalance(t *testing.T) {
+	addr := "0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2"
+
+	client := new(MockEthClient)
+	client.On("BalanceAt", mock.Anything, common.HexToAddress(addr), (*big.Int)(nil)).
+		Return(big.NewInt(2500000000000000000), nil)
+
+	s := &BOGOWISDK{client: client}
+	balance, err := s.GetNativeBalance(context.Background(), addr)
+	require.NoError(t, err)
+	assert.Equal(t, "2.5", balance.Balance)
+	assert.Equal(t, "2500000000000000000", balance.RawBalance)
+
+	_, err = s.GetNativeBalance(context.Background(), "invalid")
+	assert.EqualError(t, err, "invalid address")
+}
+
+func TestTransferNative(t *testing.T) {
+	recipient := "0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2"
+	gasPrice := big.NewInt(25000000000)
+
+	t.Run("successful transfer", func(t *testing.T) {
+		client := new(MockEthClient)
+		s := newNativeTestSDK(t, client, "1", "2")
+
+		client.On("SuggestGasPrice", mock.Anything).Return(gasPrice, nil)
+		client.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+		client.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 8000000}, nil)
+		client.On("BalanceAt", mock.Anything, s.auth.From, (*big.Int)(nil)).Return(big.NewInt(5e18), nil)
+		client.On("PendingNonceAt", mock.Anything, s.auth.From).Return(uint64(7), nil)
+		client.On("SendTransaction", mock.Anything, mock.Anything).Return(nil)
+
+		transfer, err := s.TransferNative(context.Background(), recipient, "0.75")
+		require.NoError(t, err)
+		assert.NotEmpty(t, transfer.TxHash)
+		assert.Equal(t, "0.75", transfer.Amount)
+		assert.Equal(t, uint64(7), transfer.Nonce)
+		assert.Equal(t, uint64(25200), transfer.GasLimit) // 21000 with the 1.2 multiplier
+		assert.Equal(t, "1.25", transfer.DailyRemaining)
+		assert.Len(t, s.GetNativeTransfers(), 1)
+
+		// Over the per-transfer cap
+		_, err = s.TransferNative(context.Background(), recipient, "1.5")
+		assert.ErrorIs(t, err, ErrNativeTransferCapExceeded)
+
+		// Second transfer fits, third exceeds the daily cap
+		_, err = s.TransferNative(context.Background(), recipient, "1")
+		require.NoError(t, err)
+		_, err = s.TransferNative(context.Background(), recipient, "0.5")
+		assert.ErrorIs(t, err, ErrNativeDailyCapExceeded)
+	})
+
+	t.Run("failed send releases the reservation", func(t *testing.T) {
+		client := new(MockEthClient)
+		s := newNativeTestSDK(t, client, "1", "1")
+
+		client.On("SuggestGasPrice", mock.Anything).Return(gasPrice, nil)
+		client.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+		client.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 8000000}, nil)
+		client.On("BalanceAt", mock.Anything, s.auth.From, (*big.Int)(nil)).Return(big.NewInt(5e18), nil)
+		client.On("PendingNonceAt", mock.Anything, s.auth.From).Return(uint64(0), nil)
+		client.On("SendTransaction", mock.Anything, mock.Anything).Return(errors.New("connection reset by peer")).Once()
+		client.On("SendTransaction", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := s.TransferNative(context.Background(), recipient, "1")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "connection reset by peer")
+		assert.Empty(t, s.GetNativeTransfers())
+
+		_, err = s.TransferNative(context.Background(), recipient, "1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("insufficient balance", func(t *testing.T) {
+		client := new(MockEthClient)
+		s := newNativeTestSDK(t, client, "1", "1")
+
+		client.On("SuggestGasPrice", mock.Anything).Return(gasPrice, nil)
+		client.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+		client.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 8000000}, nil)
+		client.On("BalanceAt", mock.Anything, s.auth.From, (*big.Int)(nil)).Return(big.NewInt(1e18), nil)
+
+		_, err := s.TransferNative(context.Background(), recipient, "1")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "insufficient CAM balance")
+		client.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		client := new(MockEthClient)
+		s := newNativeTestSDK(t, client, "1", "1")
+
+		client.On("SuggestGasPrice", mock.Anything).Return(gasPrice, nil)
+		client.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+		client.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 8000000}, nil)
+		client.On("BalanceAt", mock.Anything, s.auth.From, (*big.Int)(nil)).Return(big.NewInt(5e18), nil)
+
+		est, err := s.EstimateTransferNative(context.Background(), recipient, "1")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(21000), est.EstimatedGas)
+		assert.Equal(t, uint64(25200), est.GasLimit)
+		assert.Equal(t, "30000000000", est.GasPrice)
+		assert.Equal(t, "1000756000000000000", est.MaxCost)
+
+		// Nothing is sent or reserved against the daily cap
+		client.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+		assert.Empty(t, s.GetNativeTransfers())
+		assert.Zero(t, s.nativeLimits.spent.Sign())
+		_, err = s.EstimateTransferNative(context.Background(), recipient, "1")
+		assert.NoError(t, err)
+
+		_, err = s.EstimateTransferNative(context.Background(), recipient, "1.5")
+		assert.ErrorIs(t, err, ErrNativeTransferCapExceeded)
+
+		// A reservation made meanwhile counts against the estimate
+		_, err = s.nativeLimits.reserve(big.NewInt(5e17))
+		require.NoError(t, err)
+		_, err = s.EstimateTransferNative(context.Background(), recipient, "1")
+		assert.ErrorIs(t, err, ErrNativeDailyCapExceeded)
+		_, err = s.EstimateTransferNative(context.Background(), recipient, "0.5")
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(5e17), s.nativeLimits.spent)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		s := newNativeTestSDK(t, new(MockEthClient), "1", "1")
+
+		_, err := s.TransferNative(context.Background(), "invalid", "1")
+		assert.EqualError(t, err, "invalid recipient address")
+
+		_, err = s.TransferNative(context.Background(), recipient, "abc")
+		assert.EqualError(t, err, "invalid amount format")
+
+		_, err = s.TransferNative(context.Background(), recipient, "0")
+		assert.EqualError(t, err, "amount must be greater than zero")
+
+		disabled := newNativeTestSDK(t, new(MockEthClient), "", "")
+		_, err = disabled.TransferNative(context.Background(), recipient, "1")
+		assert.EqualError(t, err, "native transfers are disabled on this network")
+	})
+}
+
+func TestNativeTransferLimiterRollover(t *testing.T) {
+	limiter, err := newNativeTransferLimiter("1", "1")
+	require.NoError(t, err)
+
+	now := time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	_, err = limiter.reserve(big.NewInt(1e18))
+	require.NoError(t, err)
+	_, err = limiter.reserve(big.NewInt(1))
+	assert.ErrorIs(t, err, ErrNativeDailyCapExceeded)
+
+	now = now.Add(2 * time.Hour)
+	remaining, err := limiter.reserve(big.NewInt(5e17))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(5e17), remaining)
+}
+
+// trackerClient adds the receipt lookups of a tracker to MockEthClient; the
+// tests below only submit transactions
+type trackerClient struct {
+	*MockEthClient
+}
+
+func (c trackerClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return nil, errors.New("not mined")
+}
+
+func (c trackerClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (c trackerClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return 0, nil
+}
+
+func TestNativeDailyCapSurvivesRestart(t *testing.T) {
+	recipient := "0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2"
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "tx.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	client := new(MockEthClient)
+	client.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(25000000000), nil)
+	client.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	client.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 8000000}, nil)
+	client.On("BalanceAt", mock.Anything, mock.Anything, (*big.Int)(nil)).Return(big.NewInt(5e18), nil)
+	client.On("PendingNonceAt", mock.Anything, mock.Anything).Return(uint64(7), nil)
+	client.On("SendTransaction", mock.Anything, mock.Anything).Return(nil)
+
+	s := newNativeTestSDK(t, client, "1", "2")
+	s.SetTxTracker(txtrack.NewTracker("testnet", db, trackerClient{client}, nil, txtrack.Options{}))
+
+	_, err = s.TransferNative(context.Background(), recipient, "1")
+	require.NoError(t, err)
+	_, err = s.TransferNative(context.Background(), recipient, "0.75")
+	require.NoError(t, err)
+
+	// A restarted SDK starts from the transfers in the outbox
+	limits, err := newNativeTransferLimiter("1", "2")
+	require.NoError(t, err)
+	restarted := &BOGOWISDK{client: client, auth: s.auth, signer: s.signer, nativeLimits: limits}
+	restarted.SetTxTracker(txtrack.NewTracker("testnet", db, trackerClient{client}, nil, txtrack.Options{}))
+
+	transfers := restarted.GetNativeTransfers()
+	require.Len(t, transfers, 2)
+	assert.Equal(t, "1", transfers[0].Amount)
+	assert.Equal(t, "0.75", transfers[1].Amount)
+	assert.Equal(t, common.HexToAddress(recipient).Hex(), transfers[1].To)
+
+	_, err = restarted.TransferNative(context.Background(), recipient, "0.5")
+	assert.ErrorIs(t, err, ErrNativeDailyCapExceeded)
+
+	transfer, err := restarted.TransferNative(context.Background(), recipient, "0.25")
+	require.NoError(t, err)
+	assert.Equal(t, "0", transfer.DailyRemaining)
+}
+
+func TestNativeTransferLimiterLoadFailure(t *testing.T) {
+	limiter, err := newNativeTransferLimiter("1", "1")
+	require.NoError(t, err)
+	limiter.setLoader(func(since time.Time) ([]*NativeTransfer, error) {
+		return nil, errors.New("database is locked")
+	})
+
+	// Without today's spend the cap cannot be checked, so nothing is sent
+	_, err = limiter.reserve(big.NewInt(1))
+	assert.ErrorContains(t, err, "database is locked")
+	assert.Empty(t, limiter.history())
+}
+
+func TestParseUnits(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals uint8
+		expected string
+		wantErr  bool
+	}{
+		{"1", 18, "1000000000000000000", false},
+		{"0.75", 18, "750000000000000000", false},
+		{"1.5", 6, "1500000", false},
+		{"0.0000001", 6, "", true},
+		{"1e18", 18, "", true},
+		{"1/3", 18, "", true},
+		{"abc", 18, "", true},
+		{"", 18, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			value, err := parseUnits(tt.amount, tt.decimals)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value.String())
+		})
+	}
+}
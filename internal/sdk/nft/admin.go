@@ -0,0 +1,180 @@
+package nft
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"bogowi-blockchain-go/internal/sdk/txtrack"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Roles the tickets contract checks through the RoleManager, hashed as in Roles.sol
+var (
+	AdminRole  = crypto.Keccak256Hash([]byte("ADMIN_ROLE"))
+	PauserRole = crypto.Keccak256Hash([]byte("PAUSER_ROLE"))
+)
+
+var roleNames = map[common.Hash]string{
+	AdminRole:  "ADMIN_ROLE",
+	PauserRole: "PAUSER_ROLE",
+}
+
+// MaxRoyaltyBasisPoints is the highest royalty the contract accepts (10%)
+const MaxRoyaltyBasisPoints = 1000
+
+// royaltyDenominator is the sale price at which the EIP-2981 royalty amount
+// equals the fee in basis points
+var royaltyDenominator = big.NewInt(10000)
+
+// Royalty is the EIP-2981 royalty of the tickets
+type Royalty struct {
+	Receiver       common.Address `json:"receiver"`
+	FeeBasisPoints uint64         `json:"feeBasisPoints"`
+}
+
+// Amount returns the royalty owed on a sale at salePrice
+func (r Royalty) Amount(salePrice *big.Int) *big.Int {
+	amount := new(big.Int).Mul(salePrice, new(big.Int).SetUint64(r.FeeBasisPoints))
+	return amount.Div(amount, royaltyDenominator)
+}
+
+// RequireRole checks through the RoleManager that the backend wallet holds
+// role, so admin operations fail before a transaction reverts
+func (c *Client) RequireRole(ctx context.Context, role common.Hash) error {
+	hasRole, err := c.HasRole(ctx, role, c.GetAddress())
+	if err != nil {
+		return err
+	}
+	if !hasRole {
+		name, ok := roleNames[role]
+		if !ok {
+			name = role.Hex()
+		}
+		return fmt.Errorf("%w: %s does not hold %s", ErrMissingRole, c.GetAddress().Hex(), name)
+	}
+	return nil
+}
+
+// CheckBurnable checks that the backend wallet may burn a ticket. The
+// contract only lets the holder burn; burning is further kept to admins.
+func (c *Client) CheckBurnable(ctx context.Context, tokenID uint64) error {
+	if err := c.RequireRole(ctx, AdminRole); err != nil {
+		return err
+	}
+
+	owner, err := c.GetOwnerOf(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	if owner != c.GetAddress() {
+		return fmt.Errorf("%w: ticket %d is held by %s", ErrNotTicketHolder, tokenID, owner.Hex())
+	}
+	return nil
+}
+
+// CheckTransferUnlock checks that the backend wallet may move the transfer
+// unlock time of a ticket to unlockAt, which must be before its expiry
+func (c *Client) CheckTransferUnlock(ctx context.Context, tokenID uint64, unlockAt uint64) error {
+	if err := c.RequireRole(ctx, AdminRole); err != nil {
+		return err
+	}
+
+	ticket, err := c.GetTicketData(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	if unlockAt >= ticket.ExpiresAt {
+		return fmt.Errorf("%w: ticket %d expires at %d", ErrInvalidUnlockTime, tokenID, ticket.ExpiresAt)
+	}
+	return nil
+}
+
+// Pause pauses minting and transfers of tickets
+func (c *Client) Pause(ctx context.Context) (*types.Transaction, error) {
+	// Get gas price
+	fees, err := c.SuggestFees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	txOpts := c.newTransactOpts(ctx, fees)
+
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "tickets_pause"}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.Pause(opts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pause tickets: %w", err)
+	}
+
+	return tx, nil
+}
+
+// Unpause resumes minting and transfers of tickets
+func (c *Client) Unpause(ctx context.Context) (*types.Transaction, error) {
+	// Get gas price
+	fees, err := c.SuggestFees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	txOpts := c.newTransactOpts(ctx, fees)
+
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "tickets_unpause"}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.Unpause(opts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpause tickets: %w", err)
+	}
+
+	return tx, nil
+}
+
+// IsPaused reports whether the tickets contract is paused
+func (c *Client) IsPaused(ctx context.Context) (bool, error) {
+	paused, err := c.ticketsContract.Paused(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return false, fmt.Errorf("failed to check paused: %w", err)
+	}
+	return paused, nil
+}
+
+// GetRoyalty returns the EIP-2981 royalty of a ticket
+func (c *Client) GetRoyalty(ctx context.Context, tokenID uint64) (*Royalty, error) {
+	receiver, amount, err := c.ticketsContract.RoyaltyInfo(&bind.CallOpts{Context: ctx}, new(big.Int).SetUint64(tokenID), royaltyDenominator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get royalty info: %w", err)
+	}
+	return &Royalty{Receiver: receiver, FeeBasisPoints: amount.Uint64()}, nil
+}
+
+// SetRoyalty sets the EIP-2981 royalty of all tickets
+func (c *Client) SetRoyalty(ctx context.Context, receiver common.Address, feeBasisPoints uint64) (*types.Transaction, error) {
+	if receiver == (common.Address{}) {
+		return nil, fmt.Errorf("royalty receiver must not be the zero address")
+	}
+	if feeBasisPoints > MaxRoyaltyBasisPoints {
+		return nil, fmt.Errorf("royalty of %d basis points exceeds the maximum of %d", feeBasisPoints, MaxRoyaltyBasisPoints)
+	}
+
+	// Get gas price
+	fees, err := c.SuggestFees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	txOpts := c.newTransactOpts(ctx, fees)
+
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "set_royalty_info", Ref: receiver.Hex()}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.SetRoyaltyInfo(opts, receiver, new(big.Int).SetUint64(feeBasisPoints))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set royalty info: %w", err)
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,152 @@
+package nft
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"bogowi-blockchain-go/internal/sdk/contracts"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var adminWallet = common.HexToAddress("0x00000000000000000000000000000000000000ad")
+
+// roleCaller answers hasRole calls of a RoleManager from a set of roles
+// held by adminWallet
+type roleCaller struct {
+	t     *testing.T
+	roles map[common.Hash]bool
+}
+
+func (r *roleCaller) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x60, 0x80}, nil
+}
+
+func (r *roleCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	parsed, err := contracts.RoleManagerMetaData.GetAbi()
+	require.NoError(r.t, err)
+	args, err := parsed.Methods["hasRole"].Inputs.Unpack(call.Data[4:])
+	require.NoError(r.t, err)
+
+	role := common.Hash(args[0].([32]byte))
+	hasRole := r.roles[role] && args[1].(common.Address) == adminWallet
+	return parsed.Methods["hasRole"].Outputs.Pack(hasRole)
+}
+
+// newAdminClient returns a client signing as adminWallet whose RoleManager
+// grants roles
+func newAdminClient(t *testing.T, ticketsContract TicketsContractInterface, roles ...common.Hash) *Client {
+	held := make(map[common.Hash]bool, len(roles))
+	for _, role := range roles {
+		held[role] = true
+	}
+	caller, err := contracts.NewRoleManagerCaller(common.HexToAddress("0x00000000000000000000000000000000000000ee"), &roleCaller{t: t, roles: held})
+	require.NoError(t, err)
+
+	return &Client{
+		ticketsContract: ticketsContract,
+		roleManager:     &contracts.RoleManager{RoleManagerCaller: *caller},
+		auth:            &bind.TransactOpts{From: adminWallet},
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	client := newAdminClient(t, nil, PauserRole)
+
+	assert.NoError(t, client.RequireRole(context.Background(), PauserRole))
+
+	err := client.RequireRole(context.Background(), AdminRole)
+	assert.ErrorIs(t, err, ErrMissingRole)
+	assert.Contains(t, err.Error(), "ADMIN_ROLE")
+
+	// Without a RoleManager roles cannot be checked
+	client.roleManager = nil
+	assert.Error(t, client.RequireRole(context.Background(), PauserRole))
+}
+
+func TestCheckBurnable(t *testing.T) {
+	holder := common.HexToAddress("0x00000000000000000000000000000000000000b0")
+
+	tests := []struct {
+		name    string
+		roles   []common.Hash
+		owner   common.Address
+		wantErr error
+	}{
+		{name: "burnable", roles: []common.Hash{AdminRole}, owner: adminWallet},
+		{name: "missing role", roles: []common.Hash{PauserRole}, owner: adminWallet, wantErr: ErrMissingRole},
+		{name: "held by someone else", roles: []common.Hash{AdminRole}, owner: holder, wantErr: ErrNotTicketHolder},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockContract := new(MockTicketsContract)
+			mockContract.On("OwnerOf", mock.Anything, big.NewInt(1)).Return(tt.owner, nil)
+			client := newAdminClient(t, mockContract, tt.roles...)
+
+			err := client.CheckBurnable(context.Background(), 1)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCheckTransferUnlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		roles    []common.Hash
+		unlockAt uint64
+		wantErr  error
+	}{
+		{name: "before expiry", roles: []common.Hash{AdminRole}, unlockAt: 1999},
+		{name: "at expiry", roles: []common.Hash{AdminRole}, unlockAt: 2000, wantErr: ErrInvalidUnlockTime},
+		{name: "missing role", unlockAt: 1000, wantErr: ErrMissingRole},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockContract := new(MockTicketsContract)
+			mockContract.On("GetTicketData", mock.Anything, big.NewInt(1)).Return(TicketDataContract{ExpiresAt: 2000}, nil)
+			client := newAdminClient(t, mockContract, tt.roles...)
+
+			err := client.CheckTransferUnlock(context.Background(), 1, tt.unlockAt)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRoyalty(t *testing.T) {
+	receiver := common.HexToAddress("0x00000000000000000000000000000000000000da")
+	mockContract := new(MockTicketsContract)
+	mockContract.On("RoyaltyInfo", mock.Anything, big.NewInt(7), big.NewInt(10000)).Return(receiver, big.NewInt(500), nil)
+	mockContract.On("Paused", mock.Anything).Return(true, nil)
+	client := newAdminClient(t, mockContract, AdminRole)
+
+	royalty, err := client.GetRoyalty(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, receiver, royalty.Receiver)
+	assert.Equal(t, uint64(500), royalty.FeeBasisPoints)
+	assert.Equal(t, big.NewInt(5), royalty.Amount(big.NewInt(100)))
+
+	paused, err := client.IsPaused(context.Background())
+	require.NoError(t, err)
+	assert.True(t, paused)
+
+	// Royalties the contract rejects are not sent
+	_, err = client.SetRoyalty(context.Background(), common.Address{}, 500)
+	assert.Error(t, err)
+	_, err = client.SetRoyalty(context.Background(), receiver, MaxRoyaltyBasisPoints+1)
+	assert.Error(t, err)
+	mockContract.AssertNotCalled(t, "SetRoyaltyInfo", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,286 @@
+package nft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"bogowi-blockchain-go/internal/database"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// maxScanClockSkew is how far ahead of the server clock an offline scan
+// may be dated
+const maxScanClockSkew = time.Minute
+
+// OfflineScanWindow is how long after an offline scan it may be synced. Scan
+// times come from the scanner, so older scans are refused rather than
+// checked against QR codes and tickets that have expired since. Check-in
+// bundles are valid as long.
+const OfflineScanWindow = 24 * time.Hour
+
+// CheckRedemptionQR checks a redemption QR code scanned at the entrance of
+// eventID: the QR code must be signed by the backend, or carry the current
+// code of a rotating token, and still be valid, and
+// the ticket must belong to the event, be issued and unexpired, and still
+// be held by the redeemer. Rejections are reported in the result; errors are
+// left for failures to read the chain.
+func (c *Client) CheckRedemptionQR(ctx context.Context, qrData string, eventID [32]byte) (*CheckIn, error) {
+	return c.checkRedemptionQR(ctx, qrData, eventID, time.Now())
+}
+
+// CheckOfflineRedemption checks a redemption QR code that was scanned
+// offline at scannedAt, as CheckRedemptionQR does at the time of the scan.
+// The QR code and ticket only need to have been valid when it was scanned,
+// at most OfflineScanWindow ago: a valid result carries a fresh deadline for
+// relaying the redemption now.
+func (c *Client) CheckOfflineRedemption(ctx context.Context, qrData string, eventID [32]byte, scannedAt time.Time) (*CheckIn, error) {
+	now := time.Now()
+	if scannedAt.After(now.Add(maxScanClockSkew)) {
+		return &CheckIn{Result: CheckInInvalid, Reason: "scan time is in the future"}, nil
+	}
+	if scannedAt.Before(now.Add(-OfflineScanWindow)) {
+		return &CheckIn{Result: CheckInQRExpired, Reason: "scan is too old to be synced"}, nil
+	}
+
+	checkIn, err := c.checkRedemptionQR(ctx, qrData, eventID, scannedAt)
+	if err != nil || checkIn.Result != CheckInValid {
+		return checkIn, err
+	}
+	checkIn.Params.Deadline = time.Now().Add(RedemptionSigningWindow).Unix()
+	return checkIn, nil
+}
+
+// checkRedemptionQR checks a QR code scanned at scannedAt
+func (c *Client) checkRedemptionQR(ctx context.Context, qrData string, eventID [32]byte, scannedAt time.Time) (*CheckIn, error) {
+	if isRotatingQR(qrData) {
+		checkIn, step, err := c.checkRotatingQR(ctx, qrData, scannedAt)
+		if err != nil || checkIn.Result != "" {
+			return checkIn, err
+		}
+		checkIn, err = c.checkTicket(ctx, checkIn, eventID, scannedAt)
+		if err != nil || checkIn.Result != CheckInValid {
+			return checkIn, err
+		}
+		// The code is spent only by an accepted scan, so a scan at the wrong
+		// gate leaves it usable
+		if !c.useRotatingStep(checkIn.Params, step) {
+			checkIn.Result, checkIn.Reason = CheckInInvalid, "QR code was already used"
+		}
+		return checkIn, nil
+	}
+
+	data, err := ParseRedemptionQRCode(qrData)
+	if err != nil {
+		return &CheckIn{Result: CheckInInvalid, Reason: err.Error()}, nil
+	}
+	if !data.TokenID.IsUint64() || !data.Nonce.IsUint64() || !data.Deadline.IsInt64() {
+		return &CheckIn{Result: CheckInInvalid, Reason: "QR code values out of range"}, nil
+	}
+
+	checkIn := &CheckIn{
+		Params: RedemptionParams{
+			TokenID:  data.TokenID.Uint64(),
+			Redeemer: data.Redeemer,
+			Nonce:    data.Nonce.Uint64(),
+			Deadline: data.Deadline.Int64(),
+		},
+	}
+	reject := func(result string, reason string) (*CheckIn, error) {
+		checkIn.Result = result
+		checkIn.Reason = reason
+		return checkIn, nil
+	}
+
+	domain, err := c.RedemptionDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	typedData := redemptionTypedData(domain, data.TokenID, data.Redeemer, data.Nonce, data.Deadline)
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash typed data: %w", err)
+	}
+	if signer, err := recoverSigner(digest, data.Signature); err != nil || signer != c.GetAddress() {
+		return reject(CheckInInvalid, "QR code was not issued by this service")
+	}
+	if checkIn.Params.Deadline < scannedAt.Unix() {
+		return reject(CheckInQRExpired, "QR code expired, ask the holder to refresh it")
+	}
+
+	return c.checkTicket(ctx, checkIn, eventID, scannedAt)
+}
+
+// checkTicket checks the ticket of an authenticated QR code for a check-in
+// at eventID scanned at scannedAt
+func (c *Client) checkTicket(ctx context.Context, checkIn *CheckIn, eventID [32]byte, scannedAt time.Time) (*CheckIn, error) {
+	reject := func(result string, reason string) (*CheckIn, error) {
+		checkIn.Result = result
+		checkIn.Reason = reason
+		return checkIn, nil
+	}
+
+	ticket, err := c.GetTicketData(ctx, checkIn.Params.TokenID)
+	if err != nil {
+		// Tickets burned on redemption no longer exist on-chain
+		if c.indexer != nil {
+			if indexed, indexErr := c.indexer.Ticket(checkIn.Params.TokenID); indexErr == nil && indexed.Burned {
+				if indexed.State == database.TicketRedeemed {
+					return reject(CheckInAlreadyRedeemed, "ticket was redeemed and burned")
+				}
+				return reject(CheckInInvalid, "ticket was burned")
+			}
+		}
+		return nil, err
+	}
+	checkIn.EventID = ticket.EventID
+
+	if ticket.EventID != eventID {
+		return reject(CheckInWrongEvent, "ticket is for another event")
+	}
+	switch TicketState(ticket.State) {
+	case TicketStateRedeemed:
+		return reject(CheckInAlreadyRedeemed, "ticket was already redeemed")
+	case TicketStateExpired:
+		return reject(CheckInExpired, "ticket expired")
+	}
+	if ticket.ExpiresAt <= uint64(scannedAt.Unix()) {
+		return reject(CheckInExpired, "ticket expired")
+	}
+
+	if err := c.checkRedeemer(ctx, checkIn.Params.TokenID, checkIn.Params.Redeemer); err != nil {
+		if errors.Is(err, ErrInvalidSignature) {
+			return reject(CheckInInvalid, "ticket is no longer held by the QR code holder")
+		}
+		return nil, err
+	}
+
+	if c.indexer != nil {
+		used, err := c.indexer.NonceUsed(new(big.Int).SetUint64(checkIn.Params.Nonce))
+		if err != nil {
+			return nil, err
+		}
+		if used {
+			return reject(CheckInInvalid, "QR code was already used")
+		}
+	}
+
+	checkIn.Result = CheckInValid
+	return checkIn, nil
+}
+
+// ExportCheckInBundle returns the tickets of eventID for scanning without
+// connectivity, signed by the backend as EIP-712 typed data under the
+// contract's domain. Scanners verify the bundle and the redemption QR codes
+// against the same signer, and treat the bundle as stale after validFor.
+func (c *Client) ExportCheckInBundle(ctx context.Context, eventID [32]byte, validFor time.Duration) (*CheckInBundle, error) {
+	if c.indexer == nil {
+		return nil, ErrNoTicketIndex
+	}
+	if c.signer == nil {
+		return nil, fmt.Errorf("signer cannot be nil")
+	}
+
+	indexed, err := c.indexer.Tickets(database.TicketFilter{EventID: common.Hash(eventID).Hex()})
+	if err != nil {
+		return nil, err
+	}
+	domain, err := c.RedemptionDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	bundle := &CheckInBundle{
+		EventID:    eventID,
+		IssuedAt:   now.Unix(),
+		ValidUntil: now.Add(validFor).Unix(),
+		Tickets:    make([]BundleTicket, 0, len(indexed)),
+	}
+	for _, ticket := range indexed {
+		bundle.Tickets = append(bundle.Tickets, BundleTicket{
+			TokenID:   ticket.TokenID,
+			Owner:     common.HexToAddress(ticket.OwnerAddress),
+			Status:    bundleTicketStatus(&ticket, uint64(now.Unix())),
+			ExpiresAt: ticket.ExpiresAt,
+		})
+	}
+	bundle.TypedData = checkInBundleTypedData(domain, bundle)
+
+	bundle.Signature, err = c.signer.SignTypedData(ctx, bundle.TypedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign check-in bundle: %w", err)
+	}
+
+	// The public key is recovered from the signature, which also checks it
+	digest, _, err := apitypes.TypedDataAndHash(bundle.TypedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash typed data: %w", err)
+	}
+	publicKey, err := recoverPublicKey(digest, bundle.Signature)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Signer = crypto.PubkeyToAddress(*publicKey)
+	bundle.PublicKey = crypto.FromECDSAPub(publicKey)
+
+	return bundle, nil
+}
+
+// bundleTicketStatus returns whether a ticket can still be checked in at now
+func bundleTicketStatus(ticket *database.IndexedTicket, now uint64) string {
+	switch {
+	case ticket.State == database.TicketRedeemed:
+		return database.TicketsRedeemed
+	case ticket.State == database.TicketExpired || (ticket.ExpiresAt != 0 && ticket.ExpiresAt <= now):
+		return database.TicketsExpired
+	default:
+		return database.TicketsActive
+	}
+}
+
+// checkInBundleTypedData returns the typed data a check-in bundle is signed as
+func checkInBundleTypedData(domain EIP712Domain, bundle *CheckInBundle) apitypes.TypedData {
+	tickets := make([]interface{}, 0, len(bundle.Tickets))
+	for _, ticket := range bundle.Tickets {
+		tickets = append(tickets, map[string]interface{}{
+			"tokenId":   (*math.HexOrDecimal256)(new(big.Int).SetUint64(ticket.TokenID)),
+			"owner":     ticket.Owner.Hex(),
+			"status":    ticket.Status,
+			"expiresAt": (*math.HexOrDecimal256)(new(big.Int).SetUint64(ticket.ExpiresAt)),
+		})
+	}
+
+	redemption := redemptionTypedData(domain, new(big.Int), common.Address{}, new(big.Int), new(big.Int))
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": redemption.Types["EIP712Domain"],
+			"CheckInBundle": {
+				{Name: "eventId", Type: "bytes32"},
+				{Name: "issuedAt", Type: "uint256"},
+				{Name: "validUntil", Type: "uint256"},
+				{Name: "tickets", Type: "CheckInTicket[]"},
+			},
+			"CheckInTicket": {
+				{Name: "tokenId", Type: "uint256"},
+				{Name: "owner", Type: "address"},
+				{Name: "status", Type: "string"},
+				{Name: "expiresAt", Type: "uint256"},
+			},
+		},
+		PrimaryType: "CheckInBundle",
+		Domain:      redemption.Domain,
+		Message: apitypes.TypedDataMessage{
+			"eventId":    common.Hash(bundle.EventID).Hex(),
+			"issuedAt":   (*math.HexOrDecimal256)(big.NewInt(bundle.IssuedAt)),
+			"validUntil": (*math.HexOrDecimal256)(big.NewInt(bundle.ValidUntil)),
+			"tickets":    tickets,
+		},
+	}
+}
@@ -0,0 +1,210 @@
+package nft
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/contracts"
+	"bogowi-blockchain-go/internal/sdk/events"
+	"bogowi-blockchain-go/internal/sdk/signer"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRedemptionQR(t *testing.T) {
+	backendKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	strangerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	holder := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	someoneElse := common.HexToAddress("0x00000000000000000000000000000000000000b0")
+	contract := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	chainID := big.NewInt(501)
+	eventID := [32]byte{7}
+	future := time.Now().Add(time.Hour).Unix()
+
+	qr := func(key *ecdsa.PrivateKey, tokenID int64, deadline int64) string {
+		sig, err := GenerateRedemptionSignature(key, big.NewInt(tokenID), holder, big.NewInt(tokenID),
+			big.NewInt(deadline), chainID, contract)
+		require.NoError(t, err)
+		return GenerateRedemptionQRCode(big.NewInt(tokenID), holder, big.NewInt(tokenID), big.NewInt(deadline),
+			sig, "https://testnet.bogowi.com")
+	}
+	issued := TicketDataContract{EventID: eventID, ExpiresAt: uint64(future)}
+
+	tests := []struct {
+		name   string
+		qrData string
+		ticket TicketDataContract
+		owner  common.Address
+		want   string
+	}{
+		{"valid", qr(backendKey, 1, future), issued, holder, CheckInValid},
+		{"not a QR code", "https://example.com/menu", issued, holder, CheckInInvalid},
+		{"not issued by the backend", qr(strangerKey, 1, future), issued, holder, CheckInInvalid},
+		{"QR code expired", qr(backendKey, 1, 1700000000), issued, holder, CheckInQRExpired},
+		{"wrong event", qr(backendKey, 1, future), TicketDataContract{EventID: [32]byte{8}, ExpiresAt: uint64(future)}, holder, CheckInWrongEvent},
+		{"already redeemed", qr(backendKey, 1, future), TicketDataContract{EventID: eventID, ExpiresAt: uint64(future), State: 1}, holder, CheckInAlreadyRedeemed},
+		{"ticket expired", qr(backendKey, 1, future), TicketDataContract{EventID: eventID, ExpiresAt: 1700000000}, holder, CheckInExpired},
+		{"transferred away", qr(backendKey, 1, future), issued, someoneElse, CheckInInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockContract := new(MockTicketsContract)
+			mockContract.On("Eip712Domain", mock.Anything).Return(GetEIP712Domain(chainID, contract), nil)
+			mockContract.On("GetTicketData", mock.Anything, mock.Anything).Return(tt.ticket, nil)
+			mockContract.On("OwnerOf", mock.Anything, mock.Anything).Return(tt.owner, nil)
+			mockContract.On("GetApproved", mock.Anything, mock.Anything).Return(common.Address{}, nil)
+			mockContract.On("IsApprovedForAll", mock.Anything, tt.owner, holder).Return(false, nil)
+
+			client := &Client{
+				ticketsContract: mockContract,
+				ticketsAddress:  contract,
+				networkConfig:   &NetworkConfig{ChainID: chainID},
+				auth:            &bind.TransactOpts{From: crypto.PubkeyToAddress(backendKey.PublicKey)},
+			}
+
+			checkIn, err := client.CheckRedemptionQR(context.Background(), tt.qrData, eventID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, checkIn.Result, checkIn.Reason)
+			if tt.want == CheckInValid {
+				assert.Equal(t, RedemptionParams{TokenID: 1, Redeemer: holder, Nonce: 1, Deadline: future}, checkIn.Params)
+			}
+		})
+	}
+}
+
+func TestCheckOfflineRedemption(t *testing.T) {
+	backendKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	holder := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	contract := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	chainID := big.NewInt(501)
+	eventID := [32]byte{7}
+
+	// The QR code expired an hour ago
+	deadline := time.Now().Add(-time.Hour)
+	sig, err := GenerateRedemptionSignature(backendKey, big.NewInt(1), holder, big.NewInt(1),
+		big.NewInt(deadline.Unix()), chainID, contract)
+	require.NoError(t, err)
+	qrData := GenerateRedemptionQRCode(big.NewInt(1), holder, big.NewInt(1), big.NewInt(deadline.Unix()), sig, "")
+
+	newClient := func(expiresAt time.Time) *Client {
+		mockContract := new(MockTicketsContract)
+		mockContract.On("Eip712Domain", mock.Anything).Return(GetEIP712Domain(chainID, contract), nil)
+		mockContract.On("GetTicketData", mock.Anything, mock.Anything).Return(
+			TicketDataContract{EventID: eventID, ExpiresAt: uint64(expiresAt.Unix())}, nil)
+		mockContract.On("OwnerOf", mock.Anything, mock.Anything).Return(holder, nil)
+		return &Client{
+			ticketsContract: mockContract,
+			ticketsAddress:  contract,
+			networkConfig:   &NetworkConfig{ChainID: chainID},
+			auth:            &bind.TransactOpts{From: crypto.PubkeyToAddress(backendKey.PublicKey)},
+		}
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		scannedAt time.Time
+		expiresAt time.Time
+		want      string
+	}{
+		{"scanned in time", deadline.Add(-time.Minute), time.Now().Add(time.Hour), CheckInValid},
+		{"scanned after the deadline", deadline.Add(time.Minute), time.Now().Add(time.Hour), CheckInQRExpired},
+		{"scanned in the future", time.Now().Add(time.Hour), time.Now().Add(time.Hour), CheckInInvalid},
+		{"scanned too long ago", time.Now().Add(-OfflineScanWindow - time.Minute), time.Now().Add(time.Hour), CheckInQRExpired},
+		// Ticket expiry is checked as of the scan too
+		{"ticket expired since the scan", deadline.Add(-time.Minute), deadline, CheckInValid},
+		{"ticket expired before the scan", deadline.Add(-time.Minute), deadline.Add(-2 * time.Minute), CheckInExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkIn, err := newClient(tt.expiresAt).CheckOfflineRedemption(ctx, qrData, eventID, tt.scannedAt)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, checkIn.Result, checkIn.Reason)
+			if tt.want == CheckInValid {
+				// The redemption is relayed with a fresh deadline
+				assert.Greater(t, checkIn.Params.Deadline, time.Now().Unix())
+			}
+		})
+	}
+}
+
+func TestExportCheckInBundle(t *testing.T) {
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	backendKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	alice := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	contract := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	chainID := big.NewInt(501)
+	eventID := [32]byte{7}
+	future := uint64(time.Now().Add(time.Hour).Unix())
+
+	reader := &ticketReader{tickets: map[uint64]*TicketData{
+		1: {EventID: eventID, ExpiresAt: future},
+		2: {EventID: eventID, ExpiresAt: future},
+		3: {EventID: eventID, ExpiresAt: 1700000000},
+		4: {EventID: [32]byte{8}, ExpiresAt: future},
+	}}
+	ix := NewIndexer("testnet", contract, db, reader)
+	var block uint64
+	emit := func(name string, data interface{}) {
+		block++
+		ix.HandleEvent(context.Background(), events.Event{Name: name, Data: data, Log: types.Log{BlockNumber: block}})
+	}
+	for tokenID, ticket := range reader.tickets {
+		emit(events.Transfer, &contracts.BOGOWITicketsTransfer{To: alice, TokenId: new(big.Int).SetUint64(tokenID)})
+		emit(events.TicketMinted, &contracts.BOGOWITicketsTicketMinted{
+			TokenId:     new(big.Int).SetUint64(tokenID),
+			EventIdHash: ticket.EventID,
+			Buyer:       alice,
+		})
+	}
+	emit(events.TicketRedeemed, &contracts.BOGOWITicketsTicketRedeemed{TokenId: big.NewInt(2)})
+
+	mockContract := new(MockTicketsContract)
+	mockContract.On("Eip712Domain", mock.Anything).Return(GetEIP712Domain(chainID, contract), nil)
+	client := &Client{
+		ticketsContract: mockContract,
+		ticketsAddress:  contract,
+		networkConfig:   &NetworkConfig{ChainID: chainID},
+		signer:          signer.NewKeySigner(backendKey),
+		indexer:         ix,
+	}
+
+	bundle, err := client.ExportCheckInBundle(context.Background(), eventID, time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, []BundleTicket{
+		{TokenID: 1, Owner: alice, Status: database.TicketsActive, ExpiresAt: future},
+		{TokenID: 2, Owner: alice, Status: database.TicketsRedeemed, ExpiresAt: future},
+		{TokenID: 3, Owner: alice, Status: database.TicketsExpired, ExpiresAt: 1700000000},
+	}, bundle.Tickets)
+	assert.Equal(t, crypto.PubkeyToAddress(backendKey.PublicKey), bundle.Signer)
+	assert.Equal(t, crypto.FromECDSAPub(&backendKey.PublicKey), bundle.PublicKey)
+	assert.Equal(t, bundle.IssuedAt+3600, bundle.ValidUntil)
+
+	// The signature verifies against the typed data alone
+	digest, _, err := apitypes.TypedDataAndHash(bundle.TypedData)
+	require.NoError(t, err)
+	recovered, err := recoverSigner(digest, bundle.Signature)
+	require.NoError(t, err)
+	assert.Equal(t, bundle.Signer, recovered)
+}
@@ -2,13 +2,21 @@ package nft
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"crypto/rand"
 	"fmt"
+	"log"
 	"math/big"
 	"os"
+	"sync"
 	"time"
 
 	"bogowi-blockchain-go/internal/sdk/contracts"
+	"bogowi-blockchain-go/internal/sdk/failover"
+	"bogowi-blockchain-go/internal/sdk/gas"
+	"bogowi-blockchain-go/internal/sdk/nonce"
+	"bogowi-blockchain-go/internal/sdk/signer"
+	"bogowi-blockchain-go/internal/sdk/txtrack"
+	"bogowi-blockchain-go/internal/sdk/wallet"
 	"bogowi-blockchain-go/internal/services/datakyte"
 
 	"github.com/ethereum/go-ethereum"
@@ -16,23 +24,39 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 // Client is the main SDK client for NFT ticket operations
 type Client struct {
-	ethClient          *ethclient.Client
+	ethClient          *failover.Client
 	ticketsContract    TicketsContractInterface
 	ticketsAddress     common.Address
 	roleManager        *contracts.RoleManager
 	roleManagerAddress common.Address
 	auth               *bind.TransactOpts
+	nonces             *nonce.Manager
+	fees               *gas.Strategy
+	tracker            *txtrack.Tracker
 	chainID            *big.Int
 	network            string
 	config             *ClientConfig
 	networkConfig      *NetworkConfig
 	datakyteService    *datakyte.TicketMetadataService
-	privateKey         *ecdsa.PrivateKey
+	signer             signer.Signer
+	wallets            *wallet.Pool
+	indexer            *Indexer
+	caller             bind.ContractCaller
+
+	domainMu sync.Mutex
+	domain   *EIP712Domain
+
+	// qrSecret keys rotating redemption QR codes, whose codes change every
+	// qrPeriod; rotationSteps holds the last step accepted per rotating token
+	// nonce, in memory and for this instance only
+	qrSecret      []byte
+	qrPeriod      time.Duration
+	rotationMu    sync.Mutex
+	rotationSteps map[uint64]rotatingStep
 }
 
 // NewClient creates a new NFT SDK client
@@ -43,17 +67,26 @@ func NewClient(config ClientConfig) (*Client, error) {
 		return nil, fmt.Errorf("failed to get network config: %w", err)
 	}
 
+	if config.Confirmations > 0 {
+		networkConfig.ConfirmationWait = config.Confirmations
+	}
+
 	// Use custom RPC if provided
 	rpcURL := networkConfig.RPCURL
 	if config.CustomRPCURL != "" {
 		rpcURL = config.CustomRPCURL
 	}
 
-	// Connect to Ethereum client
+	// Connect to the RPC endpoints with failover
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	ethClient, err := ethclient.DialContext(ctx, rpcURL)
+	ethClient, err := failover.Dial(ctx, failover.Config{
+		URLs:           append([]string{rpcURL}, config.FallbackRPCURLs...),
+		RetryAttempts:  config.RetryAttempts,
+		RequestTimeout: config.RequestTimeout,
+		MaxBlockLag:    config.MaxBlockLag,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Camino network: %w", err)
 	}
@@ -69,32 +102,59 @@ func NewClient(config ClientConfig) (*Client, error) {
 			networkConfig.ChainID.String(), chainID.String())
 	}
 
-	// Parse private key
-	privateKey, err := crypto.HexToECDSA(config.PrivateKey)
-	if err != nil {
-		return nil, fmt.Errorf("invalid private key: %w", err)
+	// Use the configured signer, or a local one for the private key
+	txSigner := config.Signer
+	if txSigner == nil {
+		privateKey, err := crypto.HexToECDSA(config.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key: %w", err)
+		}
+		txSigner = signer.NewKeySigner(privateKey)
 	}
 
 	// Create auth transactor
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create transactor: %w", err)
-	}
+	auth := signer.TransactOpts(txSigner, chainID)
 
 	// Set default gas settings
 	if config.GasMultiplier == 0 {
 		config.GasMultiplier = 1.2
 	}
 
+	// Fee pricing; MaxGasPrice is a hard cap on every transaction
+	fees, err := gas.NewStrategy(gas.Config{
+		Mode:          config.FeeMode,
+		Multiplier:    config.GasMultiplier,
+		MaxGasPrice:   config.MaxGasPrice,
+		FixedGasPrice: config.FixedGasPrice,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas settings: %w", err)
+	}
+
+	// Rotating QR codes only survive restarts with a configured secret
+	qrSecret := config.QRSecret
+	if len(qrSecret) == 0 {
+		qrSecret = make([]byte, 32)
+		if _, err := rand.Read(qrSecret); err != nil {
+			return nil, fmt.Errorf("failed to generate QR secret: %w", err)
+		}
+		log.Printf("nft: no QR secret configured for %s, rotating QR codes are only valid on this instance until it restarts", config.Network)
+	}
+
 	// Initialize client
 	client := &Client{
 		ethClient:     ethClient,
+		caller:        ethClient,
 		auth:          auth,
+		nonces:        nonce.For(chainID, auth.From),
+		fees:          fees,
 		chainID:       chainID,
 		network:       config.Network,
 		config:        &config,
 		networkConfig: networkConfig,
-		privateKey:    privateKey,
+		signer:        txSigner,
+		qrSecret:      qrSecret,
+		qrPeriod:      config.QRPeriod,
 	}
 
 	// Load contract addresses from environment or config
@@ -136,9 +196,12 @@ func (c *Client) loadContracts() error {
 	c.ticketsContract = NewContractAdapter(ticketsContract)
 
 	// Load RoleManager if configured
-	roleManagerAddr := os.Getenv(fmt.Sprintf("ROLE_MANAGER_%s", c.network))
-	if roleManagerAddr != "" {
-		c.roleManagerAddress = common.HexToAddress(roleManagerAddr)
+	roleManagerAddr := c.config.RoleManager
+	if roleManagerAddr == (common.Address{}) {
+		roleManagerAddr = common.HexToAddress(os.Getenv(fmt.Sprintf("ROLE_MANAGER_%s", c.network)))
+	}
+	if roleManagerAddr != (common.Address{}) {
+		c.roleManagerAddress = roleManagerAddr
 		roleManager, err := contracts.NewRoleManager(c.roleManagerAddress, c.ethClient)
 		if err != nil {
 			return fmt.Errorf("failed to load role manager: %w", err)
@@ -194,13 +257,19 @@ func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64,
 	return uint64(gasWithBuffer), nil
 }
 
-// WaitForTransaction waits for a transaction to be confirmed
+// WaitForTransaction waits for a transaction to be confirmed. The wait ends
+// when ctx is done or after the client's WaitTimeout, whichever comes first.
 func (c *Client) WaitForTransaction(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
 	// Create a ticker for checking transaction status
 	ticker := time.NewTicker(c.networkConfig.BlockTime)
 	defer ticker.Stop()
 
-	timeout := time.After(5 * time.Minute)
+	var timeout <-chan time.Time
+	if c.config != nil && c.config.WaitTimeout > 0 {
+		timer := time.NewTimer(c.config.WaitTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
 
 	for {
 		select {
@@ -237,24 +306,122 @@ func (c *Client) WaitForTransaction(ctx context.Context, txHash common.Hash) (*t
 	}
 }
 
-// UpdateAuth updates transaction auth settings
-func (c *Client) UpdateAuth(gasLimit uint64, gasPrice *big.Int, nonce *big.Int) {
-	if gasLimit > 0 {
-		c.auth.GasLimit = gasLimit
+// newTransactOpts returns a per-call copy of the client's transaction options.
+// The shared auth is never mutated, so concurrent requests cannot race on it.
+func (c *Client) newTransactOpts(ctx context.Context, fees *gas.Fees) *bind.TransactOpts {
+	opts := &bind.TransactOpts{
+		From:    c.auth.From,
+		Signer:  c.auth.Signer,
+		Context: ctx,
 	}
-	if gasPrice != nil {
-		c.auth.GasPrice = gasPrice
+	fees.Apply(opts)
+	return opts
+}
+
+// newPooledTransactOpts returns per-call transaction options from the next
+// hot wallet of the pool, or from the client's signer without a pool. Only
+// role-gated operations use it; the client's own tickets and approvals stay
+// with its signer.
+func (c *Client) newPooledTransactOpts(ctx context.Context, fees *gas.Fees) (*bind.TransactOpts, error) {
+	if c.wallets == nil {
+		return c.newTransactOpts(ctx, fees), nil
 	}
-	if nonce != nil {
-		c.auth.Nonce = nonce
+	opts, err := c.wallets.Next(ctx)
+	if err != nil {
+		return nil, err
 	}
+	fees.Apply(opts)
+	return opts, nil
+}
+
+// SetWalletPool dispatches mints, redemptions and expiries across the hot
+// wallets of pool, which must all hold the minter and backend roles. Set the
+// pool before the transaction tracker.
+func (c *Client) SetWalletPool(pool *wallet.Pool) {
+	c.wallets = pool
 }
 
-// ResetAuth resets auth to default values
-func (c *Client) ResetAuth() {
-	c.auth.GasLimit = 0
-	c.auth.GasPrice = nil
-	c.auth.Nonce = nil
+// SetIndexer answers the ticket enumeration queries from the ticket index
+func (c *Client) SetIndexer(indexer *Indexer) {
+	c.indexer = indexer
+}
+
+// SetTxTracker makes the client record every transaction it sends in the
+// tracker's outbox before broadcasting it, and lets the tracker sign fee
+// replacements for the client's signer and hot wallets
+func (c *Client) SetTxTracker(tracker *txtrack.Tracker) {
+	c.tracker = tracker
+	tracker.RegisterSigner(c.auth.From, c.auth.Signer)
+	if c.wallets != nil {
+		for _, w := range c.wallets.Wallets() {
+			tracker.RegisterSigner(w.From, w.Signer)
+		}
+	}
+}
+
+// transact signs a transaction with the next nonce of opts.From, the
+// client's signer or a hot wallet, and broadcasts it. The nonce manager is
+// shared with every SDK client using the same key; meta describes the
+// transaction for the outbox. The gas limit is estimated from the calldata
+// with the configured multiplier; under a dry-run context the estimate is
+// recorded and gas.ErrDryRun returned.
+func (c *Client) transact(opts *bind.TransactOpts, meta txtrack.Meta, send func(opts *bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, error) {
+	if c.nonces == nil {
+		c.nonces = nonce.For(c.chainID, c.auth.From)
+	}
+	nonces := c.nonces
+	if opts.From != c.auth.From {
+		// A hot wallet of the pool
+		nonces = nonce.For(c.chainID, opts.From)
+	}
+
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	est, err := gas.EstimateCall(ctx, c.ethClient, opts, c.config.GasMultiplier, send)
+	if err != nil {
+		c.recordWallet(opts.From, err)
+		return nil, err
+	}
+	if gas.DryRun(ctx, est) {
+		return nil, gas.ErrDryRun
+	}
+	opts.GasLimit = est.GasLimit
+
+	var tx *types.Transaction
+	err = nonces.Send(ctx, c.ethClient, func(n uint64) error {
+		opts.Nonce = new(big.Int).SetUint64(n)
+		opts.NoSend = true
+
+		signed, err := send(opts)
+		if err != nil {
+			return err
+		}
+		if c.tracker != nil {
+			err = c.tracker.Submit(ctx, signed, meta)
+		} else {
+			err = c.ethClient.SendTransaction(ctx, signed)
+		}
+		if err != nil {
+			return err
+		}
+		tx = signed
+		return nil
+	})
+	c.recordWallet(opts.From, err)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// recordWallet counts a transaction of a pool wallet in its health stats
+func (c *Client) recordWallet(from common.Address, err error) {
+	if c.wallets != nil {
+		c.wallets.Record(from, err)
+	}
 }
 
 // GetNonce returns the current nonce for the client's address
@@ -277,6 +444,15 @@ func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
 	return gasPrice, nil
 }
 
+// SuggestFees prices a transaction with the client's fee strategy
+func (c *Client) SuggestFees(ctx context.Context) (*gas.Fees, error) {
+	fees := c.fees
+	if fees == nil {
+		fees = gas.Default()
+	}
+	return fees.Fees(ctx, c.ethClient)
+}
+
 // Close closes the client connections
 func (c *Client) Close() {
 	if c.ethClient != nil {
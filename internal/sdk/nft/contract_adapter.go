@@ -1,6 +1,7 @@
 package nft
 
 import (
+	"fmt"
 	"math/big"
 
 	"bogowi-blockchain-go/internal/sdk/contracts"
@@ -158,3 +159,48 @@ func (a *ContractAdapter) UpdateTransferUnlock(opts *bind.TransactOpts, tokenId
 func (a *ContractAdapter) Burn(opts *bind.TransactOpts, tokenId *big.Int) (*types.Transaction, error) {
 	return a.contract.Burn(opts, tokenId)
 }
+
+func (a *ContractAdapter) Pause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return a.contract.Pause(opts)
+}
+
+func (a *ContractAdapter) Unpause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return a.contract.Unpause(opts)
+}
+
+func (a *ContractAdapter) Paused(opts *bind.CallOpts) (bool, error) {
+	return a.contract.Paused(opts)
+}
+
+func (a *ContractAdapter) SetRoyaltyInfo(opts *bind.TransactOpts, receiver common.Address, feeBasisPoints *big.Int) (*types.Transaction, error) {
+	return a.contract.SetRoyaltyInfo(opts, receiver, feeBasisPoints)
+}
+
+func (a *ContractAdapter) RoyaltyInfo(opts *bind.CallOpts, tokenId *big.Int, salePrice *big.Int) (common.Address, *big.Int, error) {
+	royalty, err := a.contract.RoyaltyInfo(opts, tokenId, salePrice)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	return royalty.Receiver, royalty.Amount, nil
+}
+
+// eip712DomainFields flags name, version, chainId and verifyingContract in
+// the EIP-5267 fields bitmap
+const eip712DomainFields = 0x0f
+
+func (a *ContractAdapter) Eip712Domain(opts *bind.CallOpts) (EIP712Domain, error) {
+	domain, err := a.contract.Eip712Domain(opts)
+	if err != nil {
+		return EIP712Domain{}, err
+	}
+	if domain.Fields[0] != eip712DomainFields {
+		return EIP712Domain{}, fmt.Errorf("unsupported EIP-712 domain fields 0x%02x", domain.Fields[0])
+	}
+
+	return EIP712Domain{
+		Name:              domain.Name,
+		Version:           domain.Version,
+		ChainId:           domain.ChainId,
+		VerifyingContract: domain.VerifyingContract,
+	}, nil
+}
@@ -0,0 +1,322 @@
+package nft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/contracts"
+	"bogowi-blockchain-go/internal/sdk/events"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrNoTicketIndex is returned by the ticket enumeration queries of a client
+// without an indexer
+var ErrNoTicketIndex = errors.New("ticket enumeration requires the ticket event indexer")
+
+// detailsRetryInterval is how often tickets whose details could not be read
+// at mint are read again
+const detailsRetryInterval = 30 * time.Second
+
+// TicketStore persists the ticket index
+type TicketStore interface {
+	ApplyTicketChange(change *database.TicketChange) error
+	RollbackTickets(network string, contractAddr string, ancestor uint64) error
+	SetTicketDetails(network string, contractAddr string, tokenID uint64, details *database.TicketDetails) error
+	GetIndexedTicket(network string, contractAddr string, tokenID uint64) (*database.IndexedTicket, error)
+	ListIndexedTickets(filter database.TicketFilter) ([]database.IndexedTicket, error)
+	ListTicketsMissingDetails(network string, contractAddr string) ([]uint64, error)
+	CountMintedTickets(network string, contractAddr string) (uint64, error)
+	SaveUsedNonce(network string, contractAddr string, nonce string, redeemer string, block uint64) error
+	IsNonceUsed(network string, contractAddr string, nonce string) (bool, error)
+	GetEventCursor(network string, contractAddr string) (uint64, string, bool, error)
+	SaveEventCursor(network string, contractAddr string, block uint64, hash string) error
+}
+
+// TicketReader reads tickets from the contract, typically a Client
+type TicketReader interface {
+	GetTicketData(ctx context.Context, tokenID uint64) (*TicketData, error)
+}
+
+// Indexer builds an index of the tickets of a contract from its events, so
+// tickets can be listed by owner, event and state. The contract events do
+// not carry the expiry, transfer lock and flags of a ticket, so those are
+// read from the contract when the ticket is minted.
+//
+// When a reorg replaces blocks, the event manager rolls the index back to
+// the common ancestor before delivering the replacement blocks. Tickets
+// changed within the confirmation depth of the last indexed block are
+// reported as unconfirmed.
+type Indexer struct {
+	network  string
+	contract string
+	store    TicketStore
+	reader   TicketReader
+	now      func() time.Time
+
+	// depth is the confirmation depth and head the last indexed block
+	depth atomic.Uint64
+	head  atomic.Uint64
+
+	// mu keeps detail reads from overwriting a newer event
+	mu sync.Mutex
+}
+
+// NewIndexer creates an indexer for the tickets contract of a network
+func NewIndexer(network string, contract common.Address, store TicketStore, reader TicketReader) *Indexer {
+	return &Indexer{
+		network:  network,
+		contract: contract.Hex(),
+		store:    store,
+		reader:   reader,
+		now:      time.Now,
+	}
+}
+
+// SetConfirmations sets the depth at which indexed tickets are confirmed
+func (ix *Indexer) SetConfirmations(depth uint64) {
+	ix.depth.Store(depth)
+}
+
+// LoadCursor returns the last block whose events were all indexed
+func (ix *Indexer) LoadCursor(ctx context.Context) (events.Checkpoint, bool, error) {
+	block, hash, ok, err := ix.store.GetEventCursor(ix.network, ix.contract)
+	if err != nil || !ok {
+		return events.Checkpoint{}, ok, err
+	}
+	ix.head.Store(block)
+	return events.Checkpoint{Block: block, Hash: common.HexToHash(hash)}, true, nil
+}
+
+// SaveCursor stores the last block whose events were all indexed
+func (ix *Indexer) SaveCursor(ctx context.Context, checkpoint events.Checkpoint) error {
+	ix.head.Store(checkpoint.Block)
+
+	hash := ""
+	if checkpoint.Hash != (common.Hash{}) {
+		hash = checkpoint.Hash.Hex()
+	}
+	return ix.store.SaveEventCursor(ix.network, ix.contract, checkpoint.Block, hash)
+}
+
+// Rollback undoes the events of the blocks after ancestor, which a reorg
+// replaced
+func (ix *Indexer) Rollback(ctx context.Context, ancestor uint64) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	if err := ix.store.RollbackTickets(ix.network, ix.contract, ancestor); err != nil {
+		log.Printf("nft: %s failed to roll back ticket index to block %d: %v", ix.network, ancestor, err)
+	}
+}
+
+// HandleEvent applies a contract event to the index
+func (ix *Indexer) HandleEvent(ctx context.Context, ev events.Event) {
+	// Removed logs were undone by Rollback, which the manager calls first
+	if ev.Log.Removed {
+		return
+	}
+
+	change := &database.TicketChange{
+		Network:      ix.network,
+		ContractAddr: ix.contract,
+		Block:        ev.Log.BlockNumber,
+		LogIndex:     ev.Log.Index,
+		TxHash:       ev.Log.TxHash.Hex(),
+		BlockHash:    ev.Log.BlockHash.Hex(),
+	}
+	if ev.Log.BlockNumber > ix.head.Load() {
+		ix.head.Store(ev.Log.BlockNumber)
+	}
+
+	if data, ok := ev.Data.(*contracts.BOGOWITicketsNonceUsed); ok {
+		if err := ix.store.SaveUsedNonce(ix.network, ix.contract, data.Nonce.String(), data.User.Hex(), ev.Log.BlockNumber); err != nil {
+			log.Printf("nft: %s failed to index redemption nonce %s: %v", ix.network, data.Nonce, err)
+		}
+		return
+	}
+
+	var tokenID *big.Int
+	switch data := ev.Data.(type) {
+	case *contracts.BOGOWITicketsTransfer:
+		tokenID = data.TokenId
+		owner := data.To.Hex()
+		change.OwnerAddress = &owner
+		change.Minted = data.From == (common.Address{})
+		change.Burned = data.To == (common.Address{})
+	case *contracts.BOGOWITicketsTicketMinted:
+		tokenID = data.TokenId
+		owner := data.Buyer.Hex()
+		bookingID := common.Hash(data.BookingIdHash).Hex()
+		eventID := common.Hash(data.EventIdHash).Hex()
+		change.OwnerAddress = &owner
+		change.BookingID = &bookingID
+		change.EventID = &eventID
+		change.Minted = true
+	case *contracts.BOGOWITicketsTicketRedeemed:
+		tokenID = data.TokenId
+		state := database.TicketRedeemed
+		change.State = &state
+	case *contracts.BOGOWITicketsTicketExpired:
+		tokenID = data.TokenId
+		state := database.TicketExpired
+		change.State = &state
+	case *contracts.BOGOWITicketsTicketBurned:
+		tokenID = data.TokenId
+		change.Burned = true
+	case *contracts.BOGOWITicketsTransferUnlockUpdated:
+		tokenID = data.TokenId
+		unlockAt := data.NewUnlockTime
+		change.TransferUnlockAt = &unlockAt
+	default:
+		return
+	}
+	change.TokenID = tokenID.Uint64()
+
+	ix.mu.Lock()
+	err := ix.store.ApplyTicketChange(change)
+	ix.mu.Unlock()
+	if err != nil {
+		log.Printf("nft: %s failed to index %s of ticket %d: %v", ix.network, ev.Name, change.TokenID, err)
+		return
+	}
+
+	if ev.Name == events.TicketMinted {
+		if err := ix.syncDetails(ctx, change.TokenID); err != nil {
+			log.Printf("nft: %s failed to read ticket %d, retrying later: %v", ix.network, change.TokenID, err)
+		}
+	}
+}
+
+// Run reads the details of tickets that could not be read at mint until ctx
+// is cancelled
+func (ix *Indexer) Run(ctx context.Context) {
+	ticker := time.NewTicker(detailsRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		tokenIDs, err := ix.store.ListTicketsMissingDetails(ix.network, ix.contract)
+		if err != nil {
+			log.Printf("nft: %s failed to list tickets without details: %v", ix.network, err)
+			continue
+		}
+		for _, tokenID := range tokenIDs {
+			if err := ix.syncDetails(ctx, tokenID); err != nil && ctx.Err() == nil {
+				log.Printf("nft: %s failed to read ticket %d: %v", ix.network, tokenID, err)
+			}
+		}
+	}
+}
+
+// syncDetails reads the fields of a ticket the events do not carry
+func (ix *Indexer) syncDetails(ctx context.Context, tokenID uint64) error {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	data, err := ix.reader.GetTicketData(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+
+	return ix.store.SetTicketDetails(ix.network, ix.contract, tokenID, &database.TicketDetails{
+		BookingID:                  common.Hash(data.BookingID).Hex(),
+		EventID:                    common.Hash(data.EventID).Hex(),
+		TransferUnlockAt:           data.TransferUnlockAt,
+		ExpiresAt:                  data.ExpiresAt,
+		UtilityFlags:               data.UtilityFlags,
+		NonTransferableAfterRedeem: data.NonTransferableAfterRedeem,
+		BurnOnRedeem:               data.BurnOnRedeem,
+	})
+}
+
+// OwnerTickets returns the IDs of the tickets an address owns that match a
+// status filter (one of the database.Tickets* filters, or empty for all)
+func (ix *Indexer) OwnerTickets(owner common.Address, status string) ([]uint64, error) {
+	return ix.tokenIDs(database.TicketFilter{OwnerAddress: owner.Hex(), Status: status})
+}
+
+// EventTickets returns the IDs of the tickets of an event
+func (ix *Indexer) EventTickets(eventID [32]byte) ([]uint64, error) {
+	return ix.tokenIDs(database.TicketFilter{EventID: common.Hash(eventID).Hex()})
+}
+
+// Tickets returns the indexed tickets matching a filter; the network,
+// contract and time of the filter are set by the indexer
+func (ix *Indexer) Tickets(filter database.TicketFilter) ([]database.IndexedTicket, error) {
+	filter.Network = ix.network
+	filter.ContractAddr = ix.contract
+	filter.Now = uint64(ix.now().Unix())
+
+	tickets, err := ix.store.ListIndexedTickets(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ticket index: %w", err)
+	}
+	return tickets, nil
+}
+
+// Finality returns whether the last change of a ticket is confirmed
+func (ix *Indexer) Finality(ticket *database.IndexedTicket) string {
+	return events.Finality(ticket.LogBlock, ix.head.Load(), ix.depth.Load())
+}
+
+// Ticket returns the indexed state of a ticket
+func (ix *Indexer) Ticket(tokenID uint64) (*database.IndexedTicket, error) {
+	ticket, err := ix.store.GetIndexedTicket(ix.network, ix.contract, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ticket index: %w", err)
+	}
+	return ticket, nil
+}
+
+// TicketFinality returns whether the indexed state of a ticket is confirmed
+func (ix *Indexer) TicketFinality(tokenID uint64) (string, error) {
+	ticket, err := ix.Ticket(tokenID)
+	if err != nil {
+		return "", err
+	}
+	return ix.Finality(ticket), nil
+}
+
+// NonceUsed reports whether the contract marked a redemption nonce as used
+func (ix *Indexer) NonceUsed(nonce *big.Int) (bool, error) {
+	used, err := ix.store.IsNonceUsed(ix.network, ix.contract, nonce.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to query ticket index: %w", err)
+	}
+	return used, nil
+}
+
+// TotalMinted returns how many tickets were minted, including burned ones
+func (ix *Indexer) TotalMinted() (*big.Int, error) {
+	count, err := ix.store.CountMintedTickets(ix.network, ix.contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ticket index: %w", err)
+	}
+	return new(big.Int).SetUint64(count), nil
+}
+
+func (ix *Indexer) tokenIDs(filter database.TicketFilter) ([]uint64, error) {
+	tickets, err := ix.Tickets(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenIDs := make([]uint64, 0, len(tickets))
+	for _, ticket := range tickets {
+		tokenIDs = append(tokenIDs, ticket.TokenID)
+	}
+	return tokenIDs, nil
+}
@@ -0,0 +1,223 @@
+package nft
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/contracts"
+	"bogowi-blockchain-go/internal/sdk/events"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ticketReader serves ticket data from memory
+type ticketReader struct {
+	mu      sync.Mutex
+	tickets map[uint64]*TicketData
+	err     error
+}
+
+func (r *ticketReader) GetTicketData(ctx context.Context, tokenID uint64) (*TicketData, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	data, ok := r.tickets[tokenID]
+	if !ok {
+		return nil, errors.New("token does not exist")
+	}
+	return data, nil
+}
+
+func TestIndexer(t *testing.T) {
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	alice := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	bob := common.HexToAddress("0x00000000000000000000000000000000000000b0")
+	eventID := [32]byte{7}
+	now := time.Unix(1000, 0)
+
+	reader := &ticketReader{tickets: map[uint64]*TicketData{
+		1: {EventID: eventID, TransferUnlockAt: 900, ExpiresAt: 2000, NonTransferableAfterRedeem: true},
+		2: {EventID: eventID, TransferUnlockAt: 1500, ExpiresAt: 2000, NonTransferableAfterRedeem: true},
+		3: {EventID: eventID, TransferUnlockAt: 900, ExpiresAt: 2000, BurnOnRedeem: true},
+	}}
+	ix := NewIndexer("testnet", common.HexToAddress("0x00000000000000000000000000000000000000cc"), db, reader)
+	ix.now = func() time.Time { return now }
+	client := &Client{indexer: ix}
+
+	var block uint64
+	emit := func(name string, data interface{}) {
+		block++
+		ix.HandleEvent(context.Background(), events.Event{
+			Network: "testnet",
+			Name:    name,
+			Data:    data,
+			Log:     types.Log{BlockNumber: block, TxHash: common.BigToHash(new(big.Int).SetUint64(block))},
+		})
+	}
+	mint := func(tokenID int64, to common.Address) {
+		emit(events.Transfer, &contracts.BOGOWITicketsTransfer{To: to, TokenId: big.NewInt(tokenID)})
+		emit(events.TicketMinted, &contracts.BOGOWITicketsTicketMinted{
+			TokenId:     big.NewInt(tokenID),
+			EventIdHash: eventID,
+			Buyer:       to,
+		})
+	}
+
+	mint(1, alice)
+	mint(2, alice)
+	mint(3, alice)
+	emit(events.TicketRedeemed, &contracts.BOGOWITicketsTicketRedeemed{TokenId: big.NewInt(3)})
+	emit(events.TicketBurned, &contracts.BOGOWITicketsTicketBurned{TokenId: big.NewInt(3), Owner: alice})
+	emit(events.Transfer, &contracts.BOGOWITicketsTransfer{From: alice, To: common.Address{}, TokenId: big.NewInt(3)})
+
+	ctx := context.Background()
+
+	tickets, err := client.GetUserTickets(ctx, alice)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, tickets)
+
+	transferable, err := client.GetTransferableTickets(ctx, alice)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1}, transferable)
+
+	// Unlocking the second ticket makes it transferable
+	emit(events.TransferUnlockUpdated, &contracts.BOGOWITicketsTransferUnlockUpdated{TokenId: big.NewInt(2), NewUnlockTime: 950})
+	transferable, err = client.GetTransferableTickets(ctx, alice)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, transferable)
+
+	emit(events.Transfer, &contracts.BOGOWITicketsTransfer{From: alice, To: bob, TokenId: big.NewInt(1)})
+	tickets, err = client.GetUserTickets(ctx, bob)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1}, tickets)
+
+	byEvent, err := client.GetTicketsByEvent(ctx, eventID)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, byEvent)
+
+	supply, err := client.GetTotalSupply(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), supply.Int64())
+
+	// Past the expiry, the second ticket is expired without being marked
+	now = time.Unix(3000, 0)
+	active, err := client.GetActiveTickets(ctx, alice)
+	require.NoError(t, err)
+	assert.Empty(t, active)
+	expired, err := client.GetExpiredTickets(ctx, alice)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2}, expired)
+
+	emit(events.TicketExpired, &contracts.BOGOWITicketsTicketExpired{TokenId: big.NewInt(2)})
+	expired, err = client.GetExpiredTickets(ctx, alice)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2}, expired)
+	redeemed, err := client.GetRedeemedTickets(ctx, alice)
+	require.NoError(t, err)
+	assert.Empty(t, redeemed)
+}
+
+func TestIndexerReadsDetailsLater(t *testing.T) {
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	contract := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	reader := &ticketReader{err: errors.New("connection refused")}
+	ix := NewIndexer("testnet", contract, db, reader)
+
+	ix.HandleEvent(context.Background(), events.Event{
+		Name: events.TicketMinted,
+		Data: &contracts.BOGOWITicketsTicketMinted{TokenId: big.NewInt(1), Buyer: contract},
+		Log:  types.Log{BlockNumber: 1},
+	})
+
+	missing, err := db.ListTicketsMissingDetails("testnet", contract.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1}, missing)
+
+	reader.mu.Lock()
+	reader.err = nil
+	reader.tickets = map[uint64]*TicketData{1: {ExpiresAt: 2000}}
+	reader.mu.Unlock()
+	require.NoError(t, ix.syncDetails(context.Background(), 1))
+
+	ticket, err := db.GetIndexedTicket("testnet", contract.Hex(), 1)
+	require.NoError(t, err)
+	assert.True(t, ticket.DetailsSynced)
+	assert.Equal(t, uint64(2000), ticket.ExpiresAt)
+}
+
+func TestIndexerRollback(t *testing.T) {
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	alice := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	bob := common.HexToAddress("0x00000000000000000000000000000000000000b0")
+	reader := &ticketReader{tickets: map[uint64]*TicketData{1: {ExpiresAt: 2000}, 2: {ExpiresAt: 2000}}}
+	ix := NewIndexer("testnet", common.HexToAddress("0x00000000000000000000000000000000000000cc"), db, reader)
+	ix.SetConfirmations(3)
+	client := &Client{indexer: ix}
+	ctx := context.Background()
+
+	emit := func(block uint64, data interface{}) {
+		ix.HandleEvent(ctx, events.Event{
+			Name: events.Transfer,
+			Data: data,
+			Log:  types.Log{BlockNumber: block, BlockHash: common.BigToHash(new(big.Int).SetUint64(block))},
+		})
+	}
+	emit(1, &contracts.BOGOWITicketsTransfer{To: alice, TokenId: big.NewInt(1)})
+	emit(5, &contracts.BOGOWITicketsTransfer{From: alice, To: bob, TokenId: big.NewInt(1)})
+	emit(6, &contracts.BOGOWITicketsTransfer{To: alice, TokenId: big.NewInt(2)})
+	emit(6, &contracts.BOGOWITicketsNonceUsed{Nonce: big.NewInt(42), User: alice})
+	used, err := ix.NonceUsed(big.NewInt(42))
+	require.NoError(t, err)
+	assert.True(t, used)
+	require.NoError(t, ix.SaveCursor(ctx, events.Checkpoint{Block: 6}))
+
+	// Block 5 is within the confirmation depth of block 6, block 1 is not
+	finality, err := client.GetTicketFinality(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, events.Unconfirmed, finality)
+	emit(3, &contracts.BOGOWITicketsTransfer{To: alice, TokenId: big.NewInt(3)})
+	finality, err = client.GetTicketFinality(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, events.Confirmed, finality)
+
+	// Blocks after 4 are replaced: the transfer to bob and the second mint
+	// are undone, and the nonce used in block 6 is free again
+	ix.Rollback(ctx, 4)
+	used, err = ix.NonceUsed(big.NewInt(42))
+	require.NoError(t, err)
+	assert.False(t, used)
+	tickets, err := client.GetUserTickets(ctx, alice)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 3}, tickets)
+	tickets, err = client.GetUserTickets(ctx, bob)
+	require.NoError(t, err)
+	assert.Empty(t, tickets)
+}
+
+func TestQueriesWithoutIndexer(t *testing.T) {
+	client := &Client{}
+	_, err := client.GetUserTickets(context.Background(), common.Address{})
+	assert.ErrorIs(t, err, ErrNoTicketIndex)
+	_, err = client.GetTotalSupply(context.Background())
+	assert.ErrorIs(t, err, ErrNoTicketIndex)
+}
@@ -2,10 +2,14 @@ package nft
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"math/big"
 	"time"
 
+	"bogowi-blockchain-go/internal/database"
+
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -31,6 +35,34 @@ func (c *Client) GetTicketData(ctx context.Context, tokenID uint64) (*TicketData
 	}, nil
 }
 
+// expiryGracePeriodABI binds expiryGracePeriod, which the generated tickets
+// binding predates
+var expiryGracePeriodABI = mustParseABI(`[{"name":"expiryGracePeriod","type":"function","stateMutability":"view",` +
+	`"inputs":[],"outputs":[{"name":"","type":"uint256"}]}]`)
+
+// ExpiryGracePeriod returns how long after its expiry the contract lets a
+// ticket be marked expired. Admins may change it.
+func (c *Client) ExpiryGracePeriod(ctx context.Context) (time.Duration, error) {
+	input, err := expiryGracePeriodABI.Pack("expiryGracePeriod")
+	if err != nil {
+		return 0, fmt.Errorf("failed to pack expiryGracePeriod: %w", err)
+	}
+	output, err := c.caller.CallContract(ctx, ethereum.CallMsg{To: &c.ticketsAddress, Data: input}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expiry grace period: %w", err)
+	}
+	values, err := expiryGracePeriodABI.Unpack("expiryGracePeriod", output)
+	if err != nil {
+		return 0, fmt.Errorf("failed to unpack expiry grace period: %w", err)
+	}
+
+	seconds := values[0].(*big.Int)
+	if !seconds.IsInt64() || seconds.Int64() > int64(maxExpiryGracePeriod/time.Second) {
+		return 0, fmt.Errorf("expiry grace period of %s seconds is out of range", seconds)
+	}
+	return time.Duration(seconds.Int64()) * time.Second, nil
+}
+
 // IsTransferable checks if a ticket can be transferred
 func (c *Client) IsTransferable(ctx context.Context, tokenID uint64) (bool, error) {
 	opts := &bind.CallOpts{Context: ctx}
@@ -79,85 +111,72 @@ func (c *Client) GetBalanceOf(ctx context.Context, owner common.Address) (*big.I
 	return balance, nil
 }
 
-// GetTotalSupply returns the total number of tickets minted
-// Note: BOGOWITickets doesn't have a totalSupply method, would need event filtering to track
+// GetTotalSupply returns the total number of tickets minted, including
+// burned ones, as far as the ticket index has seen
 func (c *Client) GetTotalSupply(ctx context.Context) (*big.Int, error) {
-	// BOGOWITickets contract doesn't expose totalSupply
-	// This would need to be tracked via events or a separate counter
-	return nil, fmt.Errorf("getTotalSupply requires event filtering to track total minted tokens (implement in Phase 7)")
+	if c.indexer == nil {
+		return nil, ErrNoTicketIndex
+	}
+	return c.indexer.TotalMinted()
 }
 
 // GetUserTickets returns all ticket IDs owned by a user
-// Note: This requires either ERC721Enumerable support or event filtering
 func (c *Client) GetUserTickets(ctx context.Context, owner common.Address) ([]uint64, error) {
-	// First check if we have enumeration support by trying to get balance
-	balance, err := c.GetBalanceOf(ctx, owner)
-	if err != nil {
-		return nil, err
+	if c.indexer == nil {
+		return nil, ErrNoTicketIndex
 	}
-
-	if balance.Int64() == 0 {
-		return []uint64{}, nil
-	}
-
-	// Since TokenOfOwnerByIndex might not be available, we need to use events
-	// This is a placeholder that indicates event filtering is needed
-	return nil, fmt.Errorf("getUserTickets requires event filtering to enumerate tokens (implement in Phase 7). Balance: %s tokens", balance.String())
+	return c.indexer.OwnerTickets(owner, "")
 }
 
 // GetTicketsByEvent returns all tickets for a specific event
 func (c *Client) GetTicketsByEvent(ctx context.Context, eventID [32]byte) ([]uint64, error) {
-	// This would typically be done by filtering events
-	// For now, return an error indicating it needs event filtering
-	return nil, fmt.Errorf("event-based queries require event filtering (Phase 7)")
+	if c.indexer == nil {
+		return nil, ErrNoTicketIndex
+	}
+	return c.indexer.EventTickets(eventID)
 }
 
 // GetActiveTickets returns all active (non-expired, non-redeemed) tickets for an owner
-// Note: This currently requires event filtering for full functionality
 func (c *Client) GetActiveTickets(ctx context.Context, owner common.Address) ([]uint64, error) {
-	// Check balance first
-	balance, err := c.GetBalanceOf(ctx, owner)
-	if err != nil {
-		return nil, err
+	if c.indexer == nil {
+		return nil, ErrNoTicketIndex
 	}
-
-	if balance.Int64() == 0 {
-		return []uint64{}, nil
-	}
-
-	// Without enumeration, we need event filtering
-	return nil, fmt.Errorf("getActiveTickets requires event filtering (implement in Phase 7). User has %s tickets", balance.String())
+	return c.indexer.OwnerTickets(owner, database.TicketsActive)
 }
 
 // GetRedeemedTickets returns all redeemed tickets for an owner
-// Note: This currently requires event filtering for full functionality
 func (c *Client) GetRedeemedTickets(ctx context.Context, owner common.Address) ([]uint64, error) {
-	// Without enumeration, we need event filtering
-	return nil, fmt.Errorf("getRedeemedTickets requires event filtering (implement in Phase 7)")
+	if c.indexer == nil {
+		return nil, ErrNoTicketIndex
+	}
+	return c.indexer.OwnerTickets(owner, database.TicketsRedeemed)
 }
 
-// GetExpiredTickets returns all expired tickets for an owner
-// Note: This currently requires event filtering for full functionality
+// GetExpiredTickets returns all expired tickets for an owner, whether or not
+// they were marked expired on-chain
 func (c *Client) GetExpiredTickets(ctx context.Context, owner common.Address) ([]uint64, error) {
-	// Without enumeration, we need event filtering
-	return nil, fmt.Errorf("getExpiredTickets requires event filtering (implement in Phase 7)")
+	if c.indexer == nil {
+		return nil, ErrNoTicketIndex
+	}
+	return c.indexer.OwnerTickets(owner, database.TicketsExpired)
 }
 
 // GetTransferableTickets returns all tickets that can currently be transferred
-// Note: This currently requires event filtering for full functionality
 func (c *Client) GetTransferableTickets(ctx context.Context, owner common.Address) ([]uint64, error) {
-	// Check balance first
-	balance, err := c.GetBalanceOf(ctx, owner)
-	if err != nil {
-		return nil, err
+	if c.indexer == nil {
+		return nil, ErrNoTicketIndex
 	}
+	return c.indexer.OwnerTickets(owner, database.TicketsTransferable)
+}
 
-	if balance.Int64() == 0 {
-		return []uint64{}, nil
+// GetTicketFinality returns whether the indexed state of a ticket is
+// confirmed (events.Confirmed) or may still change with a reorg
+// (events.Unconfirmed)
+func (c *Client) GetTicketFinality(ctx context.Context, tokenID uint64) (string, error) {
+	if c.indexer == nil {
+		return "", ErrNoTicketIndex
 	}
-
-	// Without enumeration, we need event filtering
-	return nil, fmt.Errorf("getTransferableTickets requires event filtering (implement in Phase 7). User has %s tickets", balance.String())
+	return c.indexer.TicketFinality(tokenID)
 }
 
 // GetTicketMetadata retrieves full metadata including Datakyte data
@@ -231,15 +250,35 @@ func (c *Client) HasRole(ctx context.Context, role [32]byte, account common.Addr
 	return hasRole, nil
 }
 
-// GetRedemptionNonce gets the redemption nonce for an address
-// Note: This would need to be tracked via events or stored separately
+// GetRedemptionNonce returns a nonce for a redemption signed by address.
+// The contract keeps one set of used nonces for every redeemer, so nonces
+// are random rather than counted per address, and nonces the index saw
+// used are skipped. They stay below 2^53 so JSON clients read them exactly.
 func (c *Client) GetRedemptionNonce(ctx context.Context, address common.Address) (*big.Int, error) {
-	// The contract doesn't expose redemptionNonces publicly
-	// In practice, nonces would be tracked off-chain or via events
-	// For now, we can use the current timestamp as a simple nonce
-	return big.NewInt(time.Now().Unix()), nil
+	for {
+		nonce, err := rand.Int(rand.Reader, maxRedemptionNonce)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		nonce.Add(nonce, big.NewInt(1))
+
+		if c.indexer == nil {
+			return nonce, nil
+		}
+		used, err := c.indexer.NonceUsed(nonce)
+		if err != nil {
+			return nil, err
+		}
+		if !used {
+			return nonce, nil
+		}
+	}
 }
 
+// maxRedemptionNonce bounds generated nonces to integers JSON clients can
+// represent exactly
+var maxRedemptionNonce = new(big.Int).Lsh(big.NewInt(1), 53)
+
 // String returns the string representation of a ticket state
 func (s TicketState) String() string {
 	switch s {
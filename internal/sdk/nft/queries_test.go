@@ -2,9 +2,12 @@ package nft
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -66,6 +69,45 @@ func TestClient_GetTicketData(t *testing.T) {
 	})
 }
 
+// gracePeriodCaller answers expiryGracePeriod calls to the tickets contract
+type gracePeriodCaller struct {
+	t       *testing.T
+	tickets common.Address
+	seconds *big.Int
+	err     error
+}
+
+func (g *gracePeriodCaller) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x60, 0x80}, nil
+}
+
+func (g *gracePeriodCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	require.Equal(g.t, g.tickets, *call.To)
+	require.Equal(g.t, expiryGracePeriodABI.Methods["expiryGracePeriod"].ID, call.Data)
+	if g.err != nil {
+		return nil, g.err
+	}
+	return expiryGracePeriodABI.Methods["expiryGracePeriod"].Outputs.Pack(g.seconds)
+}
+
+func TestClient_ExpiryGracePeriod(t *testing.T) {
+	tickets := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	caller := &gracePeriodCaller{t: t, tickets: tickets, seconds: big.NewInt(3600)}
+	client := &Client{caller: caller, ticketsAddress: tickets}
+
+	period, err := client.ExpiryGracePeriod(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, period)
+
+	caller.seconds = big.NewInt(2 * 86400)
+	_, err = client.ExpiryGracePeriod(context.Background())
+	assert.ErrorContains(t, err, "out of range")
+
+	caller.err = errors.New("connection refused")
+	_, err = client.ExpiryGracePeriod(context.Background())
+	assert.ErrorContains(t, err, "failed to get expiry grace period")
+}
+
 // TestClient_IsTransferable tests the actual Client.IsTransferable method
 func TestClient_IsTransferable(t *testing.T) {
 	mockContract := new(MockTicketsContract)
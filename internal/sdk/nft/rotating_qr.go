@@ -0,0 +1,263 @@
+package nft
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// rotatingCodeLength is the length in bytes of a rotating QR code
+const rotatingCodeLength = 8
+
+// rotatingStepTolerance is how many periods a rotating QR code may be off
+// the scan time, to absorb clock drift and the time taken to scan
+const rotatingStepTolerance = 1
+
+// rotatingStep records the last step of a rotating token accepted at a scan
+type rotatingStep struct {
+	step     uint64
+	deadline int64
+}
+
+// IssueRotatingRedemption issues a rotating redemption token for tokenID to
+// its holder. The holder's app shows RotatingRedemptionQR for the current
+// time, which changes every rotating period; a screenshot of the code is
+// only accepted until the next code is shown. Each token carries a fresh
+// redemption nonce, so it is spent once the ticket is redeemed.
+func (c *Client) IssueRotatingRedemption(ctx context.Context, tokenID uint64, redeemer common.Address) (*RotatingRedemptionToken, error) {
+	if err := c.checkRedeemer(ctx, tokenID, redeemer); err != nil {
+		return nil, err
+	}
+
+	nonce, err := c.GetRedemptionNonce(ctx, redeemer)
+	if err != nil {
+		return nil, err
+	}
+	params := RedemptionParams{
+		TokenID:  tokenID,
+		Redeemer: redeemer,
+		Nonce:    nonce.Uint64(),
+		Deadline: time.Now().Add(RotatingTokenValidity).Unix(),
+	}
+
+	seed, err := c.rotatingSeed(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RotatingRedemptionToken{
+		Params:  params,
+		Seed:    seed,
+		Period:  c.rotatingPeriod(),
+		BaseURL: c.redemptionBaseURL(),
+	}, nil
+}
+
+// rotatingPeriod returns how often the codes of rotating tokens change, in
+// seconds
+func (c *Client) rotatingPeriod() int64 {
+	if c.qrPeriod < time.Second {
+		return int64(DefaultRotatingQRPeriod / time.Second)
+	}
+	return int64(c.qrPeriod / time.Second)
+}
+
+// rotatingSeed derives the seed of a rotating token from the EIP-712 digest
+// of its redemption, which binds it to the contract, ticket, holder, nonce
+// and deadline
+func (c *Client) rotatingSeed(ctx context.Context, params RedemptionParams) ([]byte, error) {
+	domain, err := c.RedemptionDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	typedData := redemptionTypedData(domain,
+		new(big.Int).SetUint64(params.TokenID),
+		params.Redeemer,
+		new(big.Int).SetUint64(params.Nonce),
+		big.NewInt(params.Deadline),
+	)
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash typed data: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, c.qrSecret)
+	mac.Write(digest)
+	return mac.Sum(nil), nil
+}
+
+// RotatingCode returns the code of a rotating token for the given step
+func RotatingCode(seed []byte, step uint64) []byte {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], step)
+
+	mac := hmac.New(sha256.New, seed)
+	mac.Write(counter[:])
+	return mac.Sum(nil)[:rotatingCodeLength]
+}
+
+// RotatingStep returns the step of a rotating token with the given period
+// in seconds at time at
+func RotatingStep(period int64, at time.Time) uint64 {
+	if period <= 0 {
+		period = int64(DefaultRotatingQRPeriod / time.Second)
+	}
+	return uint64(at.Unix() / period)
+}
+
+// RotatingRedemptionQR returns the QR code data a holder's app shows for a
+// rotating token at time at. Unlike GenerateRedemptionQRCode it carries no
+// signature: the code of the current step authenticates it, and the backend
+// signs the redemption when the ticket is checked in.
+func RotatingRedemptionQR(token *RotatingRedemptionToken, at time.Time) string {
+	step := RotatingStep(token.Period, at)
+	return fmt.Sprintf(
+		"%s/redeem?tokenId=%d&redeemer=%s&nonce=%d&deadline=%d&step=%d&code=%x",
+		token.BaseURL,
+		token.Params.TokenID,
+		token.Params.Redeemer.Hex(),
+		token.Params.Nonce,
+		token.Params.Deadline,
+		step,
+		RotatingCode(token.Seed, step),
+	)
+}
+
+// ParseRotatingRedemptionQR parses QR code data produced by
+// RotatingRedemptionQR
+func ParseRotatingRedemptionQR(qrData string) (*RotatingQRData, error) {
+	parsed, err := url.Parse(strings.TrimSpace(qrData))
+	if err != nil {
+		return nil, fmt.Errorf("invalid QR code URL: %w", err)
+	}
+	if !strings.HasSuffix(parsed.Path, "/redeem") {
+		return nil, fmt.Errorf("not a redemption QR code")
+	}
+	query := parsed.Query()
+
+	number := func(name string) (uint64, error) {
+		value, err := strconv.ParseUint(query.Get(name), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s in QR code", name)
+		}
+		return value, nil
+	}
+
+	data := &RotatingQRData{}
+	if data.Params.TokenID, err = number("tokenId"); err != nil {
+		return nil, err
+	}
+	if data.Params.Nonce, err = number("nonce"); err != nil {
+		return nil, err
+	}
+	deadline, err := number("deadline")
+	if err != nil || deadline > uint64(1<<63-1) {
+		return nil, fmt.Errorf("invalid deadline in QR code")
+	}
+	data.Params.Deadline = int64(deadline)
+	if data.Step, err = number("step"); err != nil {
+		return nil, err
+	}
+
+	redeemer := query.Get("redeemer")
+	if !common.IsHexAddress(redeemer) {
+		return nil, fmt.Errorf("invalid redeemer in QR code")
+	}
+	data.Params.Redeemer = common.HexToAddress(redeemer)
+
+	data.Code, err = hex.DecodeString(query.Get("code"))
+	if err != nil || len(data.Code) != rotatingCodeLength {
+		return nil, fmt.Errorf("invalid code in QR code")
+	}
+
+	return data, nil
+}
+
+// isRotatingQR reports whether qrData is a rotating redemption QR code
+func isRotatingQR(qrData string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(qrData))
+	return err == nil && parsed.Query().Has("code")
+}
+
+// checkRotatingQR checks the code of a rotating QR code scanned at
+// scannedAt and returns its step. Steps already used are rejected; the
+// caller marks the step used once the ticket is accepted.
+func (c *Client) checkRotatingQR(ctx context.Context, qrData string, scannedAt time.Time) (*CheckIn, uint64, error) {
+	data, err := ParseRotatingRedemptionQR(qrData)
+	if err != nil {
+		return &CheckIn{Result: CheckInInvalid, Reason: err.Error()}, 0, nil
+	}
+	checkIn := &CheckIn{Params: data.Params}
+
+	seed, err := c.rotatingSeed(ctx, data.Params)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !hmac.Equal(RotatingCode(seed, data.Step), data.Code) {
+		checkIn.Result, checkIn.Reason = CheckInInvalid, "QR code was not issued by this service"
+		return checkIn, 0, nil
+	}
+	if data.Params.Deadline < scannedAt.Unix() {
+		checkIn.Result, checkIn.Reason = CheckInQRExpired, "rotating QR token expired, ask the holder to refresh it"
+		return checkIn, 0, nil
+	}
+
+	current := RotatingStep(c.rotatingPeriod(), scannedAt)
+	if data.Step+rotatingStepTolerance < current || data.Step > current+rotatingStepTolerance {
+		checkIn.Result, checkIn.Reason = CheckInQRExpired, "QR code is stale, scan the code currently shown"
+		return checkIn, 0, nil
+	}
+	if c.rotatingStepUsed(data.Params, data.Step) {
+		checkIn.Result, checkIn.Reason = CheckInInvalid, "QR code was already used"
+		return checkIn, 0, nil
+	}
+
+	return checkIn, data.Step, nil
+}
+
+// rotatingStepUsed reports whether step or a later one was accepted for the
+// token of params
+func (c *Client) rotatingStepUsed(params RedemptionParams, step uint64) bool {
+	c.rotationMu.Lock()
+	defer c.rotationMu.Unlock()
+
+	used, ok := c.rotationSteps[params.Nonce]
+	return ok && used.step >= step
+}
+
+// useRotatingStep records step as accepted for the token of params. It
+// returns false when the step or a later one was accepted before. Accepted
+// steps are held in memory, so each API instance accepts a code once and a
+// restart forgets them; the redemption nonce still spends the token once the
+// ticket is redeemed.
+func (c *Client) useRotatingStep(params RedemptionParams, step uint64) bool {
+	c.rotationMu.Lock()
+	defer c.rotationMu.Unlock()
+
+	now := time.Now().Unix()
+	if c.rotationSteps == nil {
+		c.rotationSteps = make(map[uint64]rotatingStep)
+	}
+	for nonce, used := range c.rotationSteps {
+		if used.deadline < now {
+			delete(c.rotationSteps, nonce)
+		}
+	}
+
+	if used, ok := c.rotationSteps[params.Nonce]; ok && used.step >= step {
+		return false
+	}
+	c.rotationSteps[params.Nonce] = rotatingStep{step: step, deadline: params.Deadline}
+	return true
+}
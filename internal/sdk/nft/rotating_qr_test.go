@@ -0,0 +1,185 @@
+package nft
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingRedemptionQRRoundTrip(t *testing.T) {
+	token := &RotatingRedemptionToken{
+		Params: RedemptionParams{
+			TokenID:  10001,
+			Redeemer: common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb1"),
+			Nonce:    42,
+			Deadline: 1735689600,
+		},
+		Seed:    []byte("seed"),
+		Period:  15,
+		BaseURL: "https://testnet.bogowi.com",
+	}
+	at := time.Unix(1735680000, 0)
+
+	qrData := RotatingRedemptionQR(token, at)
+	assert.True(t, isRotatingQR(qrData))
+
+	data, err := ParseRotatingRedemptionQR(qrData)
+	require.NoError(t, err)
+	assert.Equal(t, token.Params, data.Params)
+	assert.Equal(t, uint64(1735680000/15), data.Step)
+	assert.Equal(t, RotatingCode(token.Seed, data.Step), data.Code)
+
+	// The code changes with the period
+	assert.NotEqual(t, qrData, RotatingRedemptionQR(token, at.Add(15*time.Second)))
+	assert.Equal(t, qrData, RotatingRedemptionQR(token, at.Add(14*time.Second)))
+
+	invalid := []string{
+		"https://example.com/menu?code=00",
+		strings.Replace(qrData, "code=", "code=zz", 1),
+		strings.Replace(qrData, "step=", "step=-", 1),
+		strings.Replace(qrData, "redeemer=0x", "redeemer=0xzz", 1),
+	}
+	for _, qr := range invalid {
+		_, err := ParseRotatingRedemptionQR(qr)
+		assert.Error(t, err, qr)
+	}
+}
+
+func TestCheckRotatingRedemptionQR(t *testing.T) {
+	holder := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	contract := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	chainID := big.NewInt(501)
+	eventID := [32]byte{7}
+	ctx := context.Background()
+
+	mockContract := new(MockTicketsContract)
+	mockContract.On("Eip712Domain", mock.Anything).Return(GetEIP712Domain(chainID, contract), nil)
+	mockContract.On("OwnerOf", mock.Anything, mock.Anything).Return(holder, nil)
+	mockContract.On("GetTicketData", mock.Anything, mock.Anything).Return(
+		TicketDataContract{EventID: eventID, ExpiresAt: uint64(time.Now().Add(24 * time.Hour).Unix())}, nil)
+
+	newClient := func(secret string) *Client {
+		return &Client{
+			ticketsContract: mockContract,
+			ticketsAddress:  contract,
+			networkConfig:   &NetworkConfig{ChainID: chainID},
+			auth:            &bind.TransactOpts{},
+			network:         "testnet",
+			qrSecret:        []byte(secret),
+		}
+	}
+	client := newClient("secret")
+
+	token, err := client.IssueRotatingRedemption(ctx, 1, holder)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), token.Params.TokenID)
+	assert.Equal(t, holder, token.Params.Redeemer)
+	assert.Equal(t, int64(DefaultRotatingQRPeriod/time.Second), token.Period)
+	assert.Equal(t, "https://testnet.bogowi.com", token.BaseURL)
+
+	forged, err := newClient("other secret").IssueRotatingRedemption(ctx, 1, holder)
+	require.NoError(t, err)
+	forged.Params = token.Params
+
+	now := time.Now()
+	expired := *token
+	expired.Params.Deadline = now.Add(-time.Minute).Unix()
+
+	tests := []struct {
+		name   string
+		qrData string
+		gate   [32]byte
+		want   string
+	}{
+		{"previous code at the wrong gate", RotatingRedemptionQR(token, now.Add(-DefaultRotatingQRPeriod)), [32]byte{8}, CheckInWrongEvent},
+		{"previous code", RotatingRedemptionQR(token, now.Add(-DefaultRotatingQRPeriod)), eventID, CheckInValid},
+		{"current code", RotatingRedemptionQR(token, now), eventID, CheckInValid},
+		{"screenshot replayed", RotatingRedemptionQR(token, now), eventID, CheckInInvalid},
+		{"screenshot replayed at the wrong gate", RotatingRedemptionQR(token, now), [32]byte{8}, CheckInInvalid},
+		{"older code after a newer one", RotatingRedemptionQR(token, now.Add(-DefaultRotatingQRPeriod)), eventID, CheckInInvalid},
+		{"stale code", RotatingRedemptionQR(token, now.Add(-3*DefaultRotatingQRPeriod)), eventID, CheckInQRExpired},
+		{"forged code", RotatingRedemptionQR(forged, now.Add(DefaultRotatingQRPeriod)), eventID, CheckInInvalid},
+		{"tampered token", RotatingRedemptionQR(&expired, now), eventID, CheckInInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkIn, err := client.checkRedemptionQR(ctx, tt.qrData, tt.gate, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, checkIn.Result, checkIn.Reason)
+			if tt.want == CheckInValid {
+				assert.Equal(t, token.Params, checkIn.Params)
+			}
+		})
+	}
+
+	t.Run("token expired", func(t *testing.T) {
+		params := token.Params
+		params.Deadline = now.Add(-time.Minute).Unix()
+		seed, err := client.rotatingSeed(ctx, params)
+		require.NoError(t, err)
+		stale := &RotatingRedemptionToken{Params: params, Seed: seed, Period: token.Period}
+
+		checkIn, err := client.checkRedemptionQR(ctx, RotatingRedemptionQR(stale, now), eventID, now)
+		require.NoError(t, err)
+		assert.Equal(t, CheckInQRExpired, checkIn.Result, checkIn.Reason)
+	})
+}
+
+func TestRotatingQRConfiguredPeriod(t *testing.T) {
+	holder := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	contract := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	chainID := big.NewInt(501)
+	eventID := [32]byte{7}
+	ctx := context.Background()
+
+	mockContract := new(MockTicketsContract)
+	mockContract.On("Eip712Domain", mock.Anything).Return(GetEIP712Domain(chainID, contract), nil)
+	mockContract.On("OwnerOf", mock.Anything, mock.Anything).Return(holder, nil)
+	mockContract.On("GetTicketData", mock.Anything, mock.Anything).Return(
+		TicketDataContract{EventID: eventID, ExpiresAt: uint64(time.Now().Add(24 * time.Hour).Unix())}, nil)
+
+	newClient := func(period time.Duration) *Client {
+		return &Client{
+			ticketsContract: mockContract,
+			ticketsAddress:  contract,
+			networkConfig:   &NetworkConfig{ChainID: chainID},
+			auth:            &bind.TransactOpts{},
+			network:         "testnet",
+			qrSecret:        []byte("secret"),
+			qrPeriod:        period,
+		}
+	}
+	client := newClient(time.Minute)
+
+	token, err := client.IssueRotatingRedemption(ctx, 1, holder)
+	require.NoError(t, err)
+	assert.Equal(t, int64(60), token.Period)
+
+	now := time.Now()
+	tests := []struct {
+		name   string
+		client *Client
+		qrData string
+		want   string
+	}{
+		// 45s is three default periods, but within one configured period
+		{"previous code", client, RotatingRedemptionQR(token, now.Add(-45*time.Second)), CheckInValid},
+		{"stale code", client, RotatingRedemptionQR(token, now.Add(-3*time.Minute)), CheckInQRExpired},
+		{"checked with the default period", newClient(0), RotatingRedemptionQR(token, now), CheckInQRExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkIn, err := tt.client.checkRedemptionQR(ctx, tt.qrData, eventID, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, checkIn.Result, checkIn.Reason)
+		})
+	}
+}
@@ -1,13 +1,24 @@
 package nft
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
+	"net/url"
+	"strings"
 
+	"bogowi-blockchain-go/internal/sdk/signer"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
@@ -37,27 +48,29 @@ func GetEIP712Domain(chainID *big.Int, contractAddress common.Address) EIP712Dom
 	}
 }
 
-// GenerateRedemptionSignature generates an EIP-712 signature for ticket redemption
-func GenerateRedemptionSignature(
-	privateKey *ecdsa.PrivateKey,
+// RedemptionTypedData returns the EIP-712 typed data a ticket redemption
+// signature covers, under the default BOGOWITickets domain. The struct
+// matches REDEMPTION_TYPEHASH of the contract, which repeats the chain ID
+// next to the domain.
+func RedemptionTypedData(
 	tokenID *big.Int,
 	redeemer common.Address,
 	nonce *big.Int,
 	deadline *big.Int,
 	chainID *big.Int,
 	contractAddress common.Address,
-) ([]byte, error) {
-	if privateKey == nil {
-		return nil, fmt.Errorf("private key cannot be nil")
-	}
-	// Create the EIP-712 typed data
-	domain := apitypes.TypedDataDomain{
-		Name:              "BOGOWITickets",
-		Version:           "1",
-		ChainId:           (*math.HexOrDecimal256)(chainID),
-		VerifyingContract: contractAddress.Hex(),
-	}
+) apitypes.TypedData {
+	return redemptionTypedData(GetEIP712Domain(chainID, contractAddress), tokenID, redeemer, nonce, deadline)
+}
 
+// redemptionTypedData returns the redemption typed data under domain
+func redemptionTypedData(
+	domain EIP712Domain,
+	tokenID *big.Int,
+	redeemer common.Address,
+	nonce *big.Int,
+	deadline *big.Int,
+) apitypes.TypedData {
 	types := apitypes.Types{
 		"EIP712Domain": {
 			{Name: "name", Type: "string"},
@@ -70,6 +83,7 @@ func GenerateRedemptionSignature(
 			{Name: "redeemer", Type: "address"},
 			{Name: "nonce", Type: "uint256"},
 			{Name: "deadline", Type: "uint256"},
+			{Name: "chainId", Type: "uint256"},
 		},
 	}
 
@@ -78,42 +92,54 @@ func GenerateRedemptionSignature(
 		"redeemer": redeemer.Hex(),
 		"nonce":    (*math.HexOrDecimal256)(nonce),
 		"deadline": (*math.HexOrDecimal256)(deadline),
+		"chainId":  (*math.HexOrDecimal256)(domain.ChainId),
 	}
 
-	typedData := apitypes.TypedData{
+	return apitypes.TypedData{
 		Types:       types,
 		PrimaryType: "RedeemTicket",
-		Domain:      domain,
-		Message:     message,
-	}
-
-	// Hash the typed data
-	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
-	if err != nil {
-		return nil, fmt.Errorf("failed to hash domain: %w", err)
-	}
-
-	typedDataHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
-	if err != nil {
-		return nil, fmt.Errorf("failed to hash message: %w", err)
+		Domain: apitypes.TypedDataDomain{
+			Name:              domain.Name,
+			Version:           domain.Version,
+			ChainId:           (*math.HexOrDecimal256)(domain.ChainId),
+			VerifyingContract: domain.VerifyingContract.Hex(),
+		},
+		Message: message,
 	}
+}
 
-	// Compute the digest
-	rawData := []byte(fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(typedDataHash)))
-	digest := crypto.Keccak256(rawData)
-
-	// Sign the digest
-	signature, err := crypto.Sign(digest, privateKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign: %w", err)
+// SignRedemption signs a ticket redemption with s, which may be a local key,
+// a keystore or a remote signer
+func SignRedemption(
+	ctx context.Context,
+	s signer.Signer,
+	tokenID *big.Int,
+	redeemer common.Address,
+	nonce *big.Int,
+	deadline *big.Int,
+	chainID *big.Int,
+	contractAddress common.Address,
+) ([]byte, error) {
+	if s == nil {
+		return nil, fmt.Errorf("signer cannot be nil")
 	}
+	return s.SignTypedData(ctx, RedemptionTypedData(tokenID, redeemer, nonce, deadline, chainID, contractAddress))
+}
 
-	// Adjust v value for Ethereum (27 or 28)
-	if signature[64] < 27 {
-		signature[64] += 27
+// GenerateRedemptionSignature generates an EIP-712 signature for ticket redemption
+func GenerateRedemptionSignature(
+	privateKey *ecdsa.PrivateKey,
+	tokenID *big.Int,
+	redeemer common.Address,
+	nonce *big.Int,
+	deadline *big.Int,
+	chainID *big.Int,
+	contractAddress common.Address,
+) ([]byte, error) {
+	if privateKey == nil {
+		return nil, fmt.Errorf("private key cannot be nil")
 	}
-
-	return signature, nil
+	return SignRedemption(context.Background(), signer.NewKeySigner(privateKey), tokenID, redeemer, nonce, deadline, chainID, contractAddress)
 }
 
 // VerifyRedemptionSignature verifies an EIP-712 redemption signature
@@ -127,76 +153,111 @@ func VerifyRedemptionSignature(
 	contractAddress common.Address,
 	expectedSigner common.Address,
 ) (bool, error) {
-	if len(signature) != 65 {
-		return false, fmt.Errorf("invalid signature length: expected 65, got %d", len(signature))
+	recoveredAddr, err := RecoverRedemptionSigner(signature, tokenID, redeemer, nonce, deadline, chainID, contractAddress)
+	if err != nil {
+		return false, err
 	}
+	return recoveredAddr == expectedSigner, nil
+}
 
-	// Create the same typed data structure
-	domain := apitypes.TypedDataDomain{
-		Name:              "BOGOWITickets",
-		Version:           "1",
-		ChainId:           (*math.HexOrDecimal256)(chainID),
-		VerifyingContract: contractAddress.Hex(),
+// RecoverRedemptionSigner returns the address that produced an EIP-712
+// redemption signature
+func RecoverRedemptionSigner(
+	signature []byte,
+	tokenID *big.Int,
+	redeemer common.Address,
+	nonce *big.Int,
+	deadline *big.Int,
+	chainID *big.Int,
+	contractAddress common.Address,
+) (common.Address, error) {
+	// Hash the same typed data structure
+	typedData := RedemptionTypedData(tokenID, redeemer, nonce, deadline, chainID, contractAddress)
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to hash typed data: %w", err)
 	}
 
-	types := apitypes.Types{
-		"EIP712Domain": {
-			{Name: "name", Type: "string"},
-			{Name: "version", Type: "string"},
-			{Name: "chainId", Type: "uint256"},
-			{Name: "verifyingContract", Type: "address"},
-		},
-		"RedeemTicket": {
-			{Name: "tokenId", Type: "uint256"},
-			{Name: "redeemer", Type: "address"},
-			{Name: "nonce", Type: "uint256"},
-			{Name: "deadline", Type: "uint256"},
-		},
+	return recoverSigner(digest, signature)
+}
+
+// recoverSigner returns the address whose key produced signature over digest
+func recoverSigner(digest []byte, signature []byte) (common.Address, error) {
+	pubKey, err := recoverPublicKey(digest, signature)
+	if err != nil {
+		return common.Address{}, err
 	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
 
-	message := apitypes.TypedDataMessage{
-		"tokenId":  (*math.HexOrDecimal256)(tokenID),
-		"redeemer": redeemer.Hex(),
-		"nonce":    (*math.HexOrDecimal256)(nonce),
-		"deadline": (*math.HexOrDecimal256)(deadline),
+// recoverPublicKey returns the public key whose private key produced
+// signature over digest
+func recoverPublicKey(digest []byte, signature []byte) (*ecdsa.PublicKey, error) {
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("invalid signature length: expected 65, got %d", len(signature))
 	}
 
-	typedData := apitypes.TypedData{
-		Types:       types,
-		PrimaryType: "RedeemTicket",
-		Domain:      domain,
-		Message:     message,
+	sig := make([]byte, 65)
+	copy(sig, signature)
+	if sig[64] >= 27 {
+		sig[64] -= 27
 	}
 
-	// Hash the typed data
-	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	pubKey, err := crypto.SigToPub(digest, sig)
 	if err != nil {
-		return false, fmt.Errorf("failed to hash domain: %w", err)
+		return nil, fmt.Errorf("failed to recover public key: %w", err)
 	}
+	return pubKey, nil
+}
+
+// erc1271MagicValue is returned by isValidSignature for a valid signature
+var erc1271MagicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
 
-	typedDataHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+var erc1271ABI = mustParseABI(`[{"name":"isValidSignature","type":"function","stateMutability":"view",` +
+	`"inputs":[{"name":"hash","type":"bytes32"},{"name":"signature","type":"bytes"}],` +
+	`"outputs":[{"name":"magicValue","type":"bytes4"}]}]`)
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
 	if err != nil {
-		return false, fmt.Errorf("failed to hash message: %w", err)
+		panic(err)
 	}
+	return parsed
+}
 
-	// Compute the digest
-	rawData := []byte(fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(typedDataHash)))
-	digest := crypto.Keccak256(rawData)
-
-	// Recover the signer
-	sig := make([]byte, 65)
-	copy(sig, signature)
-	if sig[64] >= 27 {
-		sig[64] -= 27
+// IsValidERC1271Signature reports whether the smart-contract wallet at
+// account accepts signature over digest. Accounts without code, and
+// wallets that revert, do not accept the signature.
+func IsValidERC1271Signature(
+	ctx context.Context,
+	caller bind.ContractCaller,
+	account common.Address,
+	digest common.Hash,
+	signature []byte,
+) (bool, error) {
+	code, err := caller.CodeAt(ctx, account, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get code of %s: %w", account.Hex(), err)
+	}
+	if len(code) == 0 {
+		return false, nil
 	}
 
-	pubKey, err := crypto.SigToPub(digest, sig)
+	input, err := erc1271ABI.Pack("isValidSignature", digest, signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to pack isValidSignature: %w", err)
+	}
+	output, err := caller.CallContract(ctx, ethereum.CallMsg{To: &account, Data: input}, nil)
 	if err != nil {
-		return false, fmt.Errorf("failed to recover public key: %w", err)
+		var reverted rpc.DataError
+		if errors.As(err, &reverted) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to call isValidSignature on %s: %w", account.Hex(), err)
 	}
 
-	recoveredAddr := crypto.PubkeyToAddress(*pubKey)
-	return recoveredAddr == expectedSigner, nil
+	// Wallets return the magic value left-aligned in a 32-byte word
+	return len(output) >= 4 && [4]byte(output[:4]) == erc1271MagicValue, nil
 }
 
 // GenerateRedemptionQRCode generates QR code data for ticket redemption
@@ -220,11 +281,48 @@ func GenerateRedemptionQRCode(
 	)
 }
 
-// ParseRedemptionQRCode parses QR code data for ticket redemption
+// ParseRedemptionQRCode parses QR code data for ticket redemption, as
+// produced by GenerateRedemptionQRCode. The chain ID is not part of the QR
+// code and is left nil.
 func ParseRedemptionQRCode(qrData string) (*RedemptionData, error) {
-	// This is a simplified parser - in production, use proper URL parsing
-	// Expected format: baseURL/redeem?tokenId=X&redeemer=0x...&nonce=Y&deadline=Z&sig=0x...
+	parsed, err := url.Parse(strings.TrimSpace(qrData))
+	if err != nil {
+		return nil, fmt.Errorf("invalid QR code URL: %w", err)
+	}
+	if !strings.HasSuffix(parsed.Path, "/redeem") {
+		return nil, fmt.Errorf("not a redemption QR code")
+	}
+	query := parsed.Query()
+
+	number := func(name string) (*big.Int, error) {
+		value, ok := new(big.Int).SetString(query.Get(name), 10)
+		if !ok || value.Sign() < 0 {
+			return nil, fmt.Errorf("invalid %s in QR code", name)
+		}
+		return value, nil
+	}
+
+	data := &RedemptionData{}
+	if data.TokenID, err = number("tokenId"); err != nil {
+		return nil, err
+	}
+	if data.Nonce, err = number("nonce"); err != nil {
+		return nil, err
+	}
+	if data.Deadline, err = number("deadline"); err != nil {
+		return nil, err
+	}
+
+	redeemer := query.Get("redeemer")
+	if !common.IsHexAddress(redeemer) {
+		return nil, fmt.Errorf("invalid redeemer in QR code")
+	}
+	data.Redeemer = common.HexToAddress(redeemer)
+
+	data.Signature, err = hex.DecodeString(strings.TrimPrefix(query.Get("sig"), "0x"))
+	if err != nil || len(data.Signature) != 65 {
+		return nil, fmt.Errorf("invalid signature in QR code")
+	}
 
-	// TODO: Implement proper URL parsing
-	return nil, fmt.Errorf("QR code parsing not yet implemented")
+	return data, nil
 }
@@ -338,7 +338,6 @@ func TestParseRedemptionQRCodeImplementation(t *testing.T) {
 		for _, tc := range testCases {
 			result, err := ParseRedemptionQRCode(tc)
 			assert.Error(t, err)
-			assert.Contains(t, err.Error(), "QR code parsing not yet implemented")
 			assert.Nil(t, result)
 		}
 	})
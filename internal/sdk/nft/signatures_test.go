@@ -1,14 +1,23 @@
 package nft
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/signer"
+
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -225,6 +234,37 @@ func TestGenerateAndVerifyRedemptionSignature(t *testing.T) {
 	})
 }
 
+func TestSignRedemption(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	keySigner := signer.NewKeySigner(privateKey)
+
+	tokenID := big.NewInt(123)
+	redeemer := common.HexToAddress("0x9876543210987654321098765432109876543210")
+	nonce := big.NewInt(1)
+	deadline := big.NewInt(1700000000)
+	chainID := big.NewInt(31337)
+	contractAddress := common.HexToAddress("0x1234567890123456789012345678901234567890")
+
+	t.Run("matches private key signature", func(t *testing.T) {
+		signature, err := SignRedemption(context.Background(), keySigner, tokenID, redeemer, nonce, deadline, chainID, contractAddress)
+		require.NoError(t, err)
+
+		expected, err := GenerateRedemptionSignature(privateKey, tokenID, redeemer, nonce, deadline, chainID, contractAddress)
+		require.NoError(t, err)
+		assert.Equal(t, expected, signature)
+
+		valid, err := VerifyRedemptionSignature(signature, tokenID, redeemer, nonce, deadline, chainID, contractAddress, keySigner.Address())
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("nil signer", func(t *testing.T) {
+		_, err := SignRedemption(context.Background(), nil, tokenID, redeemer, nonce, deadline, chainID, contractAddress)
+		assert.EqualError(t, err, "signer cannot be nil")
+	})
+}
+
 func TestGenerateRedemptionQRCode(t *testing.T) {
 	tokenID := big.NewInt(123)
 	redeemer := common.HexToAddress("0x9876543210987654321098765432109876543210")
@@ -296,14 +336,37 @@ func TestGenerateRedemptionQRCode(t *testing.T) {
 }
 
 func TestParseRedemptionQRCode(t *testing.T) {
-	t.Run("not implemented", func(t *testing.T) {
-		qrData := "https://example.com/redeem?tokenId=123&redeemer=0x123&nonce=1&deadline=1700000000&sig=0x123"
+	t.Run("round trip", func(t *testing.T) {
+		redeemer := common.HexToAddress("0x9876543210987654321098765432109876543210")
+		signature := make([]byte, 65)
+		signature[0] = 0xab
+		qrData := GenerateRedemptionQRCode(big.NewInt(123), redeemer, big.NewInt(42), big.NewInt(1700000000),
+			signature, "https://example.com")
 
 		result, err := ParseRedemptionQRCode(qrData)
 
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "QR code parsing not yet implemented")
-		assert.Nil(t, result)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(123), result.TokenID.Uint64())
+		assert.Equal(t, redeemer, result.Redeemer)
+		assert.Equal(t, uint64(42), result.Nonce.Uint64())
+		assert.Equal(t, int64(1700000000), result.Deadline.Int64())
+		assert.Equal(t, signature, result.Signature)
+	})
+
+	t.Run("invalid fields", func(t *testing.T) {
+		sig := strings.Repeat("00", 65)
+		testCases := []string{
+			"https://example.com/redeem?tokenId=123&redeemer=0x123&nonce=1&deadline=1700000000&sig=" + sig,
+			"https://example.com/redeem?tokenId=-1&redeemer=0x9876543210987654321098765432109876543210&nonce=1&deadline=1700000000&sig=" + sig,
+			"https://example.com/redeem?tokenId=1&redeemer=0x9876543210987654321098765432109876543210&nonce=1&deadline=1700000000&sig=0x123",
+			"https://example.com/redeem?tokenId=1&redeemer=0x9876543210987654321098765432109876543210&deadline=1700000000&sig=" + sig,
+		}
+
+		for _, tc := range testCases {
+			result, err := ParseRedemptionQRCode(tc)
+			assert.Error(t, err, tc)
+			assert.Nil(t, result)
+		}
 	})
 }
 
@@ -480,3 +543,173 @@ func BenchmarkVerifyRedemptionSignature(b *testing.B) {
 		)
 	}
 }
+
+func TestClientVerifyRedemption(t *testing.T) {
+	holderKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	holder := crypto.PubkeyToAddress(holderKey.PublicKey)
+	operatorKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	operator := crypto.PubkeyToAddress(operatorKey.PublicKey)
+	strangerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	stranger := crypto.PubkeyToAddress(strangerKey.PublicKey)
+
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	walletOwnerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	wallet := common.HexToAddress("0x00000000000000000000000000000000000000ee")
+
+	contract := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	chainID := big.NewInt(501)
+	mockContract := new(MockTicketsContract)
+	client := &Client{
+		ticketsContract: mockContract,
+		ticketsAddress:  contract,
+		networkConfig:   &NetworkConfig{ChainID: chainID},
+		indexer:         NewIndexer("testnet", contract, db, &ticketReader{}),
+		caller:          &walletCaller{t: t, wallet: wallet, owner: crypto.PubkeyToAddress(walletOwnerKey.PublicKey)},
+	}
+	require.NoError(t, db.SaveUsedNonce("testnet", contract.Hex(), "7", holder.Hex(), 1))
+
+	tokenID := big.NewInt(10001)
+	mockContract.On("Eip712Domain", mock.Anything).Return(GetEIP712Domain(chainID, contract), nil)
+	mockContract.On("OwnerOf", mock.Anything, tokenID).Return(holder, nil)
+	mockContract.On("GetApproved", mock.Anything, tokenID).Return(common.Address{}, nil)
+	mockContract.On("IsApprovedForAll", mock.Anything, holder, operator).Return(true, nil)
+	mockContract.On("IsApprovedForAll", mock.Anything, holder, wallet).Return(true, nil)
+	mockContract.On("IsApprovedForAll", mock.Anything, holder, stranger).Return(false, nil)
+
+	deadline := time.Now().Add(time.Minute).Unix()
+	sign := func(key *ecdsa.PrivateKey, redeemer common.Address, nonce uint64, deadline int64) []byte {
+		sig, err := GenerateRedemptionSignature(key, tokenID, redeemer, new(big.Int).SetUint64(nonce),
+			big.NewInt(deadline), chainID, contract)
+		require.NoError(t, err)
+		return sig
+	}
+	params := func(redeemer common.Address, nonce uint64, deadline int64) RedemptionParams {
+		return RedemptionParams{TokenID: tokenID.Uint64(), Redeemer: redeemer, Nonce: nonce, Deadline: deadline}
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		params    RedemptionParams
+		signature []byte
+		wantErr   error
+	}{
+		{"holder", params(holder, 1, deadline), sign(holderKey, holder, 1, deadline), nil},
+		{"approved operator", params(operator, 2, deadline), sign(operatorKey, operator, 2, deadline), nil},
+		{"stranger", params(stranger, 3, deadline), sign(strangerKey, stranger, 3, deadline), ErrInvalidSignature},
+		{"signed for someone else", params(holder, 4, deadline), sign(strangerKey, holder, 4, deadline), ErrInvalidSignature},
+		{"tampered nonce", params(holder, 5, deadline), sign(holderKey, holder, 6, deadline), ErrInvalidSignature},
+		{"expired", params(holder, 8, 1700000000), sign(holderKey, holder, 8, 1700000000), ErrSignatureExpired},
+		{"used nonce", params(holder, 7, deadline), sign(holderKey, holder, 7, deadline), ErrNonceUsed},
+		{"contract wallet", params(wallet, 9, deadline), sign(walletOwnerKey, wallet, 9, deadline), nil},
+		{"contract wallet rejects", params(wallet, 10, deadline), sign(strangerKey, wallet, 10, deadline), ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.VerifyRedemption(ctx, tt.params, tt.signature)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestGetRedemptionNonce(t *testing.T) {
+	client := &Client{}
+	seen := map[string]bool{}
+	for i := 0; i < 20; i++ {
+		nonce, err := client.GetRedemptionNonce(context.Background(), common.Address{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, nonce.Sign())
+		assert.LessOrEqual(t, nonce.BitLen(), 54)
+		seen[nonce.String()] = true
+	}
+	assert.Greater(t, len(seen), 1)
+}
+
+func TestClientPrepareRedemption(t *testing.T) {
+	holderKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	holder := crypto.PubkeyToAddress(holderKey.PublicKey)
+
+	contract := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	chainID := big.NewInt(501)
+	mockContract := new(MockTicketsContract)
+	client := &Client{
+		ticketsContract: mockContract,
+		ticketsAddress:  contract,
+		networkConfig:   &NetworkConfig{ChainID: chainID},
+		caller:          &walletCaller{t: t},
+	}
+
+	// The domain comes from the contract, not the defaults
+	domain := EIP712Domain{Name: "BOGOWITickets", Version: "2", ChainId: chainID, VerifyingContract: contract}
+	tokenID := big.NewInt(10001)
+	mockContract.On("Eip712Domain", mock.Anything).Return(domain, nil).Once()
+	mockContract.On("OwnerOf", mock.Anything, tokenID).Return(holder, nil)
+
+	ctx := context.Background()
+	request, err := client.PrepareRedemption(ctx, tokenID.Uint64(), holder)
+	require.NoError(t, err)
+	assert.Equal(t, "2", request.TypedData.Domain.Version)
+	assert.Equal(t, holder, request.Params.Redeemer)
+	assert.NotZero(t, request.Params.Nonce)
+	assert.Greater(t, request.Params.Deadline, time.Now().Unix())
+
+	// What the wallet signs is what VerifyRedemption accepts
+	signature, err := signer.NewKeySigner(holderKey).SignTypedData(ctx, request.TypedData)
+	require.NoError(t, err)
+	assert.NoError(t, client.VerifyRedemption(ctx, request.Params, signature))
+
+	// A signature under the default domain no longer matches
+	legacy, err := GenerateRedemptionSignature(holderKey, tokenID, holder, new(big.Int).SetUint64(request.Params.Nonce),
+		big.NewInt(request.Params.Deadline), chainID, contract)
+	require.NoError(t, err)
+	assert.ErrorIs(t, client.VerifyRedemption(ctx, request.Params, legacy), ErrInvalidSignature)
+	mockContract.AssertExpectations(t)
+}
+
+func TestClientRedemptionDomainChainMismatch(t *testing.T) {
+	mockContract := new(MockTicketsContract)
+	client := &Client{ticketsContract: mockContract, networkConfig: &NetworkConfig{ChainID: big.NewInt(501)}}
+	mockContract.On("Eip712Domain", mock.Anything).Return(GetEIP712Domain(big.NewInt(500), common.Address{}), nil)
+
+	_, err := client.RedemptionDomain(context.Background())
+	assert.Error(t, err)
+}
+
+// walletCaller serves a smart-contract wallet at wallet that accepts
+// signatures of owner through ERC-1271
+type walletCaller struct {
+	t      *testing.T
+	wallet common.Address
+	owner  common.Address
+}
+
+func (w *walletCaller) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	if account == w.wallet && w.wallet != (common.Address{}) {
+		return []byte{0x60, 0x80}, nil
+	}
+	return nil, nil
+}
+
+func (w *walletCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	args, err := erc1271ABI.Methods["isValidSignature"].Inputs.Unpack(call.Data[4:])
+	require.NoError(w.t, err)
+	hash := args[0].([32]byte)
+	signer, err := recoverSigner(hash[:], args[1].([]byte))
+	result := make([]byte, 32)
+	if err == nil && signer == w.owner {
+		copy(result, erc1271MagicValue[:])
+	}
+	return result, nil
+}
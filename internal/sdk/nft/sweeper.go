@@ -0,0 +1,430 @@
+package nft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"bogowi-blockchain-go/internal/database"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrSweepInProgress is returned when a sweep is started while another one
+// is running
+var ErrSweepInProgress = errors.New("an expiry sweep is already running")
+
+// Sweep defaults
+const (
+	DefaultSweepInterval   = time.Hour
+	DefaultSweepBatchSize  = 20
+	DefaultSweepBatchDelay = 10 * time.Second
+	DefaultSweepMaxPerRun  = 500
+
+	// DefaultExpiryGracePeriod is the contract's default delay after the
+	// expiry of a ticket before it may be marked expired
+	DefaultExpiryGracePeriod = 5 * time.Minute
+)
+
+// maxExpiryGracePeriod is the longest grace period the contract accepts
+const maxExpiryGracePeriod = 24 * time.Hour
+
+// What started a sweep
+const (
+	SweepScheduled = "scheduled"
+	SweepManual    = "manual"
+)
+
+// Where a sweep found its candidates
+const (
+	SweepSourceIndex    = "index"
+	SweepSourceMappings = "mappings"
+)
+
+// maxSweepReports is how many reports of past sweeps are kept
+const maxSweepReports = 10
+
+// TicketExpirer expires tickets on-chain, typically a Client
+type TicketExpirer interface {
+	TicketReader
+	ExpireTicket(ctx context.Context, tokenID uint64) (*types.Transaction, error)
+	ExpiryGracePeriod(ctx context.Context) (time.Duration, error)
+	WaitForTransaction(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// MappingStore lists the minted tickets of a network, for finding expired
+// tickets without the ticket index
+type MappingStore interface {
+	ListNFTMappingsByStatus(network string, status string) ([]database.NFTMapping, error)
+}
+
+// ExpiryHandler is called for every ticket a sweep found expired on-chain,
+// whether the sweep expired it or it already was
+type ExpiryHandler func(ctx context.Context, network string, tokenID uint64)
+
+// SweeperConfig configures an expiry sweeper. Zero values take the defaults,
+// except Interval which takes the default when zero and disables scheduled
+// sweeps when negative.
+type SweeperConfig struct {
+	Network  string
+	Interval time.Duration
+
+	// Expiries are sent BatchSize at a time, waiting for the receipts of a
+	// batch and then BatchDelay before the next; a sweep sends at most
+	// MaxPerRun and leaves the rest for the next sweep
+	BatchSize  int
+	BatchDelay time.Duration
+	MaxPerRun  int
+
+	// GracePeriod overrides the contract's expiryGracePeriod, which is
+	// otherwise read before every sweep as admins may change it
+	GracePeriod time.Duration
+}
+
+// SweptTicket is the outcome of a sweep for one ticket
+type SweptTicket struct {
+	TokenID uint64 `json:"tokenId"`
+	TxHash  string `json:"txHash,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// SweepReport reports one sweep
+type SweepReport struct {
+	Network    string        `json:"network"`
+	Trigger    string        `json:"trigger"`
+	Source     string        `json:"source,omitempty"`
+	StartedAt  time.Time     `json:"startedAt"`
+	FinishedAt time.Time     `json:"finishedAt"`
+	Candidates int           `json:"candidates"`
+	Expired    []SweptTicket `json:"expired"`
+	Skipped    []SweptTicket `json:"skipped"`
+	Failed     []SweptTicket `json:"failed"`
+	// Remaining candidates are left for the next sweep
+	Remaining int    `json:"remaining"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SweeperStatus reports the schedule and recent sweeps of a sweeper
+type SweeperStatus struct {
+	Network  string        `json:"network"`
+	Interval string        `json:"interval"`
+	Running  bool          `json:"running"`
+	Reports  []SweepReport `json:"reports"`
+}
+
+// Sweeper marks tickets past their expiry as expired on-chain. It finds
+// them in the ticket index, or among the active ticket mappings without an
+// index, checks each on-chain before expiring it and reports every sweep.
+type Sweeper struct {
+	expirer  TicketExpirer
+	indexer  *Indexer
+	mappings MappingStore
+	cfg      SweeperConfig
+
+	running atomic.Bool
+
+	// gracePeriod is the last grace period read from the contract
+	gracePeriod atomic.Int64
+
+	mu       sync.Mutex
+	handlers []ExpiryHandler
+	reports  []SweepReport
+
+	now func() time.Time
+}
+
+// NewSweeper creates a sweeper expiring tickets through expirer. Candidates
+// come from indexer, or from mappings when indexer is nil.
+func NewSweeper(expirer TicketExpirer, indexer *Indexer, mappings MappingStore, cfg SweeperConfig) *Sweeper {
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultSweepInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultSweepBatchSize
+	}
+	if cfg.BatchDelay <= 0 {
+		cfg.BatchDelay = DefaultSweepBatchDelay
+	}
+	if cfg.MaxPerRun <= 0 {
+		cfg.MaxPerRun = DefaultSweepMaxPerRun
+	}
+
+	sweeper := &Sweeper{
+		expirer:  expirer,
+		indexer:  indexer,
+		mappings: mappings,
+		cfg:      cfg,
+		now:      time.Now,
+	}
+	sweeper.gracePeriod.Store(int64(DefaultExpiryGracePeriod))
+	return sweeper
+}
+
+// OnExpired registers a handler called for every ticket found expired
+func (s *Sweeper) OnExpired(handler ExpiryHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers = append(s.handlers, handler)
+}
+
+// Run sweeps on schedule until ctx is cancelled. It returns at once when
+// scheduled sweeps are disabled.
+func (s *Sweeper) Run(ctx context.Context) {
+	if s.cfg.Interval < 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Sweep(ctx, SweepScheduled); err != nil {
+			log.Printf("nft: %s scheduled expiry sweep skipped: %v", s.cfg.Network, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep runs a sweep and returns its report. It returns ErrSweepInProgress
+// when another sweep is running.
+func (s *Sweeper) Sweep(ctx context.Context, trigger string) (*SweepReport, error) {
+	if !s.running.CompareAndSwap(false, true) {
+		return nil, ErrSweepInProgress
+	}
+	defer s.running.Store(false)
+
+	return s.sweep(ctx, trigger), nil
+}
+
+// Start runs a sweep in the background. It returns ErrSweepInProgress when
+// another sweep is running.
+func (s *Sweeper) Start(ctx context.Context, trigger string) error {
+	if !s.running.CompareAndSwap(false, true) {
+		return ErrSweepInProgress
+	}
+
+	go func() {
+		defer s.running.Store(false)
+		s.sweep(ctx, trigger)
+	}()
+	return nil
+}
+
+// Status returns the schedule of the sweeper and its recent reports, newest
+// first
+func (s *Sweeper) Status() SweeperStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	interval := "on demand"
+	if s.cfg.Interval > 0 {
+		interval = s.cfg.Interval.String()
+	}
+	reports := make([]SweepReport, len(s.reports))
+	copy(reports, s.reports)
+
+	return SweeperStatus{
+		Network:  s.cfg.Network,
+		Interval: interval,
+		Running:  s.running.Load(),
+		Reports:  reports,
+	}
+}
+
+// sweep expires the candidates in batches and records the report
+func (s *Sweeper) sweep(ctx context.Context, trigger string) *SweepReport {
+	report := &SweepReport{
+		Network:   s.cfg.Network,
+		Trigger:   trigger,
+		StartedAt: s.now().UTC(),
+		Expired:   []SweptTicket{},
+		Skipped:   []SweptTicket{},
+		Failed:    []SweptTicket{},
+	}
+	defer s.record(report)
+
+	cutoff := uint64(s.now().Add(-s.expiryGracePeriod(ctx)).Unix())
+	candidates, err := s.candidates(ctx, cutoff, report)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.Candidates = len(candidates)
+	if len(candidates) > s.cfg.MaxPerRun {
+		report.Remaining = len(candidates) - s.cfg.MaxPerRun
+		candidates = candidates[:s.cfg.MaxPerRun]
+	}
+
+	for start := 0; start < len(candidates); start += s.cfg.BatchSize {
+		if start > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(s.cfg.BatchDelay):
+			}
+		}
+		if ctx.Err() != nil {
+			report.Remaining += len(candidates) - start
+			report.Error = ctx.Err().Error()
+			return report
+		}
+
+		end := start + s.cfg.BatchSize
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+		s.expireBatch(ctx, candidates[start:end], cutoff, report)
+	}
+
+	return report
+}
+
+// expiryGracePeriod returns the configured grace period, or reads it from
+// the contract. When the contract cannot be read, the last grace period read
+// is used.
+func (s *Sweeper) expiryGracePeriod(ctx context.Context) time.Duration {
+	if s.cfg.GracePeriod > 0 {
+		return s.cfg.GracePeriod
+	}
+
+	period, err := s.expirer.ExpiryGracePeriod(ctx)
+	if err != nil {
+		period = time.Duration(s.gracePeriod.Load())
+		log.Printf("nft: %s expiry grace period unavailable, using %s: %v", s.cfg.Network, period, err)
+		return period
+	}
+	s.gracePeriod.Store(int64(period))
+	return period
+}
+
+// candidates returns the tickets that were issued and expired before cutoff
+func (s *Sweeper) candidates(ctx context.Context, cutoff uint64, report *SweepReport) ([]uint64, error) {
+	var tokenIDs []uint64
+
+	if s.indexer != nil {
+		report.Source = SweepSourceIndex
+		tickets, err := s.indexer.Tickets(database.TicketFilter{Status: database.TicketsExpired})
+		if err != nil {
+			return nil, err
+		}
+		for _, ticket := range tickets {
+			if ticket.State == database.TicketIssued && ticket.ExpiresAt <= cutoff {
+				tokenIDs = append(tokenIDs, ticket.TokenID)
+			}
+		}
+		return tokenIDs, nil
+	}
+
+	if s.mappings == nil {
+		return nil, ErrNoTicketIndex
+	}
+
+	// The mappings carry no expiry, so every active ticket is read
+	report.Source = SweepSourceMappings
+	mappings, err := s.mappings.ListNFTMappingsByStatus(s.cfg.Network, "active")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ticket mappings: %w", err)
+	}
+	for _, mapping := range mappings {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		data, err := s.expirer.GetTicketData(ctx, mapping.TokenID)
+		if err != nil {
+			report.Failed = append(report.Failed, SweptTicket{TokenID: mapping.TokenID, Reason: err.Error()})
+			continue
+		}
+		if TicketState(data.State) == TicketStateExpired ||
+			(TicketState(data.State) == TicketStateIssued && data.ExpiresAt <= cutoff) {
+			tokenIDs = append(tokenIDs, mapping.TokenID)
+		}
+	}
+	return tokenIDs, nil
+}
+
+// expireBatch checks a batch of candidates on-chain, sends their expiries
+// and waits for the receipts
+func (s *Sweeper) expireBatch(ctx context.Context, tokenIDs []uint64, cutoff uint64, report *SweepReport) {
+	type sent struct {
+		tokenID uint64
+		tx      *types.Transaction
+	}
+	var pending []sent
+
+	for _, tokenID := range tokenIDs {
+		data, err := s.expirer.GetTicketData(ctx, tokenID)
+		if err != nil {
+			report.Failed = append(report.Failed, SweptTicket{TokenID: tokenID, Reason: err.Error()})
+			continue
+		}
+
+		switch {
+		case TicketState(data.State) == TicketStateExpired:
+			report.Skipped = append(report.Skipped, SweptTicket{TokenID: tokenID, Reason: "already expired on-chain"})
+			s.notify(ctx, tokenID)
+			continue
+		case TicketState(data.State) == TicketStateRedeemed:
+			report.Skipped = append(report.Skipped, SweptTicket{TokenID: tokenID, Reason: "ticket was redeemed"})
+			continue
+		case data.ExpiresAt > cutoff:
+			report.Skipped = append(report.Skipped, SweptTicket{TokenID: tokenID, Reason: "grace period not over"})
+			continue
+		}
+
+		tx, err := s.expirer.ExpireTicket(ctx, tokenID)
+		if err != nil {
+			report.Failed = append(report.Failed, SweptTicket{TokenID: tokenID, Reason: err.Error()})
+			continue
+		}
+		pending = append(pending, sent{tokenID: tokenID, tx: tx})
+	}
+
+	for _, p := range pending {
+		swept := SweptTicket{TokenID: p.tokenID, TxHash: p.tx.Hash().Hex()}
+		if _, err := s.expirer.WaitForTransaction(ctx, p.tx.Hash()); err != nil {
+			swept.Reason = err.Error()
+			report.Failed = append(report.Failed, swept)
+			continue
+		}
+		report.Expired = append(report.Expired, swept)
+		s.notify(ctx, p.tokenID)
+	}
+}
+
+// notify calls the expiry handlers for a ticket
+func (s *Sweeper) notify(ctx context.Context, tokenID uint64) {
+	s.mu.Lock()
+	handlers := append([]ExpiryHandler(nil), s.handlers...)
+	s.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(ctx, s.cfg.Network, tokenID)
+	}
+}
+
+// record keeps the report of a finished sweep
+func (s *Sweeper) record(report *SweepReport) {
+	report.FinishedAt = s.now().UTC()
+	if report.Candidates > 0 || len(report.Failed) > 0 || report.Error != "" {
+		log.Printf("nft: %s %s expiry sweep: %d expired, %d skipped, %d failed, %d remaining",
+			report.Network, report.Trigger, len(report.Expired), len(report.Skipped), len(report.Failed), report.Remaining)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reports = append([]SweepReport{*report}, s.reports...)
+	if len(s.reports) > maxSweepReports {
+		s.reports = s.reports[:maxSweepReports]
+	}
+}
@@ -0,0 +1,263 @@
+package nft
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/contracts"
+	"bogowi-blockchain-go/internal/sdk/events"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ticketExpirer expires tickets in memory
+type ticketExpirer struct {
+	ticketReader
+	sent     []uint64
+	reverted map[uint64]bool
+	block    chan struct{}
+
+	// gracePeriod is the contract's, the default when zero
+	gracePeriod    time.Duration
+	gracePeriodErr error
+}
+
+func (e *ticketExpirer) ExpireTicket(ctx context.Context, tokenID uint64) (*types.Transaction, error) {
+	if e.block != nil {
+		<-e.block
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sent = append(e.sent, tokenID)
+	if !e.reverted[tokenID] {
+		e.tickets[tokenID].State = uint8(TicketStateExpired)
+	}
+	return types.NewTx(&types.LegacyTx{Nonce: tokenID}), nil
+}
+
+func (e *ticketExpirer) ExpiryGracePeriod(ctx context.Context) (time.Duration, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.gracePeriodErr != nil {
+		return 0, e.gracePeriodErr
+	}
+	if e.gracePeriod == 0 {
+		return DefaultExpiryGracePeriod, nil
+	}
+	return e.gracePeriod, nil
+}
+
+func (e *ticketExpirer) WaitForTransaction(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for tokenID := range e.reverted {
+		if types.NewTx(&types.LegacyTx{Nonce: tokenID}).Hash() == txHash {
+			return &types.Receipt{Status: types.ReceiptStatusFailed}, errors.New("transaction failed")
+		}
+	}
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil
+}
+
+// activeMappings lists every ticket of a reader as active
+type activeMappings map[uint64]bool
+
+func (m activeMappings) ListNFTMappingsByStatus(network string, status string) ([]database.NFTMapping, error) {
+	var mappings []database.NFTMapping
+	for tokenID := range m {
+		mappings = append(mappings, database.NFTMapping{TokenID: tokenID, Network: network, Status: status})
+	}
+	sort.Slice(mappings, func(i, j int) bool { return mappings[i].TokenID < mappings[j].TokenID })
+	return mappings, nil
+}
+
+func sweptIDs(swept []SweptTicket) []uint64 {
+	tokenIDs := make([]uint64, 0, len(swept))
+	for _, ticket := range swept {
+		tokenIDs = append(tokenIDs, ticket.TokenID)
+	}
+	return tokenIDs
+}
+
+// sweepTickets are a ticket of each kind a sweep meets at time 10000 with
+// the default grace period
+func sweepTickets() map[uint64]*TicketData {
+	return map[uint64]*TicketData{
+		1: {ExpiresAt: 9000},                                    // expired
+		2: {ExpiresAt: 9000},                                    // expiry reverts
+		3: {ExpiresAt: 9900},                                    // within the grace period
+		4: {ExpiresAt: 20000},                                   // valid
+		5: {ExpiresAt: 9000, State: uint8(TicketStateRedeemed)}, // redeemed
+		6: {ExpiresAt: 9000, State: uint8(TicketStateExpired)},  // expired elsewhere
+		7: {ExpiresAt: 8000},                                    // expired
+	}
+}
+
+func TestSweeperFromMappings(t *testing.T) {
+	expirer := &ticketExpirer{
+		ticketReader: ticketReader{tickets: sweepTickets()},
+		reverted:     map[uint64]bool{2: true},
+	}
+	mappings := activeMappings{1: true, 2: true, 3: true, 4: true, 5: true, 6: true, 7: true, 8: true}
+
+	sweeper := NewSweeper(expirer, nil, mappings, SweeperConfig{Network: "testnet", BatchSize: 2, BatchDelay: time.Millisecond})
+	sweeper.now = func() time.Time { return time.Unix(10000, 0) }
+
+	var notified []uint64
+	sweeper.OnExpired(func(ctx context.Context, network string, tokenID uint64) {
+		assert.Equal(t, "testnet", network)
+		notified = append(notified, tokenID)
+	})
+
+	report, err := sweeper.Sweep(context.Background(), SweepManual)
+	require.NoError(t, err)
+
+	assert.Equal(t, SweepSourceMappings, report.Source)
+	assert.Equal(t, SweepManual, report.Trigger)
+	assert.Equal(t, 4, report.Candidates)
+	assert.Equal(t, []uint64{1, 7}, sweptIDs(report.Expired))
+	assert.NotEmpty(t, report.Expired[0].TxHash)
+	assert.Equal(t, []uint64{6}, sweptIDs(report.Skipped))
+	// Ticket 8 has no ticket data
+	assert.Equal(t, []uint64{8, 2}, sweptIDs(report.Failed))
+	assert.Equal(t, []uint64{1, 2, 7}, expirer.sent)
+	assert.Equal(t, []uint64{1, 6, 7}, notified)
+
+	status := sweeper.Status()
+	assert.Equal(t, "1h0m0s", status.Interval)
+	assert.False(t, status.Running)
+	require.Len(t, status.Reports, 1)
+	assert.Equal(t, 4, status.Reports[0].Candidates)
+}
+
+func TestSweeperFromIndex(t *testing.T) {
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	expirer := &ticketExpirer{ticketReader: ticketReader{tickets: sweepTickets()}}
+	ix := NewIndexer("testnet", common.HexToAddress("0x00000000000000000000000000000000000000cc"), db, expirer)
+	ix.now = func() time.Time { return time.Unix(10000, 0) }
+
+	// The index has not seen ticket 5 redeemed
+	for tokenID := range expirer.tickets {
+		ix.HandleEvent(context.Background(), events.Event{
+			Name: events.TicketMinted,
+			Data: &contracts.BOGOWITicketsTicketMinted{TokenId: new(big.Int).SetUint64(tokenID)},
+			Log:  types.Log{BlockNumber: tokenID},
+		})
+	}
+	ix.HandleEvent(context.Background(), events.Event{
+		Name: events.TicketExpired,
+		Data: &contracts.BOGOWITicketsTicketExpired{TokenId: big.NewInt(6)},
+		Log:  types.Log{BlockNumber: 10},
+	})
+
+	sweeper := NewSweeper(expirer, ix, nil, SweeperConfig{Network: "testnet", MaxPerRun: 2})
+	sweeper.now = ix.now
+
+	report, err := sweeper.Sweep(context.Background(), SweepScheduled)
+	require.NoError(t, err)
+	assert.Equal(t, SweepSourceIndex, report.Source)
+	assert.Equal(t, 4, report.Candidates)
+	assert.Equal(t, 2, report.Remaining)
+	assert.Equal(t, []uint64{1, 2}, sweptIDs(report.Expired))
+
+	// The rest is swept next time, once the index has seen the expiries
+	for _, swept := range report.Expired {
+		ix.HandleEvent(context.Background(), events.Event{
+			Name: events.TicketExpired,
+			Data: &contracts.BOGOWITicketsTicketExpired{TokenId: new(big.Int).SetUint64(swept.TokenID)},
+			Log:  types.Log{BlockNumber: 11},
+		})
+	}
+	report, err = sweeper.Sweep(context.Background(), SweepScheduled)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{7}, sweptIDs(report.Expired))
+	assert.Equal(t, []SweptTicket{{TokenID: 5, Reason: "ticket was redeemed"}}, report.Skipped)
+
+	assert.Len(t, sweeper.Status().Reports, 2)
+}
+
+func TestSweeperGracePeriod(t *testing.T) {
+	// At time 10000, ticket 1 is past a 10 minute grace period but not a
+	// 20 minute one
+	newExpirer := func() *ticketExpirer {
+		return &ticketExpirer{ticketReader: ticketReader{tickets: map[uint64]*TicketData{
+			1: {ExpiresAt: 9000},
+			2: {ExpiresAt: 8000},
+		}}}
+	}
+	sweep := func(expirer *ticketExpirer, cfg SweeperConfig) *SweepReport {
+		sweeper := NewSweeper(expirer, nil, activeMappings{1: true, 2: true}, cfg)
+		sweeper.now = func() time.Time { return time.Unix(10000, 0) }
+		report, err := sweeper.Sweep(context.Background(), SweepManual)
+		require.NoError(t, err)
+		return report
+	}
+
+	t.Run("read from the contract", func(t *testing.T) {
+		expirer := newExpirer()
+		expirer.gracePeriod = 20 * time.Minute
+		report := sweep(expirer, SweeperConfig{Network: "testnet"})
+		assert.Equal(t, []uint64{2}, sweptIDs(report.Expired))
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		expirer := newExpirer()
+		expirer.gracePeriod = 20 * time.Minute
+		report := sweep(expirer, SweeperConfig{Network: "testnet", GracePeriod: 10 * time.Minute})
+		assert.Equal(t, []uint64{1, 2}, sweptIDs(report.Expired))
+	})
+
+	t.Run("contract unavailable", func(t *testing.T) {
+		expirer := newExpirer()
+		expirer.gracePeriod = 20 * time.Minute
+		sweeper := NewSweeper(expirer, nil, activeMappings{1: true, 2: true}, SweeperConfig{Network: "testnet"})
+		sweeper.now = func() time.Time { return time.Unix(10000, 0) }
+
+		// The last grace period read is kept
+		assert.Equal(t, 20*time.Minute, sweeper.expiryGracePeriod(context.Background()))
+		expirer.gracePeriodErr = errors.New("connection refused")
+		report, err := sweeper.Sweep(context.Background(), SweepManual)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{2}, sweptIDs(report.Expired))
+	})
+}
+
+func TestSweeperRunsOneSweepAtATime(t *testing.T) {
+	expirer := &ticketExpirer{
+		ticketReader: ticketReader{tickets: map[uint64]*TicketData{1: {ExpiresAt: 9000}}},
+		block:        make(chan struct{}),
+	}
+	sweeper := NewSweeper(expirer, nil, activeMappings{1: true}, SweeperConfig{Network: "testnet", Interval: -1})
+	sweeper.now = func() time.Time { return time.Unix(10000, 0) }
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	sweeper.OnExpired(func(ctx context.Context, network string, tokenID uint64) { wg.Done() })
+
+	require.NoError(t, sweeper.Start(context.Background(), SweepManual))
+	assert.True(t, sweeper.Status().Running)
+	assert.ErrorIs(t, sweeper.Start(context.Background(), SweepManual), ErrSweepInProgress)
+	_, err := sweeper.Sweep(context.Background(), SweepManual)
+	assert.ErrorIs(t, err, ErrSweepInProgress)
+
+	close(expirer.block)
+	wg.Wait()
+	assert.Eventually(t, func() bool { return !sweeper.Status().Running }, time.Second, time.Millisecond)
+	assert.Equal(t, "on demand", sweeper.Status().Interval)
+
+	// Scheduled sweeps are disabled
+	sweeper.Run(context.Background())
+	assert.Len(t, sweeper.Status().Reports, 1)
+}
@@ -32,6 +32,12 @@ type TicketsContractInterface interface {
 	RedeemTicket(opts *bind.TransactOpts, redemptionData RedemptionDataContract) (*types.Transaction, error)
 	UpdateTransferUnlock(opts *bind.TransactOpts, tokenID *big.Int, newUnlockTime uint64) (*types.Transaction, error)
 	Burn(opts *bind.TransactOpts, tokenID *big.Int) (*types.Transaction, error)
+	Pause(opts *bind.TransactOpts) (*types.Transaction, error)
+	Unpause(opts *bind.TransactOpts) (*types.Transaction, error)
+	Paused(opts *bind.CallOpts) (bool, error)
+	SetRoyaltyInfo(opts *bind.TransactOpts, receiver common.Address, feeBasisPoints *big.Int) (*types.Transaction, error)
+	RoyaltyInfo(opts *bind.CallOpts, tokenID *big.Int, salePrice *big.Int) (common.Address, *big.Int, error)
+	Eip712Domain(opts *bind.CallOpts) (EIP712Domain, error)
 }
 
 // TicketDataContract represents the contract return type for GetTicketData
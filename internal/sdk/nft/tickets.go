@@ -7,11 +7,13 @@ import (
 	"time"
 
 	"bogowi-blockchain-go/internal/sdk/contracts"
+	"bogowi-blockchain-go/internal/sdk/txtrack"
 	"bogowi-blockchain-go/internal/services/datakyte"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
 // MintTicket mints a new NFT ticket
@@ -28,36 +30,24 @@ func (c *Client) MintTicket(ctx context.Context, params MintParams) (*types.Tran
 		RewardBasisPoints: new(big.Int).SetUint64(uint64(params.RewardBasisPoints)),
 	}
 
-	// Estimate gas
-	opts := &bind.TransactOpts{
-		From:    c.auth.From,
-		Signer:  c.auth.Signer,
-		Context: ctx,
-		NoSend:  true, // Don't send, just estimate
-	}
-
-	// Create a copy of auth for gas estimation
-	_, err := c.ticketsContract.MintTicket(opts, contractParams.To, contractParams.BookingId,
-		contractParams.EventId, contractParams.UtilityFlags, contractParams.TransferUnlockAt,
-		contractParams.ExpiresAt, contractParams.MetadataURI, uint16(contractParams.RewardBasisPoints.Int64()))
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to estimate gas: %w", err)
-	}
-
 	// Set gas price
-	gasPrice, err := c.SuggestGasPrice(ctx)
+	fees, err := c.SuggestFees(ctx)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	// Update auth with gas settings
-	c.auth.GasPrice = gasPrice
-	c.auth.Context = ctx
+	// Per-call transaction options with gas settings, from the next hot wallet
+	txOpts, err := c.newPooledTransactOpts(ctx, fees)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	// Send the actual transaction
-	tx, err := c.ticketsContract.MintTicket(c.auth, contractParams.To, contractParams.BookingId,
-		contractParams.EventId, contractParams.UtilityFlags, contractParams.TransferUnlockAt,
-		contractParams.ExpiresAt, contractParams.MetadataURI, uint16(contractParams.RewardBasisPoints.Int64()))
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "ticket_mint", Ref: common.Hash(params.BookingID).Hex()}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.MintTicket(opts, contractParams.To, contractParams.BookingId,
+			contractParams.EventId, contractParams.UtilityFlags, contractParams.TransferUnlockAt,
+			contractParams.ExpiresAt, contractParams.MetadataURI, uint16(contractParams.RewardBasisPoints.Int64()))
+	})
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to mint ticket: %w", err)
 	}
@@ -79,9 +69,6 @@ func (c *Client) MintTicket(ctx context.Context, params MintParams) (*types.Tran
 		go c.syncDatakyteMetadata(tokenID, params)
 	}
 
-	// Reset auth for next transaction
-	c.ResetAuth()
-
 	return tx, tokenID, nil
 }
 
@@ -117,19 +104,22 @@ func (c *Client) BatchMint(ctx context.Context, params []MintParams) (*types.Tra
 	}
 
 	// Get gas price
-	gasPrice, err := c.SuggestGasPrice(ctx)
+	fees, err := c.SuggestFees(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	// Update auth
-	c.auth.GasPrice = gasPrice
-	c.auth.Context = ctx
-	c.auth.GasLimit = uint64(150000 * len(params)) // Estimate 150k gas per mint
+	// Per-call transaction options, from the next hot wallet
+	txOpts, err := c.newPooledTransactOpts(ctx, fees)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// Send transaction
-	tx, err := c.ticketsContract.MintBatch(c.auth, tos, bookingIds, eventIds,
-		utilityFlags, transferUnlockAts, expiresAts, metadataURIs, rewardBasisPoints)
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "ticket_batch_mint", Ref: common.Hash(params[0].BookingID).Hex()}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.MintBatch(opts, tos, bookingIds, eventIds,
+			utilityFlags, transferUnlockAts, expiresAts, metadataURIs, rewardBasisPoints)
+	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to batch mint: %w", err)
 	}
@@ -155,7 +145,6 @@ func (c *Client) BatchMint(ctx context.Context, params []MintParams) (*types.Tra
 		}
 	}
 
-	c.ResetAuth()
 	return tx, tokenIDs, nil
 }
 
@@ -221,75 +210,229 @@ func (c *Client) syncDatakyteMetadata(tokenID uint64, params MintParams) {
 // SetBaseURI updates the base URI for Datakyte metadata
 func (c *Client) SetBaseURI(ctx context.Context, baseURI string) (*types.Transaction, error) {
 	// Get gas price
-	gasPrice, err := c.SuggestGasPrice(ctx)
+	fees, err := c.SuggestFees(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	c.auth.GasPrice = gasPrice
-	c.auth.Context = ctx
+	txOpts := c.newTransactOpts(ctx, fees)
 
-	tx, err := c.ticketsContract.SetBaseURI(c.auth, baseURI)
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "set_base_uri", Ref: baseURI}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.SetBaseURI(opts, baseURI)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set base URI: %w", err)
 	}
 
-	c.ResetAuth()
 	return tx, nil
 }
 
-// ExpireTicket marks a ticket as expired
+// ExpireTicket marks a ticket as expired. Records kept off-chain are
+// updated by the handlers of the expiry Sweeper.
 func (c *Client) ExpireTicket(ctx context.Context, tokenID uint64) (*types.Transaction, error) {
 	// Get gas price
-	gasPrice, err := c.SuggestGasPrice(ctx)
+	fees, err := c.SuggestFees(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	c.auth.GasPrice = gasPrice
-	c.auth.Context = ctx
+	txOpts, err := c.newPooledTransactOpts(ctx, fees)
+	if err != nil {
+		return nil, err
+	}
 
-	tx, err := c.ticketsContract.ExpireTicket(c.auth, new(big.Int).SetUint64(tokenID))
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "ticket_expire", Ref: fmt.Sprint(tokenID)}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.ExpireTicket(opts, new(big.Int).SetUint64(tokenID))
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to expire ticket: %w", err)
 	}
 
-	// Update Datakyte status if available
-	if c.datakyteService != nil {
-		go func() {
-			// Note: Would need to store Datakyte NFT ID mapping
-			fmt.Printf("TODO: Update Datakyte status for expired ticket %d\n", tokenID)
-		}()
+	return tx, nil
+}
+
+// VerifyRedemption checks that the holder authorized a redemption: the
+// signature must be the EIP-712 redemption signature of params.Redeemer, or
+// be accepted by its wallet contract, the redeemer must own the ticket or be
+// approved for it, the deadline must not have passed and the nonce must not
+// have been used.
+func (c *Client) VerifyRedemption(ctx context.Context, params RedemptionParams, signature []byte) error {
+	if params.Deadline < time.Now().Unix() {
+		return ErrSignatureExpired
 	}
 
-	c.ResetAuth()
-	return tx, nil
+	domain, err := c.RedemptionDomain(ctx)
+	if err != nil {
+		return err
+	}
+	nonce := new(big.Int).SetUint64(params.Nonce)
+	typedData := redemptionTypedData(domain, new(big.Int).SetUint64(params.TokenID), params.Redeemer, nonce,
+		big.NewInt(params.Deadline))
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return fmt.Errorf("failed to hash typed data: %w", err)
+	}
+	if err := c.checkSigner(ctx, params.Redeemer, digest, signature); err != nil {
+		return err
+	}
+
+	if err := c.checkRedeemer(ctx, params.TokenID, params.Redeemer); err != nil {
+		return err
+	}
+
+	if c.indexer != nil {
+		used, err := c.indexer.NonceUsed(nonce)
+		if err != nil {
+			return err
+		}
+		if used {
+			return ErrNonceUsed
+		}
+	}
+	return nil
+}
+
+// checkSigner returns ErrInvalidSignature unless account signed digest,
+// either with its own key or, for a smart-contract wallet, as accepted by
+// its ERC-1271 isValidSignature
+func (c *Client) checkSigner(ctx context.Context, account common.Address, digest []byte, signature []byte) error {
+	signer, err := recoverSigner(digest, signature)
+	if err == nil && signer == account {
+		return nil
+	}
+
+	valid, callErr := IsValidERC1271Signature(ctx, c.caller, account, common.BytesToHash(digest), signature)
+	if callErr != nil {
+		return callErr
+	}
+	if valid {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return fmt.Errorf("%w: signed by %s, not the redeemer", ErrInvalidSignature, signer.Hex())
+}
+
+// RedemptionDomain returns the EIP-712 domain of the tickets contract, as
+// reported by its EIP-5267 eip712Domain(). The domain is read once and
+// cached, since the contract fixes it at deployment.
+func (c *Client) RedemptionDomain(ctx context.Context) (EIP712Domain, error) {
+	c.domainMu.Lock()
+	defer c.domainMu.Unlock()
+
+	if c.domain != nil {
+		return *c.domain, nil
+	}
+
+	domain, err := c.ticketsContract.Eip712Domain(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return EIP712Domain{}, fmt.Errorf("failed to get EIP-712 domain: %w", err)
+	}
+	if domain.ChainId == nil || domain.ChainId.Cmp(c.networkConfig.ChainID) != 0 {
+		return EIP712Domain{}, fmt.Errorf("EIP-712 domain chain ID %v does not match network chain ID %s",
+			domain.ChainId, c.networkConfig.ChainID)
+	}
+
+	c.domain = &domain
+	return domain, nil
 }
 
-// RedeemTicket redeems a ticket using EIP-712 signature
+// PrepareRedemption returns the typed data redeemer signs with
+// eth_signTypedData_v4 to redeem a ticket, with a fresh nonce and a deadline
+// RedemptionSigningWindow from now. The signature is then submitted with
+// the returned parameters.
+func (c *Client) PrepareRedemption(ctx context.Context, tokenID uint64, redeemer common.Address) (*RedemptionRequest, error) {
+	if err := c.checkRedeemer(ctx, tokenID, redeemer); err != nil {
+		return nil, err
+	}
+
+	domain, err := c.RedemptionDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := c.GetRedemptionNonce(ctx, redeemer)
+	if err != nil {
+		return nil, err
+	}
+
+	params := RedemptionParams{
+		TokenID:  tokenID,
+		Redeemer: redeemer,
+		Nonce:    nonce.Uint64(),
+		Deadline: time.Now().Add(RedemptionSigningWindow).Unix(),
+	}
+	return &RedemptionRequest{
+		Params: params,
+		TypedData: redemptionTypedData(domain, new(big.Int).SetUint64(tokenID), redeemer, nonce,
+			big.NewInt(params.Deadline)),
+	}, nil
+}
+
+// signRedemption signs params with the backend signer under the contract's
+// domain
+func (c *Client) signRedemption(ctx context.Context, params RedemptionParams) ([]byte, error) {
+	if c.signer == nil {
+		return nil, fmt.Errorf("signer cannot be nil")
+	}
+	domain, err := c.RedemptionDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.signer.SignTypedData(ctx, redemptionTypedData(domain, new(big.Int).SetUint64(params.TokenID),
+		params.Redeemer, new(big.Int).SetUint64(params.Nonce), big.NewInt(params.Deadline)))
+}
+
+// checkRedeemer returns ErrInvalidSignature unless account owns the ticket,
+// is approved for it, or is an operator of its owner
+func (c *Client) checkRedeemer(ctx context.Context, tokenID uint64, account common.Address) error {
+	owner, err := c.GetOwnerOf(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	if account == owner {
+		return nil
+	}
+
+	approved, err := c.GetApproved(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	if account == approved {
+		return nil
+	}
+
+	operator, err := c.IsApprovedForAll(ctx, owner, account)
+	if err != nil {
+		return err
+	}
+	if operator {
+		return nil
+	}
+	return fmt.Errorf("%w: %s neither owns ticket %d nor is approved for it", ErrInvalidSignature, account.Hex(), tokenID)
+}
+
+// RedeemTicket relays a redemption to the contract. The contract only
+// accepts signatures of the backend, so the redemption is signed again with
+// the backend key: callers must check the holder's authorization with
+// VerifyRedemption first.
 func (c *Client) RedeemTicket(ctx context.Context, params RedemptionParams) (*types.Transaction, error) {
 	// Generate signature
-	signature, err := GenerateRedemptionSignature(
-		c.privateKey,
-		new(big.Int).SetUint64(params.TokenID),
-		params.Redeemer,
-		new(big.Int).SetUint64(params.Nonce),
-		new(big.Int).SetInt64(params.Deadline),
-		c.networkConfig.ChainID,
-		c.ticketsAddress,
-	)
+	signature, err := c.signRedemption(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate signature: %w", err)
 	}
 
 	// Get gas price
-	gasPrice, err := c.SuggestGasPrice(ctx)
+	fees, err := c.SuggestFees(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	c.auth.GasPrice = gasPrice
-	c.auth.Context = ctx
+	txOpts, err := c.newPooledTransactOpts(ctx, fees)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create redemption data structure
 	redemptionData := RedemptionDataContract{
@@ -302,7 +445,9 @@ func (c *Client) RedeemTicket(ctx context.Context, params RedemptionParams) (*ty
 	}
 
 	// Call redeem function
-	tx, err := c.ticketsContract.RedeemTicket(c.auth, redemptionData)
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "ticket_redeem", Ref: fmt.Sprint(params.TokenID)}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.RedeemTicket(opts, redemptionData)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to redeem ticket: %w", err)
 	}
@@ -326,88 +471,96 @@ func (c *Client) RedeemTicket(ctx context.Context, params RedemptionParams) (*ty
 		return tx, fmt.Errorf("redemption transaction failed")
 	}
 
-	c.ResetAuth()
 	return tx, nil
 }
 
-// GenerateRedemptionQR generates a QR code for ticket redemption
-func (c *Client) GenerateRedemptionQR(tokenID uint64, redeemer common.Address) (string, error) {
+// GenerateRedemptionQR generates a QR code for ticket redemption, signed by
+// the backend. It returns ErrInvalidSignature if redeemer may not redeem the
+// ticket.
+func (c *Client) GenerateRedemptionQR(ctx context.Context, tokenID uint64, redeemer common.Address) (string, error) {
+	if err := c.checkRedeemer(ctx, tokenID, redeemer); err != nil {
+		return "", err
+	}
+
 	// Generate nonce and deadline
-	nonce := uint64(time.Now().Unix())
+	nonce, err := c.GetRedemptionNonce(ctx, redeemer)
+	if err != nil {
+		return "", err
+	}
 	deadline := time.Now().Add(5 * time.Minute).Unix() // 5 minute validity
 
 	// Generate signature
-	signature, err := GenerateRedemptionSignature(
-		c.privateKey,
-		new(big.Int).SetUint64(tokenID),
-		redeemer,
-		new(big.Int).SetUint64(nonce),
-		new(big.Int).SetInt64(deadline),
-		c.networkConfig.ChainID,
-		c.ticketsAddress,
-	)
+	signature, err := c.signRedemption(ctx, RedemptionParams{
+		TokenID:  tokenID,
+		Redeemer: redeemer,
+		Nonce:    nonce.Uint64(),
+		Deadline: deadline,
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to generate signature: %w", err)
 	}
 
 	// Generate QR code data
-	baseURL := "https://bogowi.com"
-	if c.network == "testnet" {
-		baseURL = "https://testnet.bogowi.com"
-	}
-
 	qrData := GenerateRedemptionQRCode(
 		new(big.Int).SetUint64(tokenID),
 		redeemer,
-		new(big.Int).SetUint64(nonce),
+		nonce,
 		new(big.Int).SetInt64(deadline),
 		signature,
-		baseURL,
+		c.redemptionBaseURL(),
 	)
 
 	return qrData, nil
 }
 
+// redemptionBaseURL returns the base URL of redemption QR codes
+func (c *Client) redemptionBaseURL() string {
+	if c.network == "testnet" {
+		return "https://testnet.bogowi.com"
+	}
+	return "https://bogowi.com"
+}
+
 // UpdateTransferUnlock updates the transfer unlock time for a ticket
 func (c *Client) UpdateTransferUnlock(ctx context.Context, tokenID uint64, newUnlockTime uint64) (*types.Transaction, error) {
 	// Get gas price
-	gasPrice, err := c.SuggestGasPrice(ctx)
+	fees, err := c.SuggestFees(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	c.auth.GasPrice = gasPrice
-	c.auth.Context = ctx
+	txOpts := c.newTransactOpts(ctx, fees)
 
-	tx, err := c.ticketsContract.UpdateTransferUnlock(
-		c.auth,
-		new(big.Int).SetUint64(tokenID),
-		newUnlockTime,
-	)
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "ticket_update_unlock", Ref: fmt.Sprint(tokenID)}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.UpdateTransferUnlock(
+			opts,
+			new(big.Int).SetUint64(tokenID),
+			newUnlockTime,
+		)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to update transfer unlock: %w", err)
 	}
 
-	c.ResetAuth()
 	return tx, nil
 }
 
 // Burn burns a ticket NFT
 func (c *Client) Burn(ctx context.Context, tokenID uint64) (*types.Transaction, error) {
 	// Get gas price
-	gasPrice, err := c.SuggestGasPrice(ctx)
+	fees, err := c.SuggestFees(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	c.auth.GasPrice = gasPrice
-	c.auth.Context = ctx
+	txOpts := c.newTransactOpts(ctx, fees)
 
-	tx, err := c.ticketsContract.Burn(c.auth, new(big.Int).SetUint64(tokenID))
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "ticket_burn", Ref: fmt.Sprint(tokenID)}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.Burn(opts, new(big.Int).SetUint64(tokenID))
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to burn ticket: %w", err)
 	}
 
-	c.ResetAuth()
 	return tx, nil
 }
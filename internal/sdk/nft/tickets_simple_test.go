@@ -6,9 +6,14 @@ import (
 	"math/big"
 	"testing"
 
+	"bogowi-blockchain-go/internal/sdk/gas"
+	"bogowi-blockchain-go/internal/sdk/signer"
+	"bogowi-blockchain-go/internal/sdk/wallet"
+
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -356,3 +361,31 @@ func TestUpdateTransferUnlockSimple(t *testing.T) {
 		})
 	}
 }
+
+// TestNewPooledTransactOpts tests that role-gated writes rotate through the hot wallets
+func TestNewPooledTransactOpts(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	primary := signer.NewKeySigner(key)
+	client := &Client{auth: signer.TransactOpts(primary, big.NewInt(501))}
+	fees := &gas.Fees{GasPrice: big.NewInt(25e9)}
+
+	// Without a pool the client's signer is used
+	opts, err := client.newPooledTransactOpts(context.Background(), fees)
+	require.NoError(t, err)
+	assert.Equal(t, primary.Address(), opts.From)
+
+	hotKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	hot := signer.NewKeySigner(hotKey)
+	pool, err := wallet.NewPool(big.NewInt(501), []signer.Signer{primary, hot}, wallet.Config{})
+	require.NoError(t, err)
+	client.SetWalletPool(pool)
+
+	for _, expected := range []common.Address{primary.Address(), hot.Address(), primary.Address()} {
+		opts, err := client.newPooledTransactOpts(context.Background(), fees)
+		require.NoError(t, err)
+		assert.Equal(t, expected, opts.From)
+		assert.Equal(t, big.NewInt(25e9), opts.GasPrice)
+	}
+}
@@ -1,15 +1,51 @@
 package nft
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/big"
+	"sort"
+	"time"
+
+	"bogowi-blockchain-go/internal/sdk/txtrack"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// CheckTransferable returns an error wrapping ErrNotTransferable that says
+// why a ticket cannot be transferred now, following the rules the contract
+// enforces on transfers, or nil when it can be.
+func (c *Client) CheckTransferable(ctx context.Context, tokenID uint64) error {
+	ticket, err := c.GetTicketData(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+
+	now := uint64(time.Now().Unix())
+	switch {
+	case TicketState(ticket.State) == TicketStateRedeemed && ticket.NonTransferableAfterRedeem:
+		return fmt.Errorf("%w: ticket %d was redeemed", ErrNotTransferable, tokenID)
+	case TicketState(ticket.State) == TicketStateExpired || ticket.ExpiresAt <= now:
+		return fmt.Errorf("%w: ticket %d expired", ErrNotTransferable, tokenID)
+	case now < ticket.TransferUnlockAt:
+		return fmt.Errorf("%w: ticket %d is locked until %s", ErrNotTransferable, tokenID,
+			time.Unix(int64(ticket.TransferUnlockAt), 0).UTC().Format(time.RFC3339))
+	}
+
+	// The contract decides at the time of the latest block
+	transferable, err := c.IsTransferable(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	if !transferable {
+		return fmt.Errorf("%w: ticket %d", ErrNotTransferable, tokenID)
+	}
+	return nil
+}
+
 // Transfer transfers a ticket to another address
 func (c *Client) Transfer(ctx context.Context, to common.Address, tokenID uint64) (*types.Transaction, error) {
 	// Check if ticket is transferable first
@@ -22,13 +58,12 @@ func (c *Client) Transfer(ctx context.Context, to common.Address, tokenID uint64
 	}
 
 	// Get gas price
-	gasPrice, err := c.SuggestGasPrice(ctx)
+	fees, err := c.SuggestFees(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	c.auth.GasPrice = gasPrice
-	c.auth.Context = ctx
+	txOpts := c.newTransactOpts(ctx, fees)
 
 	// Get the current owner to use as 'from' address
 	owner, err := c.GetOwnerOf(ctx, tokenID)
@@ -38,16 +73,18 @@ func (c *Client) Transfer(ctx context.Context, to common.Address, tokenID uint64
 
 	// Ensure the transaction sender is the owner
 	if owner != c.auth.From {
-		return nil, fmt.Errorf("sender is not the owner of token %d", tokenID)
+		return nil, fmt.Errorf("%w: sender is not the owner of token %d", ErrNotApproved, tokenID)
 	}
 
 	// Execute transfer
-	tx, err := c.ticketsContract.TransferFrom(
-		c.auth,
-		owner,
-		to,
-		new(big.Int).SetUint64(tokenID),
-	)
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "ticket_transfer", Ref: fmt.Sprint(tokenID)}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.TransferFrom(
+			opts,
+			owner,
+			to,
+			new(big.Int).SetUint64(tokenID),
+		)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to transfer ticket: %w", err)
 	}
@@ -62,7 +99,6 @@ func (c *Client) Transfer(ctx context.Context, to common.Address, tokenID uint64
 		return tx, fmt.Errorf("transfer transaction failed")
 	}
 
-	c.ResetAuth()
 	return tx, nil
 }
 
@@ -78,13 +114,12 @@ func (c *Client) SafeTransfer(ctx context.Context, to common.Address, tokenID ui
 	}
 
 	// Get gas price
-	gasPrice, err := c.SuggestGasPrice(ctx)
+	fees, err := c.SuggestFees(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	c.auth.GasPrice = gasPrice
-	c.auth.Context = ctx
+	txOpts := c.newTransactOpts(ctx, fees)
 
 	// Get the current owner
 	owner, err := c.GetOwnerOf(ctx, tokenID)
@@ -93,16 +128,18 @@ func (c *Client) SafeTransfer(ctx context.Context, to common.Address, tokenID ui
 	}
 
 	if owner != c.auth.From {
-		return nil, fmt.Errorf("sender is not the owner of token %d", tokenID)
+		return nil, fmt.Errorf("%w: sender is not the owner of token %d", ErrNotApproved, tokenID)
 	}
 
 	// Execute safe transfer
-	tx, err := c.ticketsContract.SafeTransferFrom(
-		c.auth,
-		owner,
-		to,
-		new(big.Int).SetUint64(tokenID),
-	)
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "ticket_transfer", Ref: fmt.Sprint(tokenID)}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.SafeTransferFrom(
+			opts,
+			owner,
+			to,
+			new(big.Int).SetUint64(tokenID),
+		)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to safe transfer ticket: %w", err)
 	}
@@ -117,7 +154,6 @@ func (c *Client) SafeTransfer(ctx context.Context, to common.Address, tokenID ui
 		return tx, fmt.Errorf("safe transfer transaction failed")
 	}
 
-	c.ResetAuth()
 	return tx, nil
 }
 
@@ -133,13 +169,12 @@ func (c *Client) SafeTransferWithData(ctx context.Context, to common.Address, to
 	}
 
 	// Get gas price
-	gasPrice, err := c.SuggestGasPrice(ctx)
+	fees, err := c.SuggestFees(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	c.auth.GasPrice = gasPrice
-	c.auth.Context = ctx
+	txOpts := c.newTransactOpts(ctx, fees)
 
 	// Get the current owner
 	owner, err := c.GetOwnerOf(ctx, tokenID)
@@ -148,17 +183,19 @@ func (c *Client) SafeTransferWithData(ctx context.Context, to common.Address, to
 	}
 
 	if owner != c.auth.From {
-		return nil, fmt.Errorf("sender is not the owner of token %d", tokenID)
+		return nil, fmt.Errorf("%w: sender is not the owner of token %d", ErrNotApproved, tokenID)
 	}
 
 	// Execute safe transfer with data
-	tx, err := c.ticketsContract.SafeTransferFrom0(
-		c.auth,
-		owner,
-		to,
-		new(big.Int).SetUint64(tokenID),
-		data,
-	)
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "ticket_transfer", Ref: fmt.Sprint(tokenID)}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.SafeTransferFrom0(
+			opts,
+			owner,
+			to,
+			new(big.Int).SetUint64(tokenID),
+			data,
+		)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to safe transfer with data: %w", err)
 	}
@@ -173,20 +210,18 @@ func (c *Client) SafeTransferWithData(ctx context.Context, to common.Address, to
 		return tx, fmt.Errorf("safe transfer with data transaction failed")
 	}
 
-	c.ResetAuth()
 	return tx, nil
 }
 
 // Approve approves another address to transfer a specific ticket
 func (c *Client) Approve(ctx context.Context, spender common.Address, tokenID uint64) (*types.Transaction, error) {
 	// Get gas price
-	gasPrice, err := c.SuggestGasPrice(ctx)
+	fees, err := c.SuggestFees(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	c.auth.GasPrice = gasPrice
-	c.auth.Context = ctx
+	txOpts := c.newTransactOpts(ctx, fees)
 
 	// Verify ownership
 	owner, err := c.GetOwnerOf(ctx, tokenID)
@@ -195,15 +230,17 @@ func (c *Client) Approve(ctx context.Context, spender common.Address, tokenID ui
 	}
 
 	if owner != c.auth.From {
-		return nil, fmt.Errorf("sender is not the owner of token %d", tokenID)
+		return nil, fmt.Errorf("%w: sender is not the owner of token %d", ErrNotApproved, tokenID)
 	}
 
 	// Execute approval
-	tx, err := c.ticketsContract.Approve(
-		c.auth,
-		spender,
-		new(big.Int).SetUint64(tokenID),
-	)
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "ticket_approve", Ref: fmt.Sprint(tokenID)}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.Approve(
+			opts,
+			spender,
+			new(big.Int).SetUint64(tokenID),
+		)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to approve: %w", err)
 	}
@@ -218,27 +255,27 @@ func (c *Client) Approve(ctx context.Context, spender common.Address, tokenID ui
 		return tx, fmt.Errorf("approval transaction failed")
 	}
 
-	c.ResetAuth()
 	return tx, nil
 }
 
 // SetApprovalForAll approves or revokes approval for an operator to manage all tickets
 func (c *Client) SetApprovalForAll(ctx context.Context, operator common.Address, approved bool) (*types.Transaction, error) {
 	// Get gas price
-	gasPrice, err := c.SuggestGasPrice(ctx)
+	fees, err := c.SuggestFees(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	c.auth.GasPrice = gasPrice
-	c.auth.Context = ctx
+	txOpts := c.newTransactOpts(ctx, fees)
 
 	// Execute approval for all
-	tx, err := c.ticketsContract.SetApprovalForAll(
-		c.auth,
-		operator,
-		approved,
-	)
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "ticket_approve_all", Ref: operator.Hex()}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.SetApprovalForAll(
+			opts,
+			operator,
+			approved,
+		)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set approval for all: %w", err)
 	}
@@ -253,7 +290,6 @@ func (c *Client) SetApprovalForAll(ctx context.Context, operator common.Address,
 		return tx, fmt.Errorf("set approval for all transaction failed")
 	}
 
-	c.ResetAuth()
 	return tx, nil
 }
 
@@ -304,25 +340,26 @@ func (c *Client) TransferFrom(ctx context.Context, from, to common.Address, toke
 	}
 
 	if approved != c.auth.From && !isApprovedForAll {
-		return nil, fmt.Errorf("sender is not approved to transfer token %d", tokenID)
+		return nil, fmt.Errorf("%w: sender is not approved to transfer token %d", ErrNotApproved, tokenID)
 	}
 
 	// Get gas price
-	gasPrice, err := c.SuggestGasPrice(ctx)
+	fees, err := c.SuggestFees(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	c.auth.GasPrice = gasPrice
-	c.auth.Context = ctx
+	txOpts := c.newTransactOpts(ctx, fees)
 
 	// Execute transfer
-	tx, err := c.ticketsContract.TransferFrom(
-		c.auth,
-		from,
-		to,
-		new(big.Int).SetUint64(tokenID),
-	)
+	tx, err := c.transact(txOpts, txtrack.Meta{Purpose: "ticket_transfer", Ref: fmt.Sprint(tokenID)}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.ticketsContract.TransferFrom(
+			opts,
+			from,
+			to,
+			new(big.Int).SetUint64(tokenID),
+		)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to transfer from: %w", err)
 	}
@@ -337,7 +374,6 @@ func (c *Client) TransferFrom(ctx context.Context, from, to common.Address, toke
 		return tx, fmt.Errorf("transfer from transaction failed")
 	}
 
-	c.ResetAuth()
 	return tx, nil
 }
 
@@ -361,7 +397,9 @@ func (c *Client) BatchTransfer(ctx context.Context, to common.Address, tokenIDs
 	return txs, nil
 }
 
-// TransferToMultiple transfers tickets to multiple recipients
+// TransferToMultiple transfers tickets to multiple recipients, in the order
+// of SortedRecipients and of each recipient's token IDs, so that a failure
+// leaves the same partial result every time
 func (c *Client) TransferToMultiple(ctx context.Context, transfers map[common.Address][]uint64) ([]*types.Transaction, error) {
 	if len(transfers) == 0 {
 		return nil, fmt.Errorf("no transfers provided")
@@ -369,8 +407,8 @@ func (c *Client) TransferToMultiple(ctx context.Context, transfers map[common.Ad
 
 	var txs []*types.Transaction
 
-	for recipient, tokenIDs := range transfers {
-		for _, tokenID := range tokenIDs {
+	for _, recipient := range SortedRecipients(transfers) {
+		for _, tokenID := range transfers[recipient] {
 			tx, err := c.Transfer(ctx, recipient, tokenID)
 			if err != nil {
 				// Return partial results on error
@@ -382,3 +420,16 @@ func (c *Client) TransferToMultiple(ctx context.Context, transfers map[common.Ad
 
 	return txs, nil
 }
+
+// SortedRecipients returns the recipients of transfers in ascending address
+// order
+func SortedRecipients(transfers map[common.Address][]uint64) []common.Address {
+	recipients := make([]common.Address, 0, len(transfers))
+	for recipient := range transfers {
+		recipients = append(recipients, recipient)
+	}
+	sort.Slice(recipients, func(i, j int) bool {
+		return bytes.Compare(recipients[i][:], recipients[j][:]) < 0
+	})
+	return recipients
+}
@@ -1,9 +1,11 @@
 package nft
 
 import (
+	"context"
 	"errors"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -109,3 +111,53 @@ func TestIsApprovedForAll(t *testing.T) {
 		mockContract.AssertExpectations(t)
 	})
 }
+
+func TestCheckTransferable(t *testing.T) {
+	now := uint64(time.Now().Unix())
+	future := now + 3600
+
+	tests := []struct {
+		name         string
+		ticket       TicketDataContract
+		onChain      bool
+		wantErr      bool
+		wantContains string
+	}{
+		{"transferable", TicketDataContract{ExpiresAt: future}, true, false, ""},
+		{"locked", TicketDataContract{TransferUnlockAt: future, ExpiresAt: future + 3600}, false, true, "is locked until"},
+		{"expired", TicketDataContract{ExpiresAt: now - 1}, false, true, "expired"},
+		{"expired state", TicketDataContract{ExpiresAt: future, State: uint8(TicketStateExpired)}, false, true, "expired"},
+		{"redeemed and bound", TicketDataContract{ExpiresAt: future, State: uint8(TicketStateRedeemed), NonTransferableAfterRedeem: true}, false, true, "was redeemed"},
+		{"redeemed and transferable", TicketDataContract{ExpiresAt: future, State: uint8(TicketStateRedeemed)}, true, false, ""},
+		{"rejected by the contract", TicketDataContract{ExpiresAt: future}, false, true, "not transferable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockContract := new(MockTicketsContract)
+			mockContract.On("GetTicketData", mock.Anything, big.NewInt(1)).Return(tt.ticket, nil)
+			mockContract.On("IsTransferable", mock.Anything, big.NewInt(1)).Return(tt.onChain, nil)
+			client := &Client{ticketsContract: mockContract}
+
+			err := client.CheckTransferable(context.Background(), 1)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrNotTransferable)
+			assert.Contains(t, err.Error(), tt.wantContains)
+		})
+	}
+}
+
+func TestSortedRecipients(t *testing.T) {
+	a := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	b := common.HexToAddress("0x00000000000000000000000000000000000000b2")
+	c := common.HexToAddress("0xC000000000000000000000000000000000000000")
+	transfers := map[common.Address][]uint64{c: {3}, a: {1}, b: {2}}
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, []common.Address{a, b, c}, SortedRecipients(transfers))
+	}
+	assert.Empty(t, SortedRecipients(nil))
+}
@@ -159,6 +159,48 @@ func (m *MockTicketsContract) Burn(opts *bind.TransactOpts, tokenId *big.Int) (*
 	return args.Get(0).(*types.Transaction), args.Error(1)
 }
 
+func (m *MockTicketsContract) Pause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	args := m.Called(opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Transaction), args.Error(1)
+}
+
+func (m *MockTicketsContract) Unpause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	args := m.Called(opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Transaction), args.Error(1)
+}
+
+func (m *MockTicketsContract) Paused(opts *bind.CallOpts) (bool, error) {
+	args := m.Called(opts)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTicketsContract) SetRoyaltyInfo(opts *bind.TransactOpts, receiver common.Address, feeBasisPoints *big.Int) (*types.Transaction, error) {
+	args := m.Called(opts, receiver, feeBasisPoints)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Transaction), args.Error(1)
+}
+
+func (m *MockTicketsContract) RoyaltyInfo(opts *bind.CallOpts, tokenId *big.Int, salePrice *big.Int) (common.Address, *big.Int, error) {
+	args := m.Called(opts, tokenId, salePrice)
+	if args.Get(1) == nil {
+		return args.Get(0).(common.Address), nil, args.Error(2)
+	}
+	return args.Get(0).(common.Address), args.Get(1).(*big.Int), args.Error(2)
+}
+
+func (m *MockTicketsContract) Eip712Domain(opts *bind.CallOpts) (EIP712Domain, error) {
+	args := m.Called(opts)
+	return args.Get(0).(EIP712Domain), args.Error(1)
+}
+
 // MockEthClient for testing
 type MockNFTEthClient struct {
 	mock.Mock
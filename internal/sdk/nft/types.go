@@ -4,7 +4,11 @@ import (
 	"math/big"
 	"time"
 
+	"bogowi-blockchain-go/internal/sdk/gas"
+	"bogowi-blockchain-go/internal/sdk/signer"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
 // MintParams represents parameters for minting a new ticket
@@ -59,6 +63,83 @@ type RedemptionParams struct {
 	Deadline int64
 }
 
+// RedemptionSigningWindow is how long a prepared redemption stays valid for
+// the holder to sign and submit it
+const RedemptionSigningWindow = 10 * time.Minute
+
+// RedemptionRequest is a redemption prepared for the redeemer to sign
+type RedemptionRequest struct {
+	Params    RedemptionParams
+	TypedData apitypes.TypedData
+}
+
+// DefaultRotatingQRPeriod is how often the code of a rotating redemption QR
+// code changes unless the client is configured otherwise
+const DefaultRotatingQRPeriod = 15 * time.Second
+
+// RotatingTokenValidity is how long a rotating redemption token can be shown
+const RotatingTokenValidity = 6 * time.Hour
+
+// RotatingRedemptionToken lets a holder's app show a redemption QR code that
+// changes every Period seconds, like a TOTP bound to the ticket and holder.
+// Seed is secret to the holder.
+type RotatingRedemptionToken struct {
+	Params  RedemptionParams
+	Seed    []byte
+	Period  int64
+	BaseURL string
+}
+
+// RotatingQRData is a scanned rotating redemption QR code
+type RotatingQRData struct {
+	Params RedemptionParams
+	Step   uint64
+	Code   []byte
+}
+
+// Results of checking a redemption QR code at a venue
+const (
+	CheckInValid           = "valid"
+	CheckInAlreadyRedeemed = "already_redeemed"
+	CheckInExpired         = "expired"
+	CheckInQRExpired       = "qr_expired"
+	CheckInWrongEvent      = "wrong_event"
+	CheckInInvalid         = "invalid"
+)
+
+// CheckIn is the outcome of checking a scanned redemption QR code. Params
+// holds the redemption to relay when Result is CheckInValid.
+type CheckIn struct {
+	Result  string
+	Reason  string
+	Params  RedemptionParams
+	EventID [32]byte
+}
+
+// CheckInBundle is the signed ticket list of an event for offline scanning
+type CheckInBundle struct {
+	EventID    [32]byte
+	IssuedAt   int64
+	ValidUntil int64
+	Tickets    []BundleTicket
+	// TypedData is what Signature covers, for eth_signTypedData_v4 style
+	// verification
+	TypedData apitypes.TypedData
+	Signature []byte
+	Signer    common.Address
+	// PublicKey is the uncompressed public key of Signer
+	PublicKey []byte
+}
+
+// BundleTicket is a ticket of a check-in bundle. Status is "active",
+// "redeemed" or "expired".
+type BundleTicket struct {
+	TokenID   uint64
+	Owner     common.Address
+	Status    string
+	ExpiresAt uint64
+}
+
 // EventFilter represents parameters for filtering events
 type EventFilter struct {
 	FromBlock *big.Int
@@ -110,12 +191,22 @@ type NetworkConfig struct {
 // ClientConfig represents SDK client configuration
 type ClientConfig struct {
 	PrivateKey      string
-	Network         string // "testnet" or "mainnet"
-	CustomRPCURL    string // Optional custom RPC
+	Signer          signer.Signer // signs instead of PrivateKey when set
+	Network         string        // "testnet" or "mainnet"
+	CustomRPCURL    string        // Optional custom RPC
+	FallbackRPCURLs []string      // tried in order when the primary RPC fails
 	GasMultiplier   float64
 	MaxGasPrice     *big.Int
-	RequestTimeout  time.Duration
-	RetryAttempts   int
+	FeeMode         gas.Mode       // legacy (default), eip1559 or fixed
+	FixedGasPrice   *big.Int       // used with the fixed fee mode
+	RequestTimeout  time.Duration  // bounds a single RPC attempt
+	RetryAttempts   int            // retries of transient RPC failures; negative disables
+	MaxBlockLag     uint64         // blocks an RPC endpoint may trail before it is skipped
+	WaitTimeout     time.Duration  // bounds WaitForTransaction; zero waits as long as the context allows
+	Confirmations   int            // overrides the network's ConfirmationWait when positive
+	QRSecret        []byte         // keys rotating redemption QR codes; random per client when empty
+	QRPeriod        time.Duration  // how often rotating QR codes change, in whole seconds; DefaultRotatingQRPeriod when zero
+	RoleManager     common.Address // checks the roles of admin operations; ROLE_MANAGER_<network> when zero
 	DatakyteEnabled bool
 }
 
@@ -139,8 +230,14 @@ var (
 	ErrTicketExpired       = SDKError{Code: "TICKET_EXPIRED", Message: "Ticket has expired"}
 	ErrTicketRedeemed      = SDKError{Code: "TICKET_REDEEMED", Message: "Ticket already redeemed"}
 	ErrNotTransferable     = SDKError{Code: "NOT_TRANSFERABLE", Message: "Ticket is not transferable"}
+	ErrNotApproved         = SDKError{Code: "NOT_APPROVED", Message: "Not authorized to transfer ticket"}
 	ErrInsufficientGas     = SDKError{Code: "INSUFFICIENT_GAS", Message: "Insufficient gas for transaction"}
 	ErrDatakyteSyncFailed  = SDKError{Code: "DATAKYTE_SYNC_FAILED", Message: "Failed to sync with Datakyte"}
+	ErrSignatureExpired    = SDKError{Code: "SIGNATURE_EXPIRED", Message: "Signature deadline has passed"}
+	ErrNonceUsed           = SDKError{Code: "NONCE_USED", Message: "Redemption nonce already used"}
+	ErrMissingRole         = SDKError{Code: "MISSING_ROLE", Message: "Backend wallet lacks the required role"}
+	ErrNotTicketHolder     = SDKError{Code: "NOT_TICKET_HOLDER", Message: "Ticket is not held by the backend wallet"}
+	ErrInvalidUnlockTime   = SDKError{Code: "INVALID_UNLOCK_TIME", Message: "Transfer unlock time must be before expiry"}
 )
 
 // Helper functions
@@ -0,0 +1,78 @@
+package nft
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bogowi-blockchain-go/internal/sdk/failover"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pendingNode is a JSON-RPC endpoint for which no transaction is ever mined
+func pendingNode(t *testing.T) *failover.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": nil}
+		switch req.Method {
+		case "eth_chainId":
+			resp["result"] = "0x1f5"
+		case "eth_blockNumber":
+			resp["result"] = "0x64"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := failover.Dial(context.Background(), failover.Config{URLs: []string{server.URL}, CheckInterval: time.Hour})
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestWaitForTransaction(t *testing.T) {
+	ethClient := pendingNode(t)
+	txHash := common.HexToHash("0x01")
+
+	t.Run("wait timeout", func(t *testing.T) {
+		c := &Client{
+			ethClient:     ethClient,
+			config:        &ClientConfig{WaitTimeout: 50 * time.Millisecond},
+			networkConfig: &NetworkConfig{BlockTime: 10 * time.Millisecond},
+		}
+
+		_, err := c.WaitForTransaction(context.Background(), txHash)
+		assert.EqualError(t, err, "transaction timeout")
+	})
+
+	t.Run("canceled request", func(t *testing.T) {
+		c := &Client{
+			ethClient:     ethClient,
+			config:        &ClientConfig{WaitTimeout: time.Minute},
+			networkConfig: &NetworkConfig{BlockTime: 10 * time.Millisecond},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		start := time.Now()
+		_, err := c.WaitForTransaction(ctx, txHash)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
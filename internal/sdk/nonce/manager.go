@@ -0,0 +1,108 @@
+// Package nonce hands out transaction nonces for signers shared by several
+// SDK clients, so concurrent requests never reuse or skip a nonce.
+package nonce
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Source provides the pending nonce of an account, typically an ethclient
+type Source interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// Manager serializes transaction submission for a single signer and tracks
+// the next nonce locally between sends
+type Manager struct {
+	mu      sync.Mutex
+	account common.Address
+	next    uint64
+	synced  bool
+}
+
+// NewManager creates a standalone manager for an account. Most callers
+// should use For so that all clients for the same signer share one manager.
+func NewManager(account common.Address) *Manager {
+	return &Manager{account: account}
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = make(map[string]*Manager)
+)
+
+// For returns the manager shared by every client signing for account on chainID
+func For(chainID *big.Int, account common.Address) *Manager {
+	chain := "0"
+	if chainID != nil {
+		chain = chainID.String()
+	}
+	key := chain + ":" + account.Hex()
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if m, ok := registry[key]; ok {
+		return m
+	}
+	m := NewManager(account)
+	registry[key] = m
+	return m
+}
+
+// Account returns the signer address the manager hands out nonces for
+func (m *Manager) Account() common.Address {
+	return m.account
+}
+
+// Send calls send with the next nonce while holding the signer lock, so the
+// nonce is only consumed once send succeeds. Any failure makes the manager
+// resync from the node before the next send; a "nonce too low" failure is
+// retried once with the resynced nonce.
+func (m *Manager) Send(ctx context.Context, src Source, send func(nonce uint64) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		if !m.synced {
+			pending, err := src.PendingNonceAt(ctx, m.account)
+			if err != nil {
+				return fmt.Errorf("failed to get nonce: %w", err)
+			}
+			m.next = pending
+			m.synced = true
+		}
+
+		err := send(m.next)
+		if err == nil {
+			m.next++
+			return nil
+		}
+
+		// The local view may be stale (the key was used elsewhere, or a
+		// transaction was dropped), so ask the node again next time
+		m.synced = false
+		if attempt == 0 && isNonceTooLow(err) {
+			continue
+		}
+		return err
+	}
+}
+
+// Reset forces the next send to resync the nonce from the node
+func (m *Manager) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.synced = false
+}
+
+func isNonceTooLow(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "nonce too low")
+}
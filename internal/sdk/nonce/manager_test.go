@@ -0,0 +1,142 @@
+package nonce
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource returns a fixed pending nonce and counts lookups
+type fakeSource struct {
+	mu      sync.Mutex
+	pending uint64
+	calls   int
+	err     error
+}
+
+func (f *fakeSource) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return f.pending, f.err
+}
+
+var testAccount = common.HexToAddress("0x1234567890123456789012345678901234567890")
+
+func TestManagerSendSequential(t *testing.T) {
+	src := &fakeSource{pending: 5}
+	m := NewManager(testAccount)
+
+	var used []uint64
+	for i := 0; i < 3; i++ {
+		err := m.Send(context.Background(), src, func(nonce uint64) error {
+			used = append(used, nonce)
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []uint64{5, 6, 7}, used)
+	assert.Equal(t, 1, src.calls, "nonce should only be fetched once while in sync")
+}
+
+func TestManagerSendConcurrent(t *testing.T) {
+	src := &fakeSource{pending: 0}
+	m := NewManager(testAccount)
+
+	const workers = 50
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		seen = make(map[uint64]bool)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := m.Send(context.Background(), src, func(nonce uint64) error {
+				mu.Lock()
+				defer mu.Unlock()
+				seen[nonce] = true
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, seen, workers)
+	for i := uint64(0); i < workers; i++ {
+		assert.True(t, seen[i], "nonce %d was never used", i)
+	}
+}
+
+func TestManagerResyncOnError(t *testing.T) {
+	src := &fakeSource{pending: 3}
+	m := NewManager(testAccount)
+
+	// A failed send does not consume the nonce and forces a resync
+	err := m.Send(context.Background(), src, func(nonce uint64) error {
+		return errors.New("insufficient funds")
+	})
+	assert.EqualError(t, err, "insufficient funds")
+
+	src.pending = 4 // e.g. the key was used by another process meanwhile
+	var used uint64
+	err = m.Send(context.Background(), src, func(nonce uint64) error {
+		used = nonce
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), used)
+	assert.Equal(t, 2, src.calls)
+}
+
+func TestManagerRetriesNonceTooLow(t *testing.T) {
+	src := &fakeSource{pending: 10}
+	m := NewManager(testAccount)
+
+	var used []uint64
+	err := m.Send(context.Background(), src, func(nonce uint64) error {
+		used = append(used, nonce)
+		if len(used) == 1 {
+			src.pending = 12
+			return errors.New("nonce too low: next nonce 12, tx nonce 10")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{10, 12}, used)
+
+	// Only one retry
+	err = m.Send(context.Background(), src, func(nonce uint64) error {
+		return errors.New("nonce too low")
+	})
+	assert.Error(t, err)
+}
+
+func TestManagerSourceError(t *testing.T) {
+	src := &fakeSource{err: errors.New("connection refused")}
+	m := NewManager(testAccount)
+
+	err := m.Send(context.Background(), src, func(nonce uint64) error {
+		t.Fatal("send should not be called")
+		return nil
+	})
+	assert.EqualError(t, err, "failed to get nonce: connection refused")
+}
+
+func TestFor(t *testing.T) {
+	other := common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2")
+
+	assert.Same(t, For(big.NewInt(501), testAccount), For(big.NewInt(501), testAccount))
+	assert.NotSame(t, For(big.NewInt(501), testAccount), For(big.NewInt(500), testAccount))
+	assert.NotSame(t, For(big.NewInt(501), testAccount), For(big.NewInt(501), other))
+	assert.Equal(t, testAccount, For(big.NewInt(501), testAccount).Account())
+}
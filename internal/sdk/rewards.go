@@ -5,6 +5,10 @@ import (
 	"fmt"
 	"math/big"
 
+	"bogowi-blockchain-go/internal/sdk/gas"
+	"bogowi-blockchain-go/internal/sdk/signer"
+	"bogowi-blockchain-go/internal/sdk/txtrack"
+
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -22,19 +26,25 @@ type RewardTemplate struct {
 }
 
 // ClaimRewardV2 claims a fixed reward from a template
-func (s *BOGOWISDK) ClaimRewardV2(templateID string, recipient common.Address) (*types.Transaction, error) {
+func (s *BOGOWISDK) ClaimRewardV2(ctx context.Context, templateID string, recipient common.Address) (*types.Transaction, error) {
 	if s.rewardDistributor == nil {
 		return nil, fmt.Errorf("reward distributor not initialized")
 	}
 
-	opts, err := s.getTransactOpts()
+	ctx, cancel := s.writeContext(ctx)
+	defer cancel()
+
+	opts, err := s.getTransactOpts(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction options: %v", err)
 	}
 
 	// Call the contract method using the bound contract instance
 	// The method signature is: claimReward(string templateId)
-	tx, err := s.rewardDistributor.Instance.Transact(opts, "claimReward", templateID)
+	meta := txtrack.Meta{Purpose: "reward_claim", Ref: templateID}
+	tx, err := s.transact(opts, meta, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return s.rewardDistributor.Instance.Transact(opts, "claimReward", templateID)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute claimReward: %v", err)
 	}
@@ -43,7 +53,21 @@ func (s *BOGOWISDK) ClaimRewardV2(templateID string, recipient common.Address) (
 }
 
 // ClaimCustomReward claims a custom amount reward (backend only)
-func (s *BOGOWISDK) ClaimCustomReward(recipient common.Address, amount *big.Int, reason string) (*types.Transaction, error) {
+func (s *BOGOWISDK) ClaimCustomReward(ctx context.Context, recipient common.Address, amount *big.Int, reason string) (*types.Transaction, error) {
+	ctx, cancel := s.writeContext(ctx)
+	defer cancel()
+	return s.claimCustomReward(ctx, recipient, amount, reason)
+}
+
+// EstimateClaimCustomReward estimates a custom reward claim without sending it
+func (s *BOGOWISDK) EstimateClaimCustomReward(ctx context.Context, recipient common.Address, amount *big.Int, reason string) (*gas.Estimate, error) {
+	return s.dryRun(ctx, func(ctx context.Context) error {
+		_, err := s.claimCustomReward(ctx, recipient, amount, reason)
+		return err
+	})
+}
+
+func (s *BOGOWISDK) claimCustomReward(ctx context.Context, recipient common.Address, amount *big.Int, reason string) (*types.Transaction, error) {
 	if s.rewardDistributor == nil {
 		return nil, fmt.Errorf("reward distributor not initialized")
 	}
@@ -55,35 +79,44 @@ func (s *BOGOWISDK) ClaimCustomReward(recipient common.Address, amount *big.Int,
 	}
 
 	// Get transaction options
-	opts, err := s.getTransactOpts()
+	opts, err := s.getTransactOpts(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction options: %v", err)
 	}
 
 	// Call the contract method using the bound contract instance
 	// The method signature is: claimCustomReward(address recipient, uint256 amount, string reason)
-	tx, err := s.rewardDistributor.Instance.Transact(opts, "claimCustomReward", recipient, amount, reason)
+	meta := txtrack.Meta{Purpose: "reward_custom", Ref: recipient.Hex()}
+	tx, err := s.transact(opts, meta, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return s.rewardDistributor.Instance.Transact(opts, "claimCustomReward", recipient, amount, reason)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute claimCustomReward: %v", err)
+		return nil, fmt.Errorf("failed to execute claimCustomReward: %w", err)
 	}
 
 	return tx, nil
 }
 
 // ClaimReferralBonus claims a referral bonus
-func (s *BOGOWISDK) ClaimReferralBonus(referrer common.Address, referred common.Address) (*types.Transaction, error) {
+func (s *BOGOWISDK) ClaimReferralBonus(ctx context.Context, referrer common.Address, referred common.Address) (*types.Transaction, error) {
 	if s.rewardDistributor == nil {
 		return nil, fmt.Errorf("reward distributor not initialized")
 	}
 
-	opts, err := s.getTransactOpts()
+	ctx, cancel := s.writeContext(ctx)
+	defer cancel()
+
+	opts, err := s.getTransactOpts(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction options: %v", err)
 	}
 
 	// Call the contract method using the bound contract instance
 	// The method signature is: claimReferralBonus(address referrer)
-	tx, err := s.rewardDistributor.Instance.Transact(opts, "claimReferralBonus", referrer)
+	meta := txtrack.Meta{Purpose: "referral_bonus", Ref: referred.Hex()}
+	tx, err := s.transact(opts, meta, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return s.rewardDistributor.Instance.Transact(opts, "claimReferralBonus", referrer)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute claimReferralBonus: %v", err)
 	}
@@ -92,7 +125,7 @@ func (s *BOGOWISDK) ClaimReferralBonus(referrer common.Address, referred common.
 }
 
 // CheckRewardEligibility checks if a wallet is eligible for a reward
-func (s *BOGOWISDK) CheckRewardEligibility(templateID string, wallet common.Address) (bool, string, error) {
+func (s *BOGOWISDK) CheckRewardEligibility(ctx context.Context, templateID string, wallet common.Address) (bool, string, error) {
 	if s.rewardDistributor == nil {
 		return false, "reward distributor not initialized", fmt.Errorf("reward distributor not initialized")
 	}
@@ -117,7 +150,7 @@ func (s *BOGOWISDK) CheckRewardEligibility(templateID string, wallet common.Addr
 }
 
 // GetReferrer gets the referrer address for a wallet
-func (s *BOGOWISDK) GetReferrer(wallet common.Address) (common.Address, error) {
+func (s *BOGOWISDK) GetReferrer(ctx context.Context, wallet common.Address) (common.Address, error) {
 	if s.rewardDistributor == nil {
 		return common.Address{}, fmt.Errorf("reward distributor not initialized")
 	}
@@ -128,7 +161,7 @@ func (s *BOGOWISDK) GetReferrer(wallet common.Address) (common.Address, error) {
 }
 
 // GetRewardTemplate gets details for a specific template
-func (s *BOGOWISDK) GetRewardTemplate(templateID string) (*RewardTemplate, error) {
+func (s *BOGOWISDK) GetRewardTemplate(ctx context.Context, templateID string) (*RewardTemplate, error) {
 	if s.rewardDistributor == nil {
 		return nil, fmt.Errorf("reward distributor not initialized")
 	}
@@ -159,7 +192,7 @@ func (s *BOGOWISDK) GetRewardTemplate(templateID string) (*RewardTemplate, error
 }
 
 // GetClaimCount gets the number of times a wallet has claimed a template
-func (s *BOGOWISDK) GetClaimCount(wallet common.Address, templateID string) (*big.Int, error) {
+func (s *BOGOWISDK) GetClaimCount(ctx context.Context, wallet common.Address, templateID string) (*big.Int, error) {
 	if s.rewardDistributor == nil {
 		return nil, fmt.Errorf("reward distributor not initialized")
 	}
@@ -170,7 +203,7 @@ func (s *BOGOWISDK) GetClaimCount(wallet common.Address, templateID string) (*bi
 }
 
 // IsWhitelisted checks if a wallet is whitelisted for founder bonus
-func (s *BOGOWISDK) IsWhitelisted(wallet common.Address) (bool, error) {
+func (s *BOGOWISDK) IsWhitelisted(ctx context.Context, wallet common.Address) (bool, error) {
 	if s.rewardDistributor == nil {
 		return false, fmt.Errorf("reward distributor not initialized")
 	}
@@ -181,7 +214,7 @@ func (s *BOGOWISDK) IsWhitelisted(wallet common.Address) (bool, error) {
 }
 
 // GetRemainingDailyLimit gets the remaining daily distribution limit
-func (s *BOGOWISDK) GetRemainingDailyLimit() (*big.Int, error) {
+func (s *BOGOWISDK) GetRemainingDailyLimit(ctx context.Context) (*big.Int, error) {
 	if s.rewardDistributor == nil {
 		return nil, fmt.Errorf("reward distributor not initialized")
 	}
@@ -191,25 +224,33 @@ func (s *BOGOWISDK) GetRemainingDailyLimit() (*big.Int, error) {
 	return new(big.Int).Mul(big.NewInt(400000), big.NewInt(1e18)), nil
 }
 
-// Helper method to get transaction options
-func (s *BOGOWISDK) getTransactOpts() (*bind.TransactOpts, error) {
-	auth, err := bind.NewKeyedTransactorWithChainID(s.privateKey, s.chainID)
-	if err != nil {
-		return nil, err
+// Helper method to get transaction options bound to ctx
+func (s *BOGOWISDK) getTransactOpts(ctx context.Context) (*bind.TransactOpts, error) {
+	var auth *bind.TransactOpts
+	if s.wallets != nil {
+		// Claims are spread across the hot wallet pool
+		next, err := s.wallets.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		auth = next
+	} else {
+		if s.signer == nil {
+			return nil, fmt.Errorf("signer not initialized")
+		}
+		auth = signer.TransactOpts(s.signer, s.chainID)
 	}
 
-	// Get suggested gas price from the network
-	gasPrice, err := s.client.SuggestGasPrice(context.Background())
+	// Price with the network's fee strategy; its multiplier provides the
+	// buffer that helps the transaction go through
+	fees, err := s.suggestFees(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	// Add 20% buffer to suggested price to ensure transaction goes through
-	gasPrice = new(big.Int).Mul(gasPrice, big.NewInt(120))
-	gasPrice = new(big.Int).Div(gasPrice, big.NewInt(100))
-
-	auth.GasPrice = gasPrice
-	auth.GasLimit = uint64(300000) // This could also be estimated dynamically
+	// The gas limit is left to transact, which estimates it from the calldata
+	fees.Apply(auth)
+	auth.Context = ctx
 
 	return auth, nil
 }
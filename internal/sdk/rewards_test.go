@@ -2,11 +2,13 @@ package sdk
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"errors"
 	"math/big"
 	"testing"
 
+	"bogowi-blockchain-go/internal/sdk/signer"
+	"bogowi-blockchain-go/internal/sdk/wallet"
+
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -56,6 +58,22 @@ func (m *MockRewardEthClient) SuggestGasPrice(ctx context.Context) (*big.Int, er
 	return args.Get(0).(*big.Int), args.Error(1)
 }
 
+func (m *MockRewardEthClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*big.Int), args.Error(1)
+}
+
+func (m *MockRewardEthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	args := m.Called(ctx, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Header), args.Error(1)
+}
+
 func (m *MockRewardEthClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
 	args := m.Called(ctx, account)
 	return args.Get(0).(uint64), args.Error(1)
@@ -74,6 +92,19 @@ func (m *MockRewardEthClient) CallContract(ctx context.Context, call ethereum.Ca
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *MockRewardEthClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	args := m.Called(ctx, account, blockNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*big.Int), args.Error(1)
+}
+
+func (m *MockRewardEthClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	args := m.Called(ctx, msg)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
 func (m *MockRewardEthClient) Close() {
 	m.Called()
 }
@@ -104,8 +135,12 @@ func TestClaimRewardV2(t *testing.T) {
 					big.NewInt(20000000000),
 					nil,
 				)
+				mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 8000000}, nil)
+				mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(60000), nil)
+				mockClient.On("PendingNonceAt", mock.Anything, mock.Anything).Return(uint64(0), nil)
 				mockContract.On("Transact", mock.Anything, "claimReward", []interface{}{"welcome_bonus"}).
 					Return(expectedTx, nil)
+				mockClient.On("SendTransaction", mock.Anything, expectedTx).Return(nil)
 			},
 			expectError: false,
 		},
@@ -135,6 +170,8 @@ func TestClaimRewardV2(t *testing.T) {
 			recipient:  common.HexToAddress("0x1234567890123456789012345678901234567890"),
 			setupMocks: func(mockContract *MockRewardBoundContract, mockClient *MockRewardEthClient) {
 				mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(20000000000), nil)
+				// The call fails while it is estimated, before a nonce is taken
+				mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 8000000}, nil)
 				mockContract.On("Transact", mock.Anything, "claimReward", []interface{}{"welcome_bonus"}).
 					Return(nil, errors.New("insufficient funds"))
 			},
@@ -158,12 +195,12 @@ func TestClaimRewardV2(t *testing.T) {
 				sdk.rewardDistributor = &Contract{Instance: mockContract}
 				// Generate a test private key
 				privateKey, _ := crypto.GenerateKey()
-				sdk.privateKey = privateKey
+				sdk.signer = signer.NewKeySigner(privateKey)
 			}
 
 			tt.setupMocks(mockContract, mockClient)
 
-			tx, err := sdk.ClaimRewardV2(tt.templateID, tt.recipient)
+			tx, err := sdk.ClaimRewardV2(context.Background(), tt.templateID, tt.recipient)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -199,12 +236,16 @@ func TestClaimCustomReward(t *testing.T) {
 				mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(20000000000), nil)
 
 				expectedTx := types.NewTransaction(1, common.Address{}, big.NewInt(0), 21000, big.NewInt(20000000000), nil)
+				mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 8000000}, nil)
+				mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(60000), nil)
+				mockClient.On("PendingNonceAt", mock.Anything, mock.Anything).Return(uint64(0), nil)
 				mockContract.On("Transact", mock.Anything, "claimCustomReward",
 					[]interface{}{
 						common.HexToAddress("0x1234567890123456789012345678901234567890"),
 						new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18)),
 						"Bug bounty reward",
 					}).Return(expectedTx, nil)
+				mockClient.On("SendTransaction", mock.Anything, expectedTx).Return(nil)
 			},
 			expectError: false,
 		},
@@ -224,6 +265,8 @@ func TestClaimCustomReward(t *testing.T) {
 			reason:    "Test reward",
 			setupMocks: func(mockContract *MockRewardBoundContract, mockClient *MockRewardEthClient) {
 				mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(20000000000), nil)
+				// The call fails while it is estimated, before a nonce is taken
+				mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 8000000}, nil)
 				mockContract.On("Transact", mock.Anything, "claimCustomReward", mock.Anything).
 					Return(nil, errors.New("unauthorized"))
 			},
@@ -241,12 +284,12 @@ func TestClaimCustomReward(t *testing.T) {
 				client:            mockClient,
 				chainID:           big.NewInt(1),
 				rewardDistributor: &Contract{Instance: mockContract},
-				privateKey:        func() *ecdsa.PrivateKey { key, _ := crypto.GenerateKey(); return key }(),
+				signer:            func() signer.Signer { key, _ := crypto.GenerateKey(); return signer.NewKeySigner(key) }(),
 			}
 
 			tt.setupMocks(mockContract, mockClient)
 
-			tx, err := sdk.ClaimCustomReward(tt.recipient, tt.amount, tt.reason)
+			tx, err := sdk.ClaimCustomReward(context.Background(), tt.recipient, tt.amount, tt.reason)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -280,9 +323,13 @@ func TestClaimReferralBonus(t *testing.T) {
 				mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(20000000000), nil)
 
 				expectedTx := types.NewTransaction(1, common.Address{}, big.NewInt(0), 21000, big.NewInt(20000000000), nil)
+				mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 8000000}, nil)
+				mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(60000), nil)
+				mockClient.On("PendingNonceAt", mock.Anything, mock.Anything).Return(uint64(0), nil)
 				mockContract.On("Transact", mock.Anything, "claimReferralBonus",
 					[]interface{}{common.HexToAddress("0x1111111111111111111111111111111111111111")}).
 					Return(expectedTx, nil)
+				mockClient.On("SendTransaction", mock.Anything, expectedTx).Return(nil)
 			},
 			expectError: false,
 		},
@@ -292,6 +339,8 @@ func TestClaimReferralBonus(t *testing.T) {
 			referred: common.HexToAddress("0x4444444444444444444444444444444444444444"),
 			setupMocks: func(mockContract *MockRewardBoundContract, mockClient *MockRewardEthClient) {
 				mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(20000000000), nil)
+				// The call fails while it is estimated, before a nonce is taken
+				mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 8000000}, nil)
 				mockContract.On("Transact", mock.Anything, "claimReferralBonus", mock.Anything).
 					Return(nil, errors.New("already claimed"))
 			},
@@ -309,12 +358,12 @@ func TestClaimReferralBonus(t *testing.T) {
 				client:            mockClient,
 				chainID:           big.NewInt(1),
 				rewardDistributor: &Contract{Instance: mockContract},
-				privateKey:        func() *ecdsa.PrivateKey { key, _ := crypto.GenerateKey(); return key }(),
+				signer:            func() signer.Signer { key, _ := crypto.GenerateKey(); return signer.NewKeySigner(key) }(),
 			}
 
 			tt.setupMocks(mockContract, mockClient)
 
-			tx, err := sdk.ClaimReferralBonus(tt.referrer, tt.referred)
+			tx, err := sdk.ClaimReferralBonus(context.Background(), tt.referrer, tt.referred)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -391,7 +440,7 @@ func TestCheckRewardEligibility(t *testing.T) {
 				sdk.rewardDistributor = &Contract{Instance: new(MockRewardBoundContract)}
 			}
 
-			eligible, reason, err := sdk.CheckRewardEligibility(tt.templateID, tt.wallet)
+			eligible, reason, err := sdk.CheckRewardEligibility(context.Background(), tt.templateID, tt.wallet)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -461,7 +510,7 @@ func TestGetRewardTemplate(t *testing.T) {
 				sdk.rewardDistributor = &Contract{Instance: new(MockRewardBoundContract)}
 			}
 
-			template, err := sdk.GetRewardTemplate(tt.templateID)
+			template, err := sdk.GetRewardTemplate(context.Background(), tt.templateID)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -484,7 +533,7 @@ func TestGetClaimCount(t *testing.T) {
 			rewardDistributor: &Contract{Instance: new(MockRewardBoundContract)},
 		}
 
-		count, err := sdk.GetClaimCount(wallet, "welcome_bonus")
+		count, err := sdk.GetClaimCount(context.Background(), wallet, "welcome_bonus")
 		require.NoError(t, err)
 		assert.Equal(t, big.NewInt(0), count)
 	})
@@ -492,7 +541,7 @@ func TestGetClaimCount(t *testing.T) {
 	t.Run("reward distributor not initialized", func(t *testing.T) {
 		sdk := &BOGOWISDK{}
 
-		count, err := sdk.GetClaimCount(wallet, "welcome_bonus")
+		count, err := sdk.GetClaimCount(context.Background(), wallet, "welcome_bonus")
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "reward distributor not initialized")
 		assert.Nil(t, count)
@@ -507,7 +556,7 @@ func TestIsWhitelisted(t *testing.T) {
 			rewardDistributor: &Contract{Instance: new(MockRewardBoundContract)},
 		}
 
-		whitelisted, err := sdk.IsWhitelisted(wallet)
+		whitelisted, err := sdk.IsWhitelisted(context.Background(), wallet)
 		require.NoError(t, err)
 		assert.False(t, whitelisted)
 	})
@@ -515,7 +564,7 @@ func TestIsWhitelisted(t *testing.T) {
 	t.Run("reward distributor not initialized", func(t *testing.T) {
 		sdk := &BOGOWISDK{}
 
-		whitelisted, err := sdk.IsWhitelisted(wallet)
+		whitelisted, err := sdk.IsWhitelisted(context.Background(), wallet)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "reward distributor not initialized")
 		assert.False(t, whitelisted)
@@ -528,7 +577,7 @@ func TestGetRemainingDailyLimit(t *testing.T) {
 			rewardDistributor: &Contract{Instance: new(MockRewardBoundContract)},
 		}
 
-		limit, err := sdk.GetRemainingDailyLimit()
+		limit, err := sdk.GetRemainingDailyLimit(context.Background())
 		require.NoError(t, err)
 		expectedLimit := new(big.Int).Mul(big.NewInt(400000), big.NewInt(1e18))
 		assert.Equal(t, expectedLimit, limit)
@@ -537,7 +586,7 @@ func TestGetRemainingDailyLimit(t *testing.T) {
 	t.Run("reward distributor not initialized", func(t *testing.T) {
 		sdk := &BOGOWISDK{}
 
-		limit, err := sdk.GetRemainingDailyLimit()
+		limit, err := sdk.GetRemainingDailyLimit(context.Background())
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "reward distributor not initialized")
 		assert.Nil(t, limit)
@@ -552,7 +601,7 @@ func TestGetReferrer(t *testing.T) {
 			rewardDistributor: &Contract{Instance: new(MockRewardBoundContract)},
 		}
 
-		referrer, err := sdk.GetReferrer(wallet)
+		referrer, err := sdk.GetReferrer(context.Background(), wallet)
 		require.NoError(t, err)
 		assert.Equal(t, common.Address{}, referrer)
 	})
@@ -560,7 +609,7 @@ func TestGetReferrer(t *testing.T) {
 	t.Run("reward distributor not initialized", func(t *testing.T) {
 		sdk := &BOGOWISDK{}
 
-		referrer, err := sdk.GetReferrer(wallet)
+		referrer, err := sdk.GetReferrer(context.Background(), wallet)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "reward distributor not initialized")
 		assert.Equal(t, common.Address{}, referrer)
@@ -596,14 +645,14 @@ func TestGetTransactOpts(t *testing.T) {
 			mockClient := new(MockRewardEthClient)
 
 			sdk := &BOGOWISDK{
-				client:     mockClient,
-				chainID:    big.NewInt(1),
-				privateKey: func() *ecdsa.PrivateKey { key, _ := crypto.GenerateKey(); return key }(),
+				client:  mockClient,
+				chainID: big.NewInt(1),
+				signer:  func() signer.Signer { key, _ := crypto.GenerateKey(); return signer.NewKeySigner(key) }(),
 			}
 
 			tt.setupMocks(mockClient)
 
-			opts, err := sdk.getTransactOpts()
+			opts, err := sdk.getTransactOpts(context.Background())
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -616,10 +665,37 @@ func TestGetTransactOpts(t *testing.T) {
 				expectedGasPrice := new(big.Int).Mul(big.NewInt(20000000000), big.NewInt(120))
 				expectedGasPrice = new(big.Int).Div(expectedGasPrice, big.NewInt(100))
 				assert.Equal(t, expectedGasPrice, opts.GasPrice)
-				assert.Equal(t, uint64(300000), opts.GasLimit)
+				// The gas limit is estimated per transaction in transact
+				assert.Zero(t, opts.GasLimit)
 			}
 
 			mockClient.AssertExpectations(t)
 		})
 	}
 }
+
+func TestGetTransactOptsWalletPool(t *testing.T) {
+	signers := make([]signer.Signer, 2)
+	for i := range signers {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		signers[i] = signer.NewKeySigner(key)
+	}
+	pool, err := wallet.NewPool(big.NewInt(1), signers, wallet.Config{})
+	require.NoError(t, err)
+
+	mockClient := new(MockRewardEthClient)
+	mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(20000000000), nil)
+	sdk := &BOGOWISDK{client: mockClient, chainID: big.NewInt(1)}
+	sdk.SetWalletPool(pool)
+
+	// Claims alternate between the hot wallets
+	for round := 0; round < 2; round++ {
+		for _, s := range signers {
+			opts, err := sdk.getTransactOpts(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, s.Address(), opts.From)
+			assert.NotNil(t, opts.Signer)
+		}
+	}
+}
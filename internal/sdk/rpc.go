@@ -0,0 +1,97 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"bogowi-blockchain-go/internal/config"
+	"bogowi-blockchain-go/internal/sdk/failover"
+)
+
+// RPCConfig builds the failover settings of a network from its configuration
+func RPCConfig(networkConfig *config.NetworkConfig) (failover.Config, error) {
+	cfg := failover.Config{URLs: networkConfig.RPCEndpoints()}
+
+	if networkConfig.RPCRetryAttempts != "" {
+		attempts, err := strconv.Atoi(networkConfig.RPCRetryAttempts)
+		if err != nil || attempts < 0 {
+			return cfg, fmt.Errorf("invalid RPC retry attempts %q", networkConfig.RPCRetryAttempts)
+		}
+		cfg.RetryAttempts = attempts
+		if attempts == 0 {
+			cfg.RetryAttempts = -1
+		}
+	}
+
+	if networkConfig.RPCRequestTimeout != "" {
+		timeout, err := time.ParseDuration(networkConfig.RPCRequestTimeout)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid RPC request timeout %q: %w", networkConfig.RPCRequestTimeout, err)
+		}
+		cfg.RequestTimeout = timeout
+	}
+
+	if networkConfig.RPCMaxBlockLag != "" {
+		lag, err := strconv.ParseUint(networkConfig.RPCMaxBlockLag, 10, 64)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid RPC max block lag %q", networkConfig.RPCMaxBlockLag)
+		}
+		cfg.MaxBlockLag = lag
+	}
+
+	return cfg, nil
+}
+
+// DialRPC connects to the RPC endpoints of a network with failover
+func DialRPC(ctx context.Context, networkConfig *config.NetworkConfig) (*failover.Client, error) {
+	cfg, err := RPCConfig(networkConfig)
+	if err != nil {
+		return nil, err
+	}
+	return failover.Dial(ctx, cfg)
+}
+
+// Timeouts are the per-operation deadlines of a network. A zero timeout
+// leaves the operation bounded only by the caller's context.
+type Timeouts struct {
+	// Read bounds a balance lookup, contract call or fee query
+	Read time.Duration
+	// Write bounds a transaction from pricing to broadcast
+	Write time.Duration
+	// TxWait bounds waiting for a transaction to be mined
+	TxWait time.Duration
+}
+
+// ParseTimeouts builds the operation deadlines of a network from its configuration
+func ParseTimeouts(networkConfig *config.NetworkConfig) (Timeouts, error) {
+	var timeouts Timeouts
+	for _, field := range []struct {
+		name  string
+		value string
+		dst   *time.Duration
+	}{
+		{"read timeout", networkConfig.ReadTimeout, &timeouts.Read},
+		{"write timeout", networkConfig.WriteTimeout, &timeouts.Write},
+		{"transaction wait timeout", networkConfig.TxWaitTimeout, &timeouts.TxWait},
+	} {
+		if field.value == "" {
+			continue
+		}
+		timeout, err := time.ParseDuration(field.value)
+		if err != nil || timeout < 0 {
+			return timeouts, fmt.Errorf("invalid %s %q", field.name, field.value)
+		}
+		*field.dst = timeout
+	}
+	return timeouts, nil
+}
+
+// WithTimeout bounds ctx by timeout; a zero timeout keeps ctx's own deadline
+func WithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
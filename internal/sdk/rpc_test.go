@@ -0,0 +1,118 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"bogowi-blockchain-go/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPCConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		network config.NetworkConfig
+		want    []string
+		retries int
+		timeout time.Duration
+		lag     uint64
+		errMsg  string
+	}{
+		{
+			name: "primary with fallbacks",
+			network: config.NetworkConfig{
+				RPCUrl:            "https://a.example.com",
+				RPCFallbackURLs:   []string{"https://b.example.com", "https://a.example.com", "https://c.example.com"},
+				RPCRetryAttempts:  "2",
+				RPCRequestTimeout: "5s",
+				RPCMaxBlockLag:    "10",
+			},
+			want:    []string{"https://a.example.com", "https://b.example.com", "https://c.example.com"},
+			retries: 2,
+			timeout: 5 * time.Second,
+			lag:     10,
+		},
+		{
+			name:    "retries disabled",
+			network: config.NetworkConfig{RPCUrl: "https://a.example.com", RPCRetryAttempts: "0"},
+			want:    []string{"https://a.example.com"},
+			retries: -1,
+		},
+		{
+			name:    "invalid retries",
+			network: config.NetworkConfig{RPCUrl: "https://a.example.com", RPCRetryAttempts: "-2"},
+			errMsg:  "invalid RPC retry attempts",
+		},
+		{
+			name:    "invalid timeout",
+			network: config.NetworkConfig{RPCUrl: "https://a.example.com", RPCRequestTimeout: "soon"},
+			errMsg:  "invalid RPC request timeout",
+		},
+		{
+			name:    "invalid block lag",
+			network: config.NetworkConfig{RPCUrl: "https://a.example.com", RPCMaxBlockLag: "far"},
+			errMsg:  "invalid RPC max block lag",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := RPCConfig(&tt.network)
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.URLs)
+			assert.Equal(t, tt.retries, cfg.RetryAttempts)
+			assert.Equal(t, tt.timeout, cfg.RequestTimeout)
+			assert.Equal(t, tt.lag, cfg.MaxBlockLag)
+		})
+	}
+}
+
+func TestParseTimeouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		network config.NetworkConfig
+		want    Timeouts
+		errMsg  string
+	}{
+		{
+			name:    "configured",
+			network: config.NetworkConfig{ReadTimeout: "15s", WriteTimeout: "1m", TxWaitTimeout: "5m"},
+			want:    Timeouts{Read: 15 * time.Second, Write: time.Minute, TxWait: 5 * time.Minute},
+		},
+		{
+			name:    "unset and disabled",
+			network: config.NetworkConfig{WriteTimeout: "0"},
+			want:    Timeouts{},
+		},
+		{
+			name:    "invalid read timeout",
+			network: config.NetworkConfig{ReadTimeout: "soon"},
+			errMsg:  "invalid read timeout",
+		},
+		{
+			name:    "negative wait timeout",
+			network: config.NetworkConfig{TxWaitTimeout: "-1m"},
+			errMsg:  "invalid transaction wait timeout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeouts, err := ParseTimeouts(&tt.network)
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, timeouts)
+		})
+	}
+}
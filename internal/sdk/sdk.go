@@ -2,29 +2,54 @@ package sdk
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
+	"time"
 
 	"bogowi-blockchain-go/internal/config"
+	"bogowi-blockchain-go/internal/sdk/gas"
+	"bogowi-blockchain-go/internal/sdk/nonce"
+	"bogowi-blockchain-go/internal/sdk/signer"
+	"bogowi-blockchain-go/internal/sdk/txtrack"
+	"bogowi-blockchain-go/internal/sdk/wallet"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/patrickmn/go-cache"
 )
 
 // BOGOWISDK represents the main SDK for interacting with BOGOWI blockchain contracts
 type BOGOWISDK struct {
 	client            EthClient
+	rpc               BatchCaller
 	auth              *bind.TransactOpts
 	chainID           *big.Int
 	contracts         *ContractInstances
 	config            *config.Config
-	privateKey        *ecdsa.PrivateKey
+	signer            signer.Signer
 	rewardDistributor *Contract
+	nativeLimits      *nativeTransferLimiter
+	fees              *gas.Strategy
+	tracker           *txtrack.Tracker
+
+	// Per-operation deadlines applied on top of the caller's context
+	timeouts Timeouts
+
+	// Hot wallets reward claims are dispatched across, nil to sign every
+	// transaction with the SDK signer
+	wallets *wallet.Pool
+
+	// Nonce manager shared with every client using the same signer
+	nonces     *nonce.Manager
+	noncesOnce sync.Once
+
+	// Read cache for generic token lookups, created lazily
+	cache     *cache.Cache
+	cacheOnce sync.Once
 }
 
 // ContractInstances holds all initialized contract instances
@@ -34,6 +59,9 @@ type ContractInstances struct {
 	BOGOToken         *Contract
 	RewardDistributor *Contract
 
+	// Utility contracts
+	Multicall3 *Contract
+
 	// Legacy contracts (to be removed after migration)
 	ConservationNFT  *Contract
 	CommercialNFT    *Contract
@@ -62,33 +90,62 @@ type DAOInfo struct {
 
 // NewBOGOWISDK creates a new BOGOWI SDK instance for a specific network
 func NewBOGOWISDK(networkConfig *config.NetworkConfig, privateKey string) (*BOGOWISDK, error) {
-	// Connect to Ethereum client
-	client, err := ethclient.Dial(networkConfig.RPCUrl)
+	keySigner, err := signer.FromHex(privateKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Ethereum client: %w", err)
+		return nil, err
 	}
+	return NewBOGOWISDKWithSigner(networkConfig, keySigner)
+}
 
-	// Parse private key
-	privKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
+// NewBOGOWISDKWithSigner creates a new BOGOWI SDK instance that signs
+// through txSigner, e.g. a keystore or remote signer
+func NewBOGOWISDKWithSigner(networkConfig *config.NetworkConfig, txSigner signer.Signer) (*BOGOWISDK, error) {
+	// Connect to the network's RPC endpoints with failover
+	client, err := DialRPC(context.Background(), networkConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse private key: %w", err)
+		return nil, fmt.Errorf("failed to connect to Ethereum client: %w", err)
 	}
 
 	// Get chain ID
 	chainID := big.NewInt(networkConfig.ChainID)
 
 	// Create transactor
-	auth, err := bind.NewKeyedTransactorWithChainID(privKey, chainID)
+	auth := signer.TransactOpts(txSigner, chainID)
+
+	// Caps for native CAM transfers
+	nativeLimits, err := newNativeTransferLimiter(networkConfig.NativeTransferMax, networkConfig.NativeTransferDailyMax)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure native transfers: %w", err)
+	}
+
+	// Transaction fee pricing
+	feeConfig, err := gas.ParseConfig(networkConfig.GasStrategy, networkConfig.GasMultiplier,
+		networkConfig.MaxGasPrice, networkConfig.FixedGasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure gas pricing: %w", err)
+	}
+	fees, err := gas.NewStrategy(feeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure gas pricing: %w", err)
+	}
+
+	// Operation deadlines
+	timeouts, err := ParseTimeouts(networkConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create transactor: %w", err)
+		return nil, fmt.Errorf("failed to configure timeouts: %w", err)
 	}
 
 	sdk := &BOGOWISDK{
-		client:     client,
-		auth:       auth,
-		chainID:    chainID,
-		privateKey: privKey,
-		contracts:  &ContractInstances{},
+		client:       client,
+		rpc:          client,
+		auth:         auth,
+		chainID:      chainID,
+		signer:       txSigner,
+		contracts:    &ContractInstances{},
+		nativeLimits: nativeLimits,
+		fees:         fees,
+		timeouts:     timeouts,
+		nonces:       nonce.For(chainID, auth.From),
 	}
 
 	// Initialize contracts with network-specific addresses
@@ -129,6 +186,14 @@ func (s *BOGOWISDK) initializeContractsWithConfig(networkConfig *config.NetworkC
 		s.rewardDistributor = contract
 	}
 
+	if contracts.Multicall3 != "" {
+		contract, err := s.initializeContract(contracts.Multicall3, Multicall3ABI)
+		if err != nil {
+			return fmt.Errorf("failed to initialize Multicall3: %w", err)
+		}
+		s.contracts.Multicall3 = contract
+	}
+
 	return nil
 }
 
@@ -145,9 +210,9 @@ func (s *BOGOWISDK) initializeContract(address, abiJSON string) (*Contract, erro
 		return nil, fmt.Errorf("failed to parse ABI: %w", err)
 	}
 
-	// Check if client is a real ethclient.Client to create BoundContract
-	if ethClient, ok := s.client.(*ethclient.Client); ok {
-		instance := bind.NewBoundContract(contractAddress, contractABI, ethClient, ethClient, ethClient)
+	// The client must be a full contract backend to create a BoundContract
+	if backend, ok := s.client.(bind.ContractBackend); ok {
+		instance := bind.NewBoundContract(contractAddress, contractABI, backend, backend, backend)
 		return &Contract{
 			Address:  contractAddress,
 			ABI:      contractABI,
@@ -155,21 +220,24 @@ func (s *BOGOWISDK) initializeContract(address, abiJSON string) (*Contract, erro
 		}, nil
 	}
 
-	return nil, fmt.Errorf("client is not an ethclient.Client")
+	return nil, fmt.Errorf("client is not a contract backend")
 }
 
 // GetTokenBalance gets the BOGO token balance for an address
-func (s *BOGOWISDK) GetTokenBalance(address string) (*TokenBalance, error) {
+func (s *BOGOWISDK) GetTokenBalance(ctx context.Context, address string) (*TokenBalance, error) {
 	// Use BOGOToken if available
 	if s.contracts.BOGOToken == nil {
 		return nil, fmt.Errorf("BOGO token contract not initialized")
 	}
 
+	ctx, cancel := s.readContext(ctx)
+	defer cancel()
+
 	addr := common.HexToAddress(address)
 	var balance *big.Int
 
 	err := s.contracts.BOGOToken.Instance.Call(
-		&bind.CallOpts{Context: context.Background()},
+		&bind.CallOpts{Context: ctx},
 		&[]interface{}{&balance},
 		"balanceOf",
 		addr,
@@ -178,38 +246,46 @@ func (s *BOGOWISDK) GetTokenBalance(address string) (*TokenBalance, error) {
 		return nil, fmt.Errorf("failed to get token balance: %w", err)
 	}
 
-	// Handle nil balance
-	if balance == nil {
-		balance = big.NewInt(0)
-	}
-
-	// Convert wei to ether (18 decimals)
-	decimals := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
-	balanceEther := new(big.Float).Quo(
-		new(big.Float).SetInt(balance),
-		new(big.Float).SetInt(decimals),
-	)
-
 	return &TokenBalance{
 		Address: address,
-		Balance: balanceEther.String(),
+		Balance: formatEther(balance),
 	}, nil
 }
 
+// formatEther converts a wei amount (18 decimals) to a decimal string
+func formatEther(wei *big.Int) string {
+	return formatUnits(wei, 18)
+}
+
 // GetGasPrice gets the current gas price
-func (s *BOGOWISDK) GetGasPrice() (string, error) {
-	gasPrice, err := s.client.SuggestGasPrice(context.Background())
+func (s *BOGOWISDK) GetGasPrice(ctx context.Context) (string, error) {
+	ctx, cancel := s.readContext(ctx)
+	defer cancel()
+
+	gasPrice, err := s.client.SuggestGasPrice(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	// Convert to Gwei
-	gwei := new(big.Float).Quo(new(big.Float).SetInt(gasPrice), new(big.Float).SetInt(big.NewInt(1000000000)))
-	return fmt.Sprintf("%.2f gwei", gwei), nil
+	return formatGwei(gasPrice), nil
 }
 
 // TransferBOGOTokens transfers BOGO tokens to a recipient
-func (s *BOGOWISDK) TransferBOGOTokens(to string, amount string) (string, error) {
+func (s *BOGOWISDK) TransferBOGOTokens(ctx context.Context, to string, amount string) (string, error) {
+	ctx, cancel := s.writeContext(ctx)
+	defer cancel()
+	return s.transferBOGOTokens(ctx, to, amount)
+}
+
+// EstimateTransferBOGOTokens estimates a BOGO transfer without sending it
+func (s *BOGOWISDK) EstimateTransferBOGOTokens(ctx context.Context, to string, amount string) (*gas.Estimate, error) {
+	return s.dryRun(ctx, func(ctx context.Context) error {
+		_, err := s.transferBOGOTokens(ctx, to, amount)
+		return err
+	})
+}
+
+func (s *BOGOWISDK) transferBOGOTokens(ctx context.Context, to string, amount string) (string, error) {
 	if !common.IsHexAddress(to) {
 		return "", fmt.Errorf("invalid recipient address")
 	}
@@ -233,25 +309,25 @@ func (s *BOGOWISDK) TransferBOGOTokens(to string, amount string) (string, error)
 	// Prepare transaction
 	toAddress := common.HexToAddress(to)
 
-	// Get current nonce
-	nonce, err := s.client.PendingNonceAt(context.Background(), s.auth.From)
-	if err != nil {
-		return "", fmt.Errorf("failed to get nonce: %w", err)
-	}
-
-	// Get gas price
-	gasPrice, err := s.client.SuggestGasPrice(context.Background())
+	// Price the transaction
+	fees, err := s.suggestFees(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to get gas price: %w", err)
 	}
 
-	// Set transaction options
-	s.auth.Nonce = big.NewInt(int64(nonce))
-	s.auth.GasPrice = gasPrice
-	s.auth.GasLimit = uint64(100000) // Standard gas limit for ERC20 transfer
+	// Per-call copy of the transaction options
+	opts := &bind.TransactOpts{
+		From:    s.auth.From,
+		Signer:  s.auth.Signer,
+		Context: ctx,
+	}
+	fees.Apply(opts)
 
 	// Execute transfer
-	tx, err := s.contracts.BOGOToken.Instance.Transact(s.auth, "transfer", toAddress, amountWei)
+	meta := txtrack.Meta{Purpose: "bogo_transfer", Ref: toAddress.Hex()}
+	tx, err := s.transact(opts, meta, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return s.contracts.BOGOToken.Instance.Transact(opts, "transfer", toAddress, amountWei)
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to execute transfer: %w", err)
 	}
@@ -259,19 +335,135 @@ func (s *BOGOWISDK) TransferBOGOTokens(to string, amount string) (string, error)
 	return tx.Hash().Hex(), nil
 }
 
-// GetPublicKey returns the public key associated with the private key
-func (s *BOGOWISDK) GetPublicKey() (string, error) {
-	if s.privateKey == nil {
-		return "", fmt.Errorf("private key not initialized")
+// readContext bounds a read by the network's read timeout
+func (s *BOGOWISDK) readContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return WithTimeout(ctx, s.timeouts.Read)
+}
+
+// writeContext bounds a transaction by the network's write timeout
+func (s *BOGOWISDK) writeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return WithTimeout(ctx, s.timeouts.Write)
+}
+
+// SetTxTracker makes the SDK record every transaction it sends in the
+// tracker's outbox before broadcasting it, and lets the tracker sign fee
+// replacements for the SDK wallet. The daily native transfer cap then counts
+// the transfers in the outbox, so it holds across restarts.
+func (s *BOGOWISDK) SetTxTracker(tracker *txtrack.Tracker) {
+	s.tracker = tracker
+	if s.nativeLimits != nil {
+		s.nativeLimits.setLoader(func(since time.Time) ([]*NativeTransfer, error) {
+			return s.loadNativeTransfers(tracker, since)
+		})
+	}
+	tracker.RegisterSigner(s.auth.From, s.auth.Signer)
+	if s.wallets != nil {
+		for _, w := range s.wallets.Wallets() {
+			tracker.RegisterSigner(w.From, w.Signer)
+		}
 	}
+}
 
-	publicKey := s.privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return "", fmt.Errorf("error casting public key to ECDSA")
+// SetWalletPool dispatches reward claims across the hot wallets of pool,
+// which must all hold the distributor's backend role. Token and native
+// transfers spend the SDK wallet's balance and keep using the SDK signer.
+// Set the pool before the transaction tracker.
+func (s *BOGOWISDK) SetWalletPool(pool *wallet.Pool) {
+	s.wallets = pool
+}
+
+// transact signs a transaction using the next nonce of opts.From, the SDK
+// signer or a pool wallet, and broadcasts it. opts must be a per-call copy;
+// its gas limit is estimated from the calldata and its Nonce is set by the
+// nonce manager. meta describes the transaction for the outbox. Under a
+// dry-run context the estimate is recorded and gas.ErrDryRun returned instead.
+func (s *BOGOWISDK) transact(opts *bind.TransactOpts, meta txtrack.Meta, send func(opts *bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	est, err := gas.EstimateCall(ctx, s.client, opts, s.feeStrategy().Multiplier(), send)
+	if err != nil {
+		s.recordWallet(opts.From, err)
+		return nil, err
+	}
+	if gas.DryRun(ctx, est) {
+		return nil, gas.ErrDryRun
+	}
+	opts.GasLimit = est.GasLimit
+
+	var tx *types.Transaction
+	err = s.noncesFor(opts.From).Send(ctx, s.client, func(n uint64) error {
+		opts.Nonce = new(big.Int).SetUint64(n)
+		opts.NoSend = true
+
+		signed, err := send(opts)
+		if err != nil {
+			return err
+		}
+		if err := s.broadcast(ctx, signed, meta); err != nil {
+			return err
+		}
+		tx = signed
+		return nil
+	})
+	s.recordWallet(opts.From, err)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// recordWallet counts a transaction of a pool wallet in its health stats
+func (s *BOGOWISDK) recordWallet(from common.Address, err error) {
+	if s.wallets != nil {
+		s.wallets.Record(from, err)
+	}
+}
+
+// broadcast sends a signed transaction, through the outbox when one is set
+func (s *BOGOWISDK) broadcast(ctx context.Context, tx *types.Transaction, meta txtrack.Meta) error {
+	if s.tracker != nil {
+		return s.tracker.Submit(ctx, tx, meta)
+	}
+	return s.client.SendTransaction(ctx, tx)
+}
+
+// nonceManager returns the signer's shared nonce manager, resolving it on
+// first use when the SDK was not built through NewBOGOWISDK
+func (s *BOGOWISDK) nonceManager() *nonce.Manager {
+	s.noncesOnce.Do(func() {
+		if s.nonces != nil {
+			return
+		}
+		var from common.Address
+		if s.auth != nil {
+			from = s.auth.From
+		} else if s.signer != nil {
+			from = s.signer.Address()
+		}
+		s.nonces = nonce.For(s.chainID, from)
+	})
+	return s.nonces
+}
+
+// noncesFor returns the nonce manager of from, which is the SDK signer or a
+// hot wallet of the pool
+func (s *BOGOWISDK) noncesFor(from common.Address) *nonce.Manager {
+	if s.wallets == nil || (s.auth != nil && from == s.auth.From) {
+		return s.nonceManager()
+	}
+	return nonce.For(s.chainID, from)
+}
+
+// GetPublicKey returns the address of the SDK signer
+func (s *BOGOWISDK) GetPublicKey() (string, error) {
+	if s.signer == nil {
+		return "", fmt.Errorf("signer not initialized")
 	}
 
-	return crypto.PubkeyToAddress(*publicKeyECDSA).Hex(), nil
+	return s.signer.Address().Hex(), nil
 }
 
 // Close closes the SDK and cleans up resources
@@ -2,13 +2,14 @@ package sdk
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"errors"
 	"math/big"
 	"strings"
 	"testing"
 
 	"bogowi-blockchain-go/internal/config"
+	"bogowi-blockchain-go/internal/sdk/nonce"
+	"bogowi-blockchain-go/internal/sdk/signer"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -40,6 +41,22 @@ func (m *MockEthClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
 	return args.Get(0).(*big.Int), args.Error(1)
 }
 
+func (m *MockEthClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*big.Int), args.Error(1)
+}
+
+func (m *MockEthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	args := m.Called(ctx, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Header), args.Error(1)
+}
+
 func (m *MockEthClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
 	args := m.Called(ctx, account)
 	return args.Get(0).(uint64), args.Error(1)
@@ -58,6 +75,19 @@ func (m *MockEthClient) CallContract(ctx context.Context, call ethereum.CallMsg,
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *MockEthClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	args := m.Called(ctx, account, blockNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*big.Int), args.Error(1)
+}
+
+func (m *MockEthClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	args := m.Called(ctx, msg)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
 func (m *MockEthClient) Close() {
 	m.Called()
 }
@@ -179,7 +209,7 @@ func TestGetTokenBalance(t *testing.T) {
 					Return(tt.mockBalance, nil)
 			}
 
-			balance, err := sdk.GetTokenBalance(tt.address)
+			balance, err := sdk.GetTokenBalance(context.Background(), tt.address)
 
 			if tt.wantError {
 				assert.Error(t, err)
@@ -201,7 +231,7 @@ func TestGetTokenBalance_NoContract(t *testing.T) {
 		contracts: &ContractInstances{},
 	}
 
-	balance, err := sdk.GetTokenBalance("0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2")
+	balance, err := sdk.GetTokenBalance(context.Background(), "0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "BOGO token contract not initialized")
 	assert.Nil(t, balance)
@@ -255,7 +285,7 @@ func TestGetGasPrice(t *testing.T) {
 			mockClient.On("SuggestGasPrice", mock.Anything).
 				Return(tt.mockGasPrice, tt.mockError).Once()
 
-			price, err := sdk.GetGasPrice()
+			price, err := sdk.GetGasPrice(context.Background())
 
 			if tt.wantError {
 				assert.Error(t, err)
@@ -335,26 +365,34 @@ func TestTransferBOGOTokens(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset mocks
+			// Reset mocks and the signer's nonce state
 			mockClient.ExpectedCalls = nil
 			mockContract.ExpectedCalls = nil
+			sdk.nonces = nonce.NewManager(sdk.auth.From)
 
 			if tt.to != "invalid-address" && tt.amount != "abc" {
-				mockClient.On("PendingNonceAt", mock.Anything, sdk.auth.From).
-					Return(tt.mockNonce, nil).Once()
 				mockClient.On("SuggestGasPrice", mock.Anything).
 					Return(tt.mockGasPrice, nil).Once()
+				mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).
+					Return(&types.Header{GasLimit: 8000000}, nil).Once()
 
 				if tt.mockError != nil {
+					// The call fails while it is estimated, before a nonce is taken
 					mockContract.On("Transact", mock.Anything, "transfer", mock.Anything, mock.Anything).
 						Return(nil, tt.mockError).Once()
 				} else {
+					mockClient.On("EstimateGas", mock.Anything, mock.Anything).
+						Return(uint64(52000), nil).Once()
+					mockClient.On("PendingNonceAt", mock.Anything, sdk.auth.From).
+						Return(tt.mockNonce, nil).Once()
+					// Once to build the calldata for the estimate, once to sign
 					mockContract.On("Transact", mock.Anything, "transfer", mock.Anything, mock.Anything).
-						Return(tt.mockTx, nil).Once()
+						Return(tt.mockTx, nil).Twice()
+					mockClient.On("SendTransaction", mock.Anything, tt.mockTx).Return(nil).Once()
 				}
 			}
 
-			txHash, err := sdk.TransferBOGOTokens(tt.to, tt.amount)
+			txHash, err := sdk.TransferBOGOTokens(context.Background(), tt.to, tt.amount)
 
 			if tt.wantError {
 				assert.Error(t, err)
@@ -376,7 +414,7 @@ func TestTransferBOGOTokens_NoContract(t *testing.T) {
 		contracts: &ContractInstances{},
 	}
 
-	txHash, err := sdk.TransferBOGOTokens("0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2", "100")
+	txHash, err := sdk.TransferBOGOTokens(context.Background(), "0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2", "100")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "BOGO token contract not initialized")
 	assert.Empty(t, txHash)
@@ -407,14 +445,10 @@ func TestGetPublicKey(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var privKey *ecdsa.PrivateKey
+			sdk := &BOGOWISDK{}
 			if !tt.wantError {
 				key, _ := crypto.HexToECDSA(strings.TrimPrefix(tt.privateKey, "0x"))
-				privKey = key
-			}
-
-			sdk := &BOGOWISDK{
-				privateKey: privKey,
+				sdk.signer = signer.NewKeySigner(key)
 			}
 
 			pubKey, err := sdk.GetPublicKey()
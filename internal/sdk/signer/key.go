@@ -0,0 +1,81 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// KeySigner signs with a private key held in memory
+type KeySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewKeySigner returns a signer for key
+func NewKeySigner(key *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// FromHex returns a signer for a hex private key, with or without 0x prefix
+func FromHex(privateKey string) (*KeySigner, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return NewKeySigner(key), nil
+}
+
+// NewKeystoreSigner decrypts a go-ethereum keystore file (Web3 Secret
+// Storage) with password. The key is decrypted once; the file stays the only
+// copy at rest.
+func NewKeystoreSigner(path, password string) (*KeySigner, error) {
+	if path == "" {
+		return nil, fmt.Errorf("keystore file is required for the %q signer", TypeKeystore)
+	}
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore %s: %w", path, err)
+	}
+	return NewKeySigner(key.PrivateKey), nil
+}
+
+// Address implements Signer
+func (s *KeySigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx implements Signer
+func (s *KeySigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+// SignTypedData implements Signer
+func (s *KeySigner) SignTypedData(ctx context.Context, data apitypes.TypedData) ([]byte, error) {
+	digest, _, err := apitypes.TypedDataAndHash(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash typed data: %w", err)
+	}
+
+	signature, err := crypto.Sign(digest, s.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+
+	// Adjust v value for Ethereum (27 or 28)
+	signature[64] += 27
+	return signature, nil
+}
@@ -0,0 +1,176 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// DefaultRemoteTimeout bounds a remote signing request. Clef may wait for a
+// human to approve the request, so it is generous.
+const DefaultRemoteTimeout = 2 * time.Minute
+
+// RemoteSigner signs through an external signer speaking Clef's external
+// API (account_list, account_signTransaction, account_signTypedData) over
+// HTTP, WebSocket or IPC. The key never enters this process.
+type RemoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+	timeout time.Duration
+}
+
+// signTxResult is the account_signTransaction response
+type signTxResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// NewRemoteSigner connects to the signer at url. When address is zero the
+// remote signer must manage exactly one account, which is used.
+func NewRemoteSigner(ctx context.Context, url string, address common.Address, timeout time.Duration) (*RemoteSigner, error) {
+	if url == "" {
+		return nil, fmt.Errorf("remote signer URL is required for the %q signer", TypeRemote)
+	}
+	if timeout <= 0 {
+		timeout = DefaultRemoteTimeout
+	}
+
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to remote signer: %w", err)
+	}
+
+	if address == (common.Address{}) {
+		var accounts []common.Address
+		if err := client.CallContext(ctx, &accounts, "account_list"); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("failed to list remote signer accounts: %w", err)
+		}
+		if len(accounts) != 1 {
+			client.Close()
+			return nil, fmt.Errorf("remote signer manages %d accounts, set the signer address", len(accounts))
+		}
+		address = accounts[0]
+	}
+
+	return &RemoteSigner{client: client, address: address, timeout: timeout}, nil
+}
+
+// Address implements Signer
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx implements Signer. The signed transaction is checked against the
+// request, so a signer that alters it (e.g. a Clef rule or operator) is
+// rejected rather than broadcast.
+func (s *RemoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	args, err := sendTxArgs(s.address, tx, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var result signTxResult
+	if err := s.client.CallContext(ctx, &result, "account_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(result.Raw); err != nil {
+		return nil, fmt.Errorf("remote signer returned an invalid transaction: %w", err)
+	}
+
+	txSigner := types.LatestSignerForChainID(chainID)
+	if txSigner.Hash(signed) != txSigner.Hash(tx) {
+		return nil, fmt.Errorf("remote signer changed the transaction")
+	}
+	if from, err := types.Sender(txSigner, signed); err != nil || from != s.address {
+		return nil, fmt.Errorf("remote signer signed with a different account")
+	}
+	return signed, nil
+}
+
+// SignTypedData implements Signer
+func (s *RemoteSigner) SignTypedData(ctx context.Context, data apitypes.TypedData) ([]byte, error) {
+	digest, _, err := apitypes.TypedDataAndHash(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash typed data: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var signature hexutil.Bytes
+	if err := s.client.CallContext(ctx, &signature, "account_signTypedData", common.NewMixedcaseAddress(s.address), data); err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("remote signer returned a %d-byte signature", len(signature))
+	}
+	if signature[64] < 27 {
+		signature[64] += 27
+	}
+
+	// Recover the signer to catch a signature over different data
+	sig := append([]byte(nil), signature...)
+	sig[64] -= 27
+	pubKey, err := crypto.SigToPub(digest, sig)
+	if err != nil || crypto.PubkeyToAddress(*pubKey) != s.address {
+		return nil, fmt.Errorf("remote signer returned a signature from a different account")
+	}
+	return signature, nil
+}
+
+// Close disconnects from the remote signer
+func (s *RemoteSigner) Close() {
+	s.client.Close()
+}
+
+// sendTxArgs converts tx to the account_signTransaction request
+func sendTxArgs(from common.Address, tx *types.Transaction, chainID *big.Int) (*apitypes.SendTxArgs, error) {
+	args := &apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(from),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if to := tx.To(); to != nil {
+		mixed := common.NewMixedcaseAddress(*to)
+		args.To = &mixed
+	}
+	if len(tx.Data()) > 0 {
+		// Older Clef versions only read "data", newer ones prefer "input"
+		data := hexutil.Bytes(tx.Data())
+		args.Data = &data
+		args.Input = &data
+	}
+
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.AccessListTxType:
+		accessList := tx.AccessList()
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+		args.AccessList = &accessList
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		if accessList := tx.AccessList(); len(accessList) > 0 {
+			args.AccessList = &accessList
+		}
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %d", tx.Type())
+	}
+	return args, nil
+}
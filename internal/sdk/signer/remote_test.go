@@ -0,0 +1,188 @@
+package signer
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubModeEnv makes the test binary run as a Clef stub instead of tests
+const stubModeEnv = "BOGOWI_SIGNER_STUB"
+
+// stubClef implements the account namespace of Clef's external API
+type stubClef struct {
+	key  *ecdsa.PrivateKey
+	mode string
+}
+
+type stubSignTxResult struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *types.Transaction `json:"tx"`
+}
+
+func (s *stubClef) List() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(s.key.PublicKey)}
+}
+
+func (s *stubClef) SignTransaction(args apitypes.SendTxArgs, methodSelector *string) (*stubSignTxResult, error) {
+	if s.mode == "reject" {
+		return nil, fmt.Errorf("request denied")
+	}
+	if args.Data != nil && args.Input != nil && !strings.EqualFold(args.Data.String(), args.Input.String()) {
+		return nil, fmt.Errorf("data and input differ")
+	}
+	if s.mode == "tamper" {
+		args.Gas++
+	}
+	tx, err := args.ToTransaction()
+	if err != nil {
+		return nil, err
+	}
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID((*big.Int)(args.ChainID)), s.key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &stubSignTxResult{Raw: raw, Tx: signed}, nil
+}
+
+func (s *stubClef) SignTypedData(addr common.MixedcaseAddress, data apitypes.TypedData) (hexutil.Bytes, error) {
+	if s.mode == "reject" {
+		return nil, fmt.Errorf("request denied")
+	}
+	digest, _, err := apitypes.TypedDataAndHash(data)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := crypto.Sign(digest, s.key)
+	if err != nil {
+		return nil, err
+	}
+	signature[64] += 27 // Clef returns V as 27/28
+	return signature, nil
+}
+
+// TestSignerStubProcess is not a test: started by startStub with
+// stubModeEnv set, it serves a Clef stub and prints its URL
+func TestSignerStubProcess(t *testing.T) {
+	mode := os.Getenv(stubModeEnv)
+	if mode == "" {
+		t.Skip("helper process for the remote signer tests")
+	}
+
+	key, _ := crypto.HexToECDSA(testKeyHex)
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", &stubClef{key: key, mode: mode}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("http://%s\n", listener.Addr())
+	_ = http.Serve(listener, server)
+	os.Exit(0)
+}
+
+// startStub runs a Clef stub in a separate process and returns its URL
+func startStub(t *testing.T, mode string) string {
+	t.Helper()
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestSignerStubProcess$")
+	cmd.Env = append(os.Environ(), stubModeEnv+"="+mode)
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	url, err := bufio.NewReader(stdout).ReadString('\n')
+	require.NoError(t, err)
+	return strings.TrimSpace(url)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, _ := crypto.HexToECDSA(testKeyHex)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	ctx := context.Background()
+
+	t.Run("signs through the stub", func(t *testing.T) {
+		url := startStub(t, "sign")
+
+		// The only account is picked up when no address is configured
+		s, err := New(ctx, Config{Type: TypeRemote, RemoteURL: url})
+		require.NoError(t, err)
+		defer s.(*RemoteSigner).Close()
+		assert.Equal(t, address, s.Address())
+		assertSigns(t, s)
+
+		// Legacy transactions keep their gas price
+		to := common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2")
+		legacy := types.NewTx(&types.LegacyTx{Nonce: 1, To: &to, Gas: 21000, GasPrice: big.NewInt(25e9), Value: big.NewInt(1)})
+		signed, err := s.SignTx(ctx, legacy, testChainID)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(25e9), signed.GasPrice())
+		assert.Equal(t, testChainID, signed.ChainId())
+	})
+
+	t.Run("rejects altered transactions", func(t *testing.T) {
+		url := startStub(t, "tamper")
+
+		s, err := NewRemoteSigner(ctx, url, address, time.Second)
+		require.NoError(t, err)
+		defer s.Close()
+
+		_, err = s.SignTx(ctx, testTx(common.Address{}), testChainID)
+		assert.EqualError(t, err, "remote signer changed the transaction")
+	})
+
+	t.Run("signature from another account", func(t *testing.T) {
+		url := startStub(t, "sign")
+
+		other := common.HexToAddress("0x1234567890123456789012345678901234567890")
+		s, err := NewRemoteSigner(ctx, url, other, time.Second)
+		require.NoError(t, err)
+		defer s.Close()
+
+		_, err = s.SignTypedData(ctx, testTypedData())
+		assert.EqualError(t, err, "remote signer returned a signature from a different account")
+	})
+
+	t.Run("denied request", func(t *testing.T) {
+		url := startStub(t, "reject")
+
+		s, err := NewRemoteSigner(ctx, url, address, time.Second)
+		require.NoError(t, err)
+		defer s.Close()
+
+		_, err = s.SignTx(ctx, testTx(common.Address{}), testChainID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "request denied")
+	})
+}
@@ -0,0 +1,97 @@
+// Package signer abstracts where transaction and EIP-712 signatures come
+// from: a key held in memory, an encrypted go-ethereum keystore file, or an
+// external Clef-compatible signer reached over JSON-RPC.
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Signer types accepted by Config.Type
+const (
+	TypeKey      = "key"
+	TypeKeystore = "keystore"
+	TypeRemote   = "remote"
+)
+
+// Signer signs transactions and EIP-712 typed data for a single account
+type Signer interface {
+	// Address returns the account the signer signs for
+	Address() common.Address
+	// SignTx returns tx signed for chainID
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	// SignTypedData returns the 65-byte EIP-712 signature of data, with V
+	// as 27 or 28
+	SignTypedData(ctx context.Context, data apitypes.TypedData) ([]byte, error)
+}
+
+// Config selects and configures a signer
+type Config struct {
+	// Type is "key" (default), "keystore" or "remote"
+	Type string
+
+	// PrivateKey is the hex key for the "key" signer
+	PrivateKey string
+
+	// KeystoreFile and KeystorePassword unlock the "keystore" signer
+	KeystoreFile     string
+	KeystorePassword string
+
+	// RemoteURL is the JSON-RPC endpoint of the "remote" signer, e.g. Clef
+	RemoteURL string
+	// Address selects the remote account; it may be empty when the remote
+	// signer manages exactly one account
+	Address string
+	// Timeout bounds each remote signing request, including any manual
+	// approval. Zero uses DefaultRemoteTimeout.
+	Timeout time.Duration
+}
+
+// New creates the signer described by cfg
+func New(ctx context.Context, cfg Config) (Signer, error) {
+	switch strings.ToLower(cfg.Type) {
+	case "", TypeKey:
+		if cfg.PrivateKey == "" {
+			return nil, fmt.Errorf("private key is required for the %q signer", TypeKey)
+		}
+		return FromHex(cfg.PrivateKey)
+	case TypeKeystore:
+		return NewKeystoreSigner(cfg.KeystoreFile, cfg.KeystorePassword)
+	case TypeRemote:
+		var address common.Address
+		if cfg.Address != "" {
+			if !common.IsHexAddress(cfg.Address) {
+				return nil, fmt.Errorf("invalid signer address %q", cfg.Address)
+			}
+			address = common.HexToAddress(cfg.Address)
+		}
+		return NewRemoteSigner(ctx, cfg.RemoteURL, address, cfg.Timeout)
+	default:
+		return nil, fmt.Errorf("unknown signer type %q (use %q, %q or %q)", cfg.Type, TypeKey, TypeKeystore, TypeRemote)
+	}
+}
+
+// TransactOpts returns transaction options that sign through s for chainID.
+// Signing requests for any other account are refused.
+func TransactOpts(s Signer, chainID *big.Int) *bind.TransactOpts {
+	from := s.Address()
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			return s.SignTx(context.Background(), tx, chainID)
+		},
+		Context: context.Background(),
+	}
+}
@@ -0,0 +1,197 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKeyHex = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+var testChainID = big.NewInt(501)
+
+func testTx(to common.Address) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   testChainID,
+		Nonce:     3,
+		To:        &to,
+		Value:     big.NewInt(1e15),
+		Gas:       52000,
+		GasTipCap: big.NewInt(2e9),
+		GasFeeCap: big.NewInt(50e9),
+		Data:      []byte{0xa9, 0x05, 0x9c, 0xbb},
+	})
+}
+
+func testTypedData() apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+			},
+			"Ping": {
+				{Name: "value", Type: "uint256"},
+			},
+		},
+		PrimaryType: "Ping",
+		Domain:      apitypes.TypedDataDomain{Name: "Test", ChainId: (*math.HexOrDecimal256)(testChainID)},
+		Message:     apitypes.TypedDataMessage{"value": (*math.HexOrDecimal256)(big.NewInt(7))},
+	}
+}
+
+// assertSigns checks that s produces valid transaction and typed data
+// signatures for its address
+func assertSigns(t *testing.T, s Signer) {
+	t.Helper()
+	ctx := context.Background()
+
+	tx := testTx(common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2"))
+	signed, err := s.SignTx(ctx, tx, testChainID)
+	require.NoError(t, err)
+	from, err := types.Sender(types.LatestSignerForChainID(testChainID), signed)
+	require.NoError(t, err)
+	assert.Equal(t, s.Address(), from)
+	assert.Equal(t, tx.Data(), signed.Data())
+
+	data := testTypedData()
+	signature, err := s.SignTypedData(ctx, data)
+	require.NoError(t, err)
+	require.Len(t, signature, 65)
+	assert.Contains(t, []byte{27, 28}, signature[64])
+
+	digest, _, err := apitypes.TypedDataAndHash(data)
+	require.NoError(t, err)
+	sig := append([]byte(nil), signature...)
+	sig[64] -= 27
+	pubKey, err := crypto.SigToPub(digest, sig)
+	require.NoError(t, err)
+	assert.Equal(t, s.Address(), crypto.PubkeyToAddress(*pubKey))
+}
+
+func TestKeySigner(t *testing.T) {
+	s, err := FromHex("0x" + testKeyHex)
+	require.NoError(t, err)
+
+	key, _ := crypto.HexToECDSA(testKeyHex)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), s.Address())
+	assertSigns(t, s)
+
+	_, err = FromHex("invalid")
+	assert.Error(t, err)
+}
+
+func writeKeystore(t *testing.T, password string) (string, common.Address) {
+	t.Helper()
+	key, err := crypto.HexToECDSA(testKeyHex)
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(key, password)
+	require.NoError(t, err)
+	return account.URL.Path, address
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	path, address := writeKeystore(t, "correct horse")
+
+	s, err := NewKeystoreSigner(path, "correct horse")
+	require.NoError(t, err)
+	assert.Equal(t, address, s.Address())
+	assertSigns(t, s)
+
+	_, err = NewKeystoreSigner(path, "wrong")
+	assert.ErrorIs(t, err, keystore.ErrDecrypt)
+
+	_, err = NewKeystoreSigner(filepath.Join(t.TempDir(), "missing.json"), "correct horse")
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	path, address := writeKeystore(t, "secret")
+
+	tests := []struct {
+		name        string
+		cfg         Config
+		address     common.Address
+		errContains string
+	}{
+		{
+			name:    "key by default",
+			cfg:     Config{PrivateKey: testKeyHex},
+			address: address,
+		},
+		{
+			name:    "keystore",
+			cfg:     Config{Type: "keystore", KeystoreFile: path, KeystorePassword: "secret"},
+			address: address,
+		},
+		{
+			name:        "key without private key",
+			cfg:         Config{Type: "key"},
+			errContains: "private key is required",
+		},
+		{
+			name:        "keystore without file",
+			cfg:         Config{Type: "keystore"},
+			errContains: "keystore file is required",
+		},
+		{
+			name:        "remote without URL",
+			cfg:         Config{Type: "remote"},
+			errContains: "remote signer URL is required",
+		},
+		{
+			name:        "remote with invalid address",
+			cfg:         Config{Type: "remote", RemoteURL: "http://127.0.0.1:8550", Address: "0x123"},
+			errContains: "invalid signer address",
+		},
+		{
+			name:        "unknown type",
+			cfg:         Config{Type: "hsm"},
+			errContains: "unknown signer type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(context.Background(), tt.cfg)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.address, s.Address())
+		})
+	}
+}
+
+func TestTransactOpts(t *testing.T) {
+	s, err := FromHex(testKeyHex)
+	require.NoError(t, err)
+
+	opts := TransactOpts(s, testChainID)
+	assert.Equal(t, s.Address(), opts.From)
+
+	signed, err := opts.Signer(s.Address(), testTx(common.Address{}))
+	require.NoError(t, err)
+	from, err := types.Sender(types.LatestSignerForChainID(testChainID), signed)
+	require.NoError(t, err)
+	assert.Equal(t, s.Address(), from)
+
+	_, err = opts.Signer(common.HexToAddress("0x01"), testTx(common.Address{}))
+	assert.ErrorIs(t, err, bind.ErrNotAuthorized)
+}
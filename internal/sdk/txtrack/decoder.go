@@ -0,0 +1,107 @@
+package txtrack
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Event is a decoded receipt log
+type Event struct {
+	Address  string                 `json:"address"`
+	Name     string                 `json:"name"`
+	Args     map[string]interface{} `json:"args,omitempty"`
+	Topics   []string               `json:"topics,omitempty"`
+	Data     string                 `json:"data,omitempty"`
+	LogIndex uint                   `json:"logIndex"`
+}
+
+// Decoder decodes receipt logs using the events of a set of contract ABIs
+type Decoder struct {
+	events map[common.Hash]abi.Event
+}
+
+// NewDecoder creates a decoder from contract ABI JSON definitions
+func NewDecoder(abiJSONs ...string) (*Decoder, error) {
+	d := &Decoder{events: make(map[common.Hash]abi.Event)}
+	for _, abiJSON := range abiJSONs {
+		parsed, err := abi.JSON(strings.NewReader(abiJSON))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ABI: %w", err)
+		}
+		for _, event := range parsed.Events {
+			if _, ok := d.events[event.ID]; !ok {
+				d.events[event.ID] = event
+			}
+		}
+	}
+	return d, nil
+}
+
+// Decode decodes logs. Logs without a known event are returned with their
+// raw topics and data.
+func (d *Decoder) Decode(logs []*types.Log) []Event {
+	events := make([]Event, 0, len(logs))
+	for _, log := range logs {
+		events = append(events, d.decode(log))
+	}
+	return events
+}
+
+func (d *Decoder) decode(log *types.Log) Event {
+	ev := Event{Address: log.Address.Hex(), LogIndex: log.Index}
+
+	if d != nil && len(log.Topics) > 0 {
+		if event, ok := d.events[log.Topics[0]]; ok {
+			args := make(map[string]interface{})
+			err := event.Inputs.NonIndexed().UnpackIntoMap(args, log.Data)
+			if err == nil {
+				var indexed abi.Arguments
+				for _, input := range event.Inputs {
+					if input.Indexed {
+						indexed = append(indexed, input)
+					}
+				}
+				err = abi.ParseTopicsIntoMap(args, indexed, log.Topics[1:])
+			}
+			if err == nil {
+				ev.Name = event.Name
+				ev.Args = make(map[string]interface{}, len(args))
+				for name, value := range args {
+					ev.Args[name] = jsonValue(value)
+				}
+				return ev
+			}
+		}
+	}
+
+	ev.Name = "unknown"
+	for _, topic := range log.Topics {
+		ev.Topics = append(ev.Topics, topic.Hex())
+	}
+	ev.Data = "0x" + hex.EncodeToString(log.Data)
+	return ev
+}
+
+// jsonValue converts decoded ABI values into JSON friendly forms
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case [32]byte:
+		return common.Hash(v).Hex()
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,239 @@
+package txtrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/gas"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Replacement errors
+var (
+	// ErrNotPending is returned when the nonce has no pending transaction left
+	// to replace, e.g. because one of its transactions was already mined
+	ErrNotPending = errors.New("nonce has no pending transaction")
+	// ErrNoSigner is returned when the tracker cannot sign for the sender
+	ErrNoSigner = errors.New("no signer registered for sender")
+	// ErrFeeAboveCap is returned when the bumped fee would exceed the max gas price
+	ErrFeeAboveCap = errors.New("replacement fee exceeds max gas price")
+)
+
+// cancelGasLimit covers a plain value transfer
+const cancelGasLimit = 21000
+
+// ReplacementHandler is called when a replacement transaction is mined.
+// original is the first transaction sent for the nonce, so records created
+// for it can be moved to replacement; a replacement with PurposeCancel means
+// the original operation will not happen.
+type ReplacementHandler func(ctx context.Context, original, replacement *database.TxRecord)
+
+// RegisterSigner lets the tracker sign replacements for an account
+func (t *Tracker) RegisterSigner(from common.Address, sign bind.SignerFn) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.signers[from] = sign
+}
+
+// OnReplacement registers a handler for mined replacements
+func (t *Tracker) OnReplacement(handler ReplacementHandler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onReplace = append(t.onReplace, handler)
+}
+
+// SpeedUp resends the pending transaction of a nonce with bumped fees
+func (t *Tracker) SpeedUp(ctx context.Context, from common.Address, nonce uint64) (*Transaction, error) {
+	return t.replaceNonce(ctx, from, nonce, false)
+}
+
+// Cancel replaces the pending transaction of a nonce with a zero-value
+// transfer to the sender, freeing the nonce for later transactions
+func (t *Tracker) Cancel(ctx context.Context, from common.Address, nonce uint64) (*Transaction, error) {
+	return t.replaceNonce(ctx, from, nonce, true)
+}
+
+func (t *Tracker) replaceNonce(ctx context.Context, from common.Address, nonce uint64, cancel bool) (*Transaction, error) {
+	t.replaceMu.Lock()
+	defer t.replaceMu.Unlock()
+
+	records, err := t.store.ListTransactionsByNonce(t.network, from.Hex(), nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, ErrNotFound
+	}
+
+	var latest *database.TxRecord
+	for _, rec := range records {
+		switch rec.Status {
+		case StatusMined, StatusConfirmed, StatusFailed:
+			return nil, fmt.Errorf("%w: %s is already mined", ErrNotPending, rec.Hash)
+		case StatusPending:
+			latest = rec
+		}
+	}
+	if latest == nil {
+		return nil, ErrNotPending
+	}
+
+	return t.replace(ctx, latest, cancel)
+}
+
+// replace signs and submits a transaction replacing rec. The caller holds
+// replaceMu.
+func (t *Tracker) replace(ctx context.Context, rec *database.TxRecord, cancel bool) (*Transaction, error) {
+	tx, err := decodeRawTx(rec.RawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	from := common.HexToAddress(rec.Signer)
+	t.mu.Lock()
+	sign := t.signers[from]
+	t.mu.Unlock()
+	if sign == nil {
+		return nil, fmt.Errorf("%w %s", ErrNoSigner, rec.Signer)
+	}
+
+	current, err := t.fees.Fees(ctx, t.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to price replacement: %w", err)
+	}
+	fees := gas.TxFees(tx).Bump(t.feeBump, current)
+	if max := t.fees.MaxGasPrice(); max != nil && fees.MaxPrice().Cmp(max) > 0 {
+		return nil, fmt.Errorf("%w: need %s wei, max %s wei", ErrFeeAboveCap, fees.MaxPrice(), max)
+	}
+
+	meta := Meta{Purpose: rec.Purpose, Ref: rec.RequestRef}
+	to, value, gasLimit, data := tx.To(), tx.Value(), tx.Gas(), tx.Data()
+	if cancel {
+		meta.Purpose = PurposeCancel
+		to, value, gasLimit, data = &from, new(big.Int), cancelGasLimit, nil
+	}
+
+	replacement, err := sign(from, fees.NewTx(tx.ChainId(), tx.Nonce(), to, value, gasLimit, data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign replacement: %w", err)
+	}
+
+	original := rec.Replaces
+	if original == "" {
+		original = rec.Hash
+	}
+	if err := t.submit(ctx, replacement, meta, original); err != nil {
+		return nil, fmt.Errorf("failed to broadcast replacement: %w", err)
+	}
+
+	saved, err := t.store.GetTransaction(replacement.Hash().Hex())
+	if err != nil {
+		return nil, err
+	}
+	return t.view(saved), nil
+}
+
+// replaceStuck bumps the fee of transactions pending for longer than the
+// replacement timeout. Only the latest transaction of each nonce is replaced.
+func (t *Tracker) replaceStuck(ctx context.Context, records []*database.TxRecord) {
+	t.replaceMu.Lock()
+	defer t.replaceMu.Unlock()
+
+	type nonceKey struct {
+		signer string
+		nonce  uint64
+	}
+	latest := make(map[nonceKey]*database.TxRecord)
+	var order []nonceKey
+	for _, rec := range records {
+		key := nonceKey{rec.Signer, rec.Nonce}
+		if _, ok := latest[key]; !ok {
+			order = append(order, key)
+		}
+		latest[key] = rec
+	}
+
+	for _, key := range order {
+		rec := latest[key]
+		if rec.Status != StatusPending {
+			continue
+		}
+		submitted, err := parseTimestamp(rec.CreatedAt)
+		if err != nil || time.Since(submitted) < t.replaceAfter {
+			continue
+		}
+
+		replacement, err := t.replace(ctx, rec, false)
+		if err != nil {
+			log.Printf("txtrack: failed to replace stuck %s: %v", rec.Hash, err)
+			continue
+		}
+		log.Printf("txtrack: replaced stuck %s with %s", rec.Hash, replacement.Hash)
+	}
+}
+
+// settleNonce runs once a transaction is mined: the other transactions sent
+// for its nonce can no longer be mined, and when the mined one is a
+// replacement the records of the original follow it
+func (t *Tracker) settleNonce(ctx context.Context, mined *database.TxRecord) error {
+	records, err := t.store.ListTransactionsByNonce(t.network, mined.Signer, mined.Nonce)
+	if err != nil {
+		return err
+	}
+
+	var original *database.TxRecord
+	for _, rec := range records {
+		if rec.Hash == mined.Replaces {
+			original = rec
+		}
+		if rec.Hash == mined.Hash || (rec.Status != StatusPending && rec.Status != StatusDropped) {
+			continue
+		}
+		rec.Status = StatusReplaced
+		rec.Error = "replaced by " + mined.Hash
+		if err := t.store.UpdateTransaction(rec); err != nil {
+			return err
+		}
+	}
+
+	if original == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	handlers := append([]ReplacementHandler(nil), t.onReplace...)
+	t.mu.Unlock()
+	for _, handler := range handlers {
+		handler(ctx, original, mined)
+	}
+	return nil
+}
+
+func decodeRawTx(rawTx string) (*types.Transaction, error) {
+	raw, err := hexutil.Decode(rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid raw transaction: %w", err)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("invalid raw transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// parseTimestamp parses SQLite timestamps as returned by the driver
+func parseTimestamp(value string) (time.Time, error) {
+	if ts, err := time.Parse(time.RFC3339, value); err == nil {
+		return ts, nil
+	}
+	return time.Parse("2006-01-02 15:04:05", value)
+}
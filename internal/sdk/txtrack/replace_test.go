@@ -0,0 +1,168 @@
+package txtrack
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/gas"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSigner returns a key and a transactor signing for it on chain 501
+func newSigner(t *testing.T) (*ecdsa.PrivateKey, *bind.TransactOpts) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(501))
+	require.NoError(t, err)
+	return key, auth
+}
+
+func signedTxWithKey(t *testing.T, key *ecdsa.PrivateKey, nonce uint64) *types.Transaction {
+	to := common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2")
+	tx := types.NewTx(&types.LegacyTx{Nonce: nonce, To: &to, Value: big.NewInt(1), Gas: 90000, GasPrice: big.NewInt(25e9), Data: []byte{0x01}})
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(big.NewInt(501)), key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestTrackerSpeedUpAndCancel(t *testing.T) {
+	store := newTestStore(t)
+	client := newFakeClient()
+	tracker := NewTracker("testnet", store, client, nil, Options{})
+
+	key, auth := newSigner(t)
+	original := signedTxWithKey(t, key, 4)
+	require.NoError(t, tracker.Submit(context.Background(), original, Meta{Purpose: "ticket_mint", Ref: "booking-1"}))
+
+	_, err := tracker.SpeedUp(context.Background(), auth.From, 4)
+	assert.ErrorIs(t, err, ErrNoSigner)
+	tracker.RegisterSigner(auth.From, auth.Signer)
+
+	var replaced []string
+	tracker.OnReplacement(func(ctx context.Context, original, replacement *database.TxRecord) {
+		replaced = append(replaced, original.Hash+">"+replacement.Purpose)
+	})
+
+	// Speed up keeps the call and raises the fee by at least 10%
+	spedUp, err := tracker.SpeedUp(context.Background(), auth.From, 4)
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, spedUp.Status)
+	assert.Equal(t, original.Hash().Hex(), spedUp.Replaces)
+	assert.Equal(t, "ticket_mint", spedUp.Purpose)
+	assert.Equal(t, "booking-1", spedUp.RequestRef)
+
+	rec, err := store.GetTransaction(spedUp.Hash)
+	require.NoError(t, err)
+	tx, err := decodeRawTx(rec.RawTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), tx.Nonce())
+	assert.Equal(t, original.Data(), tx.Data())
+	assert.Equal(t, big.NewInt(27.5e9), tx.GasPrice())
+
+	// Cancel replaces the latest transaction with a self-transfer
+	cancelled, err := tracker.Cancel(context.Background(), auth.From, 4)
+	require.NoError(t, err)
+	assert.Equal(t, PurposeCancel, cancelled.Purpose)
+	assert.Equal(t, original.Hash().Hex(), cancelled.Replaces)
+
+	rec, err = store.GetTransaction(cancelled.Hash)
+	require.NoError(t, err)
+	cancelTx, err := decodeRawTx(rec.RawTx)
+	require.NoError(t, err)
+	assert.Equal(t, auth.From, *cancelTx.To())
+	assert.Zero(t, cancelTx.Value().Sign())
+	assert.Empty(t, cancelTx.Data())
+	assert.Equal(t, uint64(cancelGasLimit), cancelTx.Gas())
+	assert.Equal(t, big.NewInt(30.25e9), cancelTx.GasPrice())
+
+	// The cancellation is mined; the other transactions of the nonce are replaced
+	client.mine(cancelTx, 20, types.ReceiptStatusSuccessful)
+	client.head = 20
+	client.nonce = 5
+	require.NoError(t, tracker.Poll(context.Background()))
+
+	for _, hash := range []string{original.Hash().Hex(), spedUp.Hash} {
+		rec, err := store.GetTransaction(hash)
+		require.NoError(t, err)
+		assert.Equal(t, StatusReplaced, rec.Status)
+		assert.Equal(t, "replaced by "+cancelled.Hash, rec.Error)
+	}
+	assert.Equal(t, []string{original.Hash().Hex() + ">" + PurposeCancel}, replaced)
+
+	_, err = tracker.SpeedUp(context.Background(), auth.From, 4)
+	assert.ErrorIs(t, err, ErrNotPending)
+
+	_, err = tracker.Cancel(context.Background(), auth.From, 9)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTrackerReplacesStuck(t *testing.T) {
+	t.Run("bumps after timeout", func(t *testing.T) {
+		store := newTestStore(t)
+		client := newFakeClient()
+		tracker := NewTracker("testnet", store, client, nil, Options{ReplaceAfter: time.Nanosecond, FeeBumpPercent: 20})
+
+		key, auth := newSigner(t)
+		tracker.RegisterSigner(auth.From, auth.Signer)
+		original := signedTxWithKey(t, key, 0)
+		require.NoError(t, tracker.Submit(context.Background(), original, Meta{Purpose: "ticket_mint"}))
+
+		// The market moved above the bump, so the replacement follows it
+		client.gasPrice = big.NewInt(40e9)
+		require.NoError(t, tracker.Poll(context.Background()))
+
+		records, err := store.ListTransactionsByNonce("testnet", auth.From.Hex(), 0)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, original.Hash().Hex(), records[1].Replaces)
+
+		tx, err := decodeRawTx(records[1].RawTx)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(40e9), tx.GasPrice())
+	})
+
+	t.Run("stops at max gas price", func(t *testing.T) {
+		store := newTestStore(t)
+		client := newFakeClient()
+		fees, err := gas.NewStrategy(gas.Config{MaxGasPrice: big.NewInt(26e9)})
+		require.NoError(t, err)
+		tracker := NewTracker("testnet", store, client, nil, Options{ReplaceAfter: time.Nanosecond, Fees: fees})
+
+		key, auth := newSigner(t)
+		tracker.RegisterSigner(auth.From, auth.Signer)
+		require.NoError(t, tracker.Submit(context.Background(), signedTxWithKey(t, key, 0), Meta{}))
+		require.NoError(t, tracker.Poll(context.Background()))
+
+		records, err := store.ListTransactionsByNonce("testnet", auth.From.Hex(), 0)
+		require.NoError(t, err)
+		assert.Len(t, records, 1)
+
+		_, err = tracker.SpeedUp(context.Background(), auth.From, 0)
+		assert.ErrorIs(t, err, ErrFeeAboveCap)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		store := newTestStore(t)
+		client := newFakeClient()
+		tracker := NewTracker("testnet", store, client, nil, Options{})
+
+		key, auth := newSigner(t)
+		tracker.RegisterSigner(auth.From, auth.Signer)
+		require.NoError(t, tracker.Submit(context.Background(), signedTxWithKey(t, key, 0), Meta{}))
+		require.NoError(t, tracker.Poll(context.Background()))
+
+		records, err := store.ListTransactionsByNonce("testnet", auth.From.Hex(), 0)
+		require.NoError(t, err)
+		assert.Len(t, records, 1)
+	})
+}
@@ -0,0 +1,584 @@
+// Package txtrack keeps an outbox of every transaction the API sends. Each
+// signed transaction is persisted before it is broadcast, and a monitor
+// follows receipts and confirmations until the transaction is final.
+// Transactions still in flight are picked up again after a restart, and
+// transactions stuck with an outdated fee can be replaced.
+package txtrack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/events"
+	"bogowi-blockchain-go/internal/sdk/gas"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Transaction statuses
+const (
+	// StatusPending means the transaction was broadcast but is not mined yet
+	StatusPending = "pending"
+	// StatusMined means the transaction is in a block below the confirmation depth
+	StatusMined = "mined"
+	// StatusConfirmed means the transaction succeeded and reached the confirmation depth
+	StatusConfirmed = "confirmed"
+	// StatusFailed means the transaction reverted and reached the confirmation depth
+	StatusFailed = "failed"
+	// StatusRejected means the node refused the transaction when it was broadcast
+	StatusRejected = "rejected"
+	// StatusDropped means another transaction used the nonce
+	StatusDropped = "dropped"
+	// StatusReplaced means a replacement sent by the tracker was mined instead
+	StatusReplaced = "replaced"
+)
+
+// PurposeCancel marks zero-value self-transfers sent to cancel a nonce
+const PurposeCancel = "cancel"
+
+// ErrNotFound is returned when a transaction is not tracked on the network
+var ErrNotFound = errors.New("transaction not found")
+
+// Meta describes why a transaction was sent
+type Meta struct {
+	// Purpose names the operation, e.g. "bogo_transfer" or "ticket_mint"
+	Purpose string
+	// Ref links the transaction to the request it serves, e.g. a booking ID
+	Ref string
+}
+
+// StatusHandler is called after a tracked transaction changed status while
+// being followed, e.g. when it was mined, confirmed, or reorged out of its
+// block and is pending again
+type StatusHandler func(ctx context.Context, rec *database.TxRecord, previous string)
+
+// Store persists tracked transactions
+type Store interface {
+	SaveTransaction(rec *database.TxRecord) error
+	UpdateTransaction(rec *database.TxRecord) error
+	GetTransaction(hash string) (*database.TxRecord, error)
+	ListTransactionsByStatus(network string, statuses ...string) ([]*database.TxRecord, error)
+	ListTransactionsByNonce(network string, signer string, nonce uint64) ([]*database.TxRecord, error)
+	ListTransactionsByPurpose(network string, signer string, purpose string, since time.Time) ([]*database.TxRecord, error)
+}
+
+// Client is the chain access the tracker needs, typically an ethclient
+type Client interface {
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	gas.Source
+}
+
+// Options configures a Tracker
+type Options struct {
+	// Confirmations is the depth at which a transaction is final (default 1)
+	Confirmations uint64
+	// PollInterval is how often in-flight transactions are checked (default 5s)
+	PollInterval time.Duration
+	// ReplaceAfter is how long a transaction may stay pending before it is
+	// resent with a bumped fee. Zero disables automatic replacement.
+	ReplaceAfter time.Duration
+	// FeeBumpPercent raises the fees of replacements (default and minimum 10,
+	// the price bump nodes require to accept a replacement)
+	FeeBumpPercent uint64
+	// Fees prices replacements and caps their fees (default legacy, uncapped)
+	Fees *gas.Strategy
+}
+
+// Tracker records and monitors the transactions of one network
+type Tracker struct {
+	network       string
+	store         Store
+	client        Client
+	decoder       *Decoder
+	confirmations uint64
+	interval      time.Duration
+	replaceAfter  time.Duration
+	feeBump       uint64
+	fees          *gas.Strategy
+
+	mu          sync.Mutex
+	rebroadcast map[string]bool
+	signers     map[common.Address]bind.SignerFn
+	onReplace   []ReplacementHandler
+	onStatus    []StatusHandler
+	wake        chan struct{}
+
+	// replaceMu serializes replacements so a nonce is not bumped twice at once
+	replaceMu sync.Mutex
+}
+
+// Transaction is the tracked state of a transaction
+type Transaction struct {
+	Hash                  string  `json:"transactionHash"`
+	Network               string  `json:"network"`
+	Status                string  `json:"status"`
+	Finality              string  `json:"finality"`
+	From                  string  `json:"from"`
+	To                    string  `json:"to,omitempty"`
+	Nonce                 uint64  `json:"nonce"`
+	Purpose               string  `json:"purpose,omitempty"`
+	RequestRef            string  `json:"requestRef,omitempty"`
+	Replaces              string  `json:"replaces,omitempty"`
+	Error                 string  `json:"error,omitempty"`
+	BlockNumber           uint64  `json:"blockNumber,omitempty"`
+	BlockHash             string  `json:"blockHash,omitempty"`
+	Confirmations         uint64  `json:"confirmations"`
+	RequiredConfirmations uint64  `json:"requiredConfirmations"`
+	GasUsed               uint64  `json:"gasUsed,omitempty"`
+	Success               *bool   `json:"success,omitempty"`
+	Events                []Event `json:"events"`
+	SubmittedAt           string  `json:"submittedAt"`
+	UpdatedAt             string  `json:"updatedAt"`
+}
+
+// NewTracker creates a tracker for a network
+func NewTracker(network string, store Store, client Client, decoder *Decoder, opts Options) *Tracker {
+	if opts.Confirmations == 0 {
+		opts.Confirmations = 1
+	}
+	if opts.PollInterval == 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+	if opts.FeeBumpPercent < 10 {
+		opts.FeeBumpPercent = 10
+	}
+	if opts.Fees == nil {
+		opts.Fees = gas.Default()
+	}
+
+	return &Tracker{
+		network:       network,
+		store:         store,
+		client:        client,
+		decoder:       decoder,
+		confirmations: opts.Confirmations,
+		interval:      opts.PollInterval,
+		replaceAfter:  opts.ReplaceAfter,
+		feeBump:       opts.FeeBumpPercent,
+		fees:          opts.Fees,
+		rebroadcast:   make(map[string]bool),
+		signers:       make(map[common.Address]bind.SignerFn),
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// Submit persists a signed transaction and broadcasts it. The record is
+// written first so a crash after broadcasting cannot lose the transaction.
+func (t *Tracker) Submit(ctx context.Context, tx *types.Transaction, meta Meta) error {
+	return t.submit(ctx, tx, meta, "")
+}
+
+// submit persists and broadcasts a transaction; replaces is the hash of the
+// first transaction sent for the nonce when tx is a replacement
+func (t *Tracker) submit(ctx context.Context, tx *types.Transaction, meta Meta, replaces string) error {
+	signer, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("failed to recover transaction signer: %w", err)
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode transaction: %w", err)
+	}
+
+	rec := &database.TxRecord{
+		Hash:       tx.Hash().Hex(),
+		Network:    t.network,
+		ChainID:    tx.ChainId().Int64(),
+		Signer:     signer.Hex(),
+		Nonce:      tx.Nonce(),
+		Purpose:    meta.Purpose,
+		RequestRef: meta.Ref,
+		Replaces:   replaces,
+		RawTx:      hexutil.Encode(raw),
+		Status:     StatusPending,
+	}
+	if tx.To() != nil {
+		rec.ToAddress = tx.To().Hex()
+	}
+
+	if err := t.store.SaveTransaction(rec); err != nil {
+		return fmt.Errorf("failed to persist transaction: %w", err)
+	}
+
+	if err := t.client.SendTransaction(ctx, tx); err != nil && !isAlreadyKnown(err) {
+		// After a timeout or transport error the transaction may have
+		// reached the node anyway, so it stays pending and the monitor
+		// finds out by its hash, rebroadcasting or dropping it
+		if !isRefused(err) {
+			t.notify()
+			return err
+		}
+		rec.Status = StatusRejected
+		rec.Error = err.Error()
+		if updateErr := t.store.UpdateTransaction(rec); updateErr != nil {
+			log.Printf("txtrack: failed to record rejection of %s: %v", rec.Hash, updateErr)
+		}
+		return err
+	}
+
+	t.notify()
+	return nil
+}
+
+// refusals are errors of nodes that refused a transaction outright
+var refusals = []string{
+	"nonce too low",
+	"underpriced",
+	"insufficient funds",
+	"intrinsic gas too low",
+	"exceeds block gas limit",
+	"gas limit reached",
+	"less than block base fee",
+	"higher than max fee per gas",
+	"tip higher than fee cap",
+	"exceeds the configured cap",
+	"invalid sender",
+	"oversized data",
+}
+
+// isRefused reports whether a broadcast failed because the node refused the
+// transaction, rather than with an error after which it may have been sent
+func isRefused(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, refusal := range refusals {
+		if strings.Contains(msg, refusal) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAlreadyKnown reports whether the node already has the transaction
+func isAlreadyKnown(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "known transaction")
+}
+
+// Run monitors in-flight transactions until ctx is cancelled. Transactions
+// left in flight by a previous process are picked up on the first poll.
+func (t *Tracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := t.Poll(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("txtrack: %s poll failed: %v", t.network, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-t.wake:
+		}
+	}
+}
+
+// Poll checks every in-flight transaction once
+func (t *Tracker) Poll(ctx context.Context) error {
+	records, err := t.store.ListTransactionsByStatus(t.network, StatusPending, StatusMined)
+	if err != nil {
+		return fmt.Errorf("failed to list transactions: %w", err)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	head, err := t.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block number: %w", err)
+	}
+
+	for _, rec := range records {
+		if err := t.refresh(ctx, rec, head); err != nil {
+			log.Printf("txtrack: failed to refresh %s: %v", rec.Hash, err)
+		}
+	}
+
+	if t.replaceAfter > 0 {
+		t.replaceStuck(ctx, records)
+	}
+	return nil
+}
+
+// Lookup returns the tracked state of a transaction, refreshing it first
+// when it is still in flight
+func (t *Tracker) Lookup(ctx context.Context, hash string) (*Transaction, error) {
+	rec, err := t.store.GetTransaction(common.HexToHash(hash).Hex())
+	if err != nil || rec.Network != t.network {
+		return nil, ErrNotFound
+	}
+
+	if rec.Status == StatusPending || rec.Status == StatusMined || rec.BlockNumber > 0 {
+		if head, err := t.client.BlockNumber(ctx); err == nil {
+			if rec.Status == StatusPending || rec.Status == StatusMined {
+				if err := t.refresh(ctx, rec, head); err != nil {
+					log.Printf("txtrack: failed to refresh %s: %v", rec.Hash, err)
+				}
+			} else {
+				rec.Confirmations = confirmations(rec.BlockNumber, head)
+			}
+		}
+	}
+
+	return t.view(rec), nil
+}
+
+// SentTransaction is a transaction broadcast from the outbox
+type SentTransaction struct {
+	Tx          *types.Transaction
+	SubmittedAt time.Time
+}
+
+// ListSent returns the transactions a signer broadcast for a purpose since a
+// time, oldest first. Rejected transactions never reached a node and
+// replacements resend the transaction they replace, so neither is listed.
+func (t *Tracker) ListSent(signer common.Address, purpose string, since time.Time) ([]SentTransaction, error) {
+	records, err := t.store.ListTransactionsByPurpose(t.network, signer.Hex(), purpose, since)
+	if err != nil {
+		return nil, err
+	}
+
+	var sent []SentTransaction
+	for _, rec := range records {
+		if rec.Status == StatusRejected || rec.Replaces != "" {
+			continue
+		}
+		tx, err := decodeRawTx(rec.RawTx)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", rec.Hash, err)
+		}
+		submittedAt, err := parseTimestamp(rec.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: invalid timestamp: %w", rec.Hash, err)
+		}
+		sent = append(sent, SentTransaction{Tx: tx, SubmittedAt: submittedAt.UTC()})
+	}
+	return sent, nil
+}
+
+// OnStatusChange registers a handler for status changes
+func (t *Tracker) OnStatusChange(handler StatusHandler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onStatus = append(t.onStatus, handler)
+}
+
+// Finality returns whether a tracked transaction reached a final status
+// (events.Confirmed) or may still change with a reorg (events.Unconfirmed)
+func (t *Tracker) Finality(hash string) (string, error) {
+	rec, err := t.store.GetTransaction(common.HexToHash(hash).Hex())
+	if err != nil || rec.Network != t.network {
+		return "", ErrNotFound
+	}
+	return finality(rec.Status), nil
+}
+
+// refresh updates a record from the chain and tells the status handlers
+// when its status changed
+func (t *Tracker) refresh(ctx context.Context, rec *database.TxRecord, head uint64) (err error) {
+	previous := rec.Status
+	defer func() {
+		if err == nil && rec.Status != previous {
+			t.statusChanged(ctx, rec, previous)
+		}
+	}()
+
+	receipt, err := t.client.TransactionReceipt(ctx, common.HexToHash(rec.Hash))
+	if err != nil {
+		if !errors.Is(err, ethereum.NotFound) {
+			return err
+		}
+		return t.refreshUnmined(ctx, rec)
+	}
+
+	wasPending := rec.Status == StatusPending
+	if err := t.applyReceipt(rec, receipt, head); err != nil {
+		return err
+	}
+	if err := t.store.UpdateTransaction(rec); err != nil {
+		return err
+	}
+
+	if wasPending {
+		return t.settleNonce(ctx, rec)
+	}
+	return nil
+}
+
+func (t *Tracker) applyReceipt(rec *database.TxRecord, receipt *types.Receipt, head uint64) error {
+	logs, err := json.Marshal(receipt.Logs)
+	if err != nil {
+		return fmt.Errorf("failed to encode logs: %w", err)
+	}
+
+	status := receipt.Status
+	rec.BlockNumber = receipt.BlockNumber.Uint64()
+	rec.BlockHash = receipt.BlockHash.Hex()
+	rec.GasUsed = receipt.GasUsed
+	rec.ReceiptStatus = &status
+	rec.Logs = string(logs)
+	rec.Confirmations = confirmations(rec.BlockNumber, head)
+	rec.Error = ""
+
+	switch {
+	case rec.Confirmations < t.confirmations:
+		rec.Status = StatusMined
+	case status == types.ReceiptStatusSuccessful:
+		rec.Status = StatusConfirmed
+	default:
+		rec.Status = StatusFailed
+		rec.Error = "execution reverted"
+	}
+	return nil
+}
+
+// refreshUnmined handles a transaction without a receipt: it may have been
+// reorged out, replaced by another transaction with the same nonce, or lost
+// from the node's mempool
+func (t *Tracker) refreshUnmined(ctx context.Context, rec *database.TxRecord) error {
+	changed := false
+	if rec.Status == StatusMined {
+		rec.Status = StatusPending
+		rec.BlockNumber = 0
+		rec.BlockHash = ""
+		rec.GasUsed = 0
+		rec.Confirmations = 0
+		rec.ReceiptStatus = nil
+		rec.Logs = ""
+		changed = true
+	}
+
+	nonce, err := t.client.NonceAt(ctx, common.HexToAddress(rec.Signer), nil)
+	if err == nil && nonce > rec.Nonce {
+		// The nonce is used; make sure it was not this transaction that
+		// got mined since the receipt lookup
+		if _, err := t.client.TransactionReceipt(ctx, common.HexToHash(rec.Hash)); errors.Is(err, ethereum.NotFound) {
+			rec.Status = StatusDropped
+			rec.Error = "nonce used by another transaction"
+			return t.store.UpdateTransaction(rec)
+		}
+	}
+
+	if t.markRebroadcast(rec.Hash) {
+		t.resend(ctx, rec)
+	}
+
+	if changed {
+		return t.store.UpdateTransaction(rec)
+	}
+	return nil
+}
+
+func (t *Tracker) statusChanged(ctx context.Context, rec *database.TxRecord, previous string) {
+	t.mu.Lock()
+	handlers := append([]StatusHandler(nil), t.onStatus...)
+	t.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(ctx, rec, previous)
+	}
+}
+
+// resend broadcasts a stored transaction again, e.g. after a restart when
+// the node may have lost it from its mempool
+func (t *Tracker) resend(ctx context.Context, rec *database.TxRecord) {
+	tx, err := decodeRawTx(rec.RawTx)
+	if err != nil {
+		log.Printf("txtrack: %s: %v", rec.Hash, err)
+		return
+	}
+
+	if err := t.client.SendTransaction(ctx, tx); err != nil && !isAlreadyKnown(err) {
+		log.Printf("txtrack: rebroadcast of %s failed: %v", rec.Hash, err)
+	}
+}
+
+// markRebroadcast reports whether a transaction still needs its one
+// rebroadcast in this process
+func (t *Tracker) markRebroadcast(hash string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.rebroadcast[hash] {
+		return false
+	}
+	t.rebroadcast[hash] = true
+	return true
+}
+
+func (t *Tracker) notify() {
+	select {
+	case t.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (t *Tracker) view(rec *database.TxRecord) *Transaction {
+	tx := &Transaction{
+		Hash:                  rec.Hash,
+		Network:               rec.Network,
+		Status:                rec.Status,
+		Finality:              finality(rec.Status),
+		From:                  rec.Signer,
+		To:                    rec.ToAddress,
+		Nonce:                 rec.Nonce,
+		Purpose:               rec.Purpose,
+		RequestRef:            rec.RequestRef,
+		Replaces:              rec.Replaces,
+		Error:                 rec.Error,
+		BlockNumber:           rec.BlockNumber,
+		BlockHash:             rec.BlockHash,
+		Confirmations:         rec.Confirmations,
+		RequiredConfirmations: t.confirmations,
+		GasUsed:               rec.GasUsed,
+		Events:                []Event{},
+		SubmittedAt:           rec.CreatedAt,
+		UpdatedAt:             rec.UpdatedAt,
+	}
+
+	if rec.ReceiptStatus != nil {
+		success := *rec.ReceiptStatus == types.ReceiptStatusSuccessful
+		tx.Success = &success
+	}
+
+	if rec.Logs != "" {
+		var logs []*types.Log
+		if err := json.Unmarshal([]byte(rec.Logs), &logs); err == nil {
+			tx.Events = t.decoder.Decode(logs)
+		}
+	}
+
+	return tx
+}
+
+// finality reports pending and mined transactions as unconfirmed; every
+// other status is final
+func finality(status string) string {
+	if status == StatusPending || status == StatusMined {
+		return events.Unconfirmed
+	}
+	return events.Confirmed
+}
+
+func confirmations(block uint64, head uint64) uint64 {
+	if head < block {
+		return 1
+	}
+	return head - block + 1
+}
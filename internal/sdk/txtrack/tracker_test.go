@@ -0,0 +1,365 @@
+package txtrack
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"bogowi-blockchain-go/internal/database"
+	"bogowi-blockchain-go/internal/sdk/events"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const transferEventABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"}]`
+
+// fakeClient simulates the chain for the tracker
+type fakeClient struct {
+	mu       sync.Mutex
+	sent     []common.Hash
+	sendErr  error
+	receipts map[common.Hash]*types.Receipt
+	head     uint64
+	nonce    uint64
+	gasPrice *big.Int
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{receipts: make(map[common.Hash]*types.Receipt)}
+}
+
+func (f *fakeClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, tx.Hash())
+	return f.sendErr
+}
+
+func (f *fakeClient) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if receipt, ok := f.receipts[hash]; ok {
+		return receipt, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func (f *fakeClient) BlockNumber(ctx context.Context) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.head, nil
+}
+
+func (f *fakeClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.nonce, nil
+}
+
+func (f *fakeClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.gasPrice == nil {
+		return big.NewInt(25e9), nil
+	}
+	return f.gasPrice, nil
+}
+
+func (f *fakeClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1e9), nil
+}
+
+func (f *fakeClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: new(big.Int).SetUint64(f.head)}, nil
+}
+
+func (f *fakeClient) mine(tx *types.Transaction, block uint64, status uint64, logs ...*types.Log) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.receipts[tx.Hash()] = &types.Receipt{
+		Status:      status,
+		BlockNumber: new(big.Int).SetUint64(block),
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(block)),
+		GasUsed:     52000,
+		Logs:        logs,
+	}
+}
+
+func newTestStore(t *testing.T) *database.DB {
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "tx.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func signedTx(t *testing.T, nonce uint64) *types.Transaction {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	to := common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f8f8E2")
+	tx := types.NewTx(&types.LegacyTx{Nonce: nonce, To: &to, Value: big.NewInt(1), Gas: 21000, GasPrice: big.NewInt(25e9)})
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(big.NewInt(501)), key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestTrackerSubmit(t *testing.T) {
+	store := newTestStore(t)
+	client := newFakeClient()
+	tracker := NewTracker("testnet", store, client, nil, Options{})
+
+	tx := signedTx(t, 3)
+	require.NoError(t, tracker.Submit(context.Background(), tx, Meta{Purpose: "bogo_transfer", Ref: "order-1"}))
+	assert.Equal(t, []common.Hash{tx.Hash()}, client.sent)
+
+	rec, err := store.GetTransaction(tx.Hash().Hex())
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, rec.Status)
+	assert.Equal(t, "testnet", rec.Network)
+	assert.Equal(t, int64(501), rec.ChainID)
+	assert.Equal(t, uint64(3), rec.Nonce)
+	assert.Equal(t, "bogo_transfer", rec.Purpose)
+	assert.Equal(t, "order-1", rec.RequestRef)
+	assert.NotEmpty(t, rec.RawTx)
+
+	t.Run("rejected broadcast", func(t *testing.T) {
+		client.sendErr = errors.New("insufficient funds for gas * price + value")
+		defer func() { client.sendErr = nil }()
+
+		rejected := signedTx(t, 4)
+		err := tracker.Submit(context.Background(), rejected, Meta{Purpose: "bogo_transfer"})
+		assert.EqualError(t, err, "insufficient funds for gas * price + value")
+
+		rec, err := store.GetTransaction(rejected.Hash().Hex())
+		require.NoError(t, err)
+		assert.Equal(t, StatusRejected, rec.Status)
+		assert.Equal(t, "insufficient funds for gas * price + value", rec.Error)
+	})
+
+	t.Run("ambiguous broadcast", func(t *testing.T) {
+		client.sendErr = context.DeadlineExceeded
+		sent := signedTx(t, 5)
+		err := tracker.Submit(context.Background(), sent, Meta{Purpose: "bogo_transfer"})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		client.sendErr = nil
+
+		// The transaction may have reached the node, so it is followed and
+		// rebroadcast rather than given up
+		rec, err := store.GetTransaction(sent.Hash().Hex())
+		require.NoError(t, err)
+		assert.Equal(t, StatusPending, rec.Status)
+		assert.Empty(t, rec.Error)
+
+		require.NoError(t, tracker.Poll(context.Background()))
+		broadcasts := 0
+		for _, hash := range client.sent {
+			if hash == sent.Hash() {
+				broadcasts++
+			}
+		}
+		assert.Equal(t, 2, broadcasts)
+	})
+
+	t.Run("already known", func(t *testing.T) {
+		client.sendErr = errors.New("already known")
+		defer func() { client.sendErr = nil }()
+
+		known := signedTx(t, 6)
+		require.NoError(t, tracker.Submit(context.Background(), known, Meta{Purpose: "bogo_transfer"}))
+
+		rec, err := store.GetTransaction(known.Hash().Hex())
+		require.NoError(t, err)
+		assert.Equal(t, StatusPending, rec.Status)
+	})
+}
+
+func TestTrackerConfirmations(t *testing.T) {
+	store := newTestStore(t)
+	client := newFakeClient()
+	decoder, err := NewDecoder(transferEventABI)
+	require.NoError(t, err)
+	tracker := NewTracker("testnet", store, client, decoder, Options{Confirmations: 3})
+
+	tx := signedTx(t, 0)
+	require.NoError(t, tracker.Submit(context.Background(), tx, Meta{Purpose: "bogo_transfer"}))
+
+	// Not mined yet
+	client.head = 100
+	require.NoError(t, tracker.Poll(context.Background()))
+	status, err := tracker.Lookup(context.Background(), tx.Hash().Hex())
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, status.Status)
+
+	// Mined, below the confirmation depth
+	transferLog := &types.Log{
+		Address: common.HexToAddress("0xC53c2f11e1d2e36CB5888BfEE157F78e04Bb4F76"),
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")),
+			common.BytesToHash(common.HexToAddress("0x1111111111111111111111111111111111111111").Bytes()),
+			common.BytesToHash(common.HexToAddress("0x2222222222222222222222222222222222222222").Bytes()),
+		},
+		Data:   common.LeftPadBytes(big.NewInt(1000).Bytes(), 32),
+		TxHash: tx.Hash(),
+	}
+	client.mine(tx, 101, types.ReceiptStatusSuccessful, transferLog)
+	client.head = 101
+	require.NoError(t, tracker.Poll(context.Background()))
+
+	status, err = tracker.Lookup(context.Background(), tx.Hash().Hex())
+	require.NoError(t, err)
+	assert.Equal(t, StatusMined, status.Status)
+	assert.Equal(t, uint64(1), status.Confirmations)
+	assert.Equal(t, uint64(3), status.RequiredConfirmations)
+	assert.Equal(t, uint64(52000), status.GasUsed)
+	require.NotNil(t, status.Success)
+	assert.True(t, *status.Success)
+
+	require.Len(t, status.Events, 1)
+	assert.Equal(t, "Transfer", status.Events[0].Name)
+	assert.Equal(t, "0x1111111111111111111111111111111111111111", status.Events[0].Args["from"])
+	assert.Equal(t, "0x2222222222222222222222222222222222222222", status.Events[0].Args["to"])
+	assert.Equal(t, "1000", status.Events[0].Args["value"])
+
+	// Final once deep enough
+	client.head = 103
+	require.NoError(t, tracker.Poll(context.Background()))
+	rec, err := store.GetTransaction(tx.Hash().Hex())
+	require.NoError(t, err)
+	assert.Equal(t, StatusConfirmed, rec.Status)
+
+	// Confirmations keep counting for lookups after the transaction is final
+	client.head = 110
+	status, err = tracker.Lookup(context.Background(), tx.Hash().Hex())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), status.Confirmations)
+}
+
+func TestTrackerFailedAndDropped(t *testing.T) {
+	store := newTestStore(t)
+	client := newFakeClient()
+	tracker := NewTracker("testnet", store, client, nil, Options{})
+
+	reverted := signedTx(t, 0)
+	dropped := signedTx(t, 1)
+	require.NoError(t, tracker.Submit(context.Background(), reverted, Meta{}))
+	require.NoError(t, tracker.Submit(context.Background(), dropped, Meta{}))
+
+	client.mine(reverted, 50, types.ReceiptStatusFailed)
+	client.head = 50
+	client.nonce = 2 // nonce 1 was used by some other transaction
+	require.NoError(t, tracker.Poll(context.Background()))
+
+	rec, err := store.GetTransaction(reverted.Hash().Hex())
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, rec.Status)
+
+	rec, err = store.GetTransaction(dropped.Hash().Hex())
+	require.NoError(t, err)
+	assert.Equal(t, StatusDropped, rec.Status)
+}
+
+func TestTrackerReorgedTransaction(t *testing.T) {
+	store := newTestStore(t)
+	client := newFakeClient()
+	tracker := NewTracker("testnet", store, client, nil, Options{Confirmations: 3})
+
+	var changes []string
+	tracker.OnStatusChange(func(ctx context.Context, rec *database.TxRecord, previous string) {
+		changes = append(changes, previous+"->"+rec.Status)
+	})
+
+	tx := signedTx(t, 0)
+	require.NoError(t, tracker.Submit(context.Background(), tx, Meta{}))
+
+	client.mine(tx, 101, types.ReceiptStatusSuccessful)
+	client.head = 101
+	require.NoError(t, tracker.Poll(context.Background()))
+	finality, err := tracker.Finality(tx.Hash().Hex())
+	require.NoError(t, err)
+	assert.Equal(t, events.Unconfirmed, finality)
+
+	// A reorg drops the block before the transaction is final
+	client.mu.Lock()
+	delete(client.receipts, tx.Hash())
+	client.mu.Unlock()
+	require.NoError(t, tracker.Poll(context.Background()))
+	rec, err := store.GetTransaction(tx.Hash().Hex())
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, rec.Status)
+	assert.Zero(t, rec.BlockNumber)
+
+	// Mined again in the replacement chain
+	client.mine(tx, 102, types.ReceiptStatusSuccessful)
+	client.head = 104
+	require.NoError(t, tracker.Poll(context.Background()))
+	status, err := tracker.Lookup(context.Background(), tx.Hash().Hex())
+	require.NoError(t, err)
+	assert.Equal(t, StatusConfirmed, status.Status)
+	assert.Equal(t, events.Confirmed, status.Finality)
+	assert.Equal(t, uint64(102), status.BlockNumber)
+
+	assert.Equal(t, []string{"pending->mined", "mined->pending", "pending->confirmed"}, changes)
+
+	_, err = tracker.Finality(common.HexToHash("0x01").Hex())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTrackerResumesAfterRestart(t *testing.T) {
+	store := newTestStore(t)
+	client := newFakeClient()
+
+	tx := signedTx(t, 0)
+	require.NoError(t, NewTracker("testnet", store, client, nil, Options{}).Submit(context.Background(), tx, Meta{}))
+
+	// A new process picks the transaction up and rebroadcasts it once
+	restarted := NewTracker("testnet", store, client, nil, Options{})
+	require.NoError(t, restarted.Poll(context.Background()))
+	require.NoError(t, restarted.Poll(context.Background()))
+	assert.Equal(t, []common.Hash{tx.Hash(), tx.Hash()}, client.sent)
+
+	client.mine(tx, 10, types.ReceiptStatusSuccessful)
+	client.head = 10
+	require.NoError(t, restarted.Poll(context.Background()))
+
+	rec, err := store.GetTransaction(tx.Hash().Hex())
+	require.NoError(t, err)
+	assert.Equal(t, StatusConfirmed, rec.Status)
+}
+
+func TestTrackerLookupNotFound(t *testing.T) {
+	store := newTestStore(t)
+	client := newFakeClient()
+
+	tx := signedTx(t, 0)
+	require.NoError(t, NewTracker("testnet", store, client, nil, Options{}).Submit(context.Background(), tx, Meta{}))
+
+	_, err := NewTracker("mainnet", store, client, nil, Options{}).Lookup(context.Background(), tx.Hash().Hex())
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = NewTracker("testnet", store, client, nil, Options{}).Lookup(context.Background(), common.Hash{}.Hex())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDecoderUnknownEvent(t *testing.T) {
+	decoder, err := NewDecoder(transferEventABI)
+	require.NoError(t, err)
+
+	events := decoder.Decode([]*types.Log{{
+		Address: common.HexToAddress("0x1234567890123456789012345678901234567890"),
+		Topics:  []common.Hash{common.HexToHash("0xdead")},
+		Data:    []byte{0x01},
+		Index:   2,
+	}})
+	require.Len(t, events, 1)
+	assert.Equal(t, "unknown", events[0].Name)
+	assert.Equal(t, "0x01", events[0].Data)
+	assert.Equal(t, uint(2), events[0].LogIndex)
+}
@@ -0,0 +1,289 @@
+// Package wallet spreads writes across a pool of hot wallets so throughput is
+// not capped by the nonce stream of a single account. Every wallet signs
+// with its own nonce manager; wallets whose CAM balance runs low are taken
+// out of rotation until they are topped up.
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"bogowi-blockchain-go/internal/sdk/signer"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Wallet statuses
+const (
+	// StatusActive means the wallet receives work
+	StatusActive = "active"
+	// StatusDrained means the wallet's CAM balance is below the minimum
+	StatusDrained = "drained"
+	// StatusUnknown means the wallet's balance could not be read yet
+	StatusUnknown = "unknown"
+)
+
+// DefaultCheckInterval is how often Run refreshes wallet balances
+const DefaultCheckInterval = time.Minute
+
+// ErrNoWallet is returned when every wallet in the pool is drained
+var ErrNoWallet = errors.New("no hot wallet has enough CAM to send transactions")
+
+// BalanceSource reads account balances, typically an ethclient
+type BalanceSource interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// Config configures a pool
+type Config struct {
+	// MinBalance is the CAM balance below which a wallet is drained out of
+	// rotation. Empty disables draining.
+	MinBalance string
+	// CheckInterval is how often Run refreshes balances. Zero uses
+	// DefaultCheckInterval.
+	CheckInterval time.Duration
+}
+
+// Status reports the state of a wallet in the pool
+type Status struct {
+	Address   string     `json:"address"`
+	Status    string     `json:"status"`
+	Balance   string     `json:"balance,omitempty"`
+	Sent      uint64     `json:"sent"`
+	Failed    uint64     `json:"failed"`
+	LastError string     `json:"lastError,omitempty"`
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+}
+
+type hotWallet struct {
+	opts      *bind.TransactOpts
+	balance   *big.Int
+	checked   bool
+	checkedAt time.Time
+	drained   bool
+	sent      uint64
+	failed    uint64
+	lastErr   string
+}
+
+// Pool dispatches transactions round-robin across its active wallets
+type Pool struct {
+	mu         sync.Mutex
+	wallets    []*hotWallet
+	byAddress  map[common.Address]*hotWallet
+	next       int
+	minBalance *big.Int
+	interval   time.Duration
+}
+
+// NewPool creates a pool of the signers on chainID. Every signer must hold
+// the roles needed for the operations dispatched through the pool.
+func NewPool(chainID *big.Int, signers []signer.Signer, cfg Config) (*Pool, error) {
+	if len(signers) == 0 {
+		return nil, fmt.Errorf("wallet pool needs at least one signer")
+	}
+
+	minBalance, err := ParseCAM(cfg.MinBalance)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minimum balance: %w", err)
+	}
+
+	interval := cfg.CheckInterval
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+
+	p := &Pool{
+		byAddress:  make(map[common.Address]*hotWallet),
+		minBalance: minBalance,
+		interval:   interval,
+	}
+	for _, s := range signers {
+		if _, ok := p.byAddress[s.Address()]; ok {
+			return nil, fmt.Errorf("duplicate wallet %s in pool", s.Address().Hex())
+		}
+		w := &hotWallet{opts: signer.TransactOpts(s, chainID)}
+		p.wallets = append(p.wallets, w)
+		p.byAddress[s.Address()] = w
+	}
+	return p, nil
+}
+
+// Next returns transaction options for the next active wallet. Wallets whose
+// balance has not been checked yet are used; drained wallets are skipped.
+func (p *Pool) Next(ctx context.Context) (*bind.TransactOpts, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i := 0; i < len(p.wallets); i++ {
+		w := p.wallets[(p.next+i)%len(p.wallets)]
+		if w.drained {
+			continue
+		}
+		p.next = (p.next + i + 1) % len(p.wallets)
+		return &bind.TransactOpts{
+			From:    w.opts.From,
+			Signer:  w.opts.Signer,
+			Context: ctx,
+		}, nil
+	}
+	return nil, ErrNoWallet
+}
+
+// Contains reports whether address is a wallet of the pool
+func (p *Pool) Contains(address common.Address) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, ok := p.byAddress[address]
+	return ok
+}
+
+// Wallets returns the transaction options of every wallet, e.g. to register
+// them with a transaction tracker
+func (p *Pool) Wallets() []*bind.TransactOpts {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	wallets := make([]*bind.TransactOpts, len(p.wallets))
+	for i, w := range p.wallets {
+		wallets[i] = &bind.TransactOpts{From: w.opts.From, Signer: w.opts.Signer, Context: context.Background()}
+	}
+	return wallets
+}
+
+// Record counts a transaction sent from address; err is the send result.
+// Addresses outside the pool are ignored.
+func (p *Pool) Record(address common.Address, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	w, ok := p.byAddress[address]
+	if !ok {
+		return
+	}
+	if err != nil {
+		w.failed++
+		w.lastErr = err.Error()
+		return
+	}
+	w.sent++
+}
+
+// Refresh reads the balance of every wallet and drains the ones below the
+// minimum balance. A drained wallet rejoins the rotation once topped up.
+func (p *Pool) Refresh(ctx context.Context, src BalanceSource) error {
+	p.mu.Lock()
+	addresses := make([]common.Address, len(p.wallets))
+	for i, w := range p.wallets {
+		addresses[i] = w.opts.From
+	}
+	p.mu.Unlock()
+
+	var errs []error
+	for _, address := range addresses {
+		balance, err := src.BalanceAt(ctx, address, nil)
+
+		p.mu.Lock()
+		w := p.byAddress[address]
+		if err != nil {
+			w.lastErr = fmt.Sprintf("failed to read balance: %v", err)
+			p.mu.Unlock()
+			errs = append(errs, fmt.Errorf("%s: %w", address.Hex(), err))
+			continue
+		}
+
+		drained := balance.Cmp(p.minBalance) < 0
+		if drained != w.drained && w.checked {
+			if drained {
+				log.Printf("wallet: %s drained with %s wei, below the %s wei minimum", address.Hex(), balance, p.minBalance)
+			} else {
+				log.Printf("wallet: %s back in rotation with %s wei", address.Hex(), balance)
+			}
+		}
+		w.balance = balance
+		w.drained = drained
+		w.checked = true
+		w.checkedAt = time.Now().UTC()
+		p.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// Run refreshes balances until ctx is cancelled
+func (p *Pool) Run(ctx context.Context, src BalanceSource) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Refresh(ctx, src); err != nil && ctx.Err() == nil {
+			log.Printf("wallet: balance refresh failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// MinBalance returns the balance below which a wallet is drained, in wei
+func (p *Pool) MinBalance() *big.Int {
+	return new(big.Int).Set(p.minBalance)
+}
+
+// Status reports the balance and health of every wallet
+func (p *Pool) Status() []Status {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	statuses := make([]Status, len(p.wallets))
+	for i, w := range p.wallets {
+		status := Status{
+			Address:   w.opts.From.Hex(),
+			Status:    StatusActive,
+			Sent:      w.sent,
+			Failed:    w.failed,
+			LastError: w.lastErr,
+		}
+		switch {
+		case w.drained:
+			status.Status = StatusDrained
+		case !w.checked:
+			status.Status = StatusUnknown
+		}
+		if w.checked {
+			checkedAt := w.checkedAt
+			status.Balance = w.balance.String()
+			status.CheckedAt = &checkedAt
+		}
+		statuses[i] = status
+	}
+	return statuses
+}
+
+// ParseCAM converts a decimal CAM amount to wei; empty is zero
+func ParseCAM(amount string) (*big.Int, error) {
+	amount = strings.TrimSpace(amount)
+	if amount == "" {
+		return big.NewInt(0), nil
+	}
+
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok || strings.ContainsAny(amount, "/eE") || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount: %q", amount)
+	}
+	value.Mul(value, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)))
+	if !value.IsInt() {
+		return nil, fmt.Errorf("amount %q has more than 18 decimal places", amount)
+	}
+	return new(big.Int).Set(value.Num()), nil
+}
@@ -0,0 +1,148 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"bogowi-blockchain-go/internal/sdk/signer"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubBalances map[common.Address]*big.Int
+
+func (s stubBalances) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	balance, ok := s[account]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return balance, nil
+}
+
+func newTestPool(t *testing.T, size int, minBalance string) (*Pool, []common.Address) {
+	t.Helper()
+	signers := make([]signer.Signer, size)
+	addresses := make([]common.Address, size)
+	for i := range signers {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		s := signer.NewKeySigner(key)
+		signers[i] = s
+		addresses[i] = s.Address()
+	}
+
+	pool, err := NewPool(big.NewInt(501), signers, Config{MinBalance: minBalance})
+	require.NoError(t, err)
+	return pool, addresses
+}
+
+func nextFrom(t *testing.T, pool *Pool) common.Address {
+	t.Helper()
+	opts, err := pool.Next(context.Background())
+	require.NoError(t, err)
+	return opts.From
+}
+
+func TestPoolRoundRobin(t *testing.T) {
+	pool, addresses := newTestPool(t, 3, "")
+
+	for round := 0; round < 2; round++ {
+		for _, address := range addresses {
+			assert.Equal(t, address, nextFrom(t, pool))
+		}
+	}
+
+	opts, err := pool.Next(context.Background())
+	require.NoError(t, err)
+	assert.NotNil(t, opts.Signer)
+	assert.True(t, pool.Contains(opts.From))
+	assert.False(t, pool.Contains(common.HexToAddress("0x01")))
+}
+
+func TestPoolDrainsLowBalance(t *testing.T) {
+	pool, addresses := newTestPool(t, 3, "0.5")
+	oneCAM := big.NewInt(1e18)
+	balances := stubBalances{
+		addresses[0]: oneCAM,
+		addresses[1]: big.NewInt(1e17),
+		addresses[2]: oneCAM,
+	}
+
+	require.NoError(t, pool.Refresh(context.Background(), balances))
+	for i := 0; i < 4; i++ {
+		assert.NotEqual(t, addresses[1], nextFrom(t, pool))
+	}
+
+	statuses := pool.Status()
+	require.Len(t, statuses, 3)
+	assert.Equal(t, StatusActive, statuses[0].Status)
+	assert.Equal(t, StatusDrained, statuses[1].Status)
+	assert.Equal(t, "100000000000000000", statuses[1].Balance)
+	assert.NotNil(t, statuses[1].CheckedAt)
+
+	// Topped up wallets rejoin the rotation
+	balances[addresses[1]] = oneCAM
+	require.NoError(t, pool.Refresh(context.Background(), balances))
+	seen := map[common.Address]bool{}
+	for i := 0; i < 3; i++ {
+		seen[nextFrom(t, pool)] = true
+	}
+	assert.True(t, seen[addresses[1]])
+
+	// Nothing is dispatched when every wallet is drained
+	for _, address := range addresses {
+		balances[address] = big.NewInt(0)
+	}
+	require.NoError(t, pool.Refresh(context.Background(), balances))
+	_, err := pool.Next(context.Background())
+	assert.ErrorIs(t, err, ErrNoWallet)
+}
+
+func TestPoolStatus(t *testing.T) {
+	pool, addresses := newTestPool(t, 2, "1")
+
+	// Unchecked wallets are used but reported as unknown
+	statuses := pool.Status()
+	assert.Equal(t, StatusUnknown, statuses[0].Status)
+	assert.Empty(t, statuses[0].Balance)
+
+	pool.Record(addresses[0], nil)
+	pool.Record(addresses[0], nil)
+	pool.Record(addresses[1], errors.New("nonce too low"))
+	pool.Record(common.HexToAddress("0x01"), nil)
+
+	err := pool.Refresh(context.Background(), stubBalances{addresses[0]: big.NewInt(2e18)})
+	assert.Error(t, err)
+
+	statuses = pool.Status()
+	assert.Equal(t, uint64(2), statuses[0].Sent)
+	assert.Equal(t, StatusActive, statuses[0].Status)
+	assert.Equal(t, uint64(1), statuses[1].Failed)
+	assert.Equal(t, StatusUnknown, statuses[1].Status)
+	assert.Contains(t, statuses[1].LastError, "failed to read balance")
+}
+
+func TestNewPool(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	s := signer.NewKeySigner(key)
+
+	_, err = NewPool(big.NewInt(501), nil, Config{})
+	assert.EqualError(t, err, "wallet pool needs at least one signer")
+
+	_, err = NewPool(big.NewInt(501), []signer.Signer{s, s}, Config{})
+	assert.ErrorContains(t, err, "duplicate wallet")
+
+	_, err = NewPool(big.NewInt(501), []signer.Signer{s}, Config{MinBalance: "-1"})
+	assert.ErrorContains(t, err, "invalid minimum balance")
+
+	pool, err := NewPool(big.NewInt(501), []signer.Signer{s}, Config{MinBalance: "0.25"})
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(25e16), pool.MinBalance())
+	assert.Len(t, pool.Wallets(), 1)
+}
@@ -195,6 +195,31 @@ func (s *TicketMetadataService) UpdateTicketStatus(nftID string, status string)
 	return nil
 }
 
+// UpdateTicketRecipient records the new holder of a transferred ticket
+func (s *TicketMetadataService) UpdateTicketRecipient(nftID string, recipientAddress string) error {
+	// Get current metadata
+	nft, err := s.client.GetNFT(nftID)
+	if err != nil {
+		return fmt.Errorf("failed to get NFT: %w", err)
+	}
+
+	if nft.Metadata.Properties == nil {
+		nft.Metadata.Properties = make(map[string]interface{})
+	}
+	nft.Metadata.Properties["recipient"] = map[string]interface{}{
+		"address":        recipientAddress,
+		"transferred_at": time.Now().Format(time.RFC3339),
+	}
+
+	// Update metadata
+	_, err = s.client.UpdateMetadata(nftID, nft.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to update metadata: %w", err)
+	}
+
+	return nil
+}
+
 // GetTicketMetadata retrieves metadata for a ticket by token ID
 func (s *TicketMetadataService) GetTicketMetadata(tokenID uint64) (*NFTMetadata, error) {
 	metadata, err := s.client.GetMetadataByTokenID(s.contractAddress, fmt.Sprintf("%d", tokenID))
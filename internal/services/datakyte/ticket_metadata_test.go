@@ -289,6 +289,37 @@ func TestTicketMetadataService_UpdateTicketStatus(t *testing.T) {
 	assert.Equal(t, 2, callCount, "Expected 2 API calls")
 }
 
+func TestTicketMetadataService_UpdateTicketRecipient(t *testing.T) {
+	currentNFT := NFT{
+		ID:      "nft-123",
+		TokenID: "1",
+		Metadata: NFTMetadata{
+			Name:       "Test NFT",
+			Attributes: []NFTAttribute{{TraitType: "Status", Value: "Active"}},
+		},
+	}
+
+	var updated NFTMetadata
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+		}
+		json.NewEncoder(w).Encode(Response{Success: true, Data: mustMarshal(currentNFT)})
+	}))
+	defer server.Close()
+
+	service := NewTicketMetadataService("test-api-key", "0x123", 501)
+	service.client.baseURL = server.URL
+
+	err := service.UpdateTicketRecipient("nft-123", "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb1")
+	require.NoError(t, err)
+
+	recipient, ok := updated.Properties["recipient"].(map[string]interface{})
+	require.True(t, ok, "recipient property not set")
+	assert.Equal(t, "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb1", recipient["address"])
+	assert.Equal(t, currentNFT.Metadata.Attributes, updated.Attributes)
+}
+
 func TestTicketMetadataService_GetTicketMetadata(t *testing.T) {
 	expectedMetadata := NFTMetadata{
 		Name:        "BOGOWI Eco-Experience #1",
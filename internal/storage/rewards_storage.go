@@ -21,6 +21,10 @@ type RewardsStorage interface {
 	GetReferralClaimsByWallet(ctx context.Context, wallet string, limit int) ([]*models.ReferralClaim, error)
 	UpdateReferralClaimStatus(ctx context.Context, id uint, status string, txHash string) error
 
+	// ReplaceClaimTxHash moves reward and referral claims sent with oldHash to
+	// the transaction that replaced it. Status is left unchanged when empty.
+	ReplaceClaimTxHash(ctx context.Context, oldHash, newHash, status string) error
+
 	// Templates
 	SaveRewardTemplate(ctx context.Context, template *models.RewardTemplate) error
 	GetRewardTemplate(ctx context.Context, id, network string) (*models.RewardTemplate, error)
@@ -261,6 +265,34 @@ func (s *InMemoryRewardsStorage) UpdateReferralClaimStatus(ctx context.Context,
 	return nil
 }
 
+// ReplaceClaimTxHash moves claims to a replacement transaction
+func (s *InMemoryRewardsStorage) ReplaceClaimTxHash(ctx context.Context, oldHash, newHash, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, claim := range s.rewardClaims {
+		if claim.TxHash == oldHash {
+			claim.TxHash = newHash
+			if status != "" {
+				claim.Status = status
+			}
+			claim.UpdatedAt = time.Now()
+		}
+	}
+
+	for _, claim := range s.referralClaims {
+		if claim.TxHash == oldHash {
+			claim.TxHash = newHash
+			if status != "" {
+				claim.Status = status
+			}
+			claim.UpdatedAt = time.Now()
+		}
+	}
+
+	return nil
+}
+
 // SaveRewardTemplate saves or updates a reward template
 func (s *InMemoryRewardsStorage) SaveRewardTemplate(ctx context.Context, template *models.RewardTemplate) error {
 	s.mu.Lock()
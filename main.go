@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -25,9 +26,12 @@ import (
 // @BasePath /api
 // Server represents the application server
 type Server struct {
-	srv    *http.Server
-	sdk    *sdk.BOGOWISDK
-	config *config.Config
+	srv            *http.Server
+	sdk            *sdk.BOGOWISDK
+	config         *config.Config
+	networkHandler *api.NetworkHandler
+	// cancel cancels the context of all requests
+	cancel context.CancelFunc
 }
 
 // NewServer creates a new server instance
@@ -38,13 +42,13 @@ func NewServer(cfg *config.Config) (*Server, error) {
 		return nil, fmt.Errorf("failed to initialize network handler: %w", err)
 	}
 
-	// Get default SDK based on environment
-	var defaultSDK api.SDKInterface
+	// Get default SDK based on environment; it follows the network's
+	// connection so it can be set up before the network is reachable
+	defaultNetwork := "mainnet"
 	if cfg.Environment == "development" {
-		defaultSDK, _ = networkHandler.GetSDK("testnet")
-	} else {
-		defaultSDK, _ = networkHandler.GetSDK("mainnet")
+		defaultNetwork = "testnet"
 	}
+	defaultSDK := networkHandler.DefaultSDK(defaultNetwork)
 
 	// Set Gin mode
 	if cfg.Environment == "production" {
@@ -60,19 +64,24 @@ func NewServer(cfg *config.Config) (*Server, error) {
 	}
 	router := api.CreateRouter(routerConfig)
 
-	// Create HTTP server
+	// Create HTTP server. Requests run on a context Shutdown cancels, so
+	// their RPC calls stop with the server.
+	baseCtx, cancel := context.WithCancel(context.Background())
 	srv := &http.Server{
 		Addr:              ":" + cfg.APIPort,
 		Handler:           router,
 		ReadTimeout:       10 * time.Second,
 		WriteTimeout:      10 * time.Second,
 		ReadHeaderTimeout: 5 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return baseCtx },
 	}
 
 	return &Server{
-		srv:    srv,
-		sdk:    nil, // We're using NetworkHandler now
-		config: cfg,
+		srv:            srv,
+		sdk:            nil, // We're using NetworkHandler now
+		config:         cfg,
+		networkHandler: networkHandler,
+		cancel:         cancel,
 	}, nil
 }
 
@@ -88,10 +97,17 @@ func (s *Server) Start() error {
 	return nil
 }
 
-// Shutdown gracefully shuts down the server
+// Shutdown gracefully shuts down the server. Requests still running when
+// ctx ends are canceled, then the background services of the networks stop.
 func (s *Server) Shutdown(ctx context.Context) error {
 	log.Println("🛑 Server shutting down...")
+	stop := context.AfterFunc(ctx, s.cancel)
 	err := s.srv.Shutdown(ctx)
+	stop()
+	s.cancel()
+
+	// Requests no longer use the networks, so their clients can close
+	s.networkHandler.Close()
 	if err == nil {
 		log.Println("✅ Server exited")
 	}
@@ -80,7 +80,7 @@ func TestNewServer(t *testing.T) {
 				})
 			},
 			wantErr: true,
-			errMsg:  "at least one private key (TESTNET_PRIVATE_KEY or MAINNET_PRIVATE_KEY) is required",
+			errMsg:  "at least one private key (TESTNET_PRIVATE_KEY or MAINNET_PRIVATE_KEY) or external signer (TESTNET_SIGNER or MAINNET_SIGNER) is required",
 		},
 	}
 
@@ -214,6 +214,63 @@ func TestServerShutdownTimeout(t *testing.T) {
 	_ = server.srv.Shutdown(forceCtx)
 }
 
+func TestServerShutdownCancelsRequests(t *testing.T) {
+	// Set up test environment
+	os.Setenv("TESTNET_PRIVATE_KEY", "0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+	os.Setenv("MAINNET_PRIVATE_KEY", "0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+	os.Setenv("RPC_URL", "https://columbus.camino.network/ext/bc/C/rpc")
+	os.Setenv("API_PORT", "18768")
+	defer func() {
+		os.Unsetenv("TESTNET_PRIVATE_KEY")
+		os.Unsetenv("MAINNET_PRIVATE_KEY")
+		os.Unsetenv("RPC_URL")
+		os.Unsetenv("API_PORT")
+	}()
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+
+	server, err := NewServer(cfg)
+	require.NoError(t, err)
+
+	// A request that runs until its context is canceled
+	started := make(chan struct{})
+	canceled := make(chan struct{})
+	server.srv.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+		close(canceled)
+	})
+
+	go func() {
+		_ = server.Start()
+	}()
+	time.Sleep(200 * time.Millisecond)
+
+	go func() {
+		resp, err := http.Get("http://localhost:" + cfg.APIPort + "/slow")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("request did not reach the server")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = server.Shutdown(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("shutdown did not cancel the in-flight request")
+	}
+}
+
 func TestMainFunction(t *testing.T) {
 	// This test verifies that main() can be called without panicking
 	// We can't easily test the full main() with signal handling,